@@ -0,0 +1,36 @@
+package alias
+
+import (
+	"fmt"
+
+	"github.com/stackitcloud/stackit-cli/internal/cmd/alias/delete"
+	"github.com/stackitcloud/stackit-cli/internal/cmd/alias/list"
+	"github.com/stackitcloud/stackit-cli/internal/cmd/alias/set"
+	"github.com/stackitcloud/stackit-cli/internal/cmd/params"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/args"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/utils"
+
+	"github.com/spf13/cobra"
+)
+
+func NewCmd(params *params.CmdParams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "alias",
+		Short: "Manages command aliases",
+		Long: fmt.Sprintf("%s\n%s\n%s",
+			"Manages command aliases, which are shortcuts for longer commands.",
+			`When the first argument of a command is an alias, it is replaced by its expansion before running the command. Aliases never override the built-in commands.`,
+			"Aliases are stored in the configuration of the active profile.",
+		),
+		Args: args.NoArgs,
+		Run:  utils.CmdHelp,
+	}
+	addSubcommands(cmd, params)
+	return cmd
+}
+
+func addSubcommands(cmd *cobra.Command, params *params.CmdParams) {
+	cmd.AddCommand(set.NewCmd(params))
+	cmd.AddCommand(list.NewCmd(params))
+	cmd.AddCommand(delete.NewCmd(params))
+}
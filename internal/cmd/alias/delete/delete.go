@@ -0,0 +1,67 @@
+package delete
+
+import (
+	"fmt"
+
+	"github.com/stackitcloud/stackit-cli/internal/cmd/params"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/aliases"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/args"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/examples"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/globalflags"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/print"
+
+	"github.com/spf13/cobra"
+)
+
+const (
+	aliasArg = "ALIAS"
+)
+
+type inputModel struct {
+	*globalflags.GlobalFlagModel
+	Alias string
+}
+
+func NewCmd(params *params.CmdParams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   fmt.Sprintf("delete %s", aliasArg),
+		Short: "Deletes a command alias",
+		Long:  "Deletes a command alias from the active profile.",
+		Args:  args.SingleArg(aliasArg, aliases.ValidateName),
+		Example: examples.Build(
+			examples.NewExample(
+				`Delete the alias "pgl"`,
+				"$ stackit alias delete pgl"),
+		),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			model := parseInput(params.Printer, cmd, args)
+
+			err := aliases.Delete(model.Alias)
+			if err != nil {
+				return fmt.Errorf("delete alias: %w", err)
+			}
+
+			params.Printer.Info("Deleted alias %q\n", model.Alias)
+			return nil
+		},
+	}
+	return cmd
+}
+
+func parseInput(p *print.Printer, cmd *cobra.Command, inputArgs []string) *inputModel {
+	model := inputModel{
+		GlobalFlagModel: globalflags.Parse(p, cmd),
+		Alias:           inputArgs[0],
+	}
+
+	if p.IsVerbosityDebug() {
+		modelStr, err := print.BuildDebugStrFromInputModel(model)
+		if err != nil {
+			p.Debug(print.ErrorLevel, "convert model to string for debugging: %v", err)
+		} else {
+			p.Debug(print.DebugLevel, "parsed input values: %s", modelStr)
+		}
+	}
+
+	return &model
+}
@@ -0,0 +1,89 @@
+package list
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/goccy/go-yaml"
+	"github.com/stackitcloud/stackit-cli/internal/cmd/params"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/aliases"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/args"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/examples"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/globalflags"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/print"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/tables"
+
+	"github.com/spf13/cobra"
+)
+
+type inputModel struct {
+	*globalflags.GlobalFlagModel
+}
+
+func NewCmd(params *params.CmdParams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "Lists all command aliases",
+		Long:  "Lists all command aliases of the active profile.",
+		Args:  args.NoArgs,
+		Example: examples.Build(
+			examples.NewExample(
+				`List all command aliases`,
+				"$ stackit alias list"),
+			examples.NewExample(
+				`List all command aliases in JSON format`,
+				"$ stackit alias list --output-format json"),
+		),
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			model := parseInput(params.Printer, cmd)
+
+			aliasList := aliases.List()
+			if len(aliasList) == 0 && model.OutputFormat != print.JSONOutputFormat && model.OutputFormat != print.YAMLOutputFormat {
+				params.Printer.Info("No aliases found\n")
+				return nil
+			}
+
+			return outputResult(params.Printer, model.OutputFormat, aliasList)
+		},
+	}
+	return cmd
+}
+
+func parseInput(p *print.Printer, cmd *cobra.Command) *inputModel {
+	globalFlags := globalflags.Parse(p, cmd)
+
+	return &inputModel{
+		GlobalFlagModel: globalFlags,
+	}
+}
+
+func outputResult(p *print.Printer, outputFormat string, aliasList []aliases.Alias) error {
+	switch outputFormat {
+	case print.JSONOutputFormat:
+		details, err := json.MarshalIndent(aliasList, "", "  ")
+		if err != nil {
+			return fmt.Errorf("marshal alias list: %w", err)
+		}
+		p.Outputln(string(details))
+		return nil
+	case print.YAMLOutputFormat:
+		details, err := yaml.MarshalWithOptions(aliasList, yaml.IndentSequence(true), yaml.UseJSONMarshaler())
+		if err != nil {
+			return fmt.Errorf("marshal alias list: %w", err)
+		}
+		p.Outputln(string(details))
+		return nil
+	default:
+		table := tables.NewTable()
+		table.SetHeader("ALIAS", "EXPANSION")
+		for _, alias := range aliasList {
+			table.AddRow(alias.Name, alias.Expansion)
+			table.AddSeparator()
+		}
+		err := table.Display(p)
+		if err != nil {
+			return fmt.Errorf("render table: %w", err)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,55 @@
+package list
+
+import (
+	"testing"
+
+	"github.com/stackitcloud/stackit-cli/internal/cmd/params"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/aliases"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/print"
+)
+
+func TestOutputResult(t *testing.T) {
+	type args struct {
+		outputFormat string
+		aliasList    []aliases.Alias
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name:    "empty",
+			args:    args{},
+			wantErr: false,
+		},
+		{
+			name: "aliases",
+			args: args{
+				aliasList: []aliases.Alias{
+					{Name: "pgl", Expansion: "postgresflex instance list"},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "aliases as json",
+			args: args{
+				outputFormat: print.JSONOutputFormat,
+				aliasList: []aliases.Alias{
+					{Name: "pgl", Expansion: "postgresflex instance list"},
+				},
+			},
+			wantErr: false,
+		},
+	}
+	p := print.NewPrinter()
+	p.Cmd = NewCmd(&params.CmdParams{Printer: p})
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := outputResult(p, tt.args.outputFormat, tt.args.aliasList); (err != nil) != tt.wantErr {
+				t.Errorf("outputResult() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,105 @@
+package set
+
+import (
+	"fmt"
+
+	"github.com/stackitcloud/stackit-cli/internal/cmd/params"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/aliases"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/examples"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/flags"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/globalflags"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/print"
+
+	"github.com/spf13/cobra"
+)
+
+const (
+	aliasArg     = "ALIAS"
+	expansionArg = "EXPANSION"
+
+	clobberFlag = "clobber"
+)
+
+type inputModel struct {
+	*globalflags.GlobalFlagModel
+	Alias     string
+	Expansion string
+	Clobber   bool
+}
+
+func NewCmd(params *params.CmdParams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   fmt.Sprintf("set %s %s", aliasArg, expansionArg),
+		Short: "Creates a command alias",
+		Long: fmt.Sprintf("%s\n%s\n%s",
+			"Creates a command alias in the active profile.",
+			`The expansion is the command to run, without the "stackit" prefix. It can contain the placeholders "$1", "$2", etc., which are replaced by the arguments given after the alias.`,
+			"Any arguments not used by a placeholder are appended to the expansion.",
+		),
+		Args: cobra.ExactArgs(2),
+		Example: examples.Build(
+			examples.NewExample(
+				`Create an alias "pgl" that lists PostgreSQL Flex instances in JSON format. "stackit pgl" then runs "stackit postgresflex instance list -o json"`,
+				"$ stackit alias set pgl 'postgresflex instance list -o json'"),
+			examples.NewExample(
+				`Create an alias "pgd" with a placeholder. "stackit pgd xxx" then runs "stackit postgresflex instance describe xxx"`,
+				"$ stackit alias set pgd 'postgresflex instance describe $1'"),
+			examples.NewExample(
+				`Overwrite the existing alias "pgl"`,
+				"$ stackit alias set pgl 'postgresflex instance list --limit 10' --clobber"),
+		),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			model, err := parseInput(params.Printer, cmd, args)
+			if err != nil {
+				return err
+			}
+
+			if c, _, err := cmd.Root().Find([]string{model.Alias}); err == nil && c != cmd.Root() {
+				return fmt.Errorf("%q is already a STACKIT CLI command", model.Alias)
+			}
+			if _, exists := aliases.Get(model.Alias); exists && !model.Clobber {
+				return fmt.Errorf("alias %q already exists, use the --%s flag to overwrite it", model.Alias, clobberFlag)
+			}
+
+			err = aliases.Set(model.Alias, model.Expansion)
+			if err != nil {
+				return fmt.Errorf("set alias: %w", err)
+			}
+
+			params.Printer.Info("Added alias %q for %q\n", model.Alias, model.Expansion)
+			return nil
+		},
+	}
+	configureFlags(cmd)
+	return cmd
+}
+
+func configureFlags(cmd *cobra.Command) {
+	cmd.Flags().Bool(clobberFlag, false, "Overwrite the alias if it already exists")
+}
+
+func parseInput(p *print.Printer, cmd *cobra.Command, inputArgs []string) (*inputModel, error) {
+	alias := inputArgs[0]
+	err := aliases.ValidateName(alias)
+	if err != nil {
+		return nil, err
+	}
+
+	model := inputModel{
+		GlobalFlagModel: globalflags.Parse(p, cmd),
+		Alias:           alias,
+		Expansion:       inputArgs[1],
+		Clobber:         flags.FlagToBoolValue(p, cmd, clobberFlag),
+	}
+
+	if p.IsVerbosityDebug() {
+		modelStr, err := print.BuildDebugStrFromInputModel(model)
+		if err != nil {
+			p.Debug(print.ErrorLevel, "convert model to string for debugging: %v", err)
+		} else {
+			p.Debug(print.DebugLevel, "parsed input values: %s", modelStr)
+		}
+	}
+
+	return &model, nil
+}
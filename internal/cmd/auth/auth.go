@@ -5,6 +5,7 @@ import (
 	getaccesstoken "github.com/stackitcloud/stackit-cli/internal/cmd/auth/get-access-token"
 	"github.com/stackitcloud/stackit-cli/internal/cmd/auth/login"
 	"github.com/stackitcloud/stackit-cli/internal/cmd/auth/logout"
+	"github.com/stackitcloud/stackit-cli/internal/cmd/auth/storage"
 	"github.com/stackitcloud/stackit-cli/internal/cmd/params"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/args"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/utils"
@@ -29,4 +30,5 @@ func addSubcommands(cmd *cobra.Command, params *params.CmdParams) {
 	cmd.AddCommand(logout.NewCmd(params))
 	cmd.AddCommand(activateserviceaccount.NewCmd(params))
 	cmd.AddCommand(getaccesstoken.NewCmd(params))
+	cmd.AddCommand(storage.NewCmd(params))
 }
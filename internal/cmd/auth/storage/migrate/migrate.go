@@ -0,0 +1,129 @@
+package migrate
+
+import (
+	"fmt"
+
+	"github.com/stackitcloud/stackit-cli/internal/cmd/params"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/args"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/auth"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/config"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/examples"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/flags"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/globalflags"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/print"
+
+	"github.com/spf13/cobra"
+)
+
+const (
+	toFlag      = "to"
+	profileFlag = "profile"
+)
+
+type inputModel struct {
+	*globalflags.GlobalFlagModel
+	To      auth.StorageBackend
+	Profile string
+}
+
+func NewCmd(params *params.CmdParams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "migrate",
+		Short: "Moves the authentication credentials of a profile to another storage backend",
+		Long: fmt.Sprintf("%s\n%s\n%s\n%s\n%s",
+			"Moves the authentication credentials of a profile to another storage backend and uses it for that profile from then on.",
+			`Supported backends are "keyring" (OS keyring with an encoded text file as fallback), "encrypted-file" and "pass".`,
+			`The "encrypted-file" backend encrypts the credentials with a passphrase, read from the "STACKIT_AUTH_STORAGE_PASSPHRASE" environment variable or prompted for.`,
+			`The "pass" backend uses the "pass" password manager, or a compatible command set in the "STACKIT_AUTH_STORAGE_PASS_COMMAND" environment variable.`,
+			`The migration is refused while the "STACKIT_AUTH_STORAGE_BACKEND" environment variable is set, as it overrides the backend of the profile.`,
+		),
+		Args: args.NoArgs,
+		Example: examples.Build(
+			examples.NewExample(
+				`Move the credentials of the active profile to a passphrase-encrypted file`,
+				"$ stackit auth storage migrate --to encrypted-file"),
+			examples.NewExample(
+				`Move the credentials of the profile "my-profile" to the "pass" password manager`,
+				"$ stackit auth storage migrate --to pass --profile my-profile"),
+			examples.NewExample(
+				`Move the credentials of the active profile back to the OS keyring`,
+				"$ stackit auth storage migrate --to keyring"),
+		),
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			model, err := parseInput(params.Printer, cmd)
+			if err != nil {
+				return err
+			}
+
+			current, err := auth.GetStorageBackend(model.Profile)
+			if err != nil {
+				return fmt.Errorf("get current auth storage backend: %w", err)
+			}
+
+			if !model.AssumeYes {
+				prompt := fmt.Sprintf("Are you sure you want to move the credentials of profile %q from the %q to the %q storage backend?", model.Profile, current, model.To)
+				err = params.Printer.PromptForConfirmation(prompt)
+				if err != nil {
+					return err
+				}
+			}
+
+			migrated, err := auth.MigrateProfileAuthStorage(model.Profile, model.To)
+			if err != nil {
+				return fmt.Errorf("migrate auth storage: %w", err)
+			}
+			params.Printer.Debug(print.DebugLevel, "migrated %d auth fields", migrated)
+
+			params.Printer.Info("Moved the credentials of profile %q to the %q storage backend\n", model.Profile, model.To)
+			return nil
+		},
+	}
+	configureFlags(cmd)
+	return cmd
+}
+
+func configureFlags(cmd *cobra.Command) {
+	backends := []string{}
+	for _, backend := range auth.StorageBackends {
+		backends = append(backends, string(backend))
+	}
+	cmd.Flags().Var(flags.EnumFlag(false, "", backends...), toFlag, fmt.Sprintf("Target storage backend, one of %q", backends))
+	cmd.Flags().String(profileFlag, "", "Profile whose credentials are moved. If not set, the active profile is used")
+
+	err := flags.MarkFlagsRequired(cmd, toFlag)
+	cobra.CheckErr(err)
+}
+
+func parseInput(p *print.Printer, cmd *cobra.Command) (*inputModel, error) {
+	globalFlags := globalflags.Parse(p, cmd)
+
+	profile := flags.FlagToStringValue(p, cmd, profileFlag)
+	if profile == "" {
+		var err error
+		profile, err = config.GetProfile()
+		if err != nil {
+			return nil, fmt.Errorf("get profile: %w", err)
+		}
+	}
+	err := config.ValidateProfile(profile)
+	if err != nil {
+		return nil, err
+	}
+
+	model := inputModel{
+		GlobalFlagModel: globalFlags,
+		To:              auth.StorageBackend(flags.FlagToStringValue(p, cmd, toFlag)),
+		Profile:         profile,
+	}
+
+	if p.IsVerbosityDebug() {
+		modelStr, err := print.BuildDebugStrFromInputModel(model)
+		if err != nil {
+			p.Debug(print.ErrorLevel, "convert model to string for debugging: %v", err)
+		} else {
+			p.Debug(print.DebugLevel, "parsed input values: %s", modelStr)
+		}
+	}
+
+	return &model, nil
+}
@@ -0,0 +1,32 @@
+package storage
+
+import (
+	"fmt"
+
+	"github.com/stackitcloud/stackit-cli/internal/cmd/auth/storage/migrate"
+	"github.com/stackitcloud/stackit-cli/internal/cmd/params"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/args"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/utils"
+
+	"github.com/spf13/cobra"
+)
+
+func NewCmd(params *params.CmdParams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "storage",
+		Short: "Manages the storage of the authentication credentials",
+		Long: fmt.Sprintf("%s\n%s\n%s",
+			"Manages the storage backend where the STACKIT CLI stores the authentication credentials of each profile.",
+			`By default, the OS keyring is used, falling back to an encoded text file if the keyring is unavailable.`,
+			`The backend can be overridden via the "STACKIT_AUTH_STORAGE_BACKEND" environment variable.`,
+		),
+		Args: args.NoArgs,
+		Run:  utils.CmdHelp,
+	}
+	addSubcommands(cmd, params)
+	return cmd
+}
+
+func addSubcommands(cmd *cobra.Command, params *params.CmdParams) {
+	cmd.AddCommand(migrate.NewCmd(params))
+}
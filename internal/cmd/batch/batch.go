@@ -0,0 +1,473 @@
+package batch
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	goerrors "errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/stackitcloud/stackit-cli/internal/cmd/params"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/args"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/auth"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/errors"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/examples"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/flags"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/globalflags"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/history"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/print"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/projectname"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/tables"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/transport"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/utils"
+
+	"github.com/goccy/go-yaml"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+const (
+	fileFlag        = "file"
+	parallelFlag    = "parallel"
+	stopOnErrorFlag = "stop-on-error"
+
+	stdinFile = "-"
+
+	statusSucceeded = "succeeded"
+	statusFailed    = "failed"
+	statusSkipped   = "skipped"
+)
+
+// Commands that change the configuration or the authentication, which can't run in parallel
+var configCommands = []string{"alias", "auth", "config"}
+
+type inputModel struct {
+	*globalflags.GlobalFlagModel
+	File        string
+	Parallel    int64
+	StopOnError bool
+}
+
+// batchCommand is a CLI invocation read from the batch file
+type batchCommand struct {
+	Line    int
+	Command string
+	Args    []string
+}
+
+type commandResult struct {
+	Line       int                  `json:"line"`
+	Command    string               `json:"command"`
+	Status     string               `json:"status"`
+	ExitCode   int                  `json:"exit_code"`
+	Output     any                  `json:"output,omitempty"`
+	Stderr     string               `json:"stderr,omitempty"`
+	Error      *errors.ErrorDetails `json:"error,omitempty"`
+	DurationMs int64                `json:"duration_ms"`
+}
+
+type report struct {
+	Succeeded int              `json:"succeeded"`
+	Failed    int              `json:"failed"`
+	Skipped   int              `json:"skipped"`
+	Results   []*commandResult `json:"results"`
+}
+
+// NewCmd returns the batch command. newRootCmd creates a new, independent command tree to run each command of the batch.
+func NewCmd(params *params.CmdParams, newRootCmd func(p *print.Printer) *cobra.Command) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "batch",
+		Short: "Runs many CLI commands in a single process",
+		Long: fmt.Sprintf("%s\n%s\n%s\n%s",
+			"Runs many CLI commands in a single process, reusing the configuration and, with a service account key, the access token. Commands are read from a file or from stdin, one per line, without the \"stackit\" prefix. Empty lines and lines starting with \"#\" are ignored.",
+			"The global flags set on the batch command (e.g. --project-id or --assume-yes) apply to all the commands. Commands run non-interactively, so confirmation prompts fail unless --assume-yes is set.",
+			fmt.Sprintf("With --%s, commands run concurrently and can't set global flags stored in the configuration, such as --project-id or --output-format, nor change the configuration with the auth, config or alias commands.", parallelFlag),
+			"Prints a report with the result of each command, in JSON if the output format is json. Fails if any command failed.",
+		),
+		Args: args.NoArgs,
+		Example: examples.Build(
+			examples.NewExample(
+				`Run the commands in the file "commands.txt"`,
+				"$ stackit batch -f commands.txt"),
+			examples.NewExample(
+				`Run the commands read from stdin, 4 at a time, and print a JSON report`,
+				"$ cat commands.txt | stackit batch --parallel 4 --assume-yes --output-format json"),
+			examples.NewExample(
+				`Run the commands in the file "commands.txt", skipping the remaining commands after the first failure`,
+				"$ stackit batch -f commands.txt --stop-on-error"),
+		),
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			model, err := parseInput(params.Printer, cmd)
+			if err != nil {
+				return err
+			}
+
+			var r io.Reader = cmd.InOrStdin()
+			if model.File != stdinFile {
+				f, err := os.Open(model.File)
+				if err != nil {
+					return fmt.Errorf("open batch file: %w", err)
+				}
+				defer func() { _ = f.Close() }()
+				r = f
+			}
+			commands, err := readCommands(r)
+			if err != nil {
+				return fmt.Errorf("read batch file: %w", err)
+			}
+			if model.Parallel > 1 {
+				for _, c := range commands {
+					if flag, ok := findBoundGlobalFlag(cmd.Root().PersistentFlags(), c.Args); ok {
+						return fmt.Errorf("line %d: the global flag --%s can't be set per command when running commands in parallel, set it on the batch command instead", c.Line, flag)
+					}
+					if len(c.Args) > 0 && slices.Contains(configCommands, c.Args[0]) {
+						return fmt.Errorf("line %d: %q commands change the configuration and can't run in parallel", c.Line, c.Args[0])
+					}
+				}
+
+				// Commands running in parallel can't write the configuration, so the project name is got and stored once upfront
+				if model.ProjectId != "" {
+					_, err := projectname.GetProjectName(context.Background(), params.Printer, params.CliVersion, cmd)
+					if err != nil {
+						params.Printer.Debug(print.ErrorLevel, "get project name: %v", err)
+					}
+				}
+				projectname.SkipStoring(true)
+				defer projectname.SkipStoring(false)
+			}
+
+			auth.ReuseAuthenticationConfig(params.Printer)
+			runner := &batchRunner{
+				printer:    params.Printer,
+				newRootCmd: newRootCmd,
+				globalArgs: changedGlobalFlagArgs(cmd),
+				batchFlags: cmd.Root().PersistentFlags(),
+				model:      model,
+			}
+			results := runner.run(commands)
+
+			// The commands bind the global flags of their own command trees, restore the ones of this command
+			err = globalflags.Bind(cmd.Root().PersistentFlags())
+			if err != nil {
+				return err
+			}
+
+			rep := newReport(results)
+			err = outputResult(params.Printer, model.OutputFormat, rep)
+			if err != nil {
+				return err
+			}
+			if rep.Failed > 0 {
+				return fmt.Errorf("%d of %d commands failed", rep.Failed, len(results))
+			}
+			return nil
+		},
+	}
+	configureFlags(cmd)
+	return cmd
+}
+
+func configureFlags(cmd *cobra.Command) {
+	cmd.Flags().StringP(fileFlag, "f", stdinFile, `File with the commands to run, one per line. "-" reads the commands from stdin`)
+	cmd.Flags().Int64(parallelFlag, 1, "Maximum number of commands to run at the same time")
+	cmd.Flags().Bool(stopOnErrorFlag, false, "If set, the remaining commands are skipped after a command fails")
+}
+
+func parseInput(p *print.Printer, cmd *cobra.Command) (*inputModel, error) {
+	globalFlags := globalflags.Parse(p, cmd)
+
+	parallel := flags.FlagToInt64Pointer(p, cmd, parallelFlag)
+	if parallel != nil && *parallel < 1 {
+		return nil, &errors.FlagValidationError{
+			Flag:    parallelFlag,
+			Details: "must be at least 1",
+		}
+	}
+
+	model := inputModel{
+		GlobalFlagModel: globalFlags,
+		File:            flags.FlagWithDefaultToStringValue(p, cmd, fileFlag),
+		Parallel:        1,
+		StopOnError:     flags.FlagToBoolValue(p, cmd, stopOnErrorFlag),
+	}
+	if parallel != nil {
+		model.Parallel = *parallel
+	}
+
+	if p.IsVerbosityDebug() {
+		modelStr, err := print.BuildDebugStrFromInputModel(model)
+		if err != nil {
+			p.Debug(print.ErrorLevel, "convert model to string for debugging: %v", err)
+		} else {
+			p.Debug(print.DebugLevel, "parsed input values: %s", modelStr)
+		}
+	}
+
+	return &model, nil
+}
+
+// readCommands reads the commands to run, one per line. Empty lines and comments are ignored.
+func readCommands(r io.Reader) ([]batchCommand, error) {
+	commands := []batchCommand{}
+	scanner := bufio.NewScanner(r)
+	line := 0
+	for scanner.Scan() {
+		line++
+		command := strings.TrimSpace(scanner.Text())
+		if command == "" || strings.HasPrefix(command, "#") {
+			continue
+		}
+		// Allow copying commands from scripts as they are
+		command = strings.TrimSpace(strings.TrimPrefix(command, "stackit "))
+
+		cmdArgs, err := utils.SplitArgs(command)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		commands = append(commands, batchCommand{
+			Line:    line,
+			Command: command,
+			Args:    cmdArgs,
+		})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return commands, nil
+}
+
+// changedGlobalFlagArgs returns the global flags set on the batch command, as arguments to pass to each command
+func changedGlobalFlagArgs(cmd *cobra.Command) []string {
+	globalArgs := []string{}
+	cmd.InheritedFlags().VisitAll(func(f *pflag.Flag) {
+		if f.Changed {
+			globalArgs = append(globalArgs, fmt.Sprintf("--%s=%s", f.Name, f.Value.String()))
+		}
+	})
+	return globalArgs
+}
+
+// findBoundGlobalFlag returns the name of the first global flag bound to a configuration key set in the given arguments, if any
+func findBoundGlobalFlag(globalFlags *pflag.FlagSet, cmdArgs []string) (string, bool) {
+	for _, arg := range cmdArgs {
+		var f *pflag.Flag
+		switch {
+		case strings.HasPrefix(arg, "--"):
+			name, _, _ := strings.Cut(strings.TrimPrefix(arg, "--"), "=")
+			f = globalFlags.Lookup(name)
+		case strings.HasPrefix(arg, "-") && len(arg) > 1:
+			f = globalFlags.ShorthandLookup(arg[1:2])
+		}
+		if f != nil && globalflags.IsBound(f.Name) {
+			return f.Name, true
+		}
+	}
+	return "", false
+}
+
+type batchRunner struct {
+	// Printer of the batch command, whose logger is shared with the commands
+	printer    *print.Printer
+	newRootCmd func(p *print.Printer) *cobra.Command
+	// Global flags set on the batch command, passed to each command
+	globalArgs []string
+	// Global flags of the batch command
+	batchFlags *pflag.FlagSet
+	model      *inputModel
+}
+
+// run runs the commands, at most model.Parallel at the same time, and returns their results in the same order
+func (r *batchRunner) run(commands []batchCommand) []*commandResult {
+	results := make([]*commandResult, len(commands))
+	for i, c := range commands {
+		results[i] = &commandResult{
+			Line:     c.Line,
+			Command:  c.Command,
+			Status:   statusSkipped,
+			ExitCode: errors.ExitCodeSuccess,
+		}
+	}
+
+	if r.model.Parallel == 1 {
+		for i, c := range commands {
+			p := r.printer.NewChildPrinter()
+			r.runCommand(p, r.newRootCmd(p), c, results[i], true)
+			if results[i].Status == statusFailed && r.model.StopOnError {
+				break
+			}
+		}
+		return results
+	}
+
+	// Command trees are created upfront, as creating them binds their global flags to the configuration, which isn't safe
+	// for concurrent use. Commands running in parallel can't set those global flags, see findBoundGlobalFlag
+	printers := make([]*print.Printer, len(commands))
+	rootCmds := make([]*cobra.Command, len(commands))
+	for i := range commands {
+		printers[i] = r.printer.NewChildPrinter()
+		rootCmds[i] = r.newRootCmd(printers[i])
+	}
+	// All commands read the global flags of the batch command, which are the same as theirs
+	err := globalflags.Bind(r.batchFlags)
+	if err != nil {
+		for _, result := range results {
+			result.Status = statusFailed
+			result.ExitCode = errors.ExitCode(err)
+			result.Error = errors.NewErrorDetails(err, "")
+		}
+		return results
+	}
+
+	var failed atomic.Bool
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, r.model.Parallel)
+	for i, c := range commands {
+		sem <- struct{}{}
+		if failed.Load() && r.model.StopOnError {
+			<-sem
+			break
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			r.runCommand(printers[i], rootCmds[i], c, results[i], false)
+			if results[i].Status == statusFailed {
+				failed.Store(true)
+			}
+		}()
+	}
+	wg.Wait()
+	return results
+}
+
+// runCommand runs a single command in the given command tree, created with the given printer, capturing its output in the result.
+// The ID of the failed request is only included if the command is the only one running.
+func (r *batchRunner) runCommand(p *print.Printer, rootCmd *cobra.Command, c batchCommand, result *commandResult, sequential bool) {
+	stdout := &bytes.Buffer{}
+	stderr := &bytes.Buffer{}
+	rootCmd.SetArgs(append(append([]string{}, c.Args...), r.globalArgs...))
+	rootCmd.SetOut(stdout)
+	rootCmd.SetErr(stderr)
+	// Commands run non-interactively
+	rootCmd.SetIn(strings.NewReader(""))
+
+	start := time.Now()
+	err := rootCmd.Execute()
+	result.DurationMs = time.Since(start).Milliseconds()
+	if historyErr := history.Save(p, p.Cmd, err); historyErr != nil {
+		p.Warn("%v\n", historyErr)
+	}
+	if goerrors.Is(err, transport.ErrDryRun) {
+		err = nil
+	}
+
+	result.Output = parseOutput(stdout.Bytes())
+	result.Stderr = stderr.String()
+	result.Status = statusSucceeded
+	if err != nil {
+		requestId := ""
+		if sequential {
+			requestId = transport.LastFailedRequestId()
+		}
+		result.Status = statusFailed
+		result.ExitCode = errors.ExitCode(err)
+		result.Error = errors.NewErrorDetails(err, requestId)
+	}
+}
+
+// parseOutput returns the output of a command as a JSON value if it is valid JSON, as a string otherwise
+func parseOutput(output []byte) any {
+	if len(bytes.TrimSpace(output)) == 0 {
+		return nil
+	}
+	var value any
+	if json.Unmarshal(output, &value) == nil {
+		return value
+	}
+	return string(output)
+}
+
+func newReport(results []*commandResult) *report {
+	rep := &report{Results: results}
+	for _, result := range results {
+		switch result.Status {
+		case statusSucceeded:
+			rep.Succeeded++
+		case statusFailed:
+			rep.Failed++
+		case statusSkipped:
+			rep.Skipped++
+		}
+	}
+	return rep
+}
+
+func outputResult(p *print.Printer, outputFormat string, rep *report) error {
+	switch outputFormat {
+	case print.JSONOutputFormat:
+		details, err := json.MarshalIndent(rep, "", "  ")
+		if err != nil {
+			return fmt.Errorf("marshal batch report: %w", err)
+		}
+		p.Outputln(string(details))
+
+		return nil
+	case print.YAMLOutputFormat:
+		details, err := yaml.MarshalWithOptions(rep, yaml.IndentSequence(true), yaml.UseJSONMarshaler())
+		if err != nil {
+			return fmt.Errorf("marshal batch report: %w", err)
+		}
+		p.Outputln(string(details))
+
+		return nil
+	default:
+		for _, result := range rep.Results {
+			if result.Status == statusSkipped {
+				continue
+			}
+			p.Outputf("$ stackit %s\n", result.Command)
+			if output, ok := result.Output.(string); ok {
+				p.Outputf("%s", output)
+			} else if result.Output != nil {
+				details, err := json.MarshalIndent(result.Output, "", "  ")
+				if err != nil {
+					return fmt.Errorf("marshal command output: %w", err)
+				}
+				p.Outputln(string(details))
+			}
+			p.Outputf("%s", result.Stderr)
+			if result.Error != nil {
+				p.Outputf("Error: %s\n", result.Error.Message)
+			}
+			p.Outputln("")
+		}
+
+		table := tables.NewTable()
+		table.SetHeader("LINE", "COMMAND", "STATUS", "EXIT CODE", "DURATION")
+		for _, result := range rep.Results {
+			table.AddRow(
+				strconv.Itoa(result.Line),
+				utils.Truncate(&result.Command, 50),
+				result.Status,
+				strconv.Itoa(result.ExitCode),
+				(time.Duration(result.DurationMs) * time.Millisecond).String(),
+			)
+		}
+		err := table.Display(p)
+		if err != nil {
+			return fmt.Errorf("render table: %w", err)
+		}
+		p.Outputf("%d succeeded, %d failed, %d skipped\n", rep.Succeeded, rep.Failed, rep.Skipped)
+		return nil
+	}
+}
@@ -0,0 +1,417 @@
+package batch
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stackitcloud/stackit-cli/internal/cmd/params"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/globalflags"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/print"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/uuid"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+var testProjectId = uuid.NewString()
+
+func fixtureFlagValues(mods ...func(flagValues map[string]string)) map[string]string {
+	flagValues := map[string]string{
+		globalflags.ProjectIdFlag: testProjectId,
+		fileFlag:                  "commands.txt",
+		parallelFlag:              "4",
+		stopOnErrorFlag:           "true",
+	}
+	for _, mod := range mods {
+		mod(flagValues)
+	}
+	return flagValues
+}
+
+func fixtureInputModel(mods ...func(model *inputModel)) *inputModel {
+	model := &inputModel{
+		GlobalFlagModel: &globalflags.GlobalFlagModel{
+			ProjectId: testProjectId,
+			Verbosity: globalflags.VerbosityDefault,
+		},
+		File:        "commands.txt",
+		Parallel:    4,
+		StopOnError: true,
+	}
+	for _, mod := range mods {
+		mod(model)
+	}
+	return model
+}
+
+func TestParseInput(t *testing.T) {
+	tests := []struct {
+		description   string
+		flagValues    map[string]string
+		isValid       bool
+		expectedModel *inputModel
+	}{
+		{
+			description:   "base",
+			flagValues:    fixtureFlagValues(),
+			isValid:       true,
+			expectedModel: fixtureInputModel(),
+		},
+		{
+			description: "defaults",
+			flagValues:  map[string]string{},
+			isValid:     true,
+			expectedModel: &inputModel{
+				GlobalFlagModel: &globalflags.GlobalFlagModel{
+					Verbosity: globalflags.VerbosityDefault,
+				},
+				File:     stdinFile,
+				Parallel: 1,
+			},
+		},
+		{
+			description: "parallel is zero",
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				flagValues[parallelFlag] = "0"
+			}),
+			isValid: false,
+		},
+		{
+			description: "parallel is not a number",
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				flagValues[parallelFlag] = "many"
+			}),
+			isValid: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			p := print.NewPrinter()
+			cmd := NewCmd(&params.CmdParams{Printer: p}, nil)
+			err := globalflags.Configure(cmd.Flags())
+			if err != nil {
+				t.Fatalf("configure global flags: %v", err)
+			}
+
+			for flag, value := range tt.flagValues {
+				err := cmd.Flags().Set(flag, value)
+				if err != nil {
+					if !tt.isValid {
+						return
+					}
+					t.Fatalf("setting flag --%s=%s: %v", flag, value, err)
+				}
+			}
+
+			err = cmd.ValidateRequiredFlags()
+			if err != nil {
+				if !tt.isValid {
+					return
+				}
+				t.Fatalf("error validating flags: %v", err)
+			}
+
+			model, err := parseInput(p, cmd)
+			if err != nil {
+				if !tt.isValid {
+					return
+				}
+				t.Fatalf("error parsing input: %v", err)
+			}
+
+			if !tt.isValid {
+				t.Fatalf("did not fail on invalid input")
+			}
+			diff := cmp.Diff(model, tt.expectedModel)
+			if diff != "" {
+				t.Fatalf("Data does not match: %s", diff)
+			}
+		})
+	}
+}
+
+func TestReadCommands(t *testing.T) {
+	tests := []struct {
+		description      string
+		input            string
+		isValid          bool
+		expectedCommands []batchCommand
+	}{
+		{
+			description: "base",
+			input:       "config list\ndns zone list --limit 5\n",
+			isValid:     true,
+			expectedCommands: []batchCommand{
+				{Line: 1, Command: "config list", Args: []string{"config", "list"}},
+				{Line: 2, Command: "dns zone list --limit 5", Args: []string{"dns", "zone", "list", "--limit", "5"}},
+			},
+		},
+		{
+			description: "empty lines and comments",
+			input:       "# comment\n\nconfig list\n   \n  # indented comment\n",
+			isValid:     true,
+			expectedCommands: []batchCommand{
+				{Line: 3, Command: "config list", Args: []string{"config", "list"}},
+			},
+		},
+		{
+			description: "stackit prefix",
+			input:       "stackit config list",
+			isValid:     true,
+			expectedCommands: []batchCommand{
+				{Line: 1, Command: "config list", Args: []string{"config", "list"}},
+			},
+		},
+		{
+			description: "quoted arguments",
+			input:       `dns zone create --name "my zone" --dns-name 'example.com'`,
+			isValid:     true,
+			expectedCommands: []batchCommand{
+				{
+					Line:    1,
+					Command: `dns zone create --name "my zone" --dns-name 'example.com'`,
+					Args:    []string{"dns", "zone", "create", "--name", "my zone", "--dns-name", "example.com"},
+				},
+			},
+		},
+		{
+			description:      "empty",
+			input:            "",
+			isValid:          true,
+			expectedCommands: []batchCommand{},
+		},
+		{
+			description: "unterminated quote",
+			input:       "config list\ndns zone create --name \"my zone\n",
+			isValid:     false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			commands, err := readCommands(strings.NewReader(tt.input))
+			if err != nil {
+				if !tt.isValid {
+					return
+				}
+				t.Fatalf("read commands: %v", err)
+			}
+			if !tt.isValid {
+				t.Fatalf("did not fail on invalid input")
+			}
+			diff := cmp.Diff(commands, tt.expectedCommands)
+			if diff != "" {
+				t.Fatalf("Data does not match: %s", diff)
+			}
+		})
+	}
+}
+
+func TestFindBoundGlobalFlag(t *testing.T) {
+	tests := []struct {
+		description  string
+		args         []string
+		expectedFlag string
+		expectedOk   bool
+	}{
+		{
+			description: "no global flags",
+			args:        []string{"dns", "zone", "list", "--limit", "5"},
+		},
+		{
+			description: "global flag not bound to the configuration",
+			args:        []string{"dns", "zone", "delete", "xxx", "--assume-yes"},
+		},
+		{
+			description:  "long flag",
+			args:         []string{"dns", "zone", "list", "--project-id", testProjectId},
+			expectedFlag: globalflags.ProjectIdFlag,
+			expectedOk:   true,
+		},
+		{
+			description:  "long flag with value",
+			args:         []string{"dns", "zone", "list", "--output-format=json"},
+			expectedFlag: globalflags.OutputFormatFlag,
+			expectedOk:   true,
+		},
+		{
+			description:  "shorthand",
+			args:         []string{"dns", "zone", "list", "-ojson"},
+			expectedFlag: globalflags.OutputFormatFlag,
+			expectedOk:   true,
+		},
+	}
+
+	globalFlags := pflag.NewFlagSet("global", pflag.ContinueOnError)
+	err := globalflags.Configure(globalFlags)
+	if err != nil {
+		t.Fatalf("configure global flags: %v", err)
+	}
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			flag, ok := findBoundGlobalFlag(globalFlags, tt.args)
+			if flag != tt.expectedFlag || ok != tt.expectedOk {
+				t.Fatalf("expected (%q, %t), got (%q, %t)", tt.expectedFlag, tt.expectedOk, flag, ok)
+			}
+		})
+	}
+}
+
+func TestRun(t *testing.T) {
+	tests := []struct {
+		description      string
+		commands         string
+		parallel         int64
+		stopOnError      bool
+		expectedStatuses []string
+		expectedOutputs  []any
+	}{
+		{
+			description:      "sequential",
+			commands:         "succeed foo\nfail\nsucceed bar",
+			parallel:         1,
+			expectedStatuses: []string{statusSucceeded, statusFailed, statusSucceeded},
+			expectedOutputs:  []any{"foo\n", nil, "bar\n"},
+		},
+		{
+			description:      "sequential stops on error",
+			commands:         "succeed foo\nfail\nsucceed bar",
+			parallel:         1,
+			stopOnError:      true,
+			expectedStatuses: []string{statusSucceeded, statusFailed, statusSkipped},
+			expectedOutputs:  []any{"foo\n", nil, nil},
+		},
+		{
+			description:      "parallel",
+			commands:         "succeed foo\nfail\nsucceed bar\nsucceed '{\"a\":1}'",
+			parallel:         3,
+			expectedStatuses: []string{statusSucceeded, statusFailed, statusSucceeded, statusSucceeded},
+			expectedOutputs:  []any{"foo\n", nil, "bar\n", map[string]any{"a": float64(1)}},
+		},
+		{
+			description:      "unknown command",
+			commands:         "unknown",
+			parallel:         1,
+			expectedStatuses: []string{statusFailed},
+			expectedOutputs:  []any{nil},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			batchFlags := pflag.NewFlagSet("global", pflag.ContinueOnError)
+			err := globalflags.Configure(batchFlags)
+			if err != nil {
+				t.Fatalf("configure global flags: %v", err)
+			}
+			commands, err := readCommands(strings.NewReader(tt.commands))
+			if err != nil {
+				t.Fatalf("read commands: %v", err)
+			}
+
+			runner := &batchRunner{
+				printer:    print.NewPrinter(),
+				newRootCmd: newTestRootCmd,
+				globalArgs: []string{},
+				batchFlags: batchFlags,
+				model: fixtureInputModel(func(model *inputModel) {
+					model.Parallel = tt.parallel
+					model.StopOnError = tt.stopOnError
+				}),
+			}
+			results := runner.run(commands)
+
+			statuses := []string{}
+			outputs := []any{}
+			for _, result := range results {
+				statuses = append(statuses, result.Status)
+				outputs = append(outputs, result.Output)
+			}
+			diff := cmp.Diff(statuses, tt.expectedStatuses)
+			if diff != "" {
+				t.Fatalf("Statuses do not match: %s", diff)
+			}
+			diff = cmp.Diff(outputs, tt.expectedOutputs)
+			if diff != "" {
+				t.Fatalf("Outputs do not match: %s", diff)
+			}
+		})
+	}
+}
+
+// newTestRootCmd returns a command tree with a "succeed" command printing its argument and a "fail" command
+func newTestRootCmd(_ *print.Printer) *cobra.Command {
+	rootCmd := &cobra.Command{
+		Use:           "stackit",
+		SilenceErrors: true,
+		SilenceUsage:  true,
+	}
+	rootCmd.AddCommand(
+		&cobra.Command{
+			Use:  "succeed",
+			Args: cobra.ExactArgs(1),
+			RunE: func(cmd *cobra.Command, args []string) error {
+				cmd.Println(args[0])
+				return nil
+			},
+		},
+		&cobra.Command{
+			Use: "fail",
+			RunE: func(_ *cobra.Command, _ []string) error {
+				return fmt.Errorf("failed")
+			},
+		},
+	)
+	return rootCmd
+}
+
+func TestOutputResult(t *testing.T) {
+	type args struct {
+		outputFormat string
+		rep          *report
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "empty",
+			args: args{
+				rep: &report{},
+			},
+			wantErr: false,
+		},
+		{
+			name: "results",
+			args: args{
+				rep: newReport([]*commandResult{
+					{Line: 1, Command: "config list", Status: statusSucceeded, Output: "output"},
+					{Line: 2, Command: "fail", Status: statusFailed, ExitCode: 1},
+					{Line: 3, Command: "config list", Status: statusSkipped},
+				}),
+			},
+			wantErr: false,
+		},
+		{
+			name: "json",
+			args: args{
+				outputFormat: print.JSONOutputFormat,
+				rep:          newReport([]*commandResult{{Line: 1, Command: "config list", Status: statusSucceeded}}),
+			},
+			wantErr: false,
+		},
+	}
+	p := print.NewPrinter()
+	p.Cmd = NewCmd(&params.CmdParams{Printer: p}, nil)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := outputResult(p, tt.args.outputFormat, tt.args.rep); (err != nil) != tt.wantErr {
+				t.Errorf("outputResult() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
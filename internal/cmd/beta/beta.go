@@ -4,8 +4,8 @@ import (
 	"fmt"
 
 	"github.com/stackitcloud/stackit-cli/internal/cmd/beta/alb"
-	"github.com/stackitcloud/stackit-cli/internal/cmd/beta/sqlserverflex"
 	"github.com/stackitcloud/stackit-cli/internal/cmd/params"
+	"github.com/stackitcloud/stackit-cli/internal/cmd/sqlserverflex"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/args"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/examples"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/utils"
@@ -36,6 +36,18 @@ func NewCmd(params *params.CmdParams) *cobra.Command {
 }
 
 func addSubcommands(cmd *cobra.Command, params *params.CmdParams) {
-	cmd.AddCommand(sqlserverflex.NewCmd(params))
 	cmd.AddCommand(alb.NewCmd(params))
+
+	// SQLServer Flex graduated from beta, the old command path is kept so existing scripts don't break
+	sqlserverflexCmd := sqlserverflex.NewCmd(params)
+	deprecateCommands(sqlserverflexCmd, "stackit sqlserverflex")
+	cmd.AddCommand(sqlserverflexCmd)
+}
+
+// Marks cmd and all of its children as deprecated, pointing to the same command under newPath
+func deprecateCommands(cmd *cobra.Command, newPath string) {
+	cmd.Deprecated = fmt.Sprintf("use %q instead", newPath)
+	for _, c := range cmd.Commands() {
+		deprecateCommands(c, fmt.Sprintf("%s %s", newPath, c.Name()))
+	}
 }
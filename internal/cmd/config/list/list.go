@@ -14,6 +14,7 @@ import (
 	"github.com/stackitcloud/stackit-cli/internal/pkg/args"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/config"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/examples"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/flags"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/globalflags"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/print"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/tables"
@@ -22,22 +23,36 @@ import (
 	"github.com/spf13/viper"
 )
 
+const (
+	showOriginFlag = "show-origin"
+)
+
 type inputModel struct {
 	*globalflags.GlobalFlagModel
+	ShowOrigin bool
+}
+
+type configValueWithOrigin struct {
+	Value  any    `json:"value"`
+	Origin string `json:"origin"`
 }
 
 func NewCmd(params *params.CmdParams) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "list",
 		Short: "Lists the current CLI configuration values",
-		Long: fmt.Sprintf("%s\n%s\n%s\n%s\n%s\n%s\n%s",
+		Long: fmt.Sprintf("%s\n%s\n%s\n%s\n%s\n%s\n%s\n%s\n%s\n%s\n%s",
 			"Lists the current CLI configuration values, based on the following sources (in order of precedence):",
 			"- Environment variable",
 			`  The environment variable is the name of the setting, with underscores ("_") instead of dashes ("-") and the "STACKIT" prefix.`,
 			"  Example: you can set the project ID by setting the environment variable STACKIT_PROJECT_ID.",
+			fmt.Sprintf("- Directory configuration file %q", config.DirectoryConfigFileName),
+			"  The file is searched for in the current directory and its parents, and can set the project ID, region, output format and custom endpoints.",
+			fmt.Sprintf(`  Example: you can set the project ID for a directory by adding "project_id: xxx" to the %q file in that directory.`, config.DirectoryConfigFileName),
 			"- Configuration set in CLI",
 			`  These are set using the "stackit config set" command`,
 			`  Example: you can set the project ID by running "stackit config set --project-id xxx"`,
+			`Use the "--show-origin" flag to show which source each value comes from.`,
 		),
 		Args: args.NoArgs,
 		Example: examples.Build(
@@ -47,6 +62,9 @@ func NewCmd(params *params.CmdParams) *cobra.Command {
 			examples.NewExample(
 				`List your active configuration in a json format`,
 				"$ stackit config list --output-format json"),
+			examples.NewExample(
+				`List your active configuration, showing where each value comes from`,
+				"$ stackit config list --show-origin"),
 		),
 		RunE: func(cmd *cobra.Command, _ []string) error {
 			configData := viper.AllSettings()
@@ -58,21 +76,63 @@ func NewCmd(params *params.CmdParams) *cobra.Command {
 				return fmt.Errorf("get profile: %w", err)
 			}
 
-			return outputResult(params.Printer, model.OutputFormat, configData, activeProfile)
+			var origins map[string]string
+			if model.ShowOrigin {
+				origins = getOrigins(cmd, configData)
+			}
+
+			return outputResult(params.Printer, model.OutputFormat, configData, origins, activeProfile)
 		},
 	}
+	configureFlags(cmd)
 	return cmd
 }
 
+func configureFlags(cmd *cobra.Command) {
+	cmd.Flags().Bool(showOriginFlag, false, "Show the source (flag, environment variable, directory or profile configuration file, or default) of each configuration value")
+}
+
 func parseInput(p *print.Printer, cmd *cobra.Command) *inputModel {
 	globalFlags := globalflags.Parse(p, cmd)
 
 	return &inputModel{
 		GlobalFlagModel: globalFlags,
+		ShowOrigin:      flags.FlagToBoolValue(p, cmd, showOriginFlag),
 	}
 }
 
-func outputResult(p *print.Printer, outputFormat string, configData map[string]any, activeProfile string) error {
+// getOrigins returns a description of the source of each configuration value
+func getOrigins(cmd *cobra.Command, configData map[string]any) map[string]string {
+	origins := map[string]string{}
+	for key := range configData {
+		// Global flags bound to config keys have the same name as the key, with dashes instead of underscores
+		flag := cmd.Flags().Lookup(strings.ReplaceAll(key, "_", "-"))
+		if flag != nil && flag.Changed {
+			origins[key] = config.FlagOrigin
+			continue
+		}
+
+		origin, source := config.GetOrigin(key)
+		if source != "" {
+			origin = fmt.Sprintf("%s (%s)", origin, source)
+		}
+		origins[key] = origin
+	}
+	return origins
+}
+
+func outputResult(p *print.Printer, outputFormat string, configData map[string]any, origins map[string]string, activeProfile string) error {
+	if origins != nil && (outputFormat == print.JSONOutputFormat || outputFormat == print.YAMLOutputFormat) {
+		configDataWithOrigin := map[string]any{}
+		for key, value := range configData {
+			configDataWithOrigin[key] = configValueWithOrigin{
+				Value:  value,
+				Origin: origins[key],
+			}
+		}
+		configData = configDataWithOrigin
+	}
+
 	switch outputFormat {
 	case print.JSONOutputFormat:
 		if activeProfile != "" {
@@ -105,7 +165,11 @@ func outputResult(p *print.Printer, outputFormat string, configData map[string]a
 		if activeProfile != "" {
 			table.SetTitle(fmt.Sprintf("Profile: %q", activeProfile))
 		}
-		table.SetHeader("NAME", "VALUE")
+		if origins != nil {
+			table.SetHeader("NAME", "VALUE", "ORIGIN")
+		} else {
+			table.SetHeader("NAME", "VALUE")
+		}
 		for _, key := range configKeys {
 			value := configData[key]
 
@@ -132,9 +196,13 @@ func outputResult(p *print.Printer, outputFormat string, configData map[string]a
 			}
 
 			// Replace "_" with "-" to match the flags
-			key = strings.ReplaceAll(key, "_", "-")
+			name := strings.ReplaceAll(key, "_", "-")
 
-			table.AddRow(key, valueString)
+			if origins != nil {
+				table.AddRow(name, valueString, origins[key])
+			} else {
+				table.AddRow(name, valueString)
+			}
 			table.AddSeparator()
 		}
 		err := table.Display(p)
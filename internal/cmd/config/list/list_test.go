@@ -12,6 +12,7 @@ func TestOutputResult(t *testing.T) {
 	type args struct {
 		outputFormat  string
 		configData    map[string]any
+		origins       map[string]string
 		activeProfile string
 	}
 	tests := []struct {
@@ -24,12 +25,53 @@ func TestOutputResult(t *testing.T) {
 			args:    args{},
 			wantErr: false,
 		},
+		{
+			name: "with origins",
+			args: args{
+				configData: map[string]any{
+					"project_id": "xxx",
+					"region":     "eu01",
+				},
+				origins: map[string]string{
+					"project_id": "directory config (/tmp/.stackit.yaml)",
+					"region":     "default",
+				},
+				activeProfile: "default",
+			},
+			wantErr: false,
+		},
+		{
+			name: "with origins as json",
+			args: args{
+				outputFormat: print.JSONOutputFormat,
+				configData: map[string]any{
+					"project_id": "xxx",
+				},
+				origins: map[string]string{
+					"project_id": "environment variable (STACKIT_PROJECT_ID)",
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "with origins as yaml",
+			args: args{
+				outputFormat: print.YAMLOutputFormat,
+				configData: map[string]any{
+					"project_id": "xxx",
+				},
+				origins: map[string]string{
+					"project_id": "profile config (/tmp/cli-config.json)",
+				},
+			},
+			wantErr: false,
+		},
 	}
 	p := print.NewPrinter()
 	p.Cmd = NewCmd(&params.CmdParams{Printer: p})
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if err := outputResult(p, tt.args.outputFormat, tt.args.configData, tt.args.activeProfile); (err != nil) != tt.wantErr {
+			if err := outputResult(p, tt.args.outputFormat, tt.args.configData, tt.args.origins, tt.args.activeProfile); (err != nil) != tt.wantErr {
 				t.Errorf("outputResult() error = %v, wantErr %v", err, tt.wantErr)
 			}
 		})
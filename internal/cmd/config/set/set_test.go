@@ -119,6 +119,21 @@ func TestParseInput(t *testing.T) {
 				ProjectIdSet: true,
 			},
 		},
+		{
+			description: "my IP endpoint",
+			flagValues: map[string]string{
+				myIpEndpointFlag: "https://ip.example.com",
+			},
+			isValid:       true,
+			expectedModel: &inputModel{},
+		},
+		{
+			description: "my IP endpoint invalid",
+			flagValues: map[string]string{
+				myIpEndpointFlag: "ftp://ip.example.com",
+			},
+			isValid: false,
+		},
 	}
 
 	for _, tt := range tests {
@@ -12,20 +12,26 @@ import (
 	"github.com/stackitcloud/stackit-cli/internal/pkg/print"
 
 	"github.com/spf13/cobra"
-	"github.com/spf13/viper"
 )
 
 const (
-	asyncFlag        = globalflags.AsyncFlag
-	outputFormatFlag = globalflags.OutputFormatFlag
-	projectIdFlag    = globalflags.ProjectIdFlag
-	regionFlag       = globalflags.RegionFlag
-	verbosityFlag    = globalflags.VerbosityFlag
+	asyncFlag           = globalflags.AsyncFlag
+	outputFormatFlag    = globalflags.OutputFormatFlag
+	projectIdFlag       = globalflags.ProjectIdFlag
+	regionFlag          = globalflags.RegionFlag
+	verbosityFlag       = globalflags.VerbosityFlag
+	logFormatFlag       = globalflags.LogFormatFlag
+	logFileFlag         = globalflags.LogFileFlag
+	retriesFlag         = globalflags.RetriesFlag
+	retryMaxWaitFlag    = globalflags.RetryMaxWaitFlag
+	retryAllMethodsFlag = globalflags.RetryAllMethodsFlag
 
 	sessionTimeLimitFlag                             = "session-time-limit"
 	identityProviderCustomWellKnownConfigurationFlag = "identity-provider-custom-well-known-configuration"
 	identityProviderCustomClientIdFlag               = "identity-provider-custom-client-id"
 	allowedUrlDomainFlag                             = "allowed-url-domain"
+	historyForwardUrlFlag                            = "history-forward-url"
+	myIpEndpointFlag                                 = "my-ip-endpoint"
 
 	authorizationCustomEndpointFlag     = "authorization-custom-endpoint"
 	dnsCustomEndpointFlag               = "dns-custom-endpoint"
@@ -53,16 +59,23 @@ const (
 )
 
 type inputModel struct {
-	Async        bool
-	OutputFormat bool
-	ProjectId    bool
-	Region       bool
-	Verbosity    bool
+	Async           bool
+	OutputFormat    bool
+	ProjectId       bool
+	Region          bool
+	Verbosity       bool
+	LogFormat       bool
+	LogFile         bool
+	Retries         bool
+	RetryMaxWait    bool
+	RetryAllMethods bool
 
 	SessionTimeLimit               bool
 	IdentityProviderCustomEndpoint bool
 	IdentityProviderCustomClientID bool
 	AllowedUrlDomain               bool
+	HistoryForwardUrl              bool
+	MyIpEndpoint                   bool
 
 	AuthorizationCustomEndpoint     bool
 	DNSCustomEndpoint               bool
@@ -110,102 +123,123 @@ func NewCmd(params *params.CmdParams) *cobra.Command {
 			model := parseInput(params.Printer, cmd)
 
 			if model.Async {
-				viper.Set(config.AsyncKey, config.AsyncDefault)
+				config.Set(config.AsyncKey, config.AsyncDefault)
 			}
 			if model.OutputFormat {
-				viper.Set(config.OutputFormatKey, "")
+				config.Set(config.OutputFormatKey, "")
 			}
 			if model.ProjectId {
-				viper.Set(config.ProjectIdKey, "")
+				config.Set(config.ProjectIdKey, "")
 			}
 			if model.Region {
-				viper.Set(config.RegionKey, config.RegionDefault)
+				config.Set(config.RegionKey, config.RegionDefault)
 			}
 			if model.Verbosity {
-				viper.Set(config.VerbosityKey, globalflags.VerbosityDefault)
+				config.Set(config.VerbosityKey, globalflags.VerbosityDefault)
+			}
+			if model.LogFormat {
+				config.Set(config.LogFormatKey, globalflags.LogFormatDefault)
+			}
+			if model.LogFile {
+				config.Set(config.LogFileKey, "")
+			}
+			if model.Retries {
+				config.Set(config.RetriesKey, globalflags.RetriesDefault)
+			}
+			if model.RetryMaxWait {
+				config.Set(config.RetryMaxWaitKey, globalflags.RetryMaxWaitDefault)
+			}
+			if model.RetryAllMethods {
+				config.Set(config.RetryAllMethodsKey, false)
 			}
 
 			if model.SessionTimeLimit {
-				viper.Set(config.SessionTimeLimitKey, config.SessionTimeLimitDefault)
+				config.Set(config.SessionTimeLimitKey, config.SessionTimeLimitDefault)
 			}
 			if model.IdentityProviderCustomEndpoint {
-				viper.Set(config.IdentityProviderCustomWellKnownConfigurationKey, "")
+				config.Set(config.IdentityProviderCustomWellKnownConfigurationKey, "")
 			}
 			if model.IdentityProviderCustomClientID {
-				viper.Set(config.IdentityProviderCustomClientIdKey, "")
+				config.Set(config.IdentityProviderCustomClientIdKey, "")
 			}
 			if model.AllowedUrlDomain {
-				viper.Set(config.AllowedUrlDomainKey, config.AllowedUrlDomainDefault)
+				config.Set(config.AllowedUrlDomainKey, config.AllowedUrlDomainDefault)
+			}
+			if model.HistoryForwardUrl {
+				config.Set(config.HistoryForwardUrlKey, "")
+			}
+			if model.MyIpEndpoint {
+				config.Set(config.MyIpEndpointKey, "")
 			}
 
 			if model.ObservabilityCustomEndpoint {
-				viper.Set(config.ObservabilityCustomEndpointKey, "")
+				config.Set(config.ObservabilityCustomEndpointKey, "")
 			}
 			if model.AuthorizationCustomEndpoint {
-				viper.Set(config.AuthorizationCustomEndpointKey, "")
+				config.Set(config.AuthorizationCustomEndpointKey, "")
 			}
 			if model.DNSCustomEndpoint {
-				viper.Set(config.DNSCustomEndpointKey, "")
+				config.Set(config.DNSCustomEndpointKey, "")
 			}
 			if model.LoadBalancerCustomEndpoint {
-				viper.Set(config.LoadBalancerCustomEndpointKey, "")
+				config.Set(config.LoadBalancerCustomEndpointKey, "")
 			}
 			if model.LogMeCustomEndpoint {
-				viper.Set(config.LogMeCustomEndpointKey, "")
+				config.Set(config.LogMeCustomEndpointKey, "")
 			}
 			if model.MariaDBCustomEndpoint {
-				viper.Set(config.MariaDBCustomEndpointKey, "")
+				config.Set(config.MariaDBCustomEndpointKey, "")
 			}
 			if model.MongoDBFlexCustomEndpoint {
-				viper.Set(config.MongoDBFlexCustomEndpointKey, "")
+				config.Set(config.MongoDBFlexCustomEndpointKey, "")
 			}
 			if model.ObjectStorageCustomEndpoint {
-				viper.Set(config.ObjectStorageCustomEndpointKey, "")
+				config.Set(config.ObjectStorageCustomEndpointKey, "")
 			}
 			if model.OpenSearchCustomEndpoint {
-				viper.Set(config.OpenSearchCustomEndpointKey, "")
+				config.Set(config.OpenSearchCustomEndpointKey, "")
 			}
 			if model.PostgresFlexCustomEndpoint {
-				viper.Set(config.PostgresFlexCustomEndpointKey, "")
+				config.Set(config.PostgresFlexCustomEndpointKey, "")
 			}
 			if model.RabbitMQCustomEndpoint {
-				viper.Set(config.RabbitMQCustomEndpointKey, "")
+				config.Set(config.RabbitMQCustomEndpointKey, "")
 			}
 			if model.RedisCustomEndpoint {
-				viper.Set(config.RedisCustomEndpointKey, "")
+				config.Set(config.RedisCustomEndpointKey, "")
 			}
 			if model.ResourceManagerCustomEndpoint {
-				viper.Set(config.ResourceManagerEndpointKey, "")
+				config.Set(config.ResourceManagerEndpointKey, "")
 			}
 			if model.SecretsManagerCustomEndpoint {
-				viper.Set(config.SecretsManagerCustomEndpointKey, "")
+				config.Set(config.SecretsManagerCustomEndpointKey, "")
 			}
 			if model.ServiceAccountCustomEndpoint {
-				viper.Set(config.ServiceAccountCustomEndpointKey, "")
+				config.Set(config.ServiceAccountCustomEndpointKey, "")
 			}
 			if model.ServiceEnablementCustomEndpoint {
-				viper.Set(config.ServiceEnablementCustomEndpointKey, "")
+				config.Set(config.ServiceEnablementCustomEndpointKey, "")
 			}
 			if model.ServerBackupCustomEndpoint {
-				viper.Set(config.ServerBackupCustomEndpointKey, "")
+				config.Set(config.ServerBackupCustomEndpointKey, "")
 			}
 			if model.ServerOsUpdateCustomEndpoint {
-				viper.Set(config.ServerOsUpdateCustomEndpointKey, "")
+				config.Set(config.ServerOsUpdateCustomEndpointKey, "")
 			}
 			if model.RunCommandCustomEndpoint {
-				viper.Set(config.RunCommandCustomEndpointKey, "")
+				config.Set(config.RunCommandCustomEndpointKey, "")
 			}
 			if model.SKECustomEndpoint {
-				viper.Set(config.SKECustomEndpointKey, "")
+				config.Set(config.SKECustomEndpointKey, "")
 			}
 			if model.SQLServerFlexCustomEndpoint {
-				viper.Set(config.SQLServerFlexCustomEndpointKey, "")
+				config.Set(config.SQLServerFlexCustomEndpointKey, "")
 			}
 			if model.IaaSCustomEndpoint {
-				viper.Set(config.IaaSCustomEndpointKey, "")
+				config.Set(config.IaaSCustomEndpointKey, "")
 			}
 			if model.TokenCustomEndpoint {
-				viper.Set(config.TokenCustomEndpointKey, "")
+				config.Set(config.TokenCustomEndpointKey, "")
 			}
 
 			err := config.Write()
@@ -225,11 +259,18 @@ func configureFlags(cmd *cobra.Command) {
 	cmd.Flags().Bool(regionFlag, false, "Region")
 	cmd.Flags().Bool(outputFormatFlag, false, "Output format")
 	cmd.Flags().Bool(verbosityFlag, false, "Verbosity of the CLI")
+	cmd.Flags().Bool(logFormatFlag, false, "Format of the debug logs")
+	cmd.Flags().Bool(logFileFlag, false, "File the debug logs are written to")
+	cmd.Flags().Bool(retriesFlag, false, "Maximum number of retries of requests that failed due to transient errors")
+	cmd.Flags().Bool(retryMaxWaitFlag, false, "Maximum time to wait before retrying a request")
+	cmd.Flags().Bool(retryAllMethodsFlag, false, "Retry of requests that are not idempotent")
 
 	cmd.Flags().Bool(sessionTimeLimitFlag, false, fmt.Sprintf("Maximum time before authentication is required again. If unset, defaults to %s", config.SessionTimeLimitDefault))
 	cmd.Flags().Bool(identityProviderCustomWellKnownConfigurationFlag, false, "Identity Provider well-known OpenID configuration URL. If unset, uses the default identity provider")
 	cmd.Flags().Bool(identityProviderCustomClientIdFlag, false, "Identity Provider client ID, used for user authentication")
 	cmd.Flags().Bool(allowedUrlDomainFlag, false, fmt.Sprintf("Domain name, used for the verification of the URLs that are given in the IDP endpoint and curl commands. If unset, defaults to %s", config.AllowedUrlDomainDefault))
+	cmd.Flags().Bool(historyForwardUrlFlag, false, "Endpoint the records of commands that changed resources are forwarded to. If unset, records are only written to the local journal")
+	cmd.Flags().Bool(myIpEndpointFlag, false, "Endpoint used to detect the caller's public IP address in ACL commands. If unset, uses the default endpoint")
 
 	cmd.Flags().Bool(observabilityCustomEndpointFlag, false, "Observability API base URL. If unset, uses the default base URL")
 	cmd.Flags().Bool(authorizationCustomEndpointFlag, false, "Authorization API base URL. If unset, uses the default base URL")
@@ -258,16 +299,23 @@ func configureFlags(cmd *cobra.Command) {
 
 func parseInput(p *print.Printer, cmd *cobra.Command) *inputModel {
 	model := inputModel{
-		Async:        flags.FlagToBoolValue(p, cmd, asyncFlag),
-		OutputFormat: flags.FlagToBoolValue(p, cmd, outputFormatFlag),
-		ProjectId:    flags.FlagToBoolValue(p, cmd, projectIdFlag),
-		Region:       flags.FlagToBoolValue(p, cmd, regionFlag),
-		Verbosity:    flags.FlagToBoolValue(p, cmd, verbosityFlag),
+		Async:           flags.FlagToBoolValue(p, cmd, asyncFlag),
+		OutputFormat:    flags.FlagToBoolValue(p, cmd, outputFormatFlag),
+		ProjectId:       flags.FlagToBoolValue(p, cmd, projectIdFlag),
+		Region:          flags.FlagToBoolValue(p, cmd, regionFlag),
+		Verbosity:       flags.FlagToBoolValue(p, cmd, verbosityFlag),
+		LogFormat:       flags.FlagToBoolValue(p, cmd, logFormatFlag),
+		LogFile:         flags.FlagToBoolValue(p, cmd, logFileFlag),
+		Retries:         flags.FlagToBoolValue(p, cmd, retriesFlag),
+		RetryMaxWait:    flags.FlagToBoolValue(p, cmd, retryMaxWaitFlag),
+		RetryAllMethods: flags.FlagToBoolValue(p, cmd, retryAllMethodsFlag),
 
 		SessionTimeLimit:               flags.FlagToBoolValue(p, cmd, sessionTimeLimitFlag),
 		IdentityProviderCustomEndpoint: flags.FlagToBoolValue(p, cmd, identityProviderCustomWellKnownConfigurationFlag),
 		IdentityProviderCustomClientID: flags.FlagToBoolValue(p, cmd, identityProviderCustomClientIdFlag),
 		AllowedUrlDomain:               flags.FlagToBoolValue(p, cmd, allowedUrlDomainFlag),
+		HistoryForwardUrl:              flags.FlagToBoolValue(p, cmd, historyForwardUrlFlag),
+		MyIpEndpoint:                   flags.FlagToBoolValue(p, cmd, myIpEndpointFlag),
 
 		AuthorizationCustomEndpoint:     flags.FlagToBoolValue(p, cmd, authorizationCustomEndpointFlag),
 		DNSCustomEndpoint:               flags.FlagToBoolValue(p, cmd, dnsCustomEndpointFlag),
@@ -13,15 +13,22 @@ import (
 
 func fixtureFlagValues(mods ...func(flagValues map[string]bool)) map[string]bool {
 	flagValues := map[string]bool{
-		asyncFlag:        true,
-		outputFormatFlag: true,
-		projectIdFlag:    true,
-		verbosityFlag:    true,
+		asyncFlag:           true,
+		outputFormatFlag:    true,
+		projectIdFlag:       true,
+		verbosityFlag:       true,
+		logFormatFlag:       true,
+		logFileFlag:         true,
+		retriesFlag:         true,
+		retryMaxWaitFlag:    true,
+		retryAllMethodsFlag: true,
 
 		sessionTimeLimitFlag:                             true,
 		identityProviderCustomWellKnownConfigurationFlag: true,
 		identityProviderCustomClientIdFlag:               true,
 		allowedUrlDomainFlag:                             true,
+		historyForwardUrlFlag:                            true,
+		myIpEndpointFlag:                                 true,
 
 		authorizationCustomEndpointFlag:   true,
 		dnsCustomEndpointFlag:             true,
@@ -52,15 +59,22 @@ func fixtureFlagValues(mods ...func(flagValues map[string]bool)) map[string]bool
 
 func fixtureInputModel(mods ...func(model *inputModel)) *inputModel {
 	model := &inputModel{
-		Async:        true,
-		OutputFormat: true,
-		ProjectId:    true,
-		Verbosity:    true,
+		Async:           true,
+		OutputFormat:    true,
+		ProjectId:       true,
+		Verbosity:       true,
+		LogFormat:       true,
+		LogFile:         true,
+		Retries:         true,
+		RetryMaxWait:    true,
+		RetryAllMethods: true,
 
 		SessionTimeLimit:               true,
 		IdentityProviderCustomEndpoint: true,
 		IdentityProviderCustomClientID: true,
 		AllowedUrlDomain:               true,
+		HistoryForwardUrl:              true,
+		MyIpEndpoint:                   true,
 
 		AuthorizationCustomEndpoint:   true,
 		DNSCustomEndpoint:             true,
@@ -111,11 +125,18 @@ func TestParseInput(t *testing.T) {
 				model.OutputFormat = false
 				model.ProjectId = false
 				model.Verbosity = false
+				model.LogFormat = false
+				model.LogFile = false
+				model.Retries = false
+				model.RetryMaxWait = false
+				model.RetryAllMethods = false
 
 				model.SessionTimeLimit = false
 				model.IdentityProviderCustomEndpoint = false
 				model.IdentityProviderCustomClientID = false
 				model.AllowedUrlDomain = false
+				model.HistoryForwardUrl = false
+				model.MyIpEndpoint = false
 
 				model.AuthorizationCustomEndpoint = false
 				model.DNSCustomEndpoint = false
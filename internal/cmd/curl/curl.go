@@ -17,6 +17,7 @@ import (
 	"github.com/stackitcloud/stackit-cli/internal/pkg/errors"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/examples"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/flags"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/history"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/print"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/utils"
 
@@ -112,6 +113,8 @@ func NewCmd(params *params.CmdParams) *cobra.Command {
 		},
 	}
 	configureFlags(cmd)
+	// The URL may contain credentials, e.g. in query parameters
+	history.MarkArgsSensitive(cmd)
 	return cmd
 }
 
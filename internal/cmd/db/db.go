@@ -0,0 +1,26 @@
+package db
+
+import (
+	"github.com/stackitcloud/stackit-cli/internal/cmd/db/list"
+	"github.com/stackitcloud/stackit-cli/internal/cmd/params"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/args"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/utils"
+
+	"github.com/spf13/cobra"
+)
+
+func NewCmd(params *params.CmdParams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "db",
+		Short: "Provides functionality across all database services",
+		Long:  "Provides functionality across PostgreSQL Flex, MongoDB Flex, SQLServer Flex, MariaDB, Redis, RabbitMQ, OpenSearch and LogMe.",
+		Args:  args.NoArgs,
+		Run:   utils.CmdHelp,
+	}
+	addSubcommands(cmd, params)
+	return cmd
+}
+
+func addSubcommands(cmd *cobra.Command, params *params.CmdParams) {
+	cmd.AddCommand(list.NewCmd(params))
+}
@@ -0,0 +1,633 @@
+package list
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/stackitcloud/stackit-cli/internal/cmd/params"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/args"
+	cliErr "github.com/stackitcloud/stackit-cli/internal/pkg/errors"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/examples"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/flags"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/globalflags"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/print"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/projectname"
+	logmeClient "github.com/stackitcloud/stackit-cli/internal/pkg/services/logme/client"
+	mariadbClient "github.com/stackitcloud/stackit-cli/internal/pkg/services/mariadb/client"
+	mongodbflexClient "github.com/stackitcloud/stackit-cli/internal/pkg/services/mongodbflex/client"
+	opensearchClient "github.com/stackitcloud/stackit-cli/internal/pkg/services/opensearch/client"
+	postgresflexClient "github.com/stackitcloud/stackit-cli/internal/pkg/services/postgresflex/client"
+	rabbitmqClient "github.com/stackitcloud/stackit-cli/internal/pkg/services/rabbitmq/client"
+	redisClient "github.com/stackitcloud/stackit-cli/internal/pkg/services/redis/client"
+	sqlserverflexClient "github.com/stackitcloud/stackit-cli/internal/pkg/services/sqlserverflex/client"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/tables"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/utils"
+
+	"github.com/goccy/go-yaml"
+	"github.com/spf13/cobra"
+	"github.com/stackitcloud/stackit-sdk-go/core/oapierror"
+	"github.com/stackitcloud/stackit-sdk-go/services/logme"
+	"github.com/stackitcloud/stackit-sdk-go/services/mariadb"
+	"github.com/stackitcloud/stackit-sdk-go/services/mongodbflex"
+	"github.com/stackitcloud/stackit-sdk-go/services/opensearch"
+	"github.com/stackitcloud/stackit-sdk-go/services/postgresflex"
+	"github.com/stackitcloud/stackit-sdk-go/services/rabbitmq"
+	"github.com/stackitcloud/stackit-sdk-go/services/redis"
+	"github.com/stackitcloud/stackit-sdk-go/services/sqlserverflex"
+)
+
+const (
+	engineFlag = "engine"
+	limitFlag  = "limit"
+
+	postgresFlexEngine  = "postgresflex"
+	mongoDBFlexEngine   = "mongodbflex"
+	sqlServerFlexEngine = "sqlserverflex"
+	mariaDBEngine       = "mariadb"
+	redisEngine         = "redis"
+	rabbitMQEngine      = "rabbitmq"
+	openSearchEngine    = "opensearch"
+	logMeEngine         = "logme"
+
+	// Parameter of the data service instances with the ACL, as comma separated list of CIDR ranges
+	sgwAclParameter = "sgw_acl"
+)
+
+var engines = []string{postgresFlexEngine, mongoDBFlexEngine, sqlServerFlexEngine, mariaDBEngine, redisEngine, rabbitMQEngine, openSearchEngine, logMeEngine}
+
+type inputModel struct {
+	*globalflags.GlobalFlagModel
+	Engines []string
+	Limit   *int64
+}
+
+// instance is the view of an instance common to all database services
+type instance struct {
+	Engine       string   `json:"engine"`
+	Id           string   `json:"id"`
+	Name         string   `json:"name"`
+	Version      string   `json:"version,omitempty"`
+	Plan         string   `json:"plan,omitempty"`
+	StorageClass string   `json:"storageClass,omitempty"`
+	StorageSize  *int64   `json:"storageSize,omitempty"`
+	Status       string   `json:"status,omitempty"`
+	Acl          []string `json:"acl"`
+}
+
+// service lists the instances of a database service
+type service struct {
+	engine string
+	list   func(ctx context.Context) ([]instance, error)
+}
+
+func NewCmd(params *params.CmdParams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "Lists the instances of all database services",
+		Long: fmt.Sprintf("%s\n%s",
+			"Lists the instances of PostgreSQL Flex, MongoDB Flex, SQLServer Flex, MariaDB, Redis, RabbitMQ, OpenSearch and LogMe in one view, with their engine, version, plan or flavor, storage, status and ACL.",
+			"The services are queried concurrently. Services that are not enabled for the project are skipped.",
+		),
+		Args: args.NoArgs,
+		Example: examples.Build(
+			examples.NewExample(
+				`List the instances of all database services`,
+				"$ stackit db list"),
+			examples.NewExample(
+				`List the instances of all database services in JSON format`,
+				"$ stackit db list --output-format json"),
+			examples.NewExample(
+				`List the PostgreSQL Flex and MongoDB Flex instances`,
+				"$ stackit db list --engine postgresflex,mongodbflex"),
+		),
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx := context.Background()
+			model, err := parseInput(params.Printer, cmd)
+			if err != nil {
+				return err
+			}
+
+			instances, err := listInstances(ctx, params.Printer, getServices(params, model))
+			if len(instances) == 0 {
+				if err != nil {
+					return err
+				}
+				projectLabel, err := projectname.GetProjectName(ctx, params.Printer, params.CliVersion, cmd)
+				if err != nil {
+					params.Printer.Debug(print.ErrorLevel, "get project name: %v", err)
+					projectLabel = model.ProjectId
+				}
+				params.Printer.Info("No database instances found for project %q\n", projectLabel)
+				return nil
+			}
+
+			// Truncate output
+			if model.Limit != nil && len(instances) > int(*model.Limit) {
+				instances = instances[:*model.Limit]
+			}
+
+			outputErr := outputResult(params.Printer, model.OutputFormat, instances)
+			if outputErr != nil {
+				return outputErr
+			}
+			// The instances of the other services are listed even if some services fail
+			return err
+		},
+	}
+
+	configureFlags(cmd)
+	return cmd
+}
+
+func configureFlags(cmd *cobra.Command) {
+	cmd.Flags().Var(flags.EnumSliceFlag(false, nil, engines...), engineFlag, fmt.Sprintf("Database services to list the instances of, one or more of %q. If not specified, all are listed", engines))
+	cmd.Flags().Int64(limitFlag, 0, "Maximum number of entries to list")
+}
+
+func parseInput(p *print.Printer, cmd *cobra.Command) (*inputModel, error) {
+	globalFlags := globalflags.Parse(p, cmd)
+	if globalFlags.ProjectId == "" {
+		return nil, &cliErr.ProjectIdError{}
+	}
+
+	limit := flags.FlagToInt64Pointer(p, cmd, limitFlag)
+	if limit != nil && *limit < 1 {
+		return nil, &cliErr.FlagValidationError{
+			Flag:    limitFlag,
+			Details: "must be greater than 0",
+		}
+	}
+
+	selectedEngines := flags.FlagToStringSliceValue(p, cmd, engineFlag)
+	if len(selectedEngines) == 0 {
+		selectedEngines = engines
+	}
+
+	model := inputModel{
+		GlobalFlagModel: globalFlags,
+		Engines:         selectedEngines,
+		Limit:           limit,
+	}
+
+	if p.IsVerbosityDebug() {
+		modelStr, err := print.BuildDebugStrFromInputModel(model)
+		if err != nil {
+			p.Debug(print.ErrorLevel, "convert model to string for debugging: %v", err)
+		} else {
+			p.Debug(print.DebugLevel, "parsed input values: %s", modelStr)
+		}
+	}
+
+	return &model, nil
+}
+
+// getServices returns the services of the selected engines.
+// The API clients are configured when the instances are listed, so a client that cannot be configured only fails its service.
+func getServices(params *params.CmdParams, model *inputModel) []service {
+	p, cliVersion := params.Printer, params.CliVersion
+	all := map[string]func(ctx context.Context) ([]instance, error){
+		postgresFlexEngine: func(ctx context.Context) ([]instance, error) {
+			apiClient, err := postgresflexClient.ConfigureClient(p, cliVersion)
+			if err != nil {
+				return nil, err
+			}
+			return listPostgresFlexInstances(ctx, apiClient, model)
+		},
+		mongoDBFlexEngine: func(ctx context.Context) ([]instance, error) {
+			apiClient, err := mongodbflexClient.ConfigureClient(p, cliVersion)
+			if err != nil {
+				return nil, err
+			}
+			return listMongoDBFlexInstances(ctx, apiClient, model)
+		},
+		sqlServerFlexEngine: func(ctx context.Context) ([]instance, error) {
+			apiClient, err := sqlserverflexClient.ConfigureClient(p, cliVersion)
+			if err != nil {
+				return nil, err
+			}
+			return listSQLServerFlexInstances(ctx, apiClient, model)
+		},
+		mariaDBEngine: func(ctx context.Context) ([]instance, error) {
+			apiClient, err := mariadbClient.ConfigureClient(p, cliVersion)
+			if err != nil {
+				return nil, err
+			}
+			return listMariaDBInstances(ctx, apiClient, model)
+		},
+		redisEngine: func(ctx context.Context) ([]instance, error) {
+			apiClient, err := redisClient.ConfigureClient(p, cliVersion)
+			if err != nil {
+				return nil, err
+			}
+			return listRedisInstances(ctx, apiClient, model)
+		},
+		rabbitMQEngine: func(ctx context.Context) ([]instance, error) {
+			apiClient, err := rabbitmqClient.ConfigureClient(p, cliVersion)
+			if err != nil {
+				return nil, err
+			}
+			return listRabbitMQInstances(ctx, apiClient, model)
+		},
+		openSearchEngine: func(ctx context.Context) ([]instance, error) {
+			apiClient, err := opensearchClient.ConfigureClient(p, cliVersion)
+			if err != nil {
+				return nil, err
+			}
+			return listOpenSearchInstances(ctx, apiClient, model)
+		},
+		logMeEngine: func(ctx context.Context) ([]instance, error) {
+			apiClient, err := logmeClient.ConfigureClient(p, cliVersion)
+			if err != nil {
+				return nil, err
+			}
+			return listLogMeInstances(ctx, apiClient, model)
+		},
+	}
+
+	services := []service{}
+	for _, engine := range model.Engines {
+		services = append(services, service{engine: engine, list: all[engine]})
+	}
+	return services
+}
+
+// listInstances lists the instances of all services concurrently, sorted by engine and name.
+// Services that are not enabled for the project are skipped. If other services fail, the instances of the rest are returned with an error.
+func listInstances(ctx context.Context, p *print.Printer, services []service) ([]instance, error) {
+	results := make([][]instance, len(services))
+	errs := make([]error, len(services))
+
+	var wg sync.WaitGroup
+	for i, s := range services {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i], errs[i] = s.list(ctx)
+		}()
+	}
+	wg.Wait()
+
+	instances := []instance{}
+	failed := []string{}
+	for i, s := range services {
+		err := errs[i]
+		switch {
+		case err == nil:
+			instances = append(instances, results[i]...)
+		case isServiceDisabled(err):
+			p.Info("Skipped %s: not enabled for the project\n", s.engine)
+			p.Debug(print.DebugLevel, "list %s instances: %v", s.engine, err)
+		default:
+			p.Warn("list %s instances: %v\n", s.engine, err)
+			failed = append(failed, s.engine)
+		}
+	}
+
+	sort.SliceStable(instances, func(i, j int) bool {
+		if instances[i].Engine != instances[j].Engine {
+			return instances[i].Engine < instances[j].Engine
+		}
+		return instances[i].Name < instances[j].Name
+	})
+
+	if len(failed) > 0 {
+		return instances, fmt.Errorf("list instances of %s failed", strings.Join(failed, ", "))
+	}
+	return instances, nil
+}
+
+// isServiceDisabled returns whether the error is returned by a service that is not enabled for the project,
+// or that the user has no access to
+func isServiceDisabled(err error) bool {
+	var oapiErr *oapierror.GenericOpenAPIError
+	if !errors.As(err, &oapiErr) {
+		return false
+	}
+	return oapiErr.StatusCode == http.StatusNotFound || oapiErr.StatusCode == http.StatusForbidden
+}
+
+// getDetails gets the details of the instances with the given IDs concurrently, in the same order
+func getDetails[T any](ctx context.Context, ids []string, get func(ctx context.Context, id string) (*T, error)) ([]*T, error) {
+	details := make([]*T, len(ids))
+	errs := make([]error, len(ids))
+
+	var wg sync.WaitGroup
+	for i, id := range ids {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			details[i], errs[i] = get(ctx, id)
+		}()
+	}
+	wg.Wait()
+
+	for i, err := range errs {
+		if err != nil {
+			return nil, fmt.Errorf("get instance %s: %w", ids[i], err)
+		}
+	}
+	return details, nil
+}
+
+func listPostgresFlexInstances(ctx context.Context, apiClient *postgresflex.APIClient, model *inputModel) ([]instance, error) {
+	resp, err := apiClient.ListInstancesExecute(ctx, model.ProjectId, model.Region)
+	if err != nil {
+		return nil, err
+	}
+	ids := []string{}
+	if resp.Items != nil {
+		for _, item := range *resp.Items {
+			ids = append(ids, utils.PtrString(item.Id))
+		}
+	}
+	details, err := getDetails(ctx, ids, func(ctx context.Context, id string) (*postgresflex.InstanceResponse, error) {
+		return apiClient.GetInstanceExecute(ctx, model.ProjectId, model.Region, id)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	instances := []instance{}
+	for _, d := range details {
+		if d != nil && d.Item != nil {
+			instances = append(instances, fromPostgresFlexInstance(d.Item))
+		}
+	}
+	return instances, nil
+}
+
+func listMongoDBFlexInstances(ctx context.Context, apiClient *mongodbflex.APIClient, model *inputModel) ([]instance, error) {
+	resp, err := apiClient.ListInstances(ctx, model.ProjectId, model.Region).Tag("").Execute()
+	if err != nil {
+		return nil, err
+	}
+	ids := []string{}
+	if resp.Items != nil {
+		for _, item := range *resp.Items {
+			ids = append(ids, utils.PtrString(item.Id))
+		}
+	}
+	details, err := getDetails(ctx, ids, func(ctx context.Context, id string) (*mongodbflex.GetInstanceResponse, error) {
+		return apiClient.GetInstanceExecute(ctx, model.ProjectId, id, model.Region)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	instances := []instance{}
+	for _, d := range details {
+		if d != nil && d.Item != nil {
+			instances = append(instances, fromMongoDBFlexInstance(d.Item))
+		}
+	}
+	return instances, nil
+}
+
+func listSQLServerFlexInstances(ctx context.Context, apiClient *sqlserverflex.APIClient, model *inputModel) ([]instance, error) {
+	resp, err := apiClient.ListInstancesExecute(ctx, model.ProjectId, model.Region)
+	if err != nil {
+		return nil, err
+	}
+	ids := []string{}
+	if resp.Items != nil {
+		for _, item := range *resp.Items {
+			ids = append(ids, utils.PtrString(item.Id))
+		}
+	}
+	details, err := getDetails(ctx, ids, func(ctx context.Context, id string) (*sqlserverflex.GetInstanceResponse, error) {
+		return apiClient.GetInstanceExecute(ctx, model.ProjectId, id, model.Region)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	instances := []instance{}
+	for _, d := range details {
+		if d != nil && d.Item != nil {
+			instances = append(instances, fromSQLServerFlexInstance(d.Item))
+		}
+	}
+	return instances, nil
+}
+
+func listMariaDBInstances(ctx context.Context, apiClient *mariadb.APIClient, model *inputModel) ([]instance, error) {
+	resp, err := apiClient.ListInstancesExecute(ctx, model.ProjectId)
+	if err != nil {
+		return nil, err
+	}
+	instances := []instance{}
+	if resp.Instances != nil {
+		for i := range *resp.Instances {
+			item := (*resp.Instances)[i]
+			instances = append(instances, fromDataServiceInstance(mariaDBEngine, item.InstanceId, item.Name, item.OfferingVersion, item.PlanName, (*string)(item.Status), item.Parameters))
+		}
+	}
+	return instances, nil
+}
+
+func listRedisInstances(ctx context.Context, apiClient *redis.APIClient, model *inputModel) ([]instance, error) {
+	resp, err := apiClient.ListInstancesExecute(ctx, model.ProjectId)
+	if err != nil {
+		return nil, err
+	}
+	instances := []instance{}
+	if resp.Instances != nil {
+		for i := range *resp.Instances {
+			item := (*resp.Instances)[i]
+			instances = append(instances, fromDataServiceInstance(redisEngine, item.InstanceId, item.Name, item.OfferingVersion, item.PlanName, (*string)(item.Status), item.Parameters))
+		}
+	}
+	return instances, nil
+}
+
+func listRabbitMQInstances(ctx context.Context, apiClient *rabbitmq.APIClient, model *inputModel) ([]instance, error) {
+	resp, err := apiClient.ListInstancesExecute(ctx, model.ProjectId)
+	if err != nil {
+		return nil, err
+	}
+	instances := []instance{}
+	if resp.Instances != nil {
+		for i := range *resp.Instances {
+			item := (*resp.Instances)[i]
+			instances = append(instances, fromDataServiceInstance(rabbitMQEngine, item.InstanceId, item.Name, item.OfferingVersion, item.PlanName, (*string)(item.Status), item.Parameters))
+		}
+	}
+	return instances, nil
+}
+
+func listOpenSearchInstances(ctx context.Context, apiClient *opensearch.APIClient, model *inputModel) ([]instance, error) {
+	resp, err := apiClient.ListInstancesExecute(ctx, model.ProjectId)
+	if err != nil {
+		return nil, err
+	}
+	instances := []instance{}
+	if resp.Instances != nil {
+		for i := range *resp.Instances {
+			item := (*resp.Instances)[i]
+			instances = append(instances, fromDataServiceInstance(openSearchEngine, item.InstanceId, item.Name, item.OfferingVersion, item.PlanName, (*string)(item.Status), item.Parameters))
+		}
+	}
+	return instances, nil
+}
+
+func listLogMeInstances(ctx context.Context, apiClient *logme.APIClient, model *inputModel) ([]instance, error) {
+	resp, err := apiClient.ListInstancesExecute(ctx, model.ProjectId)
+	if err != nil {
+		return nil, err
+	}
+	instances := []instance{}
+	if resp.Instances != nil {
+		for i := range *resp.Instances {
+			item := (*resp.Instances)[i]
+			instances = append(instances, fromDataServiceInstance(logMeEngine, item.InstanceId, item.Name, item.OfferingVersion, item.PlanName, (*string)(item.Status), item.Parameters))
+		}
+	}
+	return instances, nil
+}
+
+func fromPostgresFlexInstance(i *postgresflex.Instance) instance {
+	result := instance{
+		Engine:  postgresFlexEngine,
+		Id:      utils.PtrString(i.Id),
+		Name:    utils.PtrString(i.Name),
+		Version: utils.PtrString(i.Version),
+		Status:  utils.PtrString(i.Status),
+		Acl:     []string{},
+	}
+	if i.Flavor != nil {
+		result.Plan = flavorLabel(i.Flavor.Id, i.Flavor.Cpu, i.Flavor.Memory)
+	}
+	if i.Storage != nil {
+		result.StorageClass, result.StorageSize = utils.PtrString(i.Storage.Class), i.Storage.Size
+	}
+	if i.Acl != nil && i.Acl.Items != nil {
+		result.Acl = *i.Acl.Items
+	}
+	return result
+}
+
+func fromMongoDBFlexInstance(i *mongodbflex.Instance) instance {
+	result := instance{
+		Engine:  mongoDBFlexEngine,
+		Id:      utils.PtrString(i.Id),
+		Name:    utils.PtrString(i.Name),
+		Version: utils.PtrString(i.Version),
+		Status:  utils.PtrString(i.Status),
+		Acl:     []string{},
+	}
+	if i.Flavor != nil {
+		result.Plan = flavorLabel(i.Flavor.Id, i.Flavor.Cpu, i.Flavor.Memory)
+	}
+	if i.Storage != nil {
+		result.StorageClass, result.StorageSize = utils.PtrString(i.Storage.Class), i.Storage.Size
+	}
+	if i.Acl != nil && i.Acl.Items != nil {
+		result.Acl = *i.Acl.Items
+	}
+	return result
+}
+
+func fromSQLServerFlexInstance(i *sqlserverflex.Instance) instance {
+	result := instance{
+		Engine:  sqlServerFlexEngine,
+		Id:      utils.PtrString(i.Id),
+		Name:    utils.PtrString(i.Name),
+		Version: utils.PtrString(i.Version),
+		Status:  utils.PtrString(i.Status),
+		Acl:     []string{},
+	}
+	if i.Flavor != nil {
+		result.Plan = flavorLabel(i.Flavor.Id, i.Flavor.Cpu, i.Flavor.Memory)
+	}
+	if i.Storage != nil {
+		result.StorageClass, result.StorageSize = utils.PtrString(i.Storage.Class), i.Storage.Size
+	}
+	if i.Acl != nil && i.Acl.Items != nil {
+		result.Acl = *i.Acl.Items
+	}
+	return result
+}
+
+// fromDataServiceInstance returns the view of an instance of MariaDB, Redis, RabbitMQ, OpenSearch or LogMe.
+// Their storage is determined by the plan.
+func fromDataServiceInstance(engine string, id, name, version, plan, status *string, parameters *map[string]interface{}) instance {
+	result := instance{
+		Engine:  engine,
+		Id:      utils.PtrString(id),
+		Name:    utils.PtrString(name),
+		Version: utils.PtrString(version),
+		Plan:    utils.PtrString(plan),
+		Status:  utils.PtrString(status),
+		Acl:     []string{},
+	}
+	if parameters != nil {
+		if acl, ok := (*parameters)[sgwAclParameter].(string); ok && acl != "" {
+			result.Acl = strings.Split(acl, ",")
+		}
+	}
+	return result
+}
+
+// flavorLabel returns the CPU and memory of the flavor or, if not set, its ID
+func flavorLabel(id *string, cpu, memory *int64) string {
+	if cpu == nil || memory == nil {
+		return utils.PtrString(id)
+	}
+	return fmt.Sprintf("%d CPU, %d GB RAM", *cpu, *memory)
+}
+
+func outputResult(p *print.Printer, outputFormat string, instances []instance) error {
+	switch outputFormat {
+	case print.JSONOutputFormat:
+		details, err := json.MarshalIndent(instances, "", "  ")
+		if err != nil {
+			return fmt.Errorf("marshal database instance list: %w", err)
+		}
+		p.Outputln(string(details))
+
+		return nil
+	case print.YAMLOutputFormat:
+		details, err := yaml.MarshalWithOptions(instances, yaml.IndentSequence(true), yaml.UseJSONMarshaler())
+		if err != nil {
+			return fmt.Errorf("marshal database instance list: %w", err)
+		}
+		p.Outputln(string(details))
+
+		return nil
+	default:
+		table := tables.NewTable()
+		table.SetHeader("ENGINE", "ID", "NAME", "VERSION", "PLAN/FLAVOR", "STORAGE", "STATUS", "ACL")
+		for i := range instances {
+			instance := instances[i]
+			storage := ""
+			if instance.StorageSize != nil {
+				storage = fmt.Sprintf("%d GB", *instance.StorageSize)
+				if instance.StorageClass != "" {
+					storage = fmt.Sprintf("%s (%s)", storage, instance.StorageClass)
+				}
+			}
+			table.AddRow(
+				instance.Engine,
+				instance.Id,
+				instance.Name,
+				instance.Version,
+				instance.Plan,
+				storage,
+				instance.Status,
+				strings.Join(instance.Acl, ","),
+			)
+		}
+		err := table.Display(p)
+		if err != nil {
+			return fmt.Errorf("render table: %w", err)
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,455 @@
+package list
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/uuid"
+	"github.com/spf13/cobra"
+	"github.com/stackitcloud/stackit-cli/internal/cmd/params"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/globalflags"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/print"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/utils"
+	"github.com/stackitcloud/stackit-sdk-go/core/oapierror"
+	"github.com/stackitcloud/stackit-sdk-go/services/mongodbflex"
+	"github.com/stackitcloud/stackit-sdk-go/services/postgresflex"
+)
+
+var testProjectId = uuid.NewString()
+var testRegion = "eu01"
+
+func fixtureFlagValues(mods ...func(flagValues map[string]string)) map[string]string {
+	flagValues := map[string]string{
+		globalflags.ProjectIdFlag: testProjectId,
+		globalflags.RegionFlag:    testRegion,
+		limitFlag:                 "10",
+	}
+	for _, mod := range mods {
+		mod(flagValues)
+	}
+	return flagValues
+}
+
+func fixtureInputModel(mods ...func(model *inputModel)) *inputModel {
+	model := &inputModel{
+		GlobalFlagModel: &globalflags.GlobalFlagModel{
+			ProjectId: testProjectId,
+			Region:    testRegion,
+			Verbosity: globalflags.VerbosityDefault,
+		},
+		Engines: engines,
+		Limit:   utils.Ptr(int64(10)),
+	}
+	for _, mod := range mods {
+		mod(model)
+	}
+	return model
+}
+
+func TestParseInput(t *testing.T) {
+	tests := []struct {
+		description   string
+		flagValues    map[string]string
+		isValid       bool
+		expectedModel *inputModel
+	}{
+		{
+			description:   "base",
+			flagValues:    fixtureFlagValues(),
+			isValid:       true,
+			expectedModel: fixtureInputModel(),
+		},
+		{
+			description: "engines",
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				flagValues[engineFlag] = "postgresflex,redis"
+			}),
+			isValid: true,
+			expectedModel: fixtureInputModel(func(model *inputModel) {
+				model.Engines = []string{postgresFlexEngine, redisEngine}
+			}),
+		},
+		{
+			description: "engine invalid",
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				flagValues[engineFlag] = "mysql"
+			}),
+			isValid: false,
+		},
+		{
+			description: "no values",
+			flagValues:  map[string]string{},
+			isValid:     false,
+		},
+		{
+			description: "project id missing",
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				delete(flagValues, globalflags.ProjectIdFlag)
+			}),
+			isValid: false,
+		},
+		{
+			description: "project id invalid",
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				flagValues[globalflags.ProjectIdFlag] = "invalid-uuid"
+			}),
+			isValid: false,
+		},
+		{
+			description: "limit invalid",
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				flagValues[limitFlag] = "0"
+			}),
+			isValid: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			cmd := &cobra.Command{}
+			err := globalflags.Configure(cmd.Flags())
+			if err != nil {
+				t.Fatalf("configure global flags: %v", err)
+			}
+
+			configureFlags(cmd)
+
+			for flag, value := range tt.flagValues {
+				err := cmd.Flags().Set(flag, value)
+				if err != nil {
+					if !tt.isValid {
+						return
+					}
+					t.Fatalf("setting flag --%s=%s: %v", flag, value, err)
+				}
+			}
+
+			p := print.NewPrinter()
+			model, err := parseInput(p, cmd)
+			if err != nil {
+				if !tt.isValid {
+					return
+				}
+				t.Fatalf("error parsing flags: %v", err)
+			}
+
+			if !tt.isValid {
+				t.Fatalf("did not fail on invalid input")
+			}
+			diff := cmp.Diff(model, tt.expectedModel)
+			if diff != "" {
+				t.Fatalf("Data does not match: %s", diff)
+			}
+		})
+	}
+}
+
+func fixtureService(engine string, names ...string) service {
+	return service{
+		engine: engine,
+		list: func(context.Context) ([]instance, error) {
+			instances := []instance{}
+			for _, name := range names {
+				instances = append(instances, instance{Engine: engine, Name: name, Acl: []string{}})
+			}
+			return instances, nil
+		},
+	}
+}
+
+func fixtureFailingService(engine string, err error) service {
+	return service{
+		engine: engine,
+		list: func(context.Context) ([]instance, error) {
+			return nil, err
+		},
+	}
+}
+
+func TestListInstances(t *testing.T) {
+	tests := []struct {
+		description string
+		services    []service
+		isValid     bool
+		expected    []instance
+	}{
+		{
+			description: "sorted by engine and name",
+			services: []service{
+				fixtureService(redisEngine, "b", "a"),
+				fixtureService(postgresFlexEngine, "c"),
+			},
+			isValid: true,
+			expected: []instance{
+				{Engine: postgresFlexEngine, Name: "c", Acl: []string{}},
+				{Engine: redisEngine, Name: "a", Acl: []string{}},
+				{Engine: redisEngine, Name: "b", Acl: []string{}},
+			},
+		},
+		{
+			description: "disabled services are skipped",
+			services: []service{
+				fixtureService(redisEngine, "a"),
+				fixtureFailingService(postgresFlexEngine, &oapierror.GenericOpenAPIError{StatusCode: http.StatusNotFound}),
+				fixtureFailingService(logMeEngine, fmt.Errorf("wrapped: %w", &oapierror.GenericOpenAPIError{StatusCode: http.StatusForbidden})),
+			},
+			isValid: true,
+			expected: []instance{
+				{Engine: redisEngine, Name: "a", Acl: []string{}},
+			},
+		},
+		{
+			description: "failing service",
+			services: []service{
+				fixtureService(redisEngine, "a"),
+				fixtureFailingService(postgresFlexEngine, &oapierror.GenericOpenAPIError{StatusCode: http.StatusInternalServerError}),
+			},
+			isValid: false,
+			expected: []instance{
+				{Engine: redisEngine, Name: "a", Acl: []string{}},
+			},
+		},
+		{
+			description: "no services",
+			services:    []service{},
+			isValid:     true,
+			expected:    []instance{},
+		},
+	}
+
+	p := print.NewPrinter()
+	p.Cmd = NewCmd(&params.CmdParams{Printer: p})
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			instances, err := listInstances(context.Background(), p, tt.services)
+			if !tt.isValid && err == nil {
+				t.Fatalf("did not fail on failing service")
+			}
+			if tt.isValid && err != nil {
+				t.Fatalf("failed: %v", err)
+			}
+			diff := cmp.Diff(instances, tt.expected)
+			if diff != "" {
+				t.Fatalf("Data does not match: %s", diff)
+			}
+		})
+	}
+}
+
+func TestGetDetails(t *testing.T) {
+	ids := []string{"a", "b", "c"}
+
+	details, err := getDetails(context.Background(), ids, func(_ context.Context, id string) (*string, error) {
+		return utils.Ptr("instance-" + id), nil
+	})
+	if err != nil {
+		t.Fatalf("failed: %v", err)
+	}
+	diff := cmp.Diff(details, []*string{utils.Ptr("instance-a"), utils.Ptr("instance-b"), utils.Ptr("instance-c")})
+	if diff != "" {
+		t.Fatalf("Data does not match: %s", diff)
+	}
+
+	_, err = getDetails(context.Background(), ids, func(_ context.Context, id string) (*string, error) {
+		if id == "b" {
+			return nil, fmt.Errorf("not found")
+		}
+		return utils.Ptr(id), nil
+	})
+	if err == nil {
+		t.Fatalf("did not fail on failing instance")
+	}
+}
+
+func TestFromPostgresFlexInstance(t *testing.T) {
+	tests := []struct {
+		description string
+		instance    *postgresflex.Instance
+		expected    instance
+	}{
+		{
+			description: "base",
+			instance: &postgresflex.Instance{
+				Id:      utils.Ptr("id"),
+				Name:    utils.Ptr("name"),
+				Version: utils.Ptr("16"),
+				Status:  utils.Ptr("Ready"),
+				Flavor: &postgresflex.Flavor{
+					Id:     utils.Ptr("flavor-id"),
+					Cpu:    utils.Ptr(int64(2)),
+					Memory: utils.Ptr(int64(4)),
+				},
+				Storage: &postgresflex.Storage{
+					Class: utils.Ptr("premium-perf2-stackit"),
+					Size:  utils.Ptr(int64(10)),
+				},
+				Acl: &postgresflex.ACL{
+					Items: &[]string{"0.0.0.0/0"},
+				},
+			},
+			expected: instance{
+				Engine:       postgresFlexEngine,
+				Id:           "id",
+				Name:         "name",
+				Version:      "16",
+				Plan:         "2 CPU, 4 GB RAM",
+				StorageClass: "premium-perf2-stackit",
+				StorageSize:  utils.Ptr(int64(10)),
+				Status:       "Ready",
+				Acl:          []string{"0.0.0.0/0"},
+			},
+		},
+		{
+			description: "empty",
+			instance:    &postgresflex.Instance{},
+			expected: instance{
+				Engine: postgresFlexEngine,
+				Acl:    []string{},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			result := fromPostgresFlexInstance(tt.instance)
+			diff := cmp.Diff(result, tt.expected)
+			if diff != "" {
+				t.Fatalf("Data does not match: %s", diff)
+			}
+		})
+	}
+}
+
+func TestFromMongoDBFlexInstance(t *testing.T) {
+	result := fromMongoDBFlexInstance(&mongodbflex.Instance{
+		Id:      utils.Ptr("id"),
+		Name:    utils.Ptr("name"),
+		Version: utils.Ptr("7.0"),
+		Status:  mongodbflex.INSTANCESTATUS_READY.Ptr(),
+		Flavor: &mongodbflex.Flavor{
+			Id: utils.Ptr("flavor-id"),
+		},
+		Acl: &mongodbflex.ACL{
+			Items: &[]string{"10.0.0.0/8", "192.168.0.0/16"},
+		},
+	})
+	expected := instance{
+		Engine:  mongoDBFlexEngine,
+		Id:      "id",
+		Name:    "name",
+		Version: "7.0",
+		Plan:    "flavor-id",
+		Status:  string(mongodbflex.INSTANCESTATUS_READY),
+		Acl:     []string{"10.0.0.0/8", "192.168.0.0/16"},
+	}
+	diff := cmp.Diff(result, expected)
+	if diff != "" {
+		t.Fatalf("Data does not match: %s", diff)
+	}
+}
+
+func TestFromDataServiceInstance(t *testing.T) {
+	tests := []struct {
+		description string
+		parameters  *map[string]interface{}
+		expectedAcl []string
+	}{
+		{
+			description: "acl",
+			parameters:  &map[string]interface{}{sgwAclParameter: "10.0.0.0/8,192.168.0.0/16"},
+			expectedAcl: []string{"10.0.0.0/8", "192.168.0.0/16"},
+		},
+		{
+			description: "empty acl",
+			parameters:  &map[string]interface{}{sgwAclParameter: ""},
+			expectedAcl: []string{},
+		},
+		{
+			description: "no parameters",
+			expectedAcl: []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			result := fromDataServiceInstance(redisEngine, utils.Ptr("id"), utils.Ptr("name"), utils.Ptr("7"), utils.Ptr("stackit-redis-1.4.10-single"), utils.Ptr("active"), tt.parameters)
+			expected := instance{
+				Engine:  redisEngine,
+				Id:      "id",
+				Name:    "name",
+				Version: "7",
+				Plan:    "stackit-redis-1.4.10-single",
+				Status:  "active",
+				Acl:     tt.expectedAcl,
+			}
+			diff := cmp.Diff(result, expected)
+			if diff != "" {
+				t.Fatalf("Data does not match: %s", diff)
+			}
+		})
+	}
+}
+
+func TestIsServiceDisabled(t *testing.T) {
+	tests := []struct {
+		description string
+		err         error
+		expected    bool
+	}{
+		{"not found", &oapierror.GenericOpenAPIError{StatusCode: http.StatusNotFound}, true},
+		{"forbidden", &oapierror.GenericOpenAPIError{StatusCode: http.StatusForbidden}, true},
+		{"wrapped", fmt.Errorf("list: %w", &oapierror.GenericOpenAPIError{StatusCode: http.StatusNotFound}), true},
+		{"server error", &oapierror.GenericOpenAPIError{StatusCode: http.StatusInternalServerError}, false},
+		{"other error", fmt.Errorf("connection refused"), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			if isServiceDisabled(tt.err) != tt.expected {
+				t.Fatalf("expected %t for %v", tt.expected, tt.err)
+			}
+		})
+	}
+}
+
+func Test_outputResult(t *testing.T) {
+	type args struct {
+		outputFormat string
+		instances    []instance
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{"empty", args{}, false},
+		{"standard", args{"", []instance{}}, false},
+		{"complete", args{"", []instance{
+			{
+				Engine:       postgresFlexEngine,
+				StorageClass: "premium-perf2-stackit",
+				StorageSize:  utils.Ptr(int64(10)),
+				Acl:          []string{"0.0.0.0/0"},
+			},
+			{
+				Engine: redisEngine,
+			},
+		}}, false},
+		{"json", args{print.JSONOutputFormat, []instance{{Engine: redisEngine}}}, false},
+		{"yaml", args{print.YAMLOutputFormat, []instance{{Engine: redisEngine}}}, false},
+	}
+	p := print.NewPrinter()
+	p.Cmd = NewCmd(&params.CmdParams{Printer: p})
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := outputResult(p, tt.args.outputFormat, tt.args.instances); (err != nil) != tt.wantErr {
+				t.Errorf("outputResult() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
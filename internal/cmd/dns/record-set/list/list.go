@@ -15,6 +15,7 @@ import (
 	"github.com/stackitcloud/stackit-cli/internal/pkg/examples"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/flags"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/globalflags"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/pagination"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/print"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/services/dns/client"
 	dnsUtils "github.com/stackitcloud/stackit-cli/internal/pkg/services/dns/utils"
@@ -30,8 +31,6 @@ const (
 	deletedFlag     = "deleted"
 	nameLikeFlag    = "name-like"
 	orderByNameFlag = "order-by-name"
-	limitFlag       = "limit"
-	pageSizeFlag    = "page-size"
 
 	defaultPage          = 1
 	pageSizeDefault      = 100
@@ -73,6 +72,9 @@ func NewCmd(params *params.CmdParams) *cobra.Command {
 			examples.NewExample(
 				`List the deleted DNS record-sets for zone with ID "xxx"`,
 				"$ stackit dns record-set list --zone-id xxx --deleted"),
+			examples.NewExample(
+				`Stream all DNS record-sets for zone with ID "xxx" as JSON lines`,
+				"$ stackit dns record-set list --zone-id xxx --all --output-format jsonl"),
 		),
 		RunE: func(cmd *cobra.Command, _ []string) error {
 			ctx := context.Background()
@@ -87,8 +89,16 @@ func NewCmd(params *params.CmdParams) *cobra.Command {
 				return err
 			}
 
+			// Output the record sets as the pages arrive
+			if model.OutputFormat == print.JSONLinesOutputFormat {
+				return fetchRecordSets(ctx, model, apiClient, func(recordSets []dns.RecordSet) error {
+					return pagination.OutputJSONLines(params.Printer, recordSets)
+				})
+			}
+
 			// Fetch record sets
-			recordSets, err := fetchRecordSets(ctx, model, apiClient)
+			recordSets := []dns.RecordSet{}
+			err = fetchRecordSets(ctx, model, apiClient, pagination.AppendTo(&recordSets))
 			if err != nil {
 				return err
 			}
@@ -118,8 +128,7 @@ func configureFlags(cmd *cobra.Command) {
 	cmd.Flags().Bool(deletedFlag, false, "Filter for deleted record sets")
 	cmd.Flags().String(nameLikeFlag, "", "Filter by name")
 	cmd.Flags().Var(flags.EnumFlag(true, "", orderByNameFlagOptions...), orderByNameFlag, fmt.Sprintf("Order by name, one of %q", orderByNameFlagOptions))
-	cmd.Flags().Int64(limitFlag, 0, "Maximum number of entries to list")
-	cmd.Flags().Int64(pageSizeFlag, pageSizeDefault, "Number of items fetched in each API call. Does not affect the number of items in the command output")
+	pagination.ConfigureFlags(cmd, pageSizeDefault)
 
 	err := flags.MarkFlagsRequired(cmd, zoneIdFlag)
 	cobra.CheckErr(err)
@@ -131,20 +140,9 @@ func parseInput(p *print.Printer, cmd *cobra.Command) (*inputModel, error) {
 		return nil, &errors.ProjectIdError{}
 	}
 
-	limit := flags.FlagToInt64Pointer(p, cmd, limitFlag)
-	if limit != nil && *limit < 1 {
-		return nil, &errors.FlagValidationError{
-			Flag:    limitFlag,
-			Details: "must be greater than 0",
-		}
-	}
-
-	pageSize := flags.FlagWithDefaultToInt64Value(p, cmd, pageSizeFlag)
-	if pageSize < 1 {
-		return nil, &errors.FlagValidationError{
-			Flag:    pageSizeFlag,
-			Details: "must be greater than 0",
-		}
+	limit, pageSize, err := pagination.ParseFlags(p, cmd)
+	if err != nil {
+		return nil, err
 	}
 
 	active := flags.FlagToBoolValue(p, cmd, activeFlag)
@@ -161,7 +159,7 @@ func parseInput(p *print.Printer, cmd *cobra.Command) (*inputModel, error) {
 		Deleted:         flags.FlagToBoolValue(p, cmd, deletedFlag),
 		NameLike:        flags.FlagToStringPointer(p, cmd, nameLikeFlag),
 		OrderByName:     flags.FlagToStringPointer(p, cmd, orderByNameFlag),
-		Limit:           flags.FlagToInt64Pointer(p, cmd, limitFlag),
+		Limit:           limit,
 		PageSize:        pageSize,
 	}
 
@@ -217,36 +215,23 @@ type dnsClient interface {
 	ListRecordSets(ctx context.Context, projectId, zoneId string) dns.ApiListRecordSetsRequest
 }
 
-func fetchRecordSets(ctx context.Context, model *inputModel, apiClient dnsClient) ([]dns.RecordSet, error) {
+// fetchRecordSets fetches the pages of record sets and passes the record sets of each page to handlePage
+func fetchRecordSets(ctx context.Context, model *inputModel, apiClient dnsClient, handlePage func(recordSets []dns.RecordSet) error) error {
 	if model.Limit != nil && *model.Limit < model.PageSize {
 		model.PageSize = *model.Limit
 	}
-	page := 1
-	recordSets := []dns.RecordSet{}
-	for {
+	return pagination.Fetch(model.PageSize, model.Limit, func(pageIndex int) ([]dns.RecordSet, error) {
 		// Call API
-		req := buildRequest(ctx, model, apiClient, page)
+		req := buildRequest(ctx, model, apiClient, pageIndex+1)
 		resp, err := req.Execute()
 		if err != nil {
 			return nil, fmt.Errorf("get DNS record sets: %w", err)
 		}
-		respRecordSets := *resp.RrSets
-		if len(respRecordSets) == 0 {
-			break
-		}
-		recordSets = append(recordSets, respRecordSets...)
-		// Stop if no more pages
-		if len(respRecordSets) < int(model.PageSize) {
-			break
+		if resp.RrSets == nil {
+			return nil, nil
 		}
-		// Stop and truncate if limit is reached
-		if model.Limit != nil && len(recordSets) >= int(*model.Limit) {
-			recordSets = recordSets[:*model.Limit]
-			break
-		}
-		page++
-	}
-	return recordSets, nil
+		return *resp.RrSets, nil
+	}, handlePage)
 }
 
 func outputResult(p *print.Printer, outputFormat string, recordSets []dns.RecordSet) error {
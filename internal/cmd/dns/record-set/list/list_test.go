@@ -13,6 +13,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/stackitcloud/stackit-cli/internal/cmd/params"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/globalflags"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/pagination"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/print"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/utils"
 	sdkConfig "github.com/stackitcloud/stackit-sdk-go/core/config"
@@ -199,14 +200,14 @@ func TestParseInput(t *testing.T) {
 		{
 			description: "limit invalid",
 			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
-				flagValues[limitFlag] = "invalid"
+				flagValues[pagination.LimitFlag] = "invalid"
 			}),
 			isValid: false,
 		},
 		{
 			description: "limit invalid 2",
 			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
-				flagValues[limitFlag] = "0"
+				flagValues[pagination.LimitFlag] = "0"
 			}),
 			isValid: false,
 		},
@@ -482,7 +483,8 @@ func TestFetchRecordSets(t *testing.T) {
 				t.Fatalf("Failed to initialize client: %v", err)
 			}
 
-			recordSets, err := fetchRecordSets(testCtx, tt.model, client)
+			recordSets := []dns.RecordSet{}
+			err = fetchRecordSets(testCtx, tt.model, client, pagination.AppendTo(&recordSets))
 			if err != nil {
 				if !tt.apiCallFails {
 					t.Fatalf("did not fail on invalid input")
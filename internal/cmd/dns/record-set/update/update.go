@@ -10,6 +10,7 @@ import (
 	"github.com/stackitcloud/stackit-cli/internal/pkg/examples"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/flags"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/globalflags"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/history"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/print"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/services/dns/client"
 	dnsUtils "github.com/stackitcloud/stackit-cli/internal/pkg/services/dns/utils"
@@ -73,17 +74,18 @@ func NewCmd(params *params.CmdParams) *cobra.Command {
 				zoneLabel = model.ZoneId
 			}
 
-			recordSetLabel, err := dnsUtils.GetRecordSetName(ctx, apiClient, model.ProjectId, model.ZoneId, model.RecordSetId)
+			// The record set is also snapshotted, so that the update can be undone (see "stackit undo")
+			recordSetLabel := model.RecordSetId
+			recordSet, err := apiClient.GetRecordSetExecute(ctx, model.ProjectId, model.ZoneId, model.RecordSetId)
 			if err != nil {
-				params.Printer.Debug(print.ErrorLevel, "get record set name: %v", err)
-				recordSetLabel = model.RecordSetId
-			}
-
-			typeLabel, err := dnsUtils.GetRecordSetType(ctx, apiClient, model.ProjectId, model.ZoneId, model.RecordSetId)
-			if err != nil {
-				params.Printer.Debug(print.ErrorLevel, "get record set type: %v", err)
+				params.Printer.Debug(print.ErrorLevel, "get record set: %v", err)
+				recordSet = nil
+			} else {
+				if name := recordSet.Rrset.GetName(); name != "" {
+					recordSetLabel = name
+				}
+				model.Type = utils.Ptr(string(recordSet.Rrset.GetType()))
 			}
-			model.Type = typeLabel
 
 			if utils.PtrString(model.Type) == txtType {
 				err = parseTxtRecord(model.Records)
@@ -100,6 +102,14 @@ func NewCmd(params *params.CmdParams) *cobra.Command {
 				}
 			}
 
+			if recordSet != nil {
+				history.AddSnapshot(params.Printer, history.DNSRecordSetResourceType, history.UpdateOperation, &history.DNSRecordSetSnapshot{
+					ProjectId: model.ProjectId,
+					ZoneId:    model.ZoneId,
+					RecordSet: recordSet.Rrset,
+				})
+			}
+
 			// Call API
 			req := buildRequest(ctx, model, apiClient)
 			_, err = req.Execute()
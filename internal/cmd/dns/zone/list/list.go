@@ -14,6 +14,7 @@ import (
 	"github.com/stackitcloud/stackit-cli/internal/pkg/examples"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/flags"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/globalflags"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/pagination"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/print"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/projectname"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/services/dns/client"
@@ -30,8 +31,6 @@ const (
 	nameLikeFlag       = "name-like"
 	orderByNameFlag    = "order-by-name"
 	includeDeletedFlag = "include-deleted"
-	limitFlag          = "limit"
-	pageSizeFlag       = "page-size"
 
 	defaultPage          = 1
 	pageSizeDefault      = 100
@@ -69,6 +68,9 @@ func NewCmd(params *params.CmdParams) *cobra.Command {
 			examples.NewExample(
 				`List DNS zones, including deleted`,
 				"$ stackit dns zone list --include-deleted"),
+			examples.NewExample(
+				`Stream all DNS zones as JSON lines, fetching 500 zones in each API call`,
+				"$ stackit dns zone list --all --page-size 500 --output-format jsonl"),
 		),
 		RunE: func(cmd *cobra.Command, _ []string) error {
 			ctx := context.Background()
@@ -83,8 +85,16 @@ func NewCmd(params *params.CmdParams) *cobra.Command {
 				return err
 			}
 
+			// Output the zones as the pages arrive
+			if model.OutputFormat == print.JSONLinesOutputFormat {
+				return fetchZones(ctx, model, apiClient, func(zones []dns.Zone) error {
+					return pagination.OutputJSONLines(params.Printer, zones)
+				})
+			}
+
 			// Fetch zones
-			zones, err := fetchZones(ctx, model, apiClient)
+			zones := []dns.Zone{}
+			err = fetchZones(ctx, model, apiClient, pagination.AppendTo(&zones))
 			if err != nil {
 				return err
 			}
@@ -113,8 +123,7 @@ func configureFlags(cmd *cobra.Command) {
 	cmd.Flags().String(nameLikeFlag, "", "Filter by name")
 	cmd.Flags().Var(flags.EnumFlag(true, "", orderByNameFlagOptions...), orderByNameFlag, fmt.Sprintf("Order by name, one of %q", orderByNameFlagOptions))
 	cmd.Flags().Bool(includeDeletedFlag, false, "Includes successfully deleted zones (if unset, these are filtered out)")
-	cmd.Flags().Int64(limitFlag, 0, "Maximum number of entries to list")
-	cmd.Flags().Int64(pageSizeFlag, pageSizeDefault, "Number of items fetched in each API call. Does not affect the number of items in the command output")
+	pagination.ConfigureFlags(cmd, pageSizeDefault)
 }
 
 func parseInput(p *print.Printer, cmd *cobra.Command) (*inputModel, error) {
@@ -123,20 +132,9 @@ func parseInput(p *print.Printer, cmd *cobra.Command) (*inputModel, error) {
 		return nil, &errors.ProjectIdError{}
 	}
 
-	limit := flags.FlagToInt64Pointer(p, cmd, limitFlag)
-	if limit != nil && *limit < 1 {
-		return nil, &errors.FlagValidationError{
-			Flag:    limitFlag,
-			Details: "must be greater than 0",
-		}
-	}
-
-	pageSize := flags.FlagWithDefaultToInt64Value(p, cmd, pageSizeFlag)
-	if pageSize < 1 {
-		return nil, &errors.FlagValidationError{
-			Flag:    pageSizeFlag,
-			Details: "must be greater than 0",
-		}
+	limit, pageSize, err := pagination.ParseFlags(p, cmd)
+	if err != nil {
+		return nil, err
 	}
 
 	active := flags.FlagToBoolValue(p, cmd, activeFlag)
@@ -206,36 +204,23 @@ type dnsClient interface {
 	ListZones(ctx context.Context, projectId string) dns.ApiListZonesRequest
 }
 
-func fetchZones(ctx context.Context, model *inputModel, apiClient dnsClient) ([]dns.Zone, error) {
+// fetchZones fetches the pages of zones and passes the zones of each page to handlePage
+func fetchZones(ctx context.Context, model *inputModel, apiClient dnsClient, handlePage func(zones []dns.Zone) error) error {
 	if model.Limit != nil && *model.Limit < model.PageSize {
 		model.PageSize = *model.Limit
 	}
-	page := 1
-	zones := []dns.Zone{}
-	for {
+	return pagination.Fetch(model.PageSize, model.Limit, func(pageIndex int) ([]dns.Zone, error) {
 		// Call API
-		req := buildRequest(ctx, model, apiClient, page)
+		req := buildRequest(ctx, model, apiClient, pageIndex+1)
 		resp, err := req.Execute()
 		if err != nil {
 			return nil, fmt.Errorf("get DNS zones: %w", err)
 		}
-		respZones := *resp.Zones
-		if len(respZones) == 0 {
-			break
-		}
-		zones = append(zones, respZones...)
-		// Stop if no more pages
-		if len(respZones) < int(model.PageSize) {
-			break
+		if resp.Zones == nil {
+			return nil, nil
 		}
-		// Stop and truncate if limit is reached
-		if model.Limit != nil && len(zones) >= int(*model.Limit) {
-			zones = zones[:*model.Limit]
-			break
-		}
-		page++
-	}
-	return zones, nil
+		return *resp.Zones, nil
+	}, handlePage)
 }
 
 func outputResult(p *print.Printer, outputFormat string, zones []dns.Zone) error {
@@ -13,6 +13,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/stackitcloud/stackit-cli/internal/cmd/params"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/globalflags"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/pagination"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/print"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/utils"
 	sdkConfig "github.com/stackitcloud/stackit-sdk-go/core/config"
@@ -194,14 +195,23 @@ func TestParseInput(t *testing.T) {
 		{
 			description: "limit invalid",
 			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
-				flagValues[limitFlag] = "invalid"
+				flagValues[pagination.LimitFlag] = "invalid"
 			}),
 			isValid: false,
 		},
+		{
+			description: "all overrides limit",
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				flagValues[pagination.LimitFlag] = "10"
+				flagValues[pagination.AllFlag] = "true"
+			}),
+			isValid:       true,
+			expectedModel: fixtureInputModel(),
+		},
 		{
 			description: "limit invalid 2",
 			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
-				flagValues[limitFlag] = "0"
+				flagValues[pagination.LimitFlag] = "0"
 			}),
 			isValid: false,
 		},
@@ -476,7 +486,8 @@ func TestFetchZones(t *testing.T) {
 				t.Fatalf("Failed to initialize client: %v", err)
 			}
 
-			zones, err := fetchZones(testCtx, tt.model, client)
+			zones := []dns.Zone{}
+			err = fetchZones(testCtx, tt.model, client, pagination.AppendTo(&zones))
 			if err != nil {
 				if !tt.apiCallFails {
 					t.Fatalf("did not fail on invalid input")
@@ -0,0 +1,34 @@
+package history
+
+import (
+	"fmt"
+
+	"github.com/stackitcloud/stackit-cli/internal/cmd/history/list"
+	"github.com/stackitcloud/stackit-cli/internal/cmd/history/show"
+	"github.com/stackitcloud/stackit-cli/internal/cmd/params"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/args"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/utils"
+
+	"github.com/spf13/cobra"
+)
+
+func NewCmd(params *params.CmdParams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "history",
+		Short: "Shows the history of commands that changed resources",
+		Long: fmt.Sprintf("%s\n%s\n%s",
+			"Shows the history of commands that changed resources.",
+			"Every command that sends requests to create, update or delete resources is recorded in a journal in the configuration directory, with the sanitized flags, project, profile, authenticated account, machine, response statuses and IDs of the returned resources.",
+			`Records can also be forwarded to a syslog or HTTP endpoint, see "stackit config set --history-forward-url".`,
+		),
+		Args: args.NoArgs,
+		Run:  utils.CmdHelp,
+	}
+	addSubcommands(cmd, params)
+	return cmd
+}
+
+func addSubcommands(cmd *cobra.Command, params *params.CmdParams) {
+	cmd.AddCommand(list.NewCmd(params))
+	cmd.AddCommand(show.NewCmd(params))
+}
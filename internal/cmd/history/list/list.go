@@ -0,0 +1,155 @@
+package list
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/stackitcloud/stackit-cli/internal/cmd/params"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/args"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/errors"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/examples"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/flags"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/globalflags"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/history"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/print"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/tables"
+
+	"github.com/goccy/go-yaml"
+	"github.com/spf13/cobra"
+)
+
+const (
+	limitFlag   = "limit"
+	commandFlag = "command"
+)
+
+type inputModel struct {
+	*globalflags.GlobalFlagModel
+	Limit   *int64
+	Command *string
+}
+
+func NewCmd(params *params.CmdParams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "Lists the commands that changed resources",
+		Long:  "Lists the commands that changed resources, the most recent first.",
+		Args:  args.NoArgs,
+		Example: examples.Build(
+			examples.NewExample(
+				`List all recorded commands`,
+				"$ stackit history list"),
+			examples.NewExample(
+				`List the last 10 recorded commands`,
+				"$ stackit history list --limit 10"),
+			examples.NewExample(
+				`List the recorded DNS commands in JSON format`,
+				`$ stackit history list --command "stackit dns" --output-format json`),
+		),
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			model, err := parseInput(params.Printer, cmd)
+			if err != nil {
+				return err
+			}
+
+			records, err := history.List()
+			if err != nil {
+				return fmt.Errorf("list history records: %w", err)
+			}
+			records = filterRecords(records, model)
+			if len(records) == 0 && model.OutputFormat != print.JSONOutputFormat && model.OutputFormat != print.YAMLOutputFormat {
+				params.Printer.Info("No history records found\n")
+				return nil
+			}
+
+			return outputResult(params.Printer, model.OutputFormat, records)
+		},
+	}
+	configureFlags(cmd)
+	return cmd
+}
+
+func configureFlags(cmd *cobra.Command) {
+	cmd.Flags().Int64(limitFlag, 0, "Maximum number of entries to list")
+	cmd.Flags().String(commandFlag, "", `Only list the records of commands starting with this value, e.g. "stackit dns zone"`)
+}
+
+func parseInput(p *print.Printer, cmd *cobra.Command) (*inputModel, error) {
+	globalFlags := globalflags.Parse(p, cmd)
+
+	limit := flags.FlagToInt64Pointer(p, cmd, limitFlag)
+	if limit != nil && *limit < 1 {
+		return nil, &errors.FlagValidationError{
+			Flag:    limitFlag,
+			Details: "must be greater than 0",
+		}
+	}
+
+	model := inputModel{
+		GlobalFlagModel: globalFlags,
+		Limit:           limit,
+		Command:         flags.FlagToStringPointer(p, cmd, commandFlag),
+	}
+
+	if p.IsVerbosityDebug() {
+		modelStr, err := print.BuildDebugStrFromInputModel(model)
+		if err != nil {
+			p.Debug(print.ErrorLevel, "convert model to string for debugging: %v", err)
+		} else {
+			p.Debug(print.DebugLevel, "parsed input values: %s", modelStr)
+		}
+	}
+
+	return &model, nil
+}
+
+func filterRecords(records []history.Record, model *inputModel) []history.Record {
+	filtered := []history.Record{}
+	for i := range records {
+		if model.Command != nil && !strings.HasPrefix(records[i].Command, *model.Command) {
+			continue
+		}
+		filtered = append(filtered, records[i])
+	}
+	if model.Limit != nil && len(filtered) > int(*model.Limit) {
+		filtered = filtered[:*model.Limit]
+	}
+	return filtered
+}
+
+func outputResult(p *print.Printer, outputFormat string, records []history.Record) error {
+	switch outputFormat {
+	case print.JSONOutputFormat:
+		details, err := json.MarshalIndent(records, "", "  ")
+		if err != nil {
+			return fmt.Errorf("marshal history records: %w", err)
+		}
+		p.Outputln(string(details))
+
+		return nil
+	case print.YAMLOutputFormat:
+		details, err := yaml.MarshalWithOptions(records, yaml.IndentSequence(true), yaml.UseJSONMarshaler())
+		if err != nil {
+			return fmt.Errorf("marshal history records: %w", err)
+		}
+		p.Outputln(string(details))
+
+		return nil
+	default:
+		table := tables.NewTable()
+		table.SetHeader("ID", "TIME", "COMMAND", "PROJECT ID", "IDENTITY", "STATUS")
+		for i := range records {
+			r := records[i]
+			table.AddRow(r.Id, r.Time.Local().Format(time.DateTime), r.Command, r.ProjectId, r.Identity, r.Status())
+			table.AddSeparator()
+		}
+		err := table.Display(p)
+		if err != nil {
+			return fmt.Errorf("render table: %w", err)
+		}
+
+		return nil
+	}
+}
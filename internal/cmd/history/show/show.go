@@ -0,0 +1,161 @@
+package show
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/stackitcloud/stackit-cli/internal/cmd/params"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/args"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/examples"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/globalflags"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/history"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/print"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/tables"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/utils"
+
+	"github.com/goccy/go-yaml"
+	"github.com/spf13/cobra"
+)
+
+const (
+	historyIdArg = "HISTORY_ID"
+)
+
+type inputModel struct {
+	*globalflags.GlobalFlagModel
+	HistoryId string
+}
+
+func NewCmd(params *params.CmdParams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   fmt.Sprintf("show %s", historyIdArg),
+		Short: "Shows details of a command that changed resources",
+		Long:  "Shows details of a command that changed resources, including the requests it sent.",
+		Args:  args.SingleArg(historyIdArg, utils.ValidateUUID),
+		Example: examples.Build(
+			examples.NewExample(
+				`Show details of the recorded command with ID "xxx"`,
+				"$ stackit history show xxx"),
+			examples.NewExample(
+				`Show details of the recorded command with ID "xxx" in JSON format`,
+				"$ stackit history show xxx --output-format json"),
+		),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			model := parseInput(params.Printer, cmd, args)
+
+			record, err := history.Get(model.HistoryId)
+			if err != nil {
+				return err
+			}
+
+			return outputResult(params.Printer, model.OutputFormat, record)
+		},
+	}
+	return cmd
+}
+
+func parseInput(p *print.Printer, cmd *cobra.Command, inputArgs []string) *inputModel {
+	historyId := inputArgs[0]
+
+	globalFlags := globalflags.Parse(p, cmd)
+
+	model := inputModel{
+		GlobalFlagModel: globalFlags,
+		HistoryId:       historyId,
+	}
+
+	if p.IsVerbosityDebug() {
+		modelStr, err := print.BuildDebugStrFromInputModel(model)
+		if err != nil {
+			p.Debug(print.ErrorLevel, "convert model to string for debugging: %v", err)
+		} else {
+			p.Debug(print.DebugLevel, "parsed input values: %s", modelStr)
+		}
+	}
+
+	return &model
+}
+
+func outputResult(p *print.Printer, outputFormat string, record *history.Record) error {
+	if record == nil {
+		return fmt.Errorf("history record is empty")
+	}
+	switch outputFormat {
+	case print.JSONOutputFormat:
+		details, err := json.MarshalIndent(record, "", "  ")
+		if err != nil {
+			return fmt.Errorf("marshal history record: %w", err)
+		}
+		p.Outputln(string(details))
+
+		return nil
+	case print.YAMLOutputFormat:
+		details, err := yaml.MarshalWithOptions(record, yaml.IndentSequence(true), yaml.UseJSONMarshaler())
+		if err != nil {
+			return fmt.Errorf("marshal history record: %w", err)
+		}
+		p.Outputln(string(details))
+
+		return nil
+	default:
+		flagValues := make([]string, 0, len(record.Flags))
+		for name, value := range record.Flags {
+			flagValues = append(flagValues, fmt.Sprintf("--%s=%s", name, value))
+		}
+		sort.Strings(flagValues)
+		requests := make([]string, 0, len(record.Requests))
+		for _, req := range record.Requests {
+			requests = append(requests, fmt.Sprintf("%s %s (%d)", req.Method, req.URL, req.StatusCode))
+		}
+		snapshots := make([]string, 0, len(record.Snapshots))
+		for _, snapshot := range record.Snapshots {
+			snapshots = append(snapshots, fmt.Sprintf("%s of %s", snapshot.Operation, snapshot.ResourceType))
+		}
+
+		table := tables.NewTable()
+		table.AddRow("ID", record.Id)
+		table.AddSeparator()
+		table.AddRow("TIME", record.Time.Local().Format(time.DateTime))
+		table.AddSeparator()
+		table.AddRow("COMMAND", record.Command)
+		table.AddSeparator()
+		table.AddRow("ARGUMENTS", strings.Join(record.Args, " "))
+		table.AddSeparator()
+		table.AddRow("FLAGS", strings.Join(flagValues, "\n"))
+		table.AddSeparator()
+		table.AddRow("PROJECT ID", record.ProjectId)
+		table.AddSeparator()
+		table.AddRow("PROFILE", record.Profile)
+		table.AddSeparator()
+		table.AddRow("IDENTITY", record.Identity)
+		table.AddSeparator()
+		table.AddRow("HOSTNAME", record.Hostname)
+		table.AddSeparator()
+		table.AddRow("REQUESTS", strings.Join(requests, "\n"))
+		table.AddSeparator()
+		table.AddRow("RESOURCE IDS", strings.Join(record.ResourceIds, "\n"))
+		table.AddSeparator()
+		if len(snapshots) > 0 {
+			table.AddRow("UNDOABLE CHANGES", strings.Join(snapshots, "\n"))
+			table.AddSeparator()
+		}
+		if record.UndoneId != "" {
+			table.AddRow("UNDOES", record.UndoneId)
+			table.AddSeparator()
+		}
+		table.AddRow("STATUS", record.Status())
+		if record.Error != "" {
+			table.AddSeparator()
+			table.AddRow("ERROR", record.Error)
+		}
+		err := table.Display(p)
+		if err != nil {
+			return fmt.Errorf("render table: %w", err)
+		}
+
+		return nil
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/stackitcloud/stackit-cli/internal/pkg/examples"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/flags"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/globalflags"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/history"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/print"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/services/load-balancer/client"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/services/load-balancer/utils"
@@ -57,10 +58,15 @@ func NewCmd(params *params.CmdParams) *cobra.Command {
 				return err
 			}
 
-			targetLabel, err := utils.GetTargetName(ctx, apiClient, model.ProjectId, model.Region, model.LBName, model.TargetPoolName, model.IP)
+			// Fetch the target pool once: the removed target provides both the prompt label and the undo snapshot
+			req, target, err := buildRequest(ctx, model, apiClient)
 			if err != nil {
-				params.Printer.Debug(print.ErrorLevel, "get target name: %v", err)
-				targetLabel = model.IP
+				return fmt.Errorf("build request: %w", err)
+			}
+
+			targetLabel := model.IP
+			if target.GetDisplayName() != "" {
+				targetLabel = target.GetDisplayName()
 			}
 
 			if !model.AssumeYes {
@@ -71,11 +77,16 @@ func NewCmd(params *params.CmdParams) *cobra.Command {
 				}
 			}
 
+			// Snapshot the target, so that the removal can be undone (see "stackit undo")
+			history.AddSnapshot(params.Printer, history.LoadBalancerTargetResourceType, history.DeleteOperation, &history.LoadBalancerTargetSnapshot{
+				ProjectId:        model.ProjectId,
+				Region:           model.Region,
+				LoadBalancerName: model.LBName,
+				TargetPoolName:   model.TargetPoolName,
+				Target:           *target,
+			})
+
 			// Call API
-			req, err := buildRequest(ctx, model, apiClient)
-			if err != nil {
-				return fmt.Errorf("build request: %w", err)
-			}
 			_, err = req.Execute()
 			if err != nil {
 				return fmt.Errorf("remove target from target pool: %w", err)
@@ -124,23 +135,32 @@ func parseInput(p *print.Printer, cmd *cobra.Command, inputArgs []string) (*inpu
 	return &model, nil
 }
 
-func buildRequest(ctx context.Context, model *inputModel, apiClient utils.LoadBalancerClient) (loadbalancer.ApiUpdateTargetPoolRequest, error) {
+// buildRequest returns the update request together with a copy of the target it removes from the target pool
+func buildRequest(ctx context.Context, model *inputModel, apiClient utils.LoadBalancerClient) (loadbalancer.ApiUpdateTargetPoolRequest, *loadbalancer.Target, error) {
 	req := apiClient.UpdateTargetPool(ctx, model.ProjectId, model.Region, model.LBName, model.TargetPoolName)
 
 	targetPool, err := utils.GetLoadBalancerTargetPool(ctx, apiClient, model.ProjectId, model.Region, model.LBName, model.TargetPoolName)
 	if err != nil {
-		return req, fmt.Errorf("get load balancer target pool: %w", err)
+		return req, nil, fmt.Errorf("get load balancer target pool: %w", err)
+	}
+
+	var target *loadbalancer.Target
+	for _, t := range targetPool.GetTargets() {
+		if t.GetIp() == model.IP {
+			target = &t
+			break
+		}
 	}
 
 	err = utils.RemoveTargetFromTargetPool(targetPool, model.IP)
 	if err != nil {
-		return req, fmt.Errorf("remove target to target pool: %w", err)
+		return req, nil, fmt.Errorf("remove target to target pool: %w", err)
 	}
 
 	payload := utils.ToPayloadTargetPool(targetPool)
 	if payload == nil {
-		return req, fmt.Errorf("nil payload")
+		return req, nil, fmt.Errorf("nil payload")
 	}
 
-	return req.UpdateTargetPoolPayload(*payload), nil
+	return req.UpdateTargetPoolPayload(*payload), target, nil
 }
@@ -310,6 +310,7 @@ func TestBuildRequest(t *testing.T) {
 		getLoadBalancerFails bool
 		getLoadBalancerResp  *loadbalancer.LoadBalancer
 		expectedRequest      loadbalancer.ApiUpdateTargetPoolRequest
+		expectedTarget       *loadbalancer.Target
 	}{
 		{
 			description:         "base",
@@ -322,6 +323,7 @@ func TestBuildRequest(t *testing.T) {
 				})
 				*request = (*request).UpdateTargetPoolPayload(*payload)
 			}),
+			expectedTarget: &(*fixtureTargets())[0],
 		},
 		{
 			description: "empty targets",
@@ -379,7 +381,7 @@ func TestBuildRequest(t *testing.T) {
 				getLoadBalancerFails: tt.getLoadBalancerFails,
 				getLoadBalancerResp:  tt.getLoadBalancerResp,
 			}
-			request, err := buildRequest(testCtx, tt.model, client)
+			request, target, err := buildRequest(testCtx, tt.model, client)
 			if err != nil {
 				if !tt.isValid {
 					return
@@ -394,6 +396,10 @@ func TestBuildRequest(t *testing.T) {
 			if diff != "" {
 				t.Fatalf("Data does not match: %s", diff)
 			}
+			diff = cmp.Diff(target, tt.expectedTarget)
+			if diff != "" {
+				t.Fatalf("Target does not match: %s", diff)
+			}
 		})
 	}
 }
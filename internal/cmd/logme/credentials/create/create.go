@@ -9,6 +9,7 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/stackitcloud/stackit-cli/internal/cmd/params"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/args"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/connection"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/errors"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/examples"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/flags"
@@ -27,8 +28,9 @@ const (
 
 type inputModel struct {
 	*globalflags.GlobalFlagModel
-	InstanceId   string
-	ShowPassword bool
+	InstanceId        string
+	ShowPassword      bool
+	CredentialsFormat *connection.FormatOptions
 }
 
 func NewCmd(params *params.CmdParams) *cobra.Command {
@@ -44,6 +46,9 @@ func NewCmd(params *params.CmdParams) *cobra.Command {
 			examples.NewExample(
 				`Create credentials for a LogMe instance and show the password in the output`,
 				"$ stackit logme credentials create --instance-id xxx --show-password"),
+			examples.NewExample(
+				`Create credentials for a LogMe instance and apply them as a Kubernetes Secret`,
+				"$ stackit logme credentials create --instance-id xxx --format k8s-secret | kubectl apply -f -"),
 		),
 		RunE: func(cmd *cobra.Command, _ []string) error {
 			ctx := context.Background()
@@ -79,6 +84,14 @@ func NewCmd(params *params.CmdParams) *cobra.Command {
 				return fmt.Errorf("create LogMe credentials: %w", err)
 			}
 
+			if model.CredentialsFormat != nil {
+				conn, err := logmeUtils.GetCredentialsConnection(resp)
+				if err != nil {
+					return err
+				}
+				return connection.PrintCredentials(params.Printer, conn, model.CredentialsFormat, fmt.Sprintf("logme-%s", utils.PtrString(resp.Id)), model.ShowPassword)
+			}
+
 			return outputResult(params.Printer, model.OutputFormat, model.ShowPassword, instanceLabel, resp)
 		},
 	}
@@ -89,6 +102,7 @@ func NewCmd(params *params.CmdParams) *cobra.Command {
 func configureFlags(cmd *cobra.Command) {
 	cmd.Flags().Var(flags.UUIDFlag(), instanceIdFlag, "Instance ID")
 	cmd.Flags().BoolP(showPasswordFlag, "s", false, "Show password in output")
+	connection.ConfigureFormatFlags(cmd)
 
 	err := flags.MarkFlagsRequired(cmd, instanceIdFlag)
 	cobra.CheckErr(err)
@@ -100,10 +114,16 @@ func parseInput(p *print.Printer, cmd *cobra.Command) (*inputModel, error) {
 		return nil, &errors.ProjectIdError{}
 	}
 
+	credentialsFormat, err := connection.ParseFormatFlags(p, cmd)
+	if err != nil {
+		return nil, err
+	}
+
 	model := inputModel{
-		GlobalFlagModel: globalFlags,
-		InstanceId:      flags.FlagToStringValue(p, cmd, instanceIdFlag),
-		ShowPassword:    flags.FlagToBoolValue(p, cmd, showPasswordFlag),
+		GlobalFlagModel:   globalFlags,
+		InstanceId:        flags.FlagToStringValue(p, cmd, instanceIdFlag),
+		ShowPassword:      flags.FlagToBoolValue(p, cmd, showPasswordFlag),
+		CredentialsFormat: credentialsFormat,
 	}
 
 	if p.IsVerbosityDebug() {
@@ -5,8 +5,10 @@ import (
 	"testing"
 
 	"github.com/stackitcloud/stackit-cli/internal/cmd/params"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/connection"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/globalflags"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/print"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/utils"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
@@ -69,6 +71,27 @@ func TestParseInput(t *testing.T) {
 			isValid:       true,
 			expectedModel: fixtureInputModel(),
 		},
+		{
+			description: "credentials format",
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				flagValues[connection.FormatFlag] = "k8s-secret"
+				flagValues[connection.SecretNameFlag] = "my-app"
+			}),
+			isValid: true,
+			expectedModel: fixtureInputModel(func(model *inputModel) {
+				model.CredentialsFormat = &connection.FormatOptions{
+					Format:     connection.K8sSecretFormat,
+					SecretName: utils.Ptr("my-app"),
+				}
+			}),
+		},
+		{
+			description: "secret name without credentials format",
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				flagValues[connection.SecretNameFlag] = "my-app"
+			}),
+			isValid: false,
+		},
 		{
 			description: "no values",
 			flagValues:  map[string]string{},
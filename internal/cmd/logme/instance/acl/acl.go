@@ -0,0 +1,30 @@
+package acl
+
+import (
+	"github.com/stackitcloud/stackit-cli/internal/cmd/logme/instance/acl/add"
+	"github.com/stackitcloud/stackit-cli/internal/cmd/logme/instance/acl/list"
+	"github.com/stackitcloud/stackit-cli/internal/cmd/logme/instance/acl/remove"
+	"github.com/stackitcloud/stackit-cli/internal/cmd/params"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/args"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/utils"
+
+	"github.com/spf13/cobra"
+)
+
+func NewCmd(params *params.CmdParams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "acl",
+		Short: "Provides functionality for the ACLs of LogMe instances",
+		Long:  "Provides functionality for the ACLs of LogMe instances, the IP networks which are allowed to access an instance.",
+		Args:  args.NoArgs,
+		Run:   utils.CmdHelp,
+	}
+	addSubcommands(cmd, params)
+	return cmd
+}
+
+func addSubcommands(cmd *cobra.Command, params *params.CmdParams) {
+	cmd.AddCommand(list.NewCmd(params))
+	cmd.AddCommand(add.NewCmd(params))
+	cmd.AddCommand(remove.NewCmd(params))
+}
@@ -0,0 +1,101 @@
+package list
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/stackitcloud/stackit-cli/internal/cmd/params"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/acl"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/args"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/errors"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/examples"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/globalflags"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/print"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/services/logme/client"
+	logmeUtils "github.com/stackitcloud/stackit-cli/internal/pkg/services/logme/utils"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/utils"
+
+	"github.com/spf13/cobra"
+)
+
+const (
+	instanceIdArg = "INSTANCE_ID"
+)
+
+type inputModel struct {
+	*globalflags.GlobalFlagModel
+	InstanceId string
+}
+
+func NewCmd(params *params.CmdParams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   fmt.Sprintf("list %s", instanceIdArg),
+		Short: "Lists the IP networks in the ACL of a LogMe instance",
+		Long:  "Lists the IP networks in the ACL of a LogMe instance, which are allowed to access it.",
+		Args:  args.SingleArg(instanceIdArg, utils.ValidateUUID),
+		Example: examples.Build(
+			examples.NewExample(
+				`List the IP networks in the ACL of the LogMe instance with ID "xxx"`,
+				"$ stackit logme instance acl list xxx"),
+			examples.NewExample(
+				`List the IP networks in the ACL of the LogMe instance with ID "xxx" in JSON format`,
+				"$ stackit logme instance acl list xxx --output-format json"),
+		),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.Background()
+			model, err := parseInput(params.Printer, cmd, args)
+			if err != nil {
+				return err
+			}
+
+			// Configure API client
+			apiClient, err := client.ConfigureClient(params.Printer, params.CliVersion)
+			if err != nil {
+				return err
+			}
+
+			// Call API
+			instanceAcl, err := logmeUtils.GetInstanceACL(ctx, apiClient, model.ProjectId, model.InstanceId)
+			if err != nil {
+				return err
+			}
+			if len(instanceAcl) == 0 {
+				instanceLabel, err := logmeUtils.GetInstanceName(ctx, apiClient, model.ProjectId, model.InstanceId)
+				if err != nil {
+					params.Printer.Debug(print.ErrorLevel, "get instance name: %v", err)
+					instanceLabel = model.InstanceId
+				}
+				params.Printer.Info("The ACL of instance %q is empty\n", instanceLabel)
+				return nil
+			}
+
+			return acl.OutputList(params.Printer, model.OutputFormat, instanceAcl)
+		},
+	}
+	return cmd
+}
+
+func parseInput(p *print.Printer, cmd *cobra.Command, inputArgs []string) (*inputModel, error) {
+	instanceId := inputArgs[0]
+
+	globalFlags := globalflags.Parse(p, cmd)
+	if globalFlags.ProjectId == "" {
+		return nil, &errors.ProjectIdError{}
+	}
+
+	model := inputModel{
+		GlobalFlagModel: globalFlags,
+		InstanceId:      instanceId,
+	}
+
+	if p.IsVerbosityDebug() {
+		modelStr, err := print.BuildDebugStrFromInputModel(model)
+		if err != nil {
+			p.Debug(print.ErrorLevel, "convert model to string for debugging: %v", err)
+		} else {
+			p.Debug(print.DebugLevel, "parsed input values: %s", modelStr)
+		}
+	}
+
+	return &model, nil
+}
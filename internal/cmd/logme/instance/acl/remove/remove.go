@@ -0,0 +1,165 @@
+package remove
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/stackitcloud/stackit-cli/internal/cmd/params"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/acl"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/args"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/errors"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/examples"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/globalflags"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/print"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/services/logme/client"
+	logmeUtils "github.com/stackitcloud/stackit-cli/internal/pkg/services/logme/utils"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/spinner"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/utils"
+
+	"github.com/spf13/cobra"
+	"github.com/stackitcloud/stackit-sdk-go/services/logme"
+	"github.com/stackitcloud/stackit-sdk-go/services/logme/wait"
+)
+
+const (
+	instanceIdArg = "INSTANCE_ID"
+)
+
+type inputModel struct {
+	*globalflags.GlobalFlagModel
+	InstanceId string
+	ACL        *acl.Options
+}
+
+func NewCmd(params *params.CmdParams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   fmt.Sprintf("remove %s", instanceIdArg),
+		Short: "Removes IP networks from the ACL of a LogMe instance",
+		Long:  "Removes IP networks from the ACL of a LogMe instance. Entries are removed if they cover the same addresses as a given network, e.g. 192.0.2.1/24 is removed by 192.0.2.0/24. The last IP networks are only removed with --allow-empty, as no IP network can access an instance with an empty ACL.",
+		Args:  args.SingleArg(instanceIdArg, utils.ValidateUUID),
+		Example: examples.Build(
+			examples.NewExample(
+				`Remove the IP network 192.0.2.0/24 from the ACL of the LogMe instance with ID "xxx"`,
+				"$ stackit logme instance acl remove xxx --cidr 192.0.2.0/24"),
+			examples.NewExample(
+				`Remove the public IP address of this machine from the ACL of the LogMe instance with ID "xxx"`,
+				"$ stackit logme instance acl remove xxx --my-ip"),
+		),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.Background()
+			model, err := parseInput(params.Printer, cmd, args)
+			if err != nil {
+				return err
+			}
+
+			// Configure API client
+			apiClient, err := client.ConfigureClient(params.Printer, params.CliVersion)
+			if err != nil {
+				return err
+			}
+
+			instanceLabel, err := logmeUtils.GetInstanceName(ctx, apiClient, model.ProjectId, model.InstanceId)
+			if err != nil {
+				params.Printer.Debug(print.ErrorLevel, "get instance name: %v", err)
+				instanceLabel = model.InstanceId
+			}
+
+			currentAcl, err := logmeUtils.GetInstanceACL(ctx, apiClient, model.ProjectId, model.InstanceId)
+			if err != nil {
+				return err
+			}
+			cidrs, err := acl.Resolve(ctx, params.Printer, model.ACL)
+			if err != nil {
+				return err
+			}
+			updatedAcl, removed := acl.Remove(currentAcl, cidrs)
+			if len(removed) == 0 {
+				params.Printer.Info("The ACL of instance %q doesn't contain %s\n", instanceLabel, strings.Join(cidrs, ", "))
+				return nil
+			}
+			err = acl.CheckRemoval(instanceLabel, updatedAcl, model.ACL)
+			if err != nil {
+				return err
+			}
+			if len(updatedAcl) == 0 {
+				params.Printer.Warn("The ACL of instance %q will be empty, so no IP network will be able to access it\n", instanceLabel)
+			}
+
+			if !model.AssumeYes {
+				prompt := fmt.Sprintf("Are you sure you want to remove %s from the ACL of instance %q?", strings.Join(removed, ", "), instanceLabel)
+				err = params.Printer.PromptForConfirmation(prompt)
+				if err != nil {
+					return err
+				}
+			}
+
+			// Call API
+			req := buildRequest(ctx, model, apiClient, updatedAcl)
+			err = req.Execute()
+			if err != nil {
+				return fmt.Errorf("update LogMe instance: %w", err)
+			}
+
+			// Wait for async operation, if async mode not enabled
+			if !model.Async {
+				s := spinner.New(params.Printer)
+				s.Start("Updating instance")
+				_, err = wait.PartialUpdateInstanceWaitHandler(ctx, apiClient, model.ProjectId, model.InstanceId).WaitWithContext(ctx)
+				if err != nil {
+					return fmt.Errorf("wait for LogMe instance update: %w", err)
+				}
+				s.Stop()
+			}
+
+			return acl.OutputResult(params.Printer, model.OutputFormat, model.Async, instanceLabel, &acl.Result{
+				InstanceId: model.InstanceId,
+				Removed:    removed,
+				Acl:        updatedAcl,
+			})
+		},
+	}
+	acl.ConfigureRemoveFlags(cmd)
+	return cmd
+}
+
+func parseInput(p *print.Printer, cmd *cobra.Command, inputArgs []string) (*inputModel, error) {
+	instanceId := inputArgs[0]
+
+	globalFlags := globalflags.Parse(p, cmd)
+	if globalFlags.ProjectId == "" {
+		return nil, &errors.ProjectIdError{}
+	}
+
+	aclOptions, err := acl.ParseFlags(p, cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	model := inputModel{
+		GlobalFlagModel: globalFlags,
+		InstanceId:      instanceId,
+		ACL:             aclOptions,
+	}
+
+	if p.IsVerbosityDebug() {
+		modelStr, err := print.BuildDebugStrFromInputModel(model)
+		if err != nil {
+			p.Debug(print.ErrorLevel, "convert model to string for debugging: %v", err)
+		} else {
+			p.Debug(print.DebugLevel, "parsed input values: %s", modelStr)
+		}
+	}
+
+	return &model, nil
+}
+
+func buildRequest(ctx context.Context, model *inputModel, apiClient *logme.APIClient, updatedAcl []string) logme.ApiPartialUpdateInstanceRequest {
+	req := apiClient.PartialUpdateInstance(ctx, model.ProjectId, model.InstanceId)
+	req = req.PartialUpdateInstancePayload(logme.PartialUpdateInstancePayload{
+		Parameters: &logme.InstanceParameters{
+			SgwAcl: utils.Ptr(strings.Join(updatedAcl, ",")),
+		},
+	})
+	return req
+}
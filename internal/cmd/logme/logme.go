@@ -1,6 +1,7 @@
 package logme
 
 import (
+	"github.com/stackitcloud/stackit-cli/internal/cmd/logme/connect"
 	"github.com/stackitcloud/stackit-cli/internal/cmd/logme/credentials"
 	"github.com/stackitcloud/stackit-cli/internal/cmd/logme/instance"
 	"github.com/stackitcloud/stackit-cli/internal/cmd/logme/plans"
@@ -27,4 +28,5 @@ func addSubcommands(cmd *cobra.Command, params *params.CmdParams) {
 	cmd.AddCommand(instance.NewCmd(params))
 	cmd.AddCommand(plans.NewCmd(params))
 	cmd.AddCommand(credentials.NewCmd(params))
+	cmd.AddCommand(connect.NewCmd(params))
 }
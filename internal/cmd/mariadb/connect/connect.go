@@ -0,0 +1,154 @@
+package connect
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/stackitcloud/stackit-cli/internal/cmd/params"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/args"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/connection"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/errors"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/examples"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/flags"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/globalflags"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/print"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/services/mariadb/client"
+	mariadbUtils "github.com/stackitcloud/stackit-cli/internal/pkg/services/mariadb/utils"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/utils"
+
+	"github.com/spf13/cobra"
+	"github.com/stackitcloud/stackit-sdk-go/services/mariadb"
+)
+
+const (
+	instanceIdArg = "INSTANCE_ID"
+
+	credentialsIdFlag = "credentials-id" //nolint:gosec // linter false positive
+)
+
+type inputModel struct {
+	*globalflags.GlobalFlagModel
+	InstanceId    string
+	CredentialsId *string
+	Connection    *connection.Options
+}
+
+func NewCmd(params *params.CmdParams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   fmt.Sprintf("connect %s [-- CLIENT_ARGS...]", instanceIdArg),
+		Short: "Connects to a MariaDB instance with the local client",
+		Long: fmt.Sprintf("%s\n%s\n%s",
+			"Connects to a MariaDB instance with the local client (mysql by default), with the given credentials or with new credentials created for the instance.",
+			"The password is passed to the client through its environment and is never shown.",
+			fmt.Sprintf("Use --%s or --%s to print the connection URI or environment variables instead, e.g. to pass them to other programs.", connection.PrintURIFlag, connection.PrintEnvFlag),
+		),
+		Args: args.SingleArgWithPassthrough(instanceIdArg, utils.ValidateUUID),
+		Example: examples.Build(
+			examples.NewExample(
+				`Connect to a MariaDB instance with ID "xxx" with mysql, creating new credentials`,
+				"$ stackit mariadb connect xxx"),
+			examples.NewExample(
+				`Connect to a MariaDB instance with ID "xxx" with the credentials with ID "yyy" and run a command`,
+				`$ stackit mariadb connect xxx --credentials-id yyy -- -e "SHOW DATABASES"`),
+			examples.NewExample(
+				`Write the connection environment variables of a MariaDB instance with ID "xxx" to a file`,
+				"$ stackit mariadb connect xxx --credentials-id yyy --print-env > .env"),
+		),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.Background()
+			model, err := parseInput(params.Printer, cmd, args)
+			if err != nil {
+				return err
+			}
+
+			// Configure API client
+			apiClient, err := client.ConfigureClient(params.Printer, params.CliVersion)
+			if err != nil {
+				return err
+			}
+
+			var resp *mariadb.CredentialsResponse
+			if model.CredentialsId != nil {
+				resp, err = buildGetCredentialsRequest(ctx, model, apiClient).Execute()
+				if err != nil {
+					return fmt.Errorf("get MariaDB credentials: %w", err)
+				}
+			} else {
+				instanceLabel, err := mariadbUtils.GetInstanceName(ctx, apiClient, model.ProjectId, model.InstanceId)
+				if err != nil {
+					params.Printer.Debug(print.ErrorLevel, "get instance name: %v", err)
+					instanceLabel = model.InstanceId
+				}
+
+				if !model.AssumeYes {
+					prompt := fmt.Sprintf("Are you sure you want to create credentials for instance %q to connect?", instanceLabel)
+					err = params.Printer.PromptForConfirmation(prompt)
+					if err != nil {
+						return err
+					}
+				}
+
+				resp, err = buildCreateCredentialsRequest(ctx, model, apiClient).Execute()
+				if err != nil {
+					return fmt.Errorf("create MariaDB credentials: %w", err)
+				}
+				params.Printer.Info("Created credentials with ID %q\n", utils.PtrString(resp.Id))
+			}
+
+			conn, err := mariadbUtils.GetCredentialsConnection(resp)
+			if err != nil {
+				return err
+			}
+			return connection.Connect(params.Printer, conn, model.Connection)
+		},
+	}
+	configureFlags(cmd)
+	return cmd
+}
+
+func configureFlags(cmd *cobra.Command) {
+	cmd.Flags().Var(flags.UUIDFlag(), credentialsIdFlag, "ID of the credentials to connect with. If not specified, new credentials are created")
+	connection.ConfigureFlags(cmd, connection.MariaDB)
+}
+
+func parseInput(p *print.Printer, cmd *cobra.Command, inputArgs []string) (*inputModel, error) {
+	instanceId := inputArgs[0]
+
+	globalFlags := globalflags.Parse(p, cmd)
+	if globalFlags.ProjectId == "" {
+		return nil, &errors.ProjectIdError{}
+	}
+
+	connectionOptions, err := connection.ParseFlags(cmd, inputArgs)
+	if err != nil {
+		return nil, err
+	}
+
+	model := inputModel{
+		GlobalFlagModel: globalFlags,
+		InstanceId:      instanceId,
+		CredentialsId:   flags.FlagToStringPointer(p, cmd, credentialsIdFlag),
+		Connection:      connectionOptions,
+	}
+
+	if p.IsVerbosityDebug() {
+		modelStr, err := print.BuildDebugStrFromInputModel(model)
+		if err != nil {
+			p.Debug(print.ErrorLevel, "convert model to string for debugging: %v", err)
+		} else {
+			p.Debug(print.DebugLevel, "parsed input values: %s", modelStr)
+		}
+	}
+
+	return &model, nil
+}
+
+func buildGetCredentialsRequest(ctx context.Context, model *inputModel, apiClient *mariadb.APIClient) mariadb.ApiGetCredentialsRequest {
+	req := apiClient.GetCredentials(ctx, model.ProjectId, model.InstanceId, *model.CredentialsId)
+	return req
+}
+
+func buildCreateCredentialsRequest(ctx context.Context, model *inputModel, apiClient *mariadb.APIClient) mariadb.ApiCreateCredentialsRequest {
+	req := apiClient.CreateCredentials(ctx, model.ProjectId, model.InstanceId)
+	return req
+}
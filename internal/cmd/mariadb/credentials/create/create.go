@@ -8,6 +8,7 @@ import (
 	"github.com/goccy/go-yaml"
 	"github.com/stackitcloud/stackit-cli/internal/cmd/params"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/args"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/connection"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/errors"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/examples"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/flags"
@@ -28,8 +29,9 @@ const (
 
 type inputModel struct {
 	*globalflags.GlobalFlagModel
-	InstanceId   string
-	ShowPassword bool
+	InstanceId        string
+	ShowPassword      bool
+	CredentialsFormat *connection.FormatOptions
 }
 
 func NewCmd(params *params.CmdParams) *cobra.Command {
@@ -45,6 +47,9 @@ func NewCmd(params *params.CmdParams) *cobra.Command {
 			examples.NewExample(
 				`Create credentials for a MariaDB instance and show the password in the output`,
 				"$ stackit mariadb credentials create --instance-id xxx --show-password"),
+			examples.NewExample(
+				`Create credentials for a MariaDB instance and apply them as a Kubernetes Secret`,
+				"$ stackit mariadb credentials create --instance-id xxx --format k8s-secret | kubectl apply -f -"),
 		),
 		RunE: func(cmd *cobra.Command, _ []string) error {
 			ctx := context.Background()
@@ -80,6 +85,14 @@ func NewCmd(params *params.CmdParams) *cobra.Command {
 				return fmt.Errorf("create MariaDB credentials: %w", err)
 			}
 
+			if model.CredentialsFormat != nil {
+				conn, err := mariadbUtils.GetCredentialsConnection(resp)
+				if err != nil {
+					return err
+				}
+				return connection.PrintCredentials(params.Printer, conn, model.CredentialsFormat, fmt.Sprintf("mariadb-%s", utils.PtrString(resp.Id)), model.ShowPassword)
+			}
+
 			return outputResult(params.Printer, model.OutputFormat, model.ShowPassword, instanceLabel, resp)
 		},
 	}
@@ -90,6 +103,7 @@ func NewCmd(params *params.CmdParams) *cobra.Command {
 func configureFlags(cmd *cobra.Command) {
 	cmd.Flags().Var(flags.UUIDFlag(), instanceIdFlag, "Instance ID")
 	cmd.Flags().BoolP(showPasswordFlag, "s", false, "Show password in output")
+	connection.ConfigureFormatFlags(cmd)
 
 	err := flags.MarkFlagsRequired(cmd, instanceIdFlag)
 	cobra.CheckErr(err)
@@ -101,10 +115,16 @@ func parseInput(p *print.Printer, cmd *cobra.Command) (*inputModel, error) {
 		return nil, &errors.ProjectIdError{}
 	}
 
+	credentialsFormat, err := connection.ParseFormatFlags(p, cmd)
+	if err != nil {
+		return nil, err
+	}
+
 	model := inputModel{
-		GlobalFlagModel: globalFlags,
-		InstanceId:      flags.FlagToStringValue(p, cmd, instanceIdFlag),
-		ShowPassword:    flags.FlagToBoolValue(p, cmd, showPasswordFlag),
+		GlobalFlagModel:   globalFlags,
+		InstanceId:        flags.FlagToStringValue(p, cmd, instanceIdFlag),
+		ShowPassword:      flags.FlagToBoolValue(p, cmd, showPasswordFlag),
+		CredentialsFormat: credentialsFormat,
 	}
 
 	if p.IsVerbosityDebug() {
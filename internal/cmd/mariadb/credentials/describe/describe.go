@@ -8,12 +8,14 @@ import (
 	"github.com/goccy/go-yaml"
 	"github.com/stackitcloud/stackit-cli/internal/cmd/params"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/args"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/connection"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/errors"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/examples"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/flags"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/globalflags"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/print"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/services/mariadb/client"
+	mariadbUtils "github.com/stackitcloud/stackit-cli/internal/pkg/services/mariadb/utils"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/tables"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/utils"
 
@@ -29,8 +31,9 @@ const (
 
 type inputModel struct {
 	*globalflags.GlobalFlagModel
-	InstanceId    string
-	CredentialsId string
+	InstanceId        string
+	CredentialsId     string
+	CredentialsFormat *connection.FormatOptions
 }
 
 func NewCmd(params *params.CmdParams) *cobra.Command {
@@ -46,6 +49,9 @@ func NewCmd(params *params.CmdParams) *cobra.Command {
 			examples.NewExample(
 				`Get details of credentials with ID "xxx" from instance with ID "yyy" in JSON format`,
 				"$ stackit mariadb credentials describe xxx --instance-id yyy --output-format json"),
+			examples.NewExample(
+				`Write the credentials with ID "xxx" from instance with ID "yyy" as environment variables to a file`,
+				"$ stackit mariadb credentials describe xxx --instance-id yyy --format env > .env"),
 		),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			ctx := context.Background()
@@ -67,6 +73,14 @@ func NewCmd(params *params.CmdParams) *cobra.Command {
 				return fmt.Errorf("describe MariaDB credentials: %w", err)
 			}
 
+			if model.CredentialsFormat != nil {
+				conn, err := mariadbUtils.GetCredentialsConnection(resp)
+				if err != nil {
+					return err
+				}
+				return connection.PrintCredentials(params.Printer, conn, model.CredentialsFormat, fmt.Sprintf("mariadb-%s", model.CredentialsId), true)
+			}
+
 			return outputResult(params.Printer, model.OutputFormat, resp)
 		},
 	}
@@ -76,6 +90,7 @@ func NewCmd(params *params.CmdParams) *cobra.Command {
 
 func configureFlags(cmd *cobra.Command) {
 	cmd.Flags().Var(flags.UUIDFlag(), instanceIdFlag, "Instance ID")
+	connection.ConfigureFormatFlags(cmd)
 
 	err := flags.MarkFlagsRequired(cmd, instanceIdFlag)
 	cobra.CheckErr(err)
@@ -89,10 +104,16 @@ func parseInput(p *print.Printer, cmd *cobra.Command, inputArgs []string) (*inpu
 		return nil, &errors.ProjectIdError{}
 	}
 
+	credentialsFormat, err := connection.ParseFormatFlags(p, cmd)
+	if err != nil {
+		return nil, err
+	}
+
 	model := inputModel{
-		GlobalFlagModel: globalFlags,
-		InstanceId:      flags.FlagToStringValue(p, cmd, instanceIdFlag),
-		CredentialsId:   credentialsId,
+		GlobalFlagModel:   globalFlags,
+		InstanceId:        flags.FlagToStringValue(p, cmd, instanceIdFlag),
+		CredentialsId:     credentialsId,
+		CredentialsFormat: credentialsFormat,
 	}
 
 	if p.IsVerbosityDebug() {
@@ -1,6 +1,7 @@
 package instance
 
 import (
+	"github.com/stackitcloud/stackit-cli/internal/cmd/mariadb/instance/acl"
 	"github.com/stackitcloud/stackit-cli/internal/cmd/mariadb/instance/create"
 	"github.com/stackitcloud/stackit-cli/internal/cmd/mariadb/instance/delete"
 	"github.com/stackitcloud/stackit-cli/internal/cmd/mariadb/instance/describe"
@@ -31,4 +32,5 @@ func addSubcommands(cmd *cobra.Command, params *params.CmdParams) {
 	cmd.AddCommand(describe.NewCmd(params))
 	cmd.AddCommand(list.NewCmd(params))
 	cmd.AddCommand(update.NewCmd(params))
+	cmd.AddCommand(acl.NewCmd(params))
 }
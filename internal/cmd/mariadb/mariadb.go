@@ -1,6 +1,7 @@
 package mariadb
 
 import (
+	"github.com/stackitcloud/stackit-cli/internal/cmd/mariadb/connect"
 	"github.com/stackitcloud/stackit-cli/internal/cmd/mariadb/credentials"
 	"github.com/stackitcloud/stackit-cli/internal/cmd/mariadb/instance"
 	"github.com/stackitcloud/stackit-cli/internal/cmd/mariadb/plans"
@@ -27,4 +28,5 @@ func addSubcommands(cmd *cobra.Command, params *params.CmdParams) {
 	cmd.AddCommand(instance.NewCmd(params))
 	cmd.AddCommand(plans.NewCmd(params))
 	cmd.AddCommand(credentials.NewCmd(params))
+	cmd.AddCommand(connect.NewCmd(params))
 }
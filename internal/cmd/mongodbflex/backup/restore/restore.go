@@ -14,6 +14,7 @@ import (
 	"github.com/stackitcloud/stackit-cli/internal/pkg/services/mongodbflex/client"
 	mongodbUtils "github.com/stackitcloud/stackit-cli/internal/pkg/services/mongodbflex/utils"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/spinner"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/utils"
 
 	"github.com/spf13/cobra"
 	"github.com/stackitcloud/stackit-sdk-go/services/mongodbflex"
@@ -22,6 +23,7 @@ import (
 
 const (
 	instanceIdFlag       = "instance-id"
+	targetInstanceIdFlag = "target-instance-id"
 	backupInstanceIdFlag = "backup-instance-id"
 	backupIdFlag         = "backup-id"
 	timestampFlag        = "timestamp"
@@ -30,7 +32,7 @@ const (
 type inputModel struct {
 	*globalflags.GlobalFlagModel
 	InstanceId       string
-	BackupInstanceId string
+	TargetInstanceId string
 	BackupId         string
 	Timestamp        string
 }
@@ -39,10 +41,11 @@ func NewCmd(params *params.CmdParams) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "restore",
 		Short: "Restores a MongoDB Flex instance from a backup",
-		Long: fmt.Sprintf("%s\n%s\n%s",
+		Long: fmt.Sprintf("%s\n%s\n%s\n%s",
 			"Restores a MongoDB Flex instance from a backup of an instance or clones a MongoDB Flex instance from a point-in-time backup.",
 			"The backup can be specified by a backup ID or a timestamp.",
 			"You can specify the instance to which the backup will be applied. If not specified, the backup will be applied to the same instance from which it was taken.",
+			"Unless --async is set, the command waits until the restore job, as listed by \"stackit mongodbflex backup restore-jobs\", is finished.",
 		),
 		Args: args.NoArgs,
 		Example: examples.Build(
@@ -53,8 +56,8 @@ func NewCmd(params *params.CmdParams) *cobra.Command {
 				`Clone a MongoDB Flex instance with ID "yyy" via point-in-time restore to timestamp "2024-05-14T14:31:48Z"`,
 				`$ stackit mongodbflex backup restore --instance-id yyy --timestamp 2024-05-14T14:31:48Z`),
 			examples.NewExample(
-				`Restore a MongoDB Flex instance with ID "yyy", using backup from instance with ID "zzz" with backup ID "xxx"`,
-				`$ stackit mongodbflex backup restore --instance-id zzz --backup-instance-id yyy --backup-id xxx`),
+				`Restore the backup with ID "xxx" of the MongoDB Flex instance with ID "yyy" to the instance with ID "zzz"`,
+				`$ stackit mongodbflex backup restore --instance-id yyy --backup-id xxx --target-instance-id zzz`),
 		),
 		RunE: func(cmd *cobra.Command, _ []string) error {
 			ctx := context.Background()
@@ -73,43 +76,62 @@ func NewCmd(params *params.CmdParams) *cobra.Command {
 			instanceLabel, err := mongodbUtils.GetInstanceName(ctx, apiClient, model.ProjectId, model.InstanceId, model.Region)
 			if err != nil {
 				params.Printer.Debug(print.ErrorLevel, "get instance name: %v", err)
-				instanceLabel = model.ProjectId
+				instanceLabel = model.InstanceId
+			}
+			targetInstanceLabel := instanceLabel
+			if model.TargetInstanceId != model.InstanceId {
+				targetInstanceLabel, err = mongodbUtils.GetInstanceName(ctx, apiClient, model.ProjectId, model.TargetInstanceId, model.Region)
+				if err != nil {
+					params.Printer.Debug(print.ErrorLevel, "get target instance name: %v", err)
+					targetInstanceLabel = model.TargetInstanceId
+				}
 			}
 
 			if !model.AssumeYes {
-				prompt := fmt.Sprintf("Are you sure you want to restore MongoDB Flex instance %q?", instanceLabel)
+				prompt := fmt.Sprintf("Are you sure you want to restore MongoDB Flex instance %q?", targetInstanceLabel)
+				if model.TargetInstanceId != model.InstanceId {
+					prompt = fmt.Sprintf("Are you sure you want to restore a backup of MongoDB Flex instance %q to instance %q?", instanceLabel, targetInstanceLabel)
+				}
 				err = params.Printer.PromptForConfirmation(prompt)
 				if err != nil {
 					return err
 				}
 			}
 
-			// If backupInstanceId is not provided, the target is the same instance as the backup
-			if model.BackupInstanceId == "" {
-				model.BackupInstanceId = model.InstanceId
-			}
-
 			isRestoreOperation := getIsRestoreOperation(model.BackupId, model.Timestamp)
 
 			// If backupId is provided, restore the instance from the backup with the backupId
 			if isRestoreOperation {
+				// Earlier restore jobs of the same backup are ignored when waiting
+				previousJobId := ""
+				previousJob, err := mongodbUtils.GetLatestRestoreJob(ctx, apiClient, model.ProjectId, model.InstanceId, model.BackupId, model.Region)
+				if err != nil {
+					params.Printer.Debug(print.ErrorLevel, "get previous restore job: %v", err)
+				} else if previousJob != nil {
+					previousJobId = utils.PtrString(previousJob.Id)
+				}
+
 				req := buildRestoreRequest(ctx, model, apiClient)
 				_, err = req.Execute()
 				if err != nil {
 					return fmt.Errorf("restore MongoDB Flex instance: %w", err)
 				}
 
-				if !model.Async {
-					s := spinner.New(params.Printer)
-					s.Start("Restoring instance")
-					_, err = wait.RestoreInstanceWaitHandler(ctx, apiClient, model.ProjectId, model.InstanceId, model.BackupId, model.Region).WaitWithContext(ctx)
-					if err != nil {
-						return fmt.Errorf("wait for MongoDB Flex instance restoration: %w", err)
-					}
-					s.Stop()
+				if model.Async {
+					params.Printer.Outputf("Triggered restore of instance %q with backup %q\n", targetInstanceLabel, model.BackupId)
+					return nil
+				}
+
+				s := spinner.New(params.Printer)
+				s.Start("Restoring instance")
+				restoreJob, err := mongodbUtils.RestoreJobWaitHandler(ctx, apiClient, model.ProjectId, model.InstanceId, model.BackupId, previousJobId, model.Region).WaitWithContext(ctx)
+				if err != nil {
+					s.StopWithError()
+					return fmt.Errorf("wait for MongoDB Flex instance restoration: %w", err)
 				}
+				s.Stop()
 
-				params.Printer.Outputf("Restored instance %q with backup %q\n", model.InstanceId, model.BackupId)
+				params.Printer.Outputf("Restored instance %q with backup %q (restore job %s)\n", targetInstanceLabel, model.BackupId, utils.PtrString(restoreJob.Id))
 				return nil
 			}
 
@@ -123,14 +145,18 @@ func NewCmd(params *params.CmdParams) *cobra.Command {
 			if !model.Async {
 				s := spinner.New(params.Printer)
 				s.Start("Cloning instance")
-				_, err = wait.CloneInstanceWaitHandler(ctx, apiClient, model.ProjectId, model.InstanceId, model.Region).WaitWithContext(ctx)
+				_, err = wait.CloneInstanceWaitHandler(ctx, apiClient, model.ProjectId, model.TargetInstanceId, model.Region).WaitWithContext(ctx)
 				if err != nil {
 					return fmt.Errorf("wait for MongoDB Flex instance cloning: %w", err)
 				}
 				s.Stop()
 			}
 
-			params.Printer.Outputf("Cloned instance %q from backup with timestamp %q\n", model.InstanceId, model.Timestamp)
+			operationState := "Cloned"
+			if model.Async {
+				operationState = "Triggered cloning of"
+			}
+			params.Printer.Outputf("%s instance %q from backup with timestamp %q\n", operationState, targetInstanceLabel, model.Timestamp)
 			return nil
 		},
 	}
@@ -140,11 +166,16 @@ func NewCmd(params *params.CmdParams) *cobra.Command {
 
 func configureFlags(cmd *cobra.Command) {
 	cmd.Flags().Var(flags.UUIDFlag(), instanceIdFlag, "Instance ID")
+	cmd.Flags().Var(flags.UUIDFlag(), targetInstanceIdFlag, "ID of the instance to restore the backup to. If not specified, the backup is restored to the instance it was taken from")
 	cmd.Flags().Var(flags.UUIDFlag(), backupInstanceIdFlag, "Instance ID of the target instance to restore the backup to")
 	cmd.Flags().String(backupIdFlag, "", "Backup ID")
 	cmd.Flags().String(timestampFlag, "", "Timestamp to restore the instance to, in a date-time with the RFC3339 layout format, e.g. 2024-01-01T00:00:00Z")
 
-	err := flags.MarkFlagsRequired(cmd, instanceIdFlag)
+	err := cmd.Flags().MarkDeprecated(backupInstanceIdFlag, fmt.Sprintf("use --%s instead", targetInstanceIdFlag))
+	cobra.CheckErr(err)
+	cmd.MarkFlagsMutuallyExclusive(targetInstanceIdFlag, backupInstanceIdFlag)
+
+	err = flags.MarkFlagsRequired(cmd, instanceIdFlag)
 	cobra.CheckErr(err)
 }
 
@@ -163,10 +194,20 @@ func parseInput(p *print.Printer, cmd *cobra.Command) (*inputModel, error) {
 		}
 	}
 
+	instanceId := flags.FlagToStringValue(p, cmd, instanceIdFlag)
+	// If no target is provided, the backup is restored to the instance it was taken from
+	targetInstanceId := flags.FlagToStringValue(p, cmd, targetInstanceIdFlag)
+	if targetInstanceId == "" {
+		targetInstanceId = flags.FlagToStringValue(p, cmd, backupInstanceIdFlag)
+	}
+	if targetInstanceId == "" {
+		targetInstanceId = instanceId
+	}
+
 	model := inputModel{
 		GlobalFlagModel:  globalFlags,
-		InstanceId:       flags.FlagToStringValue(p, cmd, instanceIdFlag),
-		BackupInstanceId: flags.FlagToStringValue(p, cmd, backupInstanceIdFlag),
+		InstanceId:       instanceId,
+		TargetInstanceId: targetInstanceId,
 		BackupId:         flags.FlagToStringValue(p, cmd, backupIdFlag),
 		Timestamp:        flags.FlagToStringValue(p, cmd, timestampFlag),
 	}
@@ -187,7 +228,7 @@ func buildRestoreRequest(ctx context.Context, model *inputModel, apiClient *mong
 	req := apiClient.RestoreInstance(ctx, model.ProjectId, model.InstanceId, model.Region)
 	req = req.RestoreInstancePayload(mongodbflex.RestoreInstancePayload{
 		BackupId:   &model.BackupId,
-		InstanceId: &model.BackupInstanceId,
+		InstanceId: &model.TargetInstanceId,
 	})
 	return req
 }
@@ -196,7 +237,7 @@ func buildCloneRequest(ctx context.Context, model *inputModel, apiClient *mongod
 	req := apiClient.CloneInstance(ctx, model.ProjectId, model.InstanceId, model.Region)
 	req = req.CloneInstancePayload(mongodbflex.CloneInstancePayload{
 		Timestamp:  &model.Timestamp,
-		InstanceId: &model.BackupInstanceId,
+		InstanceId: &model.TargetInstanceId,
 	})
 	return req
 }
@@ -28,14 +28,14 @@ var testClient = &mongodbflex.APIClient{}
 
 var testProjectId = uuid.NewString()
 var testInstanceId = uuid.NewString()
-var testBackupInstanceId = uuid.NewString()
+var testTargetInstanceId = uuid.NewString()
 
 func fixtureFlagValues(mods ...func(flagValues map[string]string)) map[string]string {
 	flagValues := map[string]string{
 		globalflags.ProjectIdFlag: testProjectId,
 		globalflags.RegionFlag:    testRegion,
 		backupIdFlag:              testBackupId,
-		backupInstanceIdFlag:      testBackupInstanceId,
+		targetInstanceIdFlag:      testTargetInstanceId,
 		instanceIdFlag:            testInstanceId,
 	}
 	for _, mod := range mods {
@@ -53,7 +53,7 @@ func fixtureInputModel(mods ...func(model *inputModel)) *inputModel {
 		},
 		InstanceId:       testInstanceId,
 		BackupId:         testBackupId,
-		BackupInstanceId: testBackupInstanceId,
+		TargetInstanceId: testTargetInstanceId,
 	}
 	for _, mod := range mods {
 		mod(model)
@@ -65,7 +65,7 @@ func fixtureRestoreRequest(mods ...func(request mongodbflex.ApiRestoreInstanceRe
 	request := testClient.RestoreInstance(testCtx, testProjectId, testInstanceId, testRegion)
 	request = request.RestoreInstancePayload(mongodbflex.RestoreInstancePayload{
 		BackupId:   utils.Ptr(testBackupId),
-		InstanceId: utils.Ptr(testBackupInstanceId),
+		InstanceId: utils.Ptr(testTargetInstanceId),
 	})
 	for _, mod := range mods {
 		mod(request)
@@ -77,7 +77,7 @@ func fixtureCloneRequest(mods ...func(request mongodbflex.ApiCloneInstanceReques
 	request := testClient.CloneInstance(testCtx, testProjectId, testInstanceId, testRegion)
 	request = request.CloneInstancePayload(mongodbflex.CloneInstancePayload{
 		Timestamp:  utils.Ptr(testTimestamp),
-		InstanceId: utils.Ptr(testBackupInstanceId),
+		InstanceId: utils.Ptr(testTargetInstanceId),
 	})
 	for _, mod := range mods {
 		mod(request)
@@ -140,15 +140,49 @@ func TestParseInput(t *testing.T) {
 			isValid: false,
 		},
 		{
-			description: "backup instance id invalid 1",
+			description: "target instance id missing",
 			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
-				flagValues[backupInstanceIdFlag] = ""
+				delete(flagValues, targetInstanceIdFlag)
+			}),
+			isValid: true,
+			expectedModel: fixtureInputModel(func(model *inputModel) {
+				model.TargetInstanceId = testInstanceId
+			}),
+		},
+		{
+			description: "target instance id invalid 1",
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				flagValues[targetInstanceIdFlag] = ""
+			}),
+			isValid: false,
+		},
+		{
+			description: "target instance id invalid 2",
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				flagValues[targetInstanceIdFlag] = "invalid-uuid"
+			}),
+			isValid: false,
+		},
+		{
+			description: "deprecated backup instance id",
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				delete(flagValues, targetInstanceIdFlag)
+				flagValues[backupInstanceIdFlag] = testTargetInstanceId
+			}),
+			isValid:       true,
+			expectedModel: fixtureInputModel(),
+		},
+		{
+			description: "target and backup instance id both provided",
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				flagValues[backupInstanceIdFlag] = testTargetInstanceId
 			}),
 			isValid: false,
 		},
 		{
-			description: "backup instance id invalid 2",
+			description: "backup instance id invalid",
 			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				delete(flagValues, targetInstanceIdFlag)
 				flagValues[backupInstanceIdFlag] = "invalid-uuid"
 			}),
 			isValid: false,
@@ -0,0 +1,432 @@
+package connect
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stackitcloud/stackit-cli/internal/cmd/params"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/connection"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/globalflags"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/print"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/utils"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/uuid"
+	"github.com/stackitcloud/stackit-sdk-go/services/mongodbflex"
+)
+
+type testCtxKey struct{}
+
+var testCtx = context.WithValue(context.Background(), testCtxKey{}, "foo")
+var testClient = &mongodbflex.APIClient{}
+var testProjectId = uuid.NewString()
+var testInstanceId = uuid.NewString()
+var testUserId = "12345"
+var testRegion = "eu01"
+
+// Engines are compared by identity
+var engineComparer = cmp.Comparer(func(a, b *connection.Engine) bool { return a == b })
+
+type mongoDBFlexClientMocked struct {
+	listUsersFails bool
+	listUsersResp  *mongodbflex.ListUsersResponse
+}
+
+func (m *mongoDBFlexClientMocked) ListUsersExecute(_ context.Context, _, _, _ string) (*mongodbflex.ListUsersResponse, error) {
+	if m.listUsersFails {
+		return nil, fmt.Errorf("could not list users")
+	}
+	return m.listUsersResp, nil
+}
+
+func fixtureArgValues(mods ...func(argValues []string)) []string {
+	argValues := []string{
+		testInstanceId,
+	}
+	for _, mod := range mods {
+		mod(argValues)
+	}
+	return argValues
+}
+
+func fixtureFlagValues(mods ...func(flagValues map[string]string)) map[string]string {
+	flagValues := map[string]string{
+		globalflags.ProjectIdFlag: testProjectId,
+		globalflags.RegionFlag:    testRegion,
+		userFlag:                  "my-user",
+	}
+	for _, mod := range mods {
+		mod(flagValues)
+	}
+	return flagValues
+}
+
+func fixtureInputModel(mods ...func(model *inputModel)) *inputModel {
+	model := &inputModel{
+		GlobalFlagModel: &globalflags.GlobalFlagModel{
+			ProjectId: testProjectId,
+			Region:    testRegion,
+			Verbosity: globalflags.VerbosityDefault,
+		},
+		InstanceId: testInstanceId,
+		User:       "my-user",
+		Connection: &connection.Options{
+			Client:     "mongosh",
+			ClientArgs: []string{},
+		},
+	}
+	for _, mod := range mods {
+		mod(model)
+	}
+	return model
+}
+
+func TestParseInput(t *testing.T) {
+	tests := []struct {
+		description   string
+		argValues     []string
+		flagValues    map[string]string
+		isValid       bool
+		expectedModel *inputModel
+	}{
+		{
+			description:   "base",
+			argValues:     fixtureArgValues(),
+			flagValues:    fixtureFlagValues(),
+			isValid:       true,
+			expectedModel: fixtureInputModel(),
+		},
+		{
+			description: "all values",
+			argValues:   fixtureArgValues(),
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				flagValues[databaseFlag] = "my-database"
+				flagValues[resetPasswordFlag] = "true"
+				flagValues[connection.ClientFlag] = "/usr/bin/mongosh"
+				flagValues[connection.PrintURIFlag] = "true"
+			}),
+			isValid: true,
+			expectedModel: fixtureInputModel(func(model *inputModel) {
+				model.Database = utils.Ptr("my-database")
+				model.ResetPassword = true
+				model.Connection.Client = "/usr/bin/mongosh"
+				model.Connection.PrintURI = true
+			}),
+		},
+		{
+			description: "no values",
+			argValues:   []string{},
+			flagValues:  map[string]string{},
+			isValid:     false,
+		},
+		{
+			description: "no arg values",
+			argValues:   []string{},
+			flagValues:  fixtureFlagValues(),
+			isValid:     false,
+		},
+		{
+			description: "instance id invalid",
+			argValues:   []string{"invalid-uuid"},
+			flagValues:  fixtureFlagValues(),
+			isValid:     false,
+		},
+		{
+			description: "project id missing",
+			argValues:   fixtureArgValues(),
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				delete(flagValues, globalflags.ProjectIdFlag)
+			}),
+			isValid: false,
+		},
+		{
+			description: "user missing",
+			argValues:   fixtureArgValues(),
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				delete(flagValues, userFlag)
+			}),
+			isValid: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			p := print.NewPrinter()
+			cmd := NewCmd(&params.CmdParams{Printer: p})
+			err := globalflags.Configure(cmd.Flags())
+			if err != nil {
+				t.Fatalf("configure global flags: %v", err)
+			}
+
+			for flag, value := range tt.flagValues {
+				err := cmd.Flags().Set(flag, value)
+				if err != nil {
+					if !tt.isValid {
+						return
+					}
+					t.Fatalf("setting flag --%s=%s: %v", flag, value, err)
+				}
+			}
+
+			err = cmd.ValidateArgs(tt.argValues)
+			if err != nil {
+				if !tt.isValid {
+					return
+				}
+				t.Fatalf("error validating args: %v", err)
+			}
+
+			err = cmd.ValidateRequiredFlags()
+			if err != nil {
+				if !tt.isValid {
+					return
+				}
+				t.Fatalf("error validating flags: %v", err)
+			}
+
+			model, err := parseInput(p, cmd, tt.argValues)
+			if err != nil {
+				if !tt.isValid {
+					return
+				}
+				t.Fatalf("error parsing input: %v", err)
+			}
+
+			if !tt.isValid {
+				t.Fatalf("did not fail on invalid input")
+			}
+			diff := cmp.Diff(model, tt.expectedModel)
+			if diff != "" {
+				t.Fatalf("Data does not match: %s", diff)
+			}
+		})
+	}
+}
+
+func TestFindUser(t *testing.T) {
+	users := &mongodbflex.ListUsersResponse{
+		Items: &[]mongodbflex.ListUser{
+			{Id: utils.Ptr("1"), Username: utils.Ptr("other-user")},
+			{Id: utils.Ptr(testUserId), Username: utils.Ptr("my-user")},
+		},
+	}
+
+	tests := []struct {
+		description      string
+		user             string
+		listUsersFails   bool
+		isValid          bool
+		expectedUserId   string
+		expectedUsername string
+	}{
+		{
+			description:      "by username",
+			user:             "my-user",
+			isValid:          true,
+			expectedUserId:   testUserId,
+			expectedUsername: "my-user",
+		},
+		{
+			description:      "by id",
+			user:             testUserId,
+			isValid:          true,
+			expectedUserId:   testUserId,
+			expectedUsername: "my-user",
+		},
+		{
+			description: "not found",
+			user:        "unknown",
+			isValid:     false,
+		},
+		{
+			description:    "list users fails",
+			user:           "my-user",
+			listUsersFails: true,
+			isValid:        false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			client := &mongoDBFlexClientMocked{
+				listUsersFails: tt.listUsersFails,
+				listUsersResp:  users,
+			}
+			model := fixtureInputModel(func(model *inputModel) {
+				model.User = tt.user
+			})
+
+			userId, username, err := findUser(testCtx, client, model)
+			if !tt.isValid {
+				if err == nil {
+					t.Fatalf("did not fail on invalid input")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("failed on valid input: %v", err)
+			}
+			if userId != tt.expectedUserId || username != tt.expectedUsername {
+				t.Fatalf("expected user %q (%q), got %q (%q)", tt.expectedUserId, tt.expectedUsername, userId, username)
+			}
+		})
+	}
+}
+
+func TestBuildRequest(t *testing.T) {
+	model := fixtureInputModel()
+	expectedRequest := testClient.ResetUser(testCtx, testProjectId, testInstanceId, testUserId, testRegion)
+
+	request := buildRequest(testCtx, model, testClient, testUserId)
+
+	diff := cmp.Diff(request, expectedRequest,
+		cmp.AllowUnexported(expectedRequest),
+		cmpopts.EquateComparable(testCtx),
+	)
+	if diff != "" {
+		t.Fatalf("Data does not match: %s", diff)
+	}
+}
+
+func TestBuildGetUserRequest(t *testing.T) {
+	model := fixtureInputModel()
+	expectedRequest := testClient.GetUser(testCtx, testProjectId, testInstanceId, testUserId, testRegion)
+
+	request := buildGetUserRequest(testCtx, model, testClient, testUserId)
+
+	diff := cmp.Diff(request, expectedRequest,
+		cmp.AllowUnexported(expectedRequest),
+		cmpopts.EquateComparable(testCtx),
+	)
+	if diff != "" {
+		t.Fatalf("Data does not match: %s", diff)
+	}
+}
+
+func TestUserFromResponse(t *testing.T) {
+	tests := []struct {
+		description string
+		resp        *mongodbflex.GetUserResponse
+		expected    *mongodbflex.User
+	}{
+		{
+			description: "base",
+			resp: &mongodbflex.GetUserResponse{
+				Item: &mongodbflex.InstanceResponseUser{
+					Id:       utils.Ptr(testUserId),
+					Host:     utils.Ptr("example.com"),
+					Port:     utils.Ptr(int64(27017)),
+					Username: utils.Ptr("my-user"),
+					Database: utils.Ptr("default"),
+				},
+			},
+			expected: &mongodbflex.User{
+				Host:     utils.Ptr("example.com"),
+				Port:     utils.Ptr(int64(27017)),
+				Username: utils.Ptr("my-user"),
+				Database: utils.Ptr("default"),
+			},
+		},
+		{
+			description: "no item",
+			resp:        &mongodbflex.GetUserResponse{},
+		},
+		{
+			description: "nil response",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			user := userFromResponse(tt.resp)
+			diff := cmp.Diff(user, tt.expected)
+			if diff != "" {
+				t.Fatalf("Data does not match: %s", diff)
+			}
+		})
+	}
+}
+
+func TestBuildConnection(t *testing.T) {
+	fixtureResponse := func() *mongodbflex.User {
+		return &mongodbflex.User{
+			Host:     utils.Ptr("example.com"),
+			Port:     utils.Ptr(int64(27017)),
+			Username: utils.Ptr("my-user"),
+			Password: utils.Ptr("password"),
+			Database: utils.Ptr("default"),
+		}
+	}
+
+	tests := []struct {
+		description string
+		model       *inputModel
+		resp        *mongodbflex.User
+		isValid     bool
+		expected    *connection.Connection
+	}{
+		{
+			description: "base",
+			model:       fixtureInputModel(),
+			resp:        fixtureResponse(),
+			isValid:     true,
+			expected: &connection.Connection{
+				Engine:   connection.MongoDB,
+				Host:     "example.com",
+				Port:     27017,
+				Username: "my-user",
+				Password: "password",
+				Database: "default",
+			},
+		},
+		{
+			description: "database set",
+			model: fixtureInputModel(func(model *inputModel) {
+				model.Database = utils.Ptr("my-database")
+			}),
+			resp:    fixtureResponse(),
+			isValid: true,
+			expected: &connection.Connection{
+				Engine:   connection.MongoDB,
+				Host:     "example.com",
+				Port:     27017,
+				Username: "my-user",
+				Password: "password",
+				Database: "my-database",
+				Query:    "authSource=default",
+			},
+		},
+		{
+			description: "no host",
+			model:       fixtureInputModel(),
+			resp:        &mongodbflex.User{},
+			isValid:     false,
+		},
+		{
+			description: "no user",
+			model:       fixtureInputModel(),
+			isValid:     false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			conn, err := buildConnection(tt.model, tt.resp)
+			if !tt.isValid {
+				if err == nil {
+					t.Fatalf("did not fail on invalid input")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("failed on valid input: %v", err)
+			}
+			diff := cmp.Diff(conn, tt.expected, engineComparer)
+			if diff != "" {
+				t.Fatalf("Data does not match: %s", diff)
+			}
+		})
+	}
+}
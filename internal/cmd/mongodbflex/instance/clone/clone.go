@@ -0,0 +1,297 @@
+package clone
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/goccy/go-yaml"
+	"github.com/spf13/cobra"
+	"github.com/stackitcloud/stackit-cli/internal/cmd/params"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/args"
+	cliErr "github.com/stackitcloud/stackit-cli/internal/pkg/errors"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/examples"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/flags"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/globalflags"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/print"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/services/mongodbflex/client"
+	mongodbflexUtils "github.com/stackitcloud/stackit-cli/internal/pkg/services/mongodbflex/utils"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/spinner"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/utils"
+	"github.com/stackitcloud/stackit-sdk-go/services/mongodbflex"
+	"github.com/stackitcloud/stackit-sdk-go/services/mongodbflex/wait"
+)
+
+const (
+	instanceIdArg = "INSTANCE_ID"
+
+	instanceNameFlag      = "name"
+	storageClassFlag      = "storage-class"
+	storageSizeFlag       = "storage-size"
+	recoveryTimestampFlag = "recovery-timestamp"
+
+	cloneNameSuffix = "-clone"
+)
+
+type inputModel struct {
+	*globalflags.GlobalFlagModel
+
+	InstanceId        string
+	InstanceName      *string
+	StorageClass      *string
+	StorageSize       *int64
+	RecoveryTimestamp string
+}
+
+func NewCmd(params *params.CmdParams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   fmt.Sprintf("clone %s", instanceIdArg),
+		Short: "Clones a MongoDB Flex instance",
+		Long: fmt.Sprintf("%s\n%s\n%s",
+			"Clones a MongoDB Flex instance from a selected point in time.",
+			"A new instance with the same settings as the original instance is created, unless the flags are specified, and the backup of the original instance at the recovery timestamp is restored to it.",
+			"The new instance is independent of the original instance, which is left unchanged.",
+		),
+		Example: examples.Build(
+			examples.NewExample(
+				`Clone a MongoDB Flex instance with ID "xxx" from a selected recovery timestamp`,
+				`$ stackit mongodbflex instance clone xxx --recovery-timestamp 2024-05-14T14:31:48Z`),
+			examples.NewExample(
+				`Clone a MongoDB Flex instance with ID "xxx" from a selected recovery timestamp into a new instance named "my-test-instance"`,
+				`$ stackit mongodbflex instance clone xxx --recovery-timestamp 2024-05-14T14:31:48Z --name my-test-instance`),
+			examples.NewExample(
+				`Clone a MongoDB Flex instance with ID "xxx" from a selected recovery timestamp and specify storage class and size`,
+				`$ stackit mongodbflex instance clone xxx --recovery-timestamp 2024-05-14T14:31:48Z --storage-class premium-perf6-mongodb --storage-size 20`),
+		),
+		Args: args.SingleArg(instanceIdArg, utils.ValidateUUID),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.Background()
+
+			model, err := parseInput(params.Printer, cmd, args)
+			if err != nil {
+				return err
+			}
+
+			// Configure API client
+			apiClient, err := client.ConfigureClient(params.Printer, params.CliVersion)
+			if err != nil {
+				return err
+			}
+
+			sourceInstance, err := apiClient.GetInstanceExecute(ctx, model.ProjectId, model.InstanceId, model.Region)
+			if err != nil {
+				return fmt.Errorf("get MongoDB Flex instance: %w", err)
+			}
+			if sourceInstance.Item == nil {
+				return fmt.Errorf("MongoDB Flex instance %q not found", model.InstanceId)
+			}
+			instanceLabel := utils.PtrString(sourceInstance.Item.Name)
+			if instanceLabel == "" {
+				instanceLabel = model.InstanceId
+			}
+
+			if !model.AssumeYes {
+				prompt := fmt.Sprintf("Are you sure you want to clone instance %q?", instanceLabel)
+				err = params.Printer.PromptForConfirmation(prompt)
+				if err != nil {
+					return err
+				}
+			}
+
+			// Create the instance the backup is restored to
+			createReq, err := buildCreateRequest(ctx, model, apiClient, sourceInstance.Item)
+			if err != nil {
+				return err
+			}
+			createResp, err := createReq.Execute()
+			if err != nil {
+				return fmt.Errorf("create MongoDB Flex instance: %w", err)
+			}
+			instanceId := *createResp.Id
+
+			// The backup can only be restored once the new instance is ready, so this wait is done even in async mode
+			s := spinner.New(params.Printer)
+			s.Start("Creating instance")
+			_, err = wait.CreateInstanceWaitHandler(ctx, apiClient, model.ProjectId, instanceId, model.Region).WaitWithContext(ctx)
+			if err != nil {
+				s.StopWithError()
+				return fmt.Errorf("wait for MongoDB Flex instance creation: %w", err)
+			}
+			s.Stop()
+
+			// Call API
+			req := buildCloneRequest(ctx, model, apiClient, instanceId)
+			resp, err := req.Execute()
+			if err != nil {
+				return fmt.Errorf("clone MongoDB Flex instance: %w", err)
+			}
+			if resp.InstanceId == nil {
+				resp.InstanceId = utils.Ptr(instanceId)
+			}
+
+			// Wait for async operation, if async mode not enabled
+			if !model.Async {
+				s := spinner.New(params.Printer)
+				s.Start("Cloning instance")
+				_, err = wait.CloneInstanceWaitHandler(ctx, apiClient, model.ProjectId, instanceId, model.Region).WaitWithContext(ctx)
+				if err != nil {
+					s.StopWithError()
+					return fmt.Errorf("wait for MongoDB Flex instance cloning: %w", err)
+				}
+				s.Stop()
+			}
+
+			return outputResult(params.Printer, model.OutputFormat, model.Async, instanceLabel, resp)
+		},
+	}
+	configureFlags(cmd)
+	return cmd
+}
+
+func configureFlags(cmd *cobra.Command) {
+	cmd.Flags().String(recoveryTimestampFlag, "", "Recovery timestamp for the instance, in a date-time with the RFC3339 layout format, e.g. 2024-01-01T00:00:00Z")
+	cmd.Flags().StringP(instanceNameFlag, "n", "", fmt.Sprintf("Name of the new instance. If not specified, the name of the existing instance with the suffix %q will be used.", cloneNameSuffix))
+	cmd.Flags().String(storageClassFlag, "", "Storage class. If not specified, storage class from the existing instance will be used.")
+	cmd.Flags().Int64(storageSizeFlag, 0, "Storage size (in GB). If not specified, storage size from the existing instance will be used.")
+
+	err := flags.MarkFlagsRequired(cmd, recoveryTimestampFlag)
+	cobra.CheckErr(err)
+}
+
+func parseInput(p *print.Printer, cmd *cobra.Command, inputArgs []string) (*inputModel, error) {
+	instanceId := inputArgs[0]
+
+	globalFlags := globalflags.Parse(p, cmd)
+	if globalFlags.ProjectId == "" {
+		return nil, &cliErr.ProjectIdError{}
+	}
+
+	recoveryTimestamp, err := flags.FlagToDateTimePointer(p, cmd, recoveryTimestampFlag, time.RFC3339)
+	if err != nil {
+		return nil, &cliErr.FlagValidationError{
+			Flag:    recoveryTimestampFlag,
+			Details: err.Error(),
+		}
+	}
+
+	model := inputModel{
+		GlobalFlagModel:   globalFlags,
+		InstanceId:        instanceId,
+		InstanceName:      flags.FlagToStringPointer(p, cmd, instanceNameFlag),
+		StorageClass:      flags.FlagToStringPointer(p, cmd, storageClassFlag),
+		StorageSize:       flags.FlagToInt64Pointer(p, cmd, storageSizeFlag),
+		RecoveryTimestamp: recoveryTimestamp.Format(time.RFC3339),
+	}
+
+	if p.IsVerbosityDebug() {
+		modelStr, err := print.BuildDebugStrFromInputModel(model)
+		if err != nil {
+			p.Debug(print.ErrorLevel, "convert model to string for debugging: %v", err)
+		} else {
+			p.Debug(print.DebugLevel, "parsed input values: %s", modelStr)
+		}
+	}
+
+	return &model, nil
+}
+
+type MongoDBFlexClient interface {
+	CreateInstance(ctx context.Context, projectId, region string) mongodbflex.ApiCreateInstanceRequest
+	CloneInstance(ctx context.Context, projectId, instanceId, region string) mongodbflex.ApiCloneInstanceRequest
+	ListStoragesExecute(ctx context.Context, projectId, flavorId, region string) (*mongodbflex.ListStoragesResponse, error)
+}
+
+func buildCreateRequest(ctx context.Context, model *inputModel, apiClient MongoDBFlexClient, sourceInstance *mongodbflex.Instance) (mongodbflex.ApiCreateInstanceRequest, error) {
+	req := apiClient.CreateInstance(ctx, model.ProjectId, model.Region)
+
+	if sourceInstance.Flavor == nil || sourceInstance.Flavor.Id == nil {
+		return req, fmt.Errorf("flavor of MongoDB Flex instance %q not set", model.InstanceId)
+	}
+	flavorId := *sourceInstance.Flavor.Id
+
+	storage := &mongodbflex.Storage{}
+	if sourceInstance.Storage != nil {
+		storage.Class = sourceInstance.Storage.Class
+		storage.Size = sourceInstance.Storage.Size
+	}
+	if model.StorageClass != nil || model.StorageSize != nil {
+		if model.StorageClass != nil {
+			storage.Class = model.StorageClass
+		}
+		if model.StorageSize != nil {
+			storage.Size = model.StorageSize
+		}
+
+		storages, err := apiClient.ListStoragesExecute(ctx, model.ProjectId, flavorId, model.Region)
+		if err != nil {
+			return req, fmt.Errorf("get MongoDB Flex storages: %w", err)
+		}
+		err = mongodbflexUtils.ValidateStorage(storage.Class, storage.Size, storages, flavorId)
+		if err != nil {
+			return req, err
+		}
+	}
+
+	instanceName := model.InstanceName
+	if instanceName == nil {
+		instanceName = utils.Ptr(utils.PtrString(sourceInstance.Name) + cloneNameSuffix)
+	}
+
+	var acl *[]string
+	if sourceInstance.Acl != nil {
+		acl = sourceInstance.Acl.Items
+	}
+
+	req = req.CreateInstancePayload(mongodbflex.CreateInstancePayload{
+		Name:           instanceName,
+		Acl:            &mongodbflex.CreateInstancePayloadAcl{Items: acl},
+		BackupSchedule: sourceInstance.BackupSchedule,
+		FlavorId:       &flavorId,
+		Replicas:       sourceInstance.Replicas,
+		Storage:        storage,
+		Version:        sourceInstance.Version,
+		Options:        sourceInstance.Options,
+	})
+	return req, nil
+}
+
+func buildCloneRequest(ctx context.Context, model *inputModel, apiClient MongoDBFlexClient, targetInstanceId string) mongodbflex.ApiCloneInstanceRequest {
+	req := apiClient.CloneInstance(ctx, model.ProjectId, model.InstanceId, model.Region)
+	req = req.CloneInstancePayload(mongodbflex.CloneInstancePayload{
+		InstanceId: &targetInstanceId,
+		Timestamp:  &model.RecoveryTimestamp,
+	})
+	return req
+}
+
+func outputResult(p *print.Printer, outputFormat string, async bool, instanceLabel string, resp *mongodbflex.CloneInstanceResponse) error {
+	if resp == nil {
+		return fmt.Errorf("response not set")
+	}
+	switch outputFormat {
+	case print.JSONOutputFormat:
+		details, err := json.MarshalIndent(resp, "", "  ")
+		if err != nil {
+			return fmt.Errorf("marshal MongoDBFlex instance clone: %w", err)
+		}
+		p.Outputln(string(details))
+
+		return nil
+	case print.YAMLOutputFormat:
+		details, err := yaml.MarshalWithOptions(resp, yaml.IndentSequence(true), yaml.UseJSONMarshaler())
+		if err != nil {
+			return fmt.Errorf("marshal MongoDBFlex instance clone: %w", err)
+		}
+		p.Outputln(string(details))
+
+		return nil
+	default:
+		operationState := "Cloned"
+		if async {
+			operationState = "Triggered cloning of"
+		}
+		p.Info("%s instance from instance %q. New Instance ID: %s\n", operationState, instanceLabel, utils.PtrString(resp.InstanceId))
+		return nil
+	}
+}
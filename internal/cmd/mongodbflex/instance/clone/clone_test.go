@@ -0,0 +1,492 @@
+package clone
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/uuid"
+	"github.com/stackitcloud/stackit-cli/internal/cmd/params"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/globalflags"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/print"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/utils"
+	"github.com/stackitcloud/stackit-sdk-go/services/mongodbflex"
+)
+
+type testCtxKey struct{}
+
+var testCtx = context.WithValue(context.Background(), testCtxKey{}, "foo")
+var testClient = &mongodbflex.APIClient{}
+
+type mongoDBFlexClientMocked struct {
+	listStoragesFails bool
+	listStoragesResp  *mongodbflex.ListStoragesResponse
+}
+
+func (c *mongoDBFlexClientMocked) CreateInstance(ctx context.Context, projectId, region string) mongodbflex.ApiCreateInstanceRequest {
+	return testClient.CreateInstance(ctx, projectId, region)
+}
+
+func (c *mongoDBFlexClientMocked) CloneInstance(ctx context.Context, projectId, instanceId, region string) mongodbflex.ApiCloneInstanceRequest {
+	return testClient.CloneInstance(ctx, projectId, instanceId, region)
+}
+
+func (c *mongoDBFlexClientMocked) ListStoragesExecute(_ context.Context, _, _, _ string) (*mongodbflex.ListStoragesResponse, error) {
+	if c.listStoragesFails {
+		return nil, fmt.Errorf("list storages failed")
+	}
+	return c.listStoragesResp, nil
+}
+
+var testProjectId = uuid.NewString()
+var testInstanceId = uuid.NewString()
+var testTargetInstanceId = uuid.NewString()
+var testFlavorId = uuid.NewString()
+
+const (
+	testRegion            = "eu01"
+	testRecoveryTimestamp = "2024-05-14T14:31:48Z"
+	testStorageClass      = "premium-perf2-mongodb"
+	testStorageSize       = int64(10)
+)
+
+func fixtureArgValues(mods ...func(argValues []string)) []string {
+	argValues := []string{
+		testInstanceId,
+	}
+	for _, mod := range mods {
+		mod(argValues)
+	}
+	return argValues
+}
+
+func fixtureFlagValues(mods ...func(flagValues map[string]string)) map[string]string {
+	flagValues := map[string]string{
+		globalflags.ProjectIdFlag: testProjectId,
+		globalflags.RegionFlag:    testRegion,
+		recoveryTimestampFlag:     testRecoveryTimestamp,
+	}
+	for _, mod := range mods {
+		mod(flagValues)
+	}
+	return flagValues
+}
+
+func fixtureInputModel(mods ...func(model *inputModel)) *inputModel {
+	model := &inputModel{
+		GlobalFlagModel: &globalflags.GlobalFlagModel{
+			ProjectId: testProjectId,
+			Region:    testRegion,
+			Verbosity: globalflags.VerbosityDefault,
+		},
+		InstanceId:        testInstanceId,
+		RecoveryTimestamp: testRecoveryTimestamp,
+	}
+	for _, mod := range mods {
+		mod(model)
+	}
+	return model
+}
+
+func fixtureSourceInstance(mods ...func(instance *mongodbflex.Instance)) *mongodbflex.Instance {
+	instance := &mongodbflex.Instance{
+		Id:   utils.Ptr(testInstanceId),
+		Name: utils.Ptr("example-name"),
+		Acl: &mongodbflex.ACL{
+			Items: &[]string{"0.0.0.0/0"},
+		},
+		BackupSchedule: utils.Ptr("0 0/6 * * *"),
+		Flavor: &mongodbflex.Flavor{
+			Id: utils.Ptr(testFlavorId),
+		},
+		Replicas: utils.Ptr(int64(3)),
+		Storage: &mongodbflex.Storage{
+			Class: utils.Ptr(testStorageClass),
+			Size:  utils.Ptr(testStorageSize),
+		},
+		Version: utils.Ptr("6.0"),
+		Options: utils.Ptr(map[string]string{
+			"type": "Replica",
+		}),
+	}
+	for _, mod := range mods {
+		mod(instance)
+	}
+	return instance
+}
+
+func fixtureCreatePayload(mods ...func(payload *mongodbflex.CreateInstancePayload)) mongodbflex.CreateInstancePayload {
+	payload := mongodbflex.CreateInstancePayload{
+		Name: utils.Ptr("example-name-clone"),
+		Acl: &mongodbflex.CreateInstancePayloadAcl{
+			Items: &[]string{"0.0.0.0/0"},
+		},
+		BackupSchedule: utils.Ptr("0 0/6 * * *"),
+		FlavorId:       utils.Ptr(testFlavorId),
+		Replicas:       utils.Ptr(int64(3)),
+		Storage: &mongodbflex.Storage{
+			Class: utils.Ptr(testStorageClass),
+			Size:  utils.Ptr(testStorageSize),
+		},
+		Version: utils.Ptr("6.0"),
+		Options: utils.Ptr(map[string]string{
+			"type": "Replica",
+		}),
+	}
+	for _, mod := range mods {
+		mod(&payload)
+	}
+	return payload
+}
+
+func fixtureCreateRequest(mods ...func(payload *mongodbflex.CreateInstancePayload)) mongodbflex.ApiCreateInstanceRequest {
+	request := testClient.CreateInstance(testCtx, testProjectId, testRegion)
+	return request.CreateInstancePayload(fixtureCreatePayload(mods...))
+}
+
+func fixtureCloneRequest(mods ...func(request *mongodbflex.ApiCloneInstanceRequest)) mongodbflex.ApiCloneInstanceRequest {
+	request := testClient.CloneInstance(testCtx, testProjectId, testInstanceId, testRegion)
+	request = request.CloneInstancePayload(mongodbflex.CloneInstancePayload{
+		InstanceId: utils.Ptr(testTargetInstanceId),
+		Timestamp:  utils.Ptr(testRecoveryTimestamp),
+	})
+	for _, mod := range mods {
+		mod(&request)
+	}
+	return request
+}
+
+func TestParseInput(t *testing.T) {
+	tests := []struct {
+		description   string
+		argValues     []string
+		flagValues    map[string]string
+		isValid       bool
+		expectedModel *inputModel
+	}{
+		{
+			description:   "base",
+			argValues:     fixtureArgValues(),
+			flagValues:    fixtureFlagValues(),
+			isValid:       true,
+			expectedModel: fixtureInputModel(),
+		},
+		{
+			description: "all values",
+			argValues:   fixtureArgValues(),
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				flagValues[instanceNameFlag] = "my-test-instance"
+				flagValues[storageClassFlag] = "class"
+				flagValues[storageSizeFlag] = "20"
+			}),
+			isValid: true,
+			expectedModel: fixtureInputModel(func(model *inputModel) {
+				model.InstanceName = utils.Ptr("my-test-instance")
+				model.StorageClass = utils.Ptr("class")
+				model.StorageSize = utils.Ptr(int64(20))
+			}),
+		},
+		{
+			description: "recovery timestamp with offset",
+			argValues:   fixtureArgValues(),
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				flagValues[recoveryTimestampFlag] = "2024-05-14T16:31:48+02:00"
+			}),
+			isValid: true,
+			expectedModel: fixtureInputModel(func(model *inputModel) {
+				model.RecoveryTimestamp = "2024-05-14T16:31:48+02:00"
+			}),
+		},
+		{
+			description: "no values",
+			argValues:   []string{},
+			flagValues:  map[string]string{},
+			isValid:     false,
+		},
+		{
+			description: "no arg values",
+			argValues:   []string{},
+			flagValues:  fixtureFlagValues(),
+			isValid:     false,
+		},
+		{
+			description: "no flag values",
+			argValues:   fixtureArgValues(),
+			flagValues:  map[string]string{},
+			isValid:     false,
+		},
+		{
+			description: "project id missing",
+			argValues:   fixtureArgValues(),
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				delete(flagValues, globalflags.ProjectIdFlag)
+			}),
+			isValid: false,
+		},
+		{
+			description: "project id invalid",
+			argValues:   fixtureArgValues(),
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				flagValues[globalflags.ProjectIdFlag] = "invalid-uuid"
+			}),
+			isValid: false,
+		},
+		{
+			description: "instance id invalid",
+			argValues:   []string{"invalid-uuid"},
+			flagValues:  fixtureFlagValues(),
+			isValid:     false,
+		},
+		{
+			description: "recovery timestamp missing",
+			argValues:   fixtureArgValues(),
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				delete(flagValues, recoveryTimestampFlag)
+			}),
+			isValid: false,
+		},
+		{
+			description: "recovery timestamp invalid",
+			argValues:   fixtureArgValues(),
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				flagValues[recoveryTimestampFlag] = "11:00 12/12/2024"
+			}),
+			isValid: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			p := print.NewPrinter()
+			cmd := NewCmd(&params.CmdParams{Printer: p})
+			err := globalflags.Configure(cmd.Flags())
+			if err != nil {
+				t.Fatalf("configure global flags: %v", err)
+			}
+
+			for flag, value := range tt.flagValues {
+				err := cmd.Flags().Set(flag, value)
+				if err != nil {
+					if !tt.isValid {
+						return
+					}
+					t.Fatalf("setting flag --%s=%s: %v", flag, value, err)
+				}
+			}
+
+			err = cmd.ValidateArgs(tt.argValues)
+			if err != nil {
+				if !tt.isValid {
+					return
+				}
+				t.Fatalf("error validating args: %v", err)
+			}
+
+			err = cmd.ValidateRequiredFlags()
+			if err != nil {
+				if !tt.isValid {
+					return
+				}
+				t.Fatalf("error validating flags: %v", err)
+			}
+
+			model, err := parseInput(p, cmd, tt.argValues)
+			if err != nil {
+				if !tt.isValid {
+					return
+				}
+				t.Fatalf("error parsing flags: %v", err)
+			}
+
+			if !tt.isValid {
+				t.Fatalf("did not fail on invalid input")
+			}
+			diff := cmp.Diff(model, tt.expectedModel)
+			if diff != "" {
+				t.Fatalf("Data does not match: %s", diff)
+			}
+		})
+	}
+}
+
+func TestBuildCreateRequest(t *testing.T) {
+	testStorages := &mongodbflex.ListStoragesResponse{
+		StorageClasses: &[]string{testStorageClass, "class"},
+		StorageRange: &mongodbflex.StorageRange{
+			Min: utils.Ptr(int64(10)),
+			Max: utils.Ptr(int64(100)),
+		},
+	}
+
+	tests := []struct {
+		description       string
+		model             *inputModel
+		sourceInstance    *mongodbflex.Instance
+		listStoragesFails bool
+		listStoragesResp  *mongodbflex.ListStoragesResponse
+		isValid           bool
+		expectedRequest   mongodbflex.ApiCreateInstanceRequest
+	}{
+		{
+			description:     "base",
+			model:           fixtureInputModel(),
+			sourceInstance:  fixtureSourceInstance(),
+			isValid:         true,
+			expectedRequest: fixtureCreateRequest(),
+		},
+		{
+			description: "name set",
+			model: fixtureInputModel(func(model *inputModel) {
+				model.InstanceName = utils.Ptr("my-test-instance")
+			}),
+			sourceInstance: fixtureSourceInstance(),
+			isValid:        true,
+			expectedRequest: fixtureCreateRequest(func(payload *mongodbflex.CreateInstancePayload) {
+				payload.Name = utils.Ptr("my-test-instance")
+			}),
+		},
+		{
+			description: "storage class and size set",
+			model: fixtureInputModel(func(model *inputModel) {
+				model.StorageClass = utils.Ptr("class")
+				model.StorageSize = utils.Ptr(int64(20))
+			}),
+			sourceInstance:   fixtureSourceInstance(),
+			listStoragesResp: testStorages,
+			isValid:          true,
+			expectedRequest: fixtureCreateRequest(func(payload *mongodbflex.CreateInstancePayload) {
+				payload.Storage = &mongodbflex.Storage{
+					Class: utils.Ptr("class"),
+					Size:  utils.Ptr(int64(20)),
+				}
+			}),
+		},
+		{
+			description: "storage size set",
+			model: fixtureInputModel(func(model *inputModel) {
+				model.StorageSize = utils.Ptr(int64(20))
+			}),
+			sourceInstance:   fixtureSourceInstance(),
+			listStoragesResp: testStorages,
+			isValid:          true,
+			expectedRequest: fixtureCreateRequest(func(payload *mongodbflex.CreateInstancePayload) {
+				payload.Storage = &mongodbflex.Storage{
+					Class: utils.Ptr(testStorageClass),
+					Size:  utils.Ptr(int64(20)),
+				}
+			}),
+		},
+		{
+			description: "storage class invalid",
+			model: fixtureInputModel(func(model *inputModel) {
+				model.StorageClass = utils.Ptr("invalid-class")
+			}),
+			sourceInstance:   fixtureSourceInstance(),
+			listStoragesResp: testStorages,
+			isValid:          false,
+		},
+		{
+			description: "storage size invalid",
+			model: fixtureInputModel(func(model *inputModel) {
+				model.StorageSize = utils.Ptr(int64(200))
+			}),
+			sourceInstance:   fixtureSourceInstance(),
+			listStoragesResp: testStorages,
+			isValid:          false,
+		},
+		{
+			description: "list storages fails",
+			model: fixtureInputModel(func(model *inputModel) {
+				model.StorageSize = utils.Ptr(int64(20))
+			}),
+			sourceInstance:    fixtureSourceInstance(),
+			listStoragesFails: true,
+			isValid:           false,
+		},
+		{
+			description: "flavor missing",
+			model:       fixtureInputModel(),
+			sourceInstance: fixtureSourceInstance(func(instance *mongodbflex.Instance) {
+				instance.Flavor = nil
+			}),
+			isValid: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			client := &mongoDBFlexClientMocked{
+				listStoragesFails: tt.listStoragesFails,
+				listStoragesResp:  tt.listStoragesResp,
+			}
+			request, err := buildCreateRequest(testCtx, tt.model, client, tt.sourceInstance)
+			if err != nil {
+				if !tt.isValid {
+					return
+				}
+				t.Fatalf("error building request: %v", err)
+			}
+			if !tt.isValid {
+				t.Fatalf("did not fail on invalid input")
+			}
+
+			diff := cmp.Diff(request, tt.expectedRequest,
+				cmp.AllowUnexported(tt.expectedRequest),
+				cmpopts.EquateComparable(testCtx),
+			)
+			if diff != "" {
+				t.Fatalf("Data does not match: %s", diff)
+			}
+		})
+	}
+}
+
+func TestBuildCloneRequest(t *testing.T) {
+	expectedRequest := fixtureCloneRequest()
+
+	request := buildCloneRequest(testCtx, fixtureInputModel(), &mongoDBFlexClientMocked{}, testTargetInstanceId)
+
+	diff := cmp.Diff(request, expectedRequest,
+		cmp.AllowUnexported(expectedRequest),
+		cmpopts.EquateComparable(testCtx),
+	)
+	if diff != "" {
+		t.Fatalf("Data does not match: %s", diff)
+	}
+}
+
+func Test_outputResult(t *testing.T) {
+	type args struct {
+		OutputFormat  string
+		instanceLabel string
+		async         bool
+		resp          *mongodbflex.CloneInstanceResponse
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{"empty", args{}, true},
+		{"standard", args{
+			instanceLabel: "foo",
+			resp:          &mongodbflex.CloneInstanceResponse{InstanceId: utils.Ptr("id")},
+		}, false},
+		{"json", args{
+			OutputFormat: print.JSONOutputFormat,
+			resp:         &mongodbflex.CloneInstanceResponse{InstanceId: utils.Ptr("id")},
+		}, false},
+	}
+	p := print.NewPrinter()
+	p.Cmd = NewCmd(&params.CmdParams{Printer: p})
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := outputResult(p, tt.args.OutputFormat, tt.args.async, tt.args.instanceLabel, tt.args.resp); (err != nil) != tt.wantErr {
+				t.Errorf("outputResult() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
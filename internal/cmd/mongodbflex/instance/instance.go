@@ -1,6 +1,8 @@
 package instance
 
 import (
+	"github.com/stackitcloud/stackit-cli/internal/cmd/mongodbflex/instance/acl"
+	"github.com/stackitcloud/stackit-cli/internal/cmd/mongodbflex/instance/clone"
 	"github.com/stackitcloud/stackit-cli/internal/cmd/mongodbflex/instance/create"
 	"github.com/stackitcloud/stackit-cli/internal/cmd/mongodbflex/instance/delete"
 	"github.com/stackitcloud/stackit-cli/internal/cmd/mongodbflex/instance/describe"
@@ -31,4 +33,6 @@ func addSubcommands(cmd *cobra.Command, params *params.CmdParams) {
 	cmd.AddCommand(describe.NewCmd(params))
 	cmd.AddCommand(list.NewCmd(params))
 	cmd.AddCommand(update.NewCmd(params))
+	cmd.AddCommand(clone.NewCmd(params))
+	cmd.AddCommand(acl.NewCmd(params))
 }
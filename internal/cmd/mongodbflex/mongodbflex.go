@@ -2,6 +2,7 @@ package mongodbflex
 
 import (
 	"github.com/stackitcloud/stackit-cli/internal/cmd/mongodbflex/backup"
+	"github.com/stackitcloud/stackit-cli/internal/cmd/mongodbflex/connect"
 	"github.com/stackitcloud/stackit-cli/internal/cmd/mongodbflex/instance"
 	"github.com/stackitcloud/stackit-cli/internal/cmd/mongodbflex/options"
 	"github.com/stackitcloud/stackit-cli/internal/cmd/mongodbflex/user"
@@ -29,4 +30,5 @@ func addSubcommands(cmd *cobra.Command, params *params.CmdParams) {
 	cmd.AddCommand(user.NewCmd(params))
 	cmd.AddCommand(options.NewCmd(params))
 	cmd.AddCommand(backup.NewCmd(params))
+	cmd.AddCommand(connect.NewCmd(params))
 }
@@ -9,6 +9,7 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/stackitcloud/stackit-cli/internal/cmd/params"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/args"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/connection"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/errors"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/examples"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/flags"
@@ -34,10 +35,11 @@ var (
 type inputModel struct {
 	*globalflags.GlobalFlagModel
 
-	InstanceId string
-	Username   *string
-	Database   *string
-	Roles      *[]string
+	InstanceId        string
+	Username          *string
+	Database          *string
+	Roles             *[]string
+	CredentialsFormat *connection.FormatOptions
 }
 
 func NewCmd(params *params.CmdParams) *cobra.Command {
@@ -57,6 +59,9 @@ func NewCmd(params *params.CmdParams) *cobra.Command {
 			examples.NewExample(
 				`Create a MongoDB Flex user for instance with ID "xxx" with an automatically generated username`,
 				"$ stackit mongodbflex user create --instance-id xxx --role read --database default"),
+			examples.NewExample(
+				`Create a MongoDB Flex user for instance with ID "xxx" and apply its credentials as a Kubernetes Secret`,
+				"$ stackit mongodbflex user create --instance-id xxx --role read --database default --format k8s-secret | kubectl apply -f -"),
 		),
 		Args: args.NoArgs,
 		RunE: func(cmd *cobra.Command, _ []string) error {
@@ -94,6 +99,14 @@ func NewCmd(params *params.CmdParams) *cobra.Command {
 			}
 			user := resp.Item
 
+			if model.CredentialsFormat != nil {
+				conn, err := buildConnection(user)
+				if err != nil {
+					return err
+				}
+				return connection.PrintCredentials(params.Printer, conn, model.CredentialsFormat, fmt.Sprintf("mongodbflex-%s", conn.Username), true)
+			}
+
 			return outputResult(params.Printer, model.OutputFormat, instanceLabel, user)
 		},
 	}
@@ -109,6 +122,7 @@ func configureFlags(cmd *cobra.Command) {
 	cmd.Flags().String(usernameFlag, "", "Username of the user. If not specified, a random username will be assigned")
 	cmd.Flags().String(databaseFlag, "", "The database inside the MongoDB instance that the user has access to. If it does not exist, it will be created once the user writes to it")
 	cmd.Flags().Var(flags.EnumSliceFlag(false, rolesDefault, roleOptions...), roleFlag, fmt.Sprintf("Roles of the user, possible values are %q", roleOptions))
+	connection.ConfigureFormatFlags(cmd)
 
 	err := flags.MarkFlagsRequired(cmd, instanceIdFlag, databaseFlag)
 	cobra.CheckErr(err)
@@ -120,12 +134,18 @@ func parseInput(p *print.Printer, cmd *cobra.Command) (*inputModel, error) {
 		return nil, &errors.ProjectIdError{}
 	}
 
+	credentialsFormat, err := connection.ParseFormatFlags(p, cmd)
+	if err != nil {
+		return nil, err
+	}
+
 	model := inputModel{
-		GlobalFlagModel: globalFlags,
-		InstanceId:      flags.FlagToStringValue(p, cmd, instanceIdFlag),
-		Username:        flags.FlagToStringPointer(p, cmd, usernameFlag),
-		Database:        flags.FlagToStringPointer(p, cmd, databaseFlag),
-		Roles:           flags.FlagWithDefaultToStringSlicePointer(p, cmd, roleFlag),
+		GlobalFlagModel:   globalFlags,
+		InstanceId:        flags.FlagToStringValue(p, cmd, instanceIdFlag),
+		Username:          flags.FlagToStringPointer(p, cmd, usernameFlag),
+		Database:          flags.FlagToStringPointer(p, cmd, databaseFlag),
+		Roles:             flags.FlagWithDefaultToStringSlicePointer(p, cmd, roleFlag),
+		CredentialsFormat: credentialsFormat,
 	}
 
 	if p.IsVerbosityDebug() {
@@ -150,6 +170,26 @@ func buildRequest(ctx context.Context, model *inputModel, apiClient *mongodbflex
 	return req
 }
 
+// buildConnection returns the connection to the instance as the created user
+func buildConnection(user *mongodbflex.User) (*connection.Connection, error) {
+	if user == nil {
+		return nil, fmt.Errorf("no user passed")
+	}
+
+	conn := &connection.Connection{
+		Engine:   connection.MongoDB,
+		Host:     utils.PtrString(user.Host),
+		Port:     utils.PtrValue(user.Port),
+		Username: utils.PtrString(user.Username),
+		Password: utils.PtrString(user.Password),
+		Database: utils.PtrString(user.Database),
+	}
+	if conn.Host == "" {
+		return nil, fmt.Errorf("no host of the instance returned")
+	}
+	return conn, nil
+}
+
 func outputResult(p *print.Printer, outputFormat, instanceLabel string, user *mongodbflex.User) error {
 	if user == nil {
 		return fmt.Errorf("user is nil")
@@ -8,6 +8,7 @@ import (
 	"github.com/goccy/go-yaml"
 	"github.com/stackitcloud/stackit-cli/internal/cmd/params"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/args"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/connection"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/errors"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/examples"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/flags"
@@ -30,8 +31,9 @@ const (
 type inputModel struct {
 	*globalflags.GlobalFlagModel
 
-	InstanceId string
-	UserId     string
+	InstanceId        string
+	UserId            string
+	CredentialsFormat *connection.FormatOptions
 }
 
 func NewCmd(params *params.CmdParams) *cobra.Command {
@@ -50,6 +52,9 @@ func NewCmd(params *params.CmdParams) *cobra.Command {
 			examples.NewExample(
 				`Get details of a MongoDB Flex user with ID "xxx" of instance with ID "yyy" in JSON format`,
 				"$ stackit mongodbflex user describe xxx --instance-id yyy --output-format json"),
+			examples.NewExample(
+				`Get the connection URI of a MongoDB Flex user with ID "xxx" of instance with ID "yyy"`,
+				"$ stackit mongodbflex user describe xxx --instance-id yyy --format uri"),
 		),
 		Args: args.SingleArg(userIdArg, utils.ValidateUUID),
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -72,6 +77,14 @@ func NewCmd(params *params.CmdParams) *cobra.Command {
 				return fmt.Errorf("get MongoDB Flex user: %w", err)
 			}
 
+			if model.CredentialsFormat != nil {
+				conn, err := buildConnection(resp.Item)
+				if err != nil {
+					return err
+				}
+				return connection.PrintCredentials(params.Printer, conn, model.CredentialsFormat, fmt.Sprintf("mongodbflex-%s", conn.Username), true)
+			}
+
 			return outputResult(params.Printer, model.OutputFormat, *resp.Item)
 		},
 	}
@@ -82,6 +95,7 @@ func NewCmd(params *params.CmdParams) *cobra.Command {
 
 func configureFlags(cmd *cobra.Command) {
 	cmd.Flags().Var(flags.UUIDFlag(), instanceIdFlag, "ID of the instance")
+	connection.ConfigureFormatFlags(cmd)
 
 	err := flags.MarkFlagsRequired(cmd, instanceIdFlag)
 	cobra.CheckErr(err)
@@ -95,10 +109,16 @@ func parseInput(p *print.Printer, cmd *cobra.Command, inputArgs []string) (*inpu
 		return nil, &errors.ProjectIdError{}
 	}
 
+	credentialsFormat, err := connection.ParseFormatFlags(p, cmd)
+	if err != nil {
+		return nil, err
+	}
+
 	model := inputModel{
-		GlobalFlagModel: globalFlags,
-		InstanceId:      flags.FlagToStringValue(p, cmd, instanceIdFlag),
-		UserId:          userId,
+		GlobalFlagModel:   globalFlags,
+		InstanceId:        flags.FlagToStringValue(p, cmd, instanceIdFlag),
+		UserId:            userId,
+		CredentialsFormat: credentialsFormat,
 	}
 
 	if p.IsVerbosityDebug() {
@@ -118,6 +138,25 @@ func buildRequest(ctx context.Context, model *inputModel, apiClient *mongodbflex
 	return req
 }
 
+// buildConnection returns the connection to the instance as the user, without its password
+func buildConnection(user *mongodbflex.InstanceResponseUser) (*connection.Connection, error) {
+	if user == nil {
+		return nil, fmt.Errorf("no user passed")
+	}
+
+	conn := &connection.Connection{
+		Engine:   connection.MongoDB,
+		Host:     utils.PtrString(user.Host),
+		Port:     utils.PtrValue(user.Port),
+		Username: utils.PtrString(user.Username),
+		Database: utils.PtrString(user.Database),
+	}
+	if conn.Host == "" {
+		return nil, fmt.Errorf("no host of the instance returned")
+	}
+	return conn, nil
+}
+
 func outputResult(p *print.Printer, outputFormat string, user mongodbflex.InstanceResponseUser) error {
 	switch outputFormat {
 	case print.JSONOutputFormat:
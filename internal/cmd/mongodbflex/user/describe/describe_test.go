@@ -5,8 +5,10 @@ import (
 	"testing"
 
 	"github.com/stackitcloud/stackit-cli/internal/cmd/params"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/connection"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/globalflags"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/print"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/utils"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
@@ -26,6 +28,9 @@ var testProjectId = uuid.NewString()
 var testInstanceId = uuid.NewString()
 var testUserId = uuid.NewString()
 
+// Engines are compared by identity
+var engineComparer = cmp.Comparer(func(a, b *connection.Engine) bool { return a == b })
+
 func fixtureArgValues(mods ...func(argValues []string)) []string {
 	argValues := []string{
 		testUserId,
@@ -87,6 +92,29 @@ func TestParseInput(t *testing.T) {
 			isValid:       true,
 			expectedModel: fixtureInputModel(),
 		},
+		{
+			description: "credentials format",
+			argValues:   fixtureArgValues(),
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				flagValues[connection.FormatFlag] = "k8s-secret"
+				flagValues[connection.SecretNameFlag] = "my-app"
+			}),
+			isValid: true,
+			expectedModel: fixtureInputModel(func(model *inputModel) {
+				model.CredentialsFormat = &connection.FormatOptions{
+					Format:     connection.K8sSecretFormat,
+					SecretName: utils.Ptr("my-app"),
+				}
+			}),
+		},
+		{
+			description: "secret name without credentials format",
+			argValues:   fixtureArgValues(),
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				flagValues[connection.SecretNameFlag] = "my-app"
+			}),
+			isValid: false,
+		},
 		{
 			description: "no values",
 			argValues:   fixtureArgValues(),
@@ -249,6 +277,61 @@ func TestBuildRequest(t *testing.T) {
 	}
 }
 
+func TestBuildConnection(t *testing.T) {
+	tests := []struct {
+		description string
+		user        *mongodbflex.InstanceResponseUser
+		isValid     bool
+		expected    *connection.Connection
+	}{
+		{
+			description: "base",
+			user: &mongodbflex.InstanceResponseUser{
+				Host:     utils.Ptr("example.com"),
+				Port:     utils.Ptr(int64(27017)),
+				Username: utils.Ptr("johndoe"),
+				Database: utils.Ptr("default"),
+			},
+			isValid: true,
+			expected: &connection.Connection{
+				Engine:   connection.MongoDB,
+				Host:     "example.com",
+				Port:     27017,
+				Username: "johndoe",
+				Database: "default",
+			},
+		},
+		{
+			description: "no host",
+			user:        &mongodbflex.InstanceResponseUser{},
+			isValid:     false,
+		},
+		{
+			description: "no user",
+			isValid:     false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			conn, err := buildConnection(tt.user)
+			if !tt.isValid {
+				if err == nil {
+					t.Fatalf("did not fail on invalid input")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("failed on valid input: %v", err)
+			}
+			diff := cmp.Diff(conn, tt.expected, engineComparer)
+			if diff != "" {
+				t.Fatalf("Data does not match: %s", diff)
+			}
+		})
+	}
+}
+
 func TestOutputResult(t *testing.T) {
 	type args struct {
 		outputFormat         string
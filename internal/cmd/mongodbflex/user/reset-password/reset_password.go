@@ -8,11 +8,13 @@ import (
 	"github.com/goccy/go-yaml"
 	"github.com/stackitcloud/stackit-cli/internal/cmd/params"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/args"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/connection"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/errors"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/examples"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/flags"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/globalflags"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/print"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/rotation"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/services/mongodbflex/client"
 	mongodbflexUtils "github.com/stackitcloud/stackit-cli/internal/pkg/services/mongodbflex/utils"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/utils"
@@ -32,20 +34,25 @@ type inputModel struct {
 
 	InstanceId string
 	UserId     string
+	Rotation   *rotation.Options
 }
 
 func NewCmd(params *params.CmdParams) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   fmt.Sprintf("reset-password %s", userIdArg),
 		Short: "Resets the password of a MongoDB Flex user",
-		Long: fmt.Sprintf("%s\ns%s",
+		Long: fmt.Sprintf("%s\n%s\n%s",
 			"Resets the password of a MongoDB Flex user.",
 			"The new password is visible after and cannot be retrieved later.",
+			fmt.Sprintf("With --%s, --%s or --%s, the connection with the new password is written there instead, e.g. to rotate the password of an application.", rotation.ToFileFlag, rotation.ToSecretsManagerFlag, rotation.ToK8sSecretFlag),
 		),
 		Example: examples.Build(
 			examples.NewExample(
 				`Reset the password of a MongoDB Flex user with ID "xxx" of instance with ID "yyy"`,
 				"$ stackit mongodbflex user reset-password xxx --instance-id yyy"),
+			examples.NewExample(
+				`Reset the password of a MongoDB Flex user with ID "xxx" of instance with ID "yyy" and update the Kubernetes Secret "my-namespace/mongodbflex" with the new connection`,
+				"$ stackit mongodbflex user reset-password xxx --instance-id yyy --to-k8s-secret my-namespace/mongodbflex"),
 		),
 		Args: args.SingleArg(userIdArg, utils.ValidateUUID),
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -88,6 +95,10 @@ func NewCmd(params *params.CmdParams) *cobra.Command {
 				return fmt.Errorf("reset MongoDB Flex user password: %w", err)
 			}
 
+			if len(model.Rotation.Targets) > 0 {
+				return writeTargets(ctx, params.Printer, model, userLabel, instanceLabel, user)
+			}
+
 			return outputResult(params.Printer, model.OutputFormat, userLabel, instanceLabel, user)
 		},
 	}
@@ -99,6 +110,8 @@ func NewCmd(params *params.CmdParams) *cobra.Command {
 func configureFlags(cmd *cobra.Command) {
 	cmd.Flags().Var(flags.UUIDFlag(), instanceIdFlag, "ID of the instance")
 
+	rotation.ConfigureFlags(cmd, false)
+
 	err := flags.MarkFlagsRequired(cmd, instanceIdFlag)
 	cobra.CheckErr(err)
 }
@@ -111,10 +124,16 @@ func parseInput(p *print.Printer, cmd *cobra.Command, inputArgs []string) (*inpu
 		return nil, &errors.ProjectIdError{}
 	}
 
+	rotationOptions, err := rotation.ParseFlags(p, cmd, false)
+	if err != nil {
+		return nil, err
+	}
+
 	model := inputModel{
 		GlobalFlagModel: globalFlags,
 		InstanceId:      flags.FlagToStringValue(p, cmd, instanceIdFlag),
 		UserId:          userId,
+		Rotation:        rotationOptions,
 	}
 
 	if p.IsVerbosityDebug() {
@@ -164,3 +183,44 @@ func outputResult(p *print.Printer, outputFormat, userLabel, instanceLabel strin
 		return nil
 	}
 }
+
+// writeTargets writes the connection with the new password to the rotation targets and outputs the rotation report.
+// If a target cannot be written, the new password is output instead, as it cannot be retrieved later.
+func writeTargets(ctx context.Context, p *print.Printer, model *inputModel, userLabel, instanceLabel string, user *mongodbflex.User) error {
+	report := &rotation.Report{
+		InstanceId: model.InstanceId,
+		UserId:     model.UserId,
+	}
+	conn, err := buildConnection(user)
+	if err == nil {
+		report.Targets, err = rotation.WriteTargets(ctx, p, model.Rotation, conn)
+	}
+	if err != nil {
+		outputErr := outputResult(p, model.OutputFormat, userLabel, instanceLabel, user)
+		if outputErr != nil {
+			p.Debug(print.ErrorLevel, "output new password: %v", outputErr)
+		}
+		return err
+	}
+	return rotation.OutputReport(p, model.OutputFormat, instanceLabel, report)
+}
+
+// buildConnection returns the connection to the instance as the user with the new password
+func buildConnection(user *mongodbflex.User) (*connection.Connection, error) {
+	if user == nil {
+		return nil, fmt.Errorf("no user passed")
+	}
+
+	conn := &connection.Connection{
+		Engine:   connection.MongoDB,
+		Host:     utils.PtrString(user.Host),
+		Port:     utils.PtrValue(user.Port),
+		Username: utils.PtrString(user.Username),
+		Password: utils.PtrString(user.Password),
+		Database: utils.PtrString(user.Database),
+	}
+	if conn.Host == "" {
+		return nil, fmt.Errorf("no host of the instance returned")
+	}
+	return conn, nil
+}
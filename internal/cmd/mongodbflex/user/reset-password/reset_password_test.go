@@ -5,8 +5,11 @@ import (
 	"testing"
 
 	"github.com/stackitcloud/stackit-cli/internal/cmd/params"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/connection"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/globalflags"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/print"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/rotation"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/utils"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
@@ -26,6 +29,18 @@ var testProjectId = uuid.NewString()
 var testInstanceId = uuid.NewString()
 var testUserId = uuid.NewString()
 
+// Engines are compared by identity
+var engineComparer = cmp.Comparer(func(a, b *connection.Engine) bool { return a == b })
+
+// Targets are compared by their description
+type testTarget string
+
+func (t testTarget) String() string { return string(t) }
+
+func (testTarget) Write(context.Context, *connection.Connection) error { return nil }
+
+var targetComparer = cmp.Comparer(func(a, b rotation.Target) bool { return a.String() == b.String() })
+
 func fixtureArgValues(mods ...func(argValues []string)) []string {
 	argValues := []string{
 		testUserId,
@@ -57,6 +72,9 @@ func fixtureInputModel(mods ...func(model *inputModel)) *inputModel {
 		},
 		InstanceId: testInstanceId,
 		UserId:     testUserId,
+		Rotation: &rotation.Options{
+			Targets: []rotation.Target{},
+		},
 	}
 	for _, mod := range mods {
 		mod(model)
@@ -87,6 +105,29 @@ func TestParseInput(t *testing.T) {
 			isValid:       true,
 			expectedModel: fixtureInputModel(),
 		},
+		{
+			description: "with targets",
+			argValues:   fixtureArgValues(),
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				flagValues[rotation.ToFileFlag] = ".env"
+				flagValues[rotation.ToK8sSecretFlag] = "mongodbflex"
+			}),
+			isValid: true,
+			expectedModel: fixtureInputModel(func(model *inputModel) {
+				model.Rotation.Targets = []rotation.Target{
+					testTarget("file .env"),
+					testTarget("Kubernetes Secret mongodbflex"),
+				}
+			}),
+		},
+		{
+			description: "kubeconfig without Kubernetes Secret",
+			argValues:   fixtureArgValues(),
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				flagValues[rotation.KubeconfigFlag] = "kubeconfig.yaml"
+			}),
+			isValid: false,
+		},
 		{
 			description: "no values",
 			argValues:   fixtureArgValues(),
@@ -213,7 +254,7 @@ func TestParseInput(t *testing.T) {
 			if !tt.isValid {
 				t.Fatalf("did not fail on invalid input")
 			}
-			diff := cmp.Diff(model, tt.expectedModel)
+			diff := cmp.Diff(model, tt.expectedModel, targetComparer)
 			if diff != "" {
 				t.Fatalf("Data does not match: %s", diff)
 			}
@@ -284,3 +325,60 @@ func TestOutputResult(t *testing.T) {
 		})
 	}
 }
+
+func TestBuildConnection(t *testing.T) {
+	tests := []struct {
+		description string
+		user        *mongodbflex.User
+		isValid     bool
+		expected    *connection.Connection
+	}{
+		{
+			description: "base",
+			user: &mongodbflex.User{
+				Host:     utils.Ptr("example.com"),
+				Port:     utils.Ptr(int64(27017)),
+				Username: utils.Ptr("johndoe"),
+				Password: utils.Ptr("password"),
+				Database: utils.Ptr("default"),
+			},
+			isValid: true,
+			expected: &connection.Connection{
+				Engine:   connection.MongoDB,
+				Host:     "example.com",
+				Port:     27017,
+				Username: "johndoe",
+				Password: "password",
+				Database: "default",
+			},
+		},
+		{
+			description: "no host",
+			user:        &mongodbflex.User{},
+			isValid:     false,
+		},
+		{
+			description: "no user",
+			isValid:     false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			conn, err := buildConnection(tt.user)
+			if !tt.isValid {
+				if err == nil {
+					t.Fatalf("did not fail on invalid input")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("failed on valid input: %v", err)
+			}
+			diff := cmp.Diff(conn, tt.expected, engineComparer)
+			if diff != "" {
+				t.Fatalf("Data does not match: %s", diff)
+			}
+		})
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/stackitcloud/stackit-cli/internal/cmd/params"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/args"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/connection"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/errors"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/examples"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/flags"
@@ -25,6 +26,9 @@ const (
 	expireDateFlag         = "expire-date"
 	credentialsGroupIdFlag = "credentials-group-id"
 	expirationTimeFormat   = time.RFC3339
+
+	// Host of the S3 API of Object Storage in a region
+	s3EndpointFormat = "object.storage.%s.onstackit.cloud"
 )
 
 type inputModel struct {
@@ -32,6 +36,7 @@ type inputModel struct {
 	ExpireDate         *time.Time
 	CredentialsGroupId string
 	HidePassword       bool
+	CredentialsFormat  *connection.FormatOptions
 }
 
 func NewCmd(params *params.CmdParams) *cobra.Command {
@@ -47,6 +52,9 @@ func NewCmd(params *params.CmdParams) *cobra.Command {
 			examples.NewExample(
 				`Create credentials for a credentials group with ID "xxx", including a specific expiration date`,
 				"$ stackit object-storage credentials create --credentials-group-id xxx --expire-date 2024-03-06T00:00:00.000Z"),
+			examples.NewExample(
+				`Create credentials for a credentials group with ID "xxx" and apply them as a Kubernetes Secret`,
+				"$ stackit object-storage credentials create --credentials-group-id xxx --format k8s-secret | kubectl apply -f -"),
 		),
 		RunE: func(cmd *cobra.Command, _ []string) error {
 			ctx := context.Background()
@@ -82,6 +90,14 @@ func NewCmd(params *params.CmdParams) *cobra.Command {
 				return fmt.Errorf("create Object Storage credentials: %w", err)
 			}
 
+			if model.CredentialsFormat != nil {
+				conn, err := buildConnection(model, resp)
+				if err != nil {
+					return err
+				}
+				return connection.PrintCredentials(params.Printer, conn, model.CredentialsFormat, fmt.Sprintf("object-storage-%s", utils.PtrString(resp.KeyId)), true)
+			}
+
 			return outputResult(params.Printer, model.OutputFormat, credentialsGroupLabel, resp)
 		},
 	}
@@ -92,6 +108,7 @@ func NewCmd(params *params.CmdParams) *cobra.Command {
 func configureFlags(cmd *cobra.Command) {
 	cmd.Flags().String(expireDateFlag, "", "Expiration date for the credentials, in a date-time with the RFC3339 layout format, e.g. 2024-01-01T00:00:00Z")
 	cmd.Flags().Var(flags.UUIDFlag(), credentialsGroupIdFlag, "Credentials Group ID")
+	connection.ConfigureFormatFlags(cmd)
 
 	err := flags.MarkFlagsRequired(cmd, credentialsGroupIdFlag)
 	cobra.CheckErr(err)
@@ -111,10 +128,16 @@ func parseInput(p *print.Printer, cmd *cobra.Command) (*inputModel, error) {
 		}
 	}
 
+	credentialsFormat, err := connection.ParseFormatFlags(p, cmd)
+	if err != nil {
+		return nil, err
+	}
+
 	model := inputModel{
 		GlobalFlagModel:    globalFlags,
 		ExpireDate:         expireDate,
 		CredentialsGroupId: flags.FlagToStringValue(p, cmd, credentialsGroupIdFlag),
+		CredentialsFormat:  credentialsFormat,
 	}
 
 	if p.IsVerbosityDebug() {
@@ -138,6 +161,19 @@ func buildRequest(ctx context.Context, model *inputModel, apiClient *objectstora
 	return req
 }
 
+// buildConnection returns the connection to the S3 API of the region with the created access key
+func buildConnection(model *inputModel, resp *objectstorage.CreateAccessKeyResponse) (*connection.Connection, error) {
+	if resp == nil {
+		return nil, fmt.Errorf("create access key response is empty")
+	}
+	return &connection.Connection{
+		Engine:   connection.ObjectStorage,
+		Host:     fmt.Sprintf(s3EndpointFormat, model.Region),
+		Username: utils.PtrString(resp.AccessKey),
+		Password: utils.PtrString(resp.SecretAccessKey),
+	}, nil
+}
+
 func outputResult(p *print.Printer, outputFormat, credentialsGroupLabel string, resp *objectstorage.CreateAccessKeyResponse) error {
 	if resp == nil {
 		return fmt.Errorf("create access key response is empty")
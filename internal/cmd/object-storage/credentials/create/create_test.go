@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/stackitcloud/stackit-cli/internal/cmd/params"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/connection"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/globalflags"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/print"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/utils"
@@ -28,6 +29,9 @@ var testCredentialsGroupId = uuid.NewString()
 var testExpirationDate = "2024-01-01T00:00:00Z"
 var testRegion = "eu01"
 
+// Engines are compared by identity
+var engineComparer = cmp.Comparer(func(a, b *connection.Engine) bool { return a == b })
+
 func fixtureFlagValues(mods ...func(flagValues map[string]string)) map[string]string {
 	flagValues := map[string]string{
 		projectIdFlag:          testProjectId,
@@ -99,6 +103,27 @@ func TestParseInput(t *testing.T) {
 			isValid:       true,
 			expectedModel: fixtureInputModel(),
 		},
+		{
+			description: "credentials format",
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				flagValues[connection.FormatFlag] = "k8s-secret"
+				flagValues[connection.SecretNameFlag] = "my-app"
+			}),
+			isValid: true,
+			expectedModel: fixtureInputModel(func(model *inputModel) {
+				model.CredentialsFormat = &connection.FormatOptions{
+					Format:     connection.K8sSecretFormat,
+					SecretName: utils.Ptr("my-app"),
+				}
+			}),
+		},
+		{
+			description: "secret name without credentials format",
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				flagValues[connection.SecretNameFlag] = "my-app"
+			}),
+			isValid: false,
+		},
 		{
 			description: "no values",
 			flagValues:  map[string]string{},
@@ -253,6 +278,53 @@ func TestBuildRequest(t *testing.T) {
 	}
 }
 
+func TestBuildConnection(t *testing.T) {
+	tests := []struct {
+		description string
+		resp        *objectstorage.CreateAccessKeyResponse
+		isValid     bool
+		expected    *connection.Connection
+	}{
+		{
+			description: "base",
+			resp: &objectstorage.CreateAccessKeyResponse{
+				AccessKey:       utils.Ptr("access-key"),
+				SecretAccessKey: utils.Ptr("secret-key"),
+			},
+			isValid: true,
+			expected: &connection.Connection{
+				Engine:   connection.ObjectStorage,
+				Host:     "object.storage.eu01.onstackit.cloud",
+				Username: "access-key",
+				Password: "secret-key",
+			},
+		},
+		{
+			description: "no response",
+			isValid:     false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			conn, err := buildConnection(fixtureInputModel(), tt.resp)
+			if !tt.isValid {
+				if err == nil {
+					t.Fatalf("did not fail on invalid input")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("failed on valid input: %v", err)
+			}
+			diff := cmp.Diff(conn, tt.expected, engineComparer)
+			if diff != "" {
+				t.Fatalf("Data does not match: %s", diff)
+			}
+		})
+	}
+}
+
 func TestOutputResult(t *testing.T) {
 	type args struct {
 		outputFormat            string
@@ -0,0 +1,30 @@
+package alertconfig
+
+import (
+	generatepayload "github.com/stackitcloud/stackit-cli/internal/cmd/observability/alert-config/generate-payload"
+	"github.com/stackitcloud/stackit-cli/internal/cmd/observability/alert-config/get"
+	"github.com/stackitcloud/stackit-cli/internal/cmd/observability/alert-config/set"
+	"github.com/stackitcloud/stackit-cli/internal/cmd/params"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/args"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/utils"
+
+	"github.com/spf13/cobra"
+)
+
+func NewCmd(params *params.CmdParams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "alert-config",
+		Short: "Provides functionality for the alertmanager configuration in Observability",
+		Long:  "Provides functionality for the alertmanager configuration in Observability, which defines the receivers of alerts, the routes to them and the inhibit rules.",
+		Args:  args.NoArgs,
+		Run:   utils.CmdHelp,
+	}
+	addSubcommands(cmd, params)
+	return cmd
+}
+
+func addSubcommands(cmd *cobra.Command, params *params.CmdParams) {
+	cmd.AddCommand(generatepayload.NewCmd(params))
+	cmd.AddCommand(get.NewCmd(params))
+	cmd.AddCommand(set.NewCmd(params))
+}
@@ -0,0 +1,138 @@
+package generatepayload
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/stackitcloud/stackit-cli/internal/cmd/params"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/args"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/examples"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/fileutils"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/flags"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/globalflags"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/print"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/services/observability/client"
+	observabilityUtils "github.com/stackitcloud/stackit-cli/internal/pkg/services/observability/utils"
+
+	"github.com/spf13/cobra"
+	"github.com/stackitcloud/stackit-sdk-go/services/observability"
+)
+
+const (
+	instanceIdFlag = "instance-id"
+	filePathFlag   = "file-path"
+)
+
+type inputModel struct {
+	*globalflags.GlobalFlagModel
+	InstanceId *string
+	FilePath   *string
+}
+
+func NewCmd(params *params.CmdParams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "generate-payload",
+		Short: "Generates a payload to set the alertmanager configuration of an Observability instance",
+		Long: fmt.Sprintf("%s\n%s\n%s\n%s\n%s",
+			"Generates a JSON payload with values to be used as --payload input for setting the alertmanager configuration.",
+			"To change the existing configuration of an Observability instance, provide the instance ID.",
+			"To obtain a default payload, run the command with no flags.",
+			"Note that the default receiver is only an example and should be adapted to your use case.",
+			"See https://docs.api.stackit.cloud/documentation/argus/version/v1#tag/alert-config for information regarding the payload structure.",
+		),
+		Args: args.NoArgs,
+		Example: examples.Build(
+			examples.NewExample(
+				`Generate a payload with default values, and adapt it with custom values for the different configuration options`,
+				`$ stackit observability alert-config generate-payload --file-path ./payload.json`,
+				`<Modify payload in file, if needed>`,
+				`$ stackit observability alert-config set --payload @./payload.json --instance-id xxx`),
+			examples.NewExample(
+				`Generate a payload with the current alertmanager configuration of Observability instance xxx, and adapt it with custom values for the different configuration options`,
+				`$ stackit observability alert-config generate-payload --instance-id xxx --file-path ./payload.json`,
+				`<Modify payload in file>`,
+				`$ stackit observability alert-config set --payload @./payload.json --instance-id xxx`),
+		),
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx := context.Background()
+			model, err := parseInput(params.Printer, cmd)
+			if err != nil {
+				return err
+			}
+
+			if model.InstanceId == nil {
+				payload := observabilityUtils.DefaultUpdateAlertConfigsPayload
+				return outputResult(params.Printer, model.FilePath, &payload)
+			}
+
+			// Configure API client
+			apiClient, err := client.ConfigureClient(params.Printer, params.CliVersion)
+			if err != nil {
+				return err
+			}
+
+			req := buildRequest(ctx, model, apiClient)
+			resp, err := req.Execute()
+			if err != nil {
+				return fmt.Errorf("read Observability alert config: %w", err)
+			}
+
+			payload, err := observabilityUtils.MapToUpdateAlertConfigsPayload(resp)
+			if err != nil {
+				return fmt.Errorf("map update alert config payload: %w", err)
+			}
+
+			return outputResult(params.Printer, model.FilePath, payload)
+		},
+	}
+	configureFlags(cmd)
+	return cmd
+}
+
+func configureFlags(cmd *cobra.Command) {
+	cmd.Flags().Var(flags.UUIDFlag(), instanceIdFlag, "If set, generates a payload with the current alertmanager configuration of the given instance. If unset, generates a payload with default values")
+	cmd.Flags().StringP(filePathFlag, "f", "", "If set, writes the payload to the given file. If unset, writes the payload to the standard output")
+}
+
+func parseInput(p *print.Printer, cmd *cobra.Command) (*inputModel, error) {
+	globalFlags := globalflags.Parse(p, cmd)
+
+	instanceId := flags.FlagToStringPointer(p, cmd, instanceIdFlag)
+	if instanceId != nil && globalFlags.ProjectId == "" {
+		return nil, fmt.Errorf("if an instance-id is provided then project-id must be provided")
+	}
+
+	return &inputModel{
+		GlobalFlagModel: globalFlags,
+		InstanceId:      instanceId,
+		FilePath:        flags.FlagToStringPointer(p, cmd, filePathFlag),
+	}, nil
+}
+
+func buildRequest(ctx context.Context, model *inputModel, apiClient *observability.APIClient) observability.ApiGetAlertConfigsRequest {
+	req := apiClient.GetAlertConfigs(ctx, *model.InstanceId, model.ProjectId)
+	return req
+}
+
+func outputResult(p *print.Printer, filePath *string, payload *observability.UpdateAlertConfigsPayload) error {
+	if payload == nil {
+		return fmt.Errorf("payload is nil")
+	}
+
+	payloadBytes, err := json.MarshalIndent(*payload, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal payload: %w", err)
+	}
+
+	if filePath != nil {
+		err = fileutils.WriteToFile(*filePath, string(payloadBytes))
+		if err != nil {
+			return fmt.Errorf("write payload to the file: %w", err)
+		}
+	} else {
+		p.Outputln(string(payloadBytes))
+	}
+
+	return nil
+}
@@ -0,0 +1,190 @@
+package get
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/goccy/go-yaml"
+	"github.com/spf13/cobra"
+	"github.com/stackitcloud/stackit-cli/internal/cmd/params"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/args"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/errors"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/examples"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/flags"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/globalflags"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/print"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/services/observability/client"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/tables"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/utils"
+	"github.com/stackitcloud/stackit-sdk-go/services/observability"
+)
+
+const (
+	instanceIdFlag = "instance-id"
+)
+
+type inputModel struct {
+	*globalflags.GlobalFlagModel
+	InstanceId string
+}
+
+func NewCmd(params *params.CmdParams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "get",
+		Short: "Shows the alertmanager configuration of an Observability instance",
+		Long:  "Shows the alertmanager configuration of an Observability instance. The full configuration, including the settings of the receivers, is shown with the JSON and YAML output formats.",
+		Args:  args.NoArgs,
+		Example: examples.Build(
+			examples.NewExample(
+				`Get the alertmanager configuration of Observability instance "xxx"`,
+				"$ stackit observability alert-config get --instance-id xxx"),
+			examples.NewExample(
+				`Get the full alertmanager configuration of Observability instance "xxx" in YAML format`,
+				"$ stackit observability alert-config get --instance-id xxx --output-format yaml"),
+		),
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx := context.Background()
+			model, err := parseInput(params.Printer, cmd)
+			if err != nil {
+				return err
+			}
+			// Configure API client
+			apiClient, err := client.ConfigureClient(params.Printer, params.CliVersion)
+			if err != nil {
+				return err
+			}
+
+			// Call API
+			req := buildRequest(ctx, model, apiClient)
+			resp, err := req.Execute()
+			if err != nil {
+				return fmt.Errorf("read alert config: %w", err)
+			}
+
+			return outputResult(params.Printer, model.OutputFormat, resp.Data)
+		},
+	}
+	configureFlags(cmd)
+	return cmd
+}
+
+func configureFlags(cmd *cobra.Command) {
+	cmd.Flags().Var(flags.UUIDFlag(), instanceIdFlag, "Instance ID")
+
+	err := flags.MarkFlagsRequired(cmd, instanceIdFlag)
+	cobra.CheckErr(err)
+}
+
+func parseInput(p *print.Printer, cmd *cobra.Command) (*inputModel, error) {
+	globalFlags := globalflags.Parse(p, cmd)
+	if globalFlags.ProjectId == "" {
+		return nil, &errors.ProjectIdError{}
+	}
+
+	return &inputModel{
+		GlobalFlagModel: globalFlags,
+		InstanceId:      flags.FlagToStringValue(p, cmd, instanceIdFlag),
+	}, nil
+}
+
+func buildRequest(ctx context.Context, model *inputModel, apiClient *observability.APIClient) observability.ApiGetAlertConfigsRequest {
+	req := apiClient.GetAlertConfigs(ctx, model.InstanceId, model.ProjectId)
+	return req
+}
+
+func outputResult(p *print.Printer, outputFormat string, config *observability.Alert) error {
+	if config == nil {
+		return fmt.Errorf("alert config is nil")
+	}
+
+	switch outputFormat {
+	case print.JSONOutputFormat:
+		details, err := json.MarshalIndent(config, "", "  ")
+		if err != nil {
+			return fmt.Errorf("marshal alert config: %w", err)
+		}
+		p.Outputln(string(details))
+
+		return nil
+	case print.YAMLOutputFormat:
+		details, err := yaml.MarshalWithOptions(config, yaml.IndentSequence(true), yaml.UseJSONMarshaler())
+		if err != nil {
+			return fmt.Errorf("marshal alert config: %w", err)
+		}
+		p.Outputln(string(details))
+
+		return nil
+	default:
+		table := tables.NewTable()
+		if config.Global != nil {
+			table.AddRow("RESOLVE TIMEOUT", utils.PtrString(config.Global.ResolveTimeout))
+			table.AddSeparator()
+			table.AddRow("SMTP FROM", utils.PtrString(config.Global.SmtpFrom))
+			table.AddSeparator()
+			table.AddRow("SMTP SMARTHOST", utils.PtrString(config.Global.SmtpSmarthost))
+			table.AddSeparator()
+		}
+		if config.Route != nil {
+			subRoutes := 0
+			if config.Route.Routes != nil {
+				subRoutes = len(*config.Route.Routes)
+			}
+			table.AddRow("DEFAULT RECEIVER", utils.PtrString(config.Route.Receiver))
+			table.AddSeparator()
+			table.AddRow("GROUP BY", utils.JoinStringPtr(config.Route.GroupBy, ","))
+			table.AddSeparator()
+			table.AddRow("GROUP WAIT", utils.PtrString(config.Route.GroupWait))
+			table.AddSeparator()
+			table.AddRow("GROUP INTERVAL", utils.PtrString(config.Route.GroupInterval))
+			table.AddSeparator()
+			table.AddRow("REPEAT INTERVAL", utils.PtrString(config.Route.RepeatInterval))
+			table.AddSeparator()
+			table.AddRow("ROUTES", subRoutes)
+			table.AddSeparator()
+		}
+		if config.Receivers != nil {
+			for _, receiver := range *config.Receivers {
+				table.AddRow(fmt.Sprintf("RECEIVER %s", utils.PtrString(receiver.Name)), formatReceiver(&receiver))
+				table.AddSeparator()
+			}
+		}
+		inhibitRules := 0
+		if config.InhibitRules != nil {
+			inhibitRules = len(*config.InhibitRules)
+		}
+		table.AddRow("INHIBIT RULES", inhibitRules)
+
+		err := table.Display(p)
+		if err != nil {
+			return fmt.Errorf("render table: %w", err)
+		}
+
+		return nil
+	}
+}
+
+// formatReceiver lists the targets of a receiver, without any credentials
+func formatReceiver(receiver *observability.Receivers) string {
+	var targets []string
+	if receiver.EmailConfigs != nil {
+		for _, c := range *receiver.EmailConfigs {
+			targets = append(targets, fmt.Sprintf("email: %s", utils.PtrString(c.To)))
+		}
+	}
+	if receiver.WebHookConfigs != nil {
+		for _, c := range *receiver.WebHookConfigs {
+			targets = append(targets, fmt.Sprintf("webhook: %s", utils.PtrString(c.Url)))
+		}
+	}
+	if receiver.OpsgenieConfigs != nil {
+		for _, c := range *receiver.OpsgenieConfigs {
+			targets = append(targets, fmt.Sprintf("opsgenie: %s", utils.PtrString(c.ApiUrl)))
+		}
+	}
+	if len(targets) == 0 {
+		return "None"
+	}
+	return strings.Join(targets, "\n")
+}
@@ -0,0 +1,137 @@
+package set
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/stackitcloud/stackit-cli/internal/cmd/params"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/args"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/errors"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/examples"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/flags"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/globalflags"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/print"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/services/observability/client"
+	observabilityUtils "github.com/stackitcloud/stackit-cli/internal/pkg/services/observability/utils"
+
+	"github.com/spf13/cobra"
+	"github.com/stackitcloud/stackit-sdk-go/services/observability"
+)
+
+const (
+	instanceIdFlag = "instance-id"
+	payloadFlag    = "payload"
+)
+
+type inputModel struct {
+	*globalflags.GlobalFlagModel
+	InstanceId string
+	Payload    observability.UpdateAlertConfigsPayload
+}
+
+func NewCmd(params *params.CmdParams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "set",
+		Short: "Sets the alertmanager configuration of an Observability instance",
+		Long: fmt.Sprintf("%s\n%s\n%s\n%s",
+			"Sets the alertmanager configuration of an Observability instance, replacing its receivers, routes and inhibit rules.",
+			"The payload can be provided as a JSON or YAML string or a file path prefixed with \"@\".",
+			"The payload is validated before it is submitted: receiver names must be unique, every route must point to an existing receiver and all durations must be valid.",
+			"See https://docs.api.stackit.cloud/documentation/argus/version/v1#tag/alert-config for information regarding the payload structure.",
+		),
+		Args: args.NoArgs,
+		Example: examples.Build(
+			examples.NewExample(
+				`Set the alertmanager configuration of Observability instance "xxx", using an API payload sourced from the file "./alertmanager.yaml"`,
+				"$ stackit observability alert-config set --payload @./alertmanager.yaml --instance-id xxx"),
+			examples.NewExample(
+				`Set the alertmanager configuration of Observability instance "xxx", using an API payload provided as a JSON string`,
+				`$ stackit observability alert-config set --payload "{...}" --instance-id xxx`),
+			examples.NewExample(
+				`Generate a payload with the current alertmanager configuration, and adapt it with custom values for the different configuration options`,
+				`$ stackit observability alert-config generate-payload --instance-id xxx > ./payload.json`,
+				`<Modify payload in file>`,
+				`$ stackit observability alert-config set --payload @./payload.json --instance-id xxx`),
+		),
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx := context.Background()
+			model, err := parseInput(params.Printer, cmd)
+			if err != nil {
+				return err
+			}
+
+			// Configure API client
+			apiClient, err := client.ConfigureClient(params.Printer, params.CliVersion)
+			if err != nil {
+				return err
+			}
+
+			instanceLabel, err := observabilityUtils.GetInstanceName(ctx, apiClient, model.InstanceId, model.ProjectId)
+			if err != nil {
+				params.Printer.Debug(print.ErrorLevel, "get instance name: %v", err)
+				instanceLabel = model.InstanceId
+			}
+
+			if !model.AssumeYes {
+				prompt := fmt.Sprintf("Are you sure you want to replace the alertmanager configuration of Observability instance %q?", instanceLabel)
+				err = params.Printer.PromptForConfirmation(prompt)
+				if err != nil {
+					return err
+				}
+			}
+
+			// Call API
+			req := buildRequest(ctx, model, apiClient)
+			_, err = req.Execute()
+			if err != nil {
+				return fmt.Errorf("set alert config: %w", err)
+			}
+
+			// The API has no status to wait on, so async mode is default
+			params.Printer.Info("Set alertmanager configuration of Observability instance %q\n", instanceLabel)
+			return nil
+		},
+	}
+	configureFlags(cmd)
+	return cmd
+}
+
+func configureFlags(cmd *cobra.Command) {
+	cmd.Flags().Var(flags.ReadFromFileFlag(), payloadFlag, `Request payload (JSON or YAML). Can be a string or a file path, if prefixed with "@". Example: @./alertmanager.yaml`)
+	cmd.Flags().Var(flags.UUIDFlag(), instanceIdFlag, "Instance ID")
+
+	err := flags.MarkFlagsRequired(cmd, instanceIdFlag, payloadFlag)
+	cobra.CheckErr(err)
+}
+
+func parseInput(p *print.Printer, cmd *cobra.Command) (*inputModel, error) {
+	globalFlags := globalflags.Parse(p, cmd)
+	if globalFlags.ProjectId == "" {
+		return nil, &errors.ProjectIdError{}
+	}
+
+	var payload observability.UpdateAlertConfigsPayload
+	err := observabilityUtils.ParsePayload(flags.FlagToStringValue(p, cmd, payloadFlag), &payload)
+	if err == nil {
+		err = observabilityUtils.ValidateAlertConfigsPayload(&payload)
+	}
+	if err != nil {
+		return nil, &errors.FlagValidationError{
+			Flag:    payloadFlag,
+			Details: err.Error(),
+		}
+	}
+
+	return &inputModel{
+		GlobalFlagModel: globalFlags,
+		InstanceId:      flags.FlagToStringValue(p, cmd, instanceIdFlag),
+		Payload:         payload,
+	}, nil
+}
+
+func buildRequest(ctx context.Context, model *inputModel, apiClient *observability.APIClient) observability.ApiUpdateAlertConfigsRequest {
+	req := apiClient.UpdateAlertConfigs(ctx, model.InstanceId, model.ProjectId)
+
+	req = req.UpdateAlertConfigsPayload(model.Payload)
+	return req
+}
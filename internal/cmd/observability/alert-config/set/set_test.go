@@ -0,0 +1,268 @@
+package set
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stackitcloud/stackit-cli/internal/cmd/params"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/globalflags"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/print"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/utils"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/uuid"
+	"github.com/stackitcloud/stackit-sdk-go/services/observability"
+)
+
+type testCtxKey struct{}
+
+var testCtx = context.WithValue(context.Background(), testCtxKey{}, "foo")
+var testClient = &observability.APIClient{}
+var testProjectId = uuid.NewString()
+var testInstanceId = uuid.NewString()
+
+const testPayload = `{
+  "receivers": [
+    {
+      "name": "team-a",
+      "emailConfigs": [{"to": "team-a@example.com"}]
+    },
+    {
+      "name": "team-b"
+    }
+  ],
+  "route": {
+    "receiver": "team-a",
+    "groupWait": "30s",
+    "routes": [
+      {
+        "receiver": "team-b",
+        "repeatInterval": "4h"
+      }
+    ]
+  }
+}`
+
+const testPayloadYAML = `receivers:
+  - name: team-a
+    emailConfigs:
+      - to: team-a@example.com
+  - name: team-b
+route:
+  receiver: team-a
+  groupWait: 30s
+  routes:
+    - receiver: team-b
+      repeatInterval: 4h
+`
+
+func fixturePayload(mods ...func(payload *observability.UpdateAlertConfigsPayload)) observability.UpdateAlertConfigsPayload {
+	payload := observability.UpdateAlertConfigsPayload{
+		Receivers: &[]observability.UpdateAlertConfigsPayloadReceiversInner{
+			{
+				Name: utils.Ptr("team-a"),
+				EmailConfigs: &[]observability.CreateAlertConfigReceiverPayloadEmailConfigsInner{
+					{To: utils.Ptr("team-a@example.com")},
+				},
+			},
+			{
+				Name: utils.Ptr("team-b"),
+			},
+		},
+		Route: &observability.UpdateAlertConfigsPayloadRoute{
+			Receiver:  utils.Ptr("team-a"),
+			GroupWait: utils.Ptr("30s"),
+			Routes: &[]observability.CreateAlertConfigRoutePayloadRoutesInner{
+				{
+					Receiver:       utils.Ptr("team-b"),
+					RepeatInterval: utils.Ptr("4h"),
+				},
+			},
+		},
+	}
+	for _, mod := range mods {
+		mod(&payload)
+	}
+	return payload
+}
+
+func fixtureFlagValues(mods ...func(flagValues map[string]string)) map[string]string {
+	flagValues := map[string]string{
+		globalflags.ProjectIdFlag: testProjectId,
+		instanceIdFlag:            testInstanceId,
+		payloadFlag:               testPayload,
+	}
+	for _, mod := range mods {
+		mod(flagValues)
+	}
+	return flagValues
+}
+
+func fixtureInputModel(mods ...func(model *inputModel)) *inputModel {
+	model := &inputModel{
+		GlobalFlagModel: &globalflags.GlobalFlagModel{
+			ProjectId: testProjectId,
+			Verbosity: globalflags.VerbosityDefault,
+		},
+		InstanceId: testInstanceId,
+		Payload:    fixturePayload(),
+	}
+	for _, mod := range mods {
+		mod(model)
+	}
+	return model
+}
+
+func fixtureRequest(mods ...func(request *observability.ApiUpdateAlertConfigsRequest)) observability.ApiUpdateAlertConfigsRequest {
+	request := testClient.UpdateAlertConfigs(testCtx, testInstanceId, testProjectId)
+	request = request.UpdateAlertConfigsPayload(fixturePayload())
+	for _, mod := range mods {
+		mod(&request)
+	}
+	return request
+}
+
+func TestParseInput(t *testing.T) {
+	tests := []struct {
+		description   string
+		flagValues    map[string]string
+		isValid       bool
+		expectedModel *inputModel
+	}{
+		{
+			description:   "base",
+			flagValues:    fixtureFlagValues(),
+			isValid:       true,
+			expectedModel: fixtureInputModel(),
+		},
+		{
+			description: "yaml payload",
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				flagValues[payloadFlag] = testPayloadYAML
+			}),
+			isValid:       true,
+			expectedModel: fixtureInputModel(),
+		},
+		{
+			description: "no values",
+			flagValues:  map[string]string{},
+			isValid:     false,
+		},
+		{
+			description: "project id missing",
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				delete(flagValues, globalflags.ProjectIdFlag)
+			}),
+			isValid: false,
+		},
+		{
+			description: "instance id missing",
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				delete(flagValues, instanceIdFlag)
+			}),
+			isValid: false,
+		},
+		{
+			description: "instance id invalid",
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				flagValues[instanceIdFlag] = "invalid-uuid"
+			}),
+			isValid: false,
+		},
+		{
+			description: "payload missing",
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				delete(flagValues, payloadFlag)
+			}),
+			isValid: false,
+		},
+		{
+			description: "payload malformed",
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				flagValues[payloadFlag] = `{"route": `
+			}),
+			isValid: false,
+		},
+		{
+			description: "route to undefined receiver",
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				flagValues[payloadFlag] = `{"receivers": [{"name": "team-a"}], "route": {"receiver": "team-b"}}`
+			}),
+			isValid: false,
+		},
+		{
+			description: "duplicate receivers",
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				flagValues[payloadFlag] = `{"receivers": [{"name": "team-a"}, {"name": "team-a"}], "route": {"receiver": "team-a"}}`
+			}),
+			isValid: false,
+		},
+		{
+			description: "invalid duration",
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				flagValues[payloadFlag] = `{"receivers": [{"name": "team-a"}], "route": {"receiver": "team-a", "groupWait": "soon"}}`
+			}),
+			isValid: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			p := print.NewPrinter()
+			cmd := NewCmd(&params.CmdParams{Printer: p})
+			err := globalflags.Configure(cmd.Flags())
+			if err != nil {
+				t.Fatalf("configure global flags: %v", err)
+			}
+
+			for flag, value := range tt.flagValues {
+				err := cmd.Flags().Set(flag, value)
+				if err != nil {
+					if !tt.isValid {
+						return
+					}
+					t.Fatalf("setting flag --%s=%s: %v", flag, value, err)
+				}
+			}
+
+			err = cmd.ValidateRequiredFlags()
+			if err != nil {
+				if !tt.isValid {
+					return
+				}
+				t.Fatalf("error validating flags: %v", err)
+			}
+
+			model, err := parseInput(p, cmd)
+			if err != nil {
+				if !tt.isValid {
+					return
+				}
+				t.Fatalf("error parsing input: %v", err)
+			}
+
+			if !tt.isValid {
+				t.Fatalf("did not fail on invalid input")
+			}
+			diff := cmp.Diff(model, tt.expectedModel)
+			if diff != "" {
+				t.Fatalf("Data does not match: %s", diff)
+			}
+		})
+	}
+}
+
+func TestBuildRequest(t *testing.T) {
+	expectedRequest := fixtureRequest()
+
+	request := buildRequest(testCtx, fixtureInputModel(), testClient)
+
+	diff := cmp.Diff(request, expectedRequest,
+		cmp.AllowUnexported(expectedRequest),
+		cmpopts.EquateComparable(testCtx),
+	)
+	if diff != "" {
+		t.Fatalf("Data does not match: %s", diff)
+	}
+}
@@ -0,0 +1,36 @@
+package alertgroup
+
+import (
+	"github.com/stackitcloud/stackit-cli/internal/cmd/observability/alert-group/create"
+	"github.com/stackitcloud/stackit-cli/internal/cmd/observability/alert-group/delete"
+	"github.com/stackitcloud/stackit-cli/internal/cmd/observability/alert-group/describe"
+	generatepayload "github.com/stackitcloud/stackit-cli/internal/cmd/observability/alert-group/generate-payload"
+	"github.com/stackitcloud/stackit-cli/internal/cmd/observability/alert-group/list"
+	"github.com/stackitcloud/stackit-cli/internal/cmd/observability/alert-group/update"
+	"github.com/stackitcloud/stackit-cli/internal/cmd/params"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/args"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/utils"
+
+	"github.com/spf13/cobra"
+)
+
+func NewCmd(params *params.CmdParams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "alert-group",
+		Short: "Provides functionality for alert groups in Observability",
+		Long:  "Provides functionality for alert groups in Observability. An alert group holds alerting rules, which are evaluated together at the interval of the group.",
+		Args:  args.NoArgs,
+		Run:   utils.CmdHelp,
+	}
+	addSubcommands(cmd, params)
+	return cmd
+}
+
+func addSubcommands(cmd *cobra.Command, params *params.CmdParams) {
+	cmd.AddCommand(generatepayload.NewCmd(params))
+	cmd.AddCommand(create.NewCmd(params))
+	cmd.AddCommand(delete.NewCmd(params))
+	cmd.AddCommand(update.NewCmd(params))
+	cmd.AddCommand(list.NewCmd(params))
+	cmd.AddCommand(describe.NewCmd(params))
+}
@@ -0,0 +1,153 @@
+package create
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/stackitcloud/stackit-cli/internal/cmd/params"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/args"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/errors"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/examples"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/flags"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/globalflags"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/print"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/services/observability/client"
+	observabilityUtils "github.com/stackitcloud/stackit-cli/internal/pkg/services/observability/utils"
+
+	"github.com/spf13/cobra"
+	"github.com/stackitcloud/stackit-sdk-go/services/observability"
+)
+
+const (
+	payloadFlag    = "payload"
+	instanceIdFlag = "instance-id"
+)
+
+type inputModel struct {
+	*globalflags.GlobalFlagModel
+	InstanceId string
+	Payload    observability.CreateAlertgroupsPayload
+}
+
+func NewCmd(params *params.CmdParams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "create",
+		Short: "Creates an alert group for an Observability instance",
+		Long: fmt.Sprintf("%s\n%s\n%s\n%s\n%s",
+			"Creates an alert group for an Observability instance.",
+			"The payload can be provided as a JSON or YAML string or a file path prefixed with \"@\".",
+			"If no payload is provided, a default payload will be used.",
+			"The name and interval of the group are validated and the PromQL expressions of its rules are parsed with the Prometheus parser before the alert group is created.",
+			"See https://docs.api.stackit.cloud/documentation/argus/version/v1#tag/alert-groups for information regarding the payload structure.",
+		),
+		Args: args.NoArgs,
+		Example: examples.Build(
+			examples.NewExample(
+				`Create an alert group on Observability instance "xxx" using default configuration`,
+				"$ stackit observability alert-group create --instance-id xxx"),
+			examples.NewExample(
+				`Create an alert group on Observability instance "xxx" using an API payload sourced from the file "./payload.yaml"`,
+				"$ stackit observability alert-group create --payload @./payload.yaml --instance-id xxx"),
+			examples.NewExample(
+				`Create an alert group on Observability instance "xxx" using an API payload provided as a JSON string`,
+				`$ stackit observability alert-group create --payload "{...}" --instance-id xxx`),
+			examples.NewExample(
+				`Generate a payload with default values, and adapt it with custom values for the different configuration options`,
+				`$ stackit observability alert-group generate-payload > ./payload.json`,
+				`<Modify payload in file, if needed>`,
+				`$ stackit observability alert-group create --payload @./payload.json --instance-id xxx`),
+		),
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx := context.Background()
+			model, err := parseInput(params.Printer, cmd)
+			if err != nil {
+				return err
+			}
+
+			// Configure API client
+			apiClient, err := client.ConfigureClient(params.Printer, params.CliVersion)
+			if err != nil {
+				return err
+			}
+
+			instanceLabel, err := observabilityUtils.GetInstanceName(ctx, apiClient, model.InstanceId, model.ProjectId)
+			if err != nil {
+				params.Printer.Debug(print.ErrorLevel, "get instance name: %v", err)
+				instanceLabel = model.InstanceId
+			}
+
+			if !model.AssumeYes {
+				prompt := fmt.Sprintf("Are you sure you want to create alert group %q on Observability instance %q?", *model.Payload.Name, instanceLabel)
+				err = params.Printer.PromptForConfirmation(prompt)
+				if err != nil {
+					return err
+				}
+			}
+
+			// Call API
+			req := buildRequest(ctx, model, apiClient)
+			_, err = req.Execute()
+			if err != nil {
+				return fmt.Errorf("create alert group: %w", err)
+			}
+
+			// The API has no status to wait on, so async mode is default
+			params.Printer.Outputf("Created alert group with name %q for Observability instance %q\n", *model.Payload.Name, instanceLabel)
+			return nil
+		},
+	}
+	configureFlags(cmd)
+	return cmd
+}
+
+func configureFlags(cmd *cobra.Command) {
+	cmd.Flags().Var(flags.ReadFromFileFlag(), payloadFlag, `Request payload (JSON or YAML). Can be a string or a file path, if prefixed with "@" (example: @./payload.yaml). If unset, will use a default payload (you can check it by running "stackit observability alert-group generate-payload")`)
+	cmd.Flags().Var(flags.UUIDFlag(), instanceIdFlag, "Instance ID")
+
+	err := flags.MarkFlagsRequired(cmd, instanceIdFlag)
+	cobra.CheckErr(err)
+}
+
+func parseInput(p *print.Printer, cmd *cobra.Command) (*inputModel, error) {
+	globalFlags := globalflags.Parse(p, cmd)
+	if globalFlags.ProjectId == "" {
+		return nil, &errors.ProjectIdError{}
+	}
+
+	payload := observabilityUtils.DefaultCreateAlertGroupPayload
+	payloadValue := flags.FlagToStringPointer(p, cmd, payloadFlag)
+	if payloadValue != nil {
+		payload = observability.CreateAlertgroupsPayload{}
+		err := observabilityUtils.ParsePayload(*payloadValue, &payload)
+		if err != nil {
+			return nil, &errors.FlagValidationError{
+				Flag:    payloadFlag,
+				Details: err.Error(),
+			}
+		}
+	}
+
+	err := observabilityUtils.ValidateAlertGroupName(payload.Name)
+	if err == nil {
+		err = observabilityUtils.ValidateAlertGroup(payload.Interval, payload.Rules)
+	}
+	if err != nil {
+		return nil, &errors.FlagValidationError{
+			Flag:    payloadFlag,
+			Details: err.Error(),
+		}
+	}
+
+	return &inputModel{
+		GlobalFlagModel: globalFlags,
+		InstanceId:      flags.FlagToStringValue(p, cmd, instanceIdFlag),
+		Payload:         payload,
+	}, nil
+}
+
+func buildRequest(ctx context.Context, model *inputModel, apiClient *observability.APIClient) observability.ApiCreateAlertgroupsRequest {
+	req := apiClient.CreateAlertgroups(ctx, model.InstanceId, model.ProjectId)
+
+	req = req.CreateAlertgroupsPayload(model.Payload)
+	return req
+}
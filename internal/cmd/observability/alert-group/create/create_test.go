@@ -0,0 +1,250 @@
+package create
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stackitcloud/stackit-cli/internal/cmd/params"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/globalflags"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/print"
+	observabilityUtils "github.com/stackitcloud/stackit-cli/internal/pkg/services/observability/utils"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/utils"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/uuid"
+	"github.com/stackitcloud/stackit-sdk-go/services/observability"
+)
+
+type testCtxKey struct{}
+
+var testCtx = context.WithValue(context.Background(), testCtxKey{}, "foo")
+var testClient = &observability.APIClient{}
+var testProjectId = uuid.NewString()
+var testInstanceId = uuid.NewString()
+
+var testPayload = observability.CreateAlertgroupsPayload{
+	Name:     utils.Ptr("my-group"),
+	Interval: utils.Ptr("1m"),
+	Rules: &[]observability.UpdateAlertgroupsRequestInnerRulesInner{
+		{
+			Alert: utils.Ptr("HighErrorRate"),
+			Expr:  utils.Ptr(`sum(rate(http_requests_total{code=~"5.."}[5m])) > 1`),
+			For:   utils.Ptr("10m"),
+			Labels: &map[string]interface{}{
+				"severity": "warning",
+			},
+		},
+	},
+}
+
+func fixtureFlagValues(mods ...func(flagValues map[string]string)) map[string]string {
+	flagValues := map[string]string{
+		globalflags.ProjectIdFlag: testProjectId,
+		instanceIdFlag:            testInstanceId,
+		payloadFlag: `{
+			"name": "my-group",
+			"interval": "1m",
+			"rules": [
+				{
+					"alert": "HighErrorRate",
+					"expr": "sum(rate(http_requests_total{code=~\"5..\"}[5m])) > 1",
+					"for": "10m",
+					"labels": {
+						"severity": "warning"
+					}
+				}
+			]
+		}`,
+	}
+	for _, mod := range mods {
+		mod(flagValues)
+	}
+	return flagValues
+}
+
+func fixtureInputModel(mods ...func(model *inputModel)) *inputModel {
+	model := &inputModel{
+		GlobalFlagModel: &globalflags.GlobalFlagModel{
+			ProjectId: testProjectId,
+			Verbosity: globalflags.VerbosityDefault,
+		},
+		InstanceId: testInstanceId,
+		Payload:    testPayload,
+	}
+	for _, mod := range mods {
+		mod(model)
+	}
+	return model
+}
+
+func fixtureRequest(mods ...func(request *observability.ApiCreateAlertgroupsRequest)) observability.ApiCreateAlertgroupsRequest {
+	request := testClient.CreateAlertgroups(testCtx, testInstanceId, testProjectId)
+	request = request.CreateAlertgroupsPayload(testPayload)
+	for _, mod := range mods {
+		mod(&request)
+	}
+	return request
+}
+
+func TestParseInput(t *testing.T) {
+	tests := []struct {
+		description   string
+		flagValues    map[string]string
+		isValid       bool
+		expectedModel *inputModel
+	}{
+		{
+			description:   "base",
+			flagValues:    fixtureFlagValues(),
+			isValid:       true,
+			expectedModel: fixtureInputModel(),
+		},
+		{
+			description: "yaml payload",
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				flagValues[payloadFlag] = `name: my-group
+interval: 1m
+rules:
+  - alert: HighErrorRate
+    expr: sum(rate(http_requests_total{code=~"5.."}[5m])) > 1
+    for: 10m
+    labels:
+      severity: warning
+`
+			}),
+			isValid:       true,
+			expectedModel: fixtureInputModel(),
+		},
+		{
+			description: "default payload",
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				delete(flagValues, payloadFlag)
+			}),
+			isValid: true,
+			expectedModel: fixtureInputModel(func(model *inputModel) {
+				model.Payload = observabilityUtils.DefaultCreateAlertGroupPayload
+			}),
+		},
+		{
+			description: "no values",
+			flagValues:  map[string]string{},
+			isValid:     false,
+		},
+		{
+			description: "project id missing",
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				delete(flagValues, globalflags.ProjectIdFlag)
+			}),
+			isValid: false,
+		},
+		{
+			description: "project id invalid",
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				flagValues[globalflags.ProjectIdFlag] = "invalid-uuid"
+			}),
+			isValid: false,
+		},
+		{
+			description: "instance id missing",
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				delete(flagValues, instanceIdFlag)
+			}),
+			isValid: false,
+		},
+		{
+			description: "instance id invalid",
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				flagValues[instanceIdFlag] = "invalid-uuid"
+			}),
+			isValid: false,
+		},
+		{
+			description: "invalid payload",
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				flagValues[payloadFlag] = "name: [my-group"
+			}),
+			isValid: false,
+		},
+		{
+			description: "invalid group name",
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				flagValues[payloadFlag] = `{"name": "my group", "rules": [{"alert": "Down", "expr": "up == 0"}]}`
+			}),
+			isValid: false,
+		},
+		{
+			description: "invalid expression",
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				flagValues[payloadFlag] = `{"name": "my-group", "rules": [{"alert": "Down", "expr": "sum(up == 0"}]}`
+			}),
+			isValid: false,
+		},
+		{
+			description: "no rules",
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				flagValues[payloadFlag] = `{"name": "my-group", "rules": []}`
+			}),
+			isValid: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			p := print.NewPrinter()
+			cmd := NewCmd(&params.CmdParams{Printer: p})
+			err := globalflags.Configure(cmd.Flags())
+			if err != nil {
+				t.Fatalf("configure global flags: %v", err)
+			}
+
+			for flag, value := range tt.flagValues {
+				err := cmd.Flags().Set(flag, value)
+				if err != nil {
+					if !tt.isValid {
+						return
+					}
+					t.Fatalf("setting flag --%s=%s: %v", flag, value, err)
+				}
+			}
+
+			err = cmd.ValidateRequiredFlags()
+			if err != nil {
+				if !tt.isValid {
+					return
+				}
+				t.Fatalf("error validating flags: %v", err)
+			}
+
+			model, err := parseInput(p, cmd)
+			if err != nil {
+				if !tt.isValid {
+					return
+				}
+				t.Fatalf("error parsing flags: %v", err)
+			}
+
+			if !tt.isValid {
+				t.Fatalf("did not fail on invalid input")
+			}
+			diff := cmp.Diff(model, tt.expectedModel)
+			if diff != "" {
+				t.Fatalf("Data does not match: %s", diff)
+			}
+		})
+	}
+}
+
+func TestBuildRequest(t *testing.T) {
+	expectedRequest := fixtureRequest()
+
+	request := buildRequest(testCtx, fixtureInputModel(), testClient)
+
+	diff := cmp.Diff(request, expectedRequest,
+		cmp.AllowUnexported(expectedRequest),
+		cmpopts.EquateComparable(testCtx),
+	)
+	if diff != "" {
+		t.Fatalf("Data does not match: %s", diff)
+	}
+}
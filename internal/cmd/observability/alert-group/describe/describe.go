@@ -0,0 +1,177 @@
+package describe
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/goccy/go-yaml"
+	"github.com/spf13/cobra"
+	"github.com/stackitcloud/stackit-cli/internal/cmd/params"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/args"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/errors"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/examples"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/flags"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/globalflags"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/print"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/services/observability/client"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/tables"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/utils"
+	"github.com/stackitcloud/stackit-sdk-go/services/observability"
+)
+
+const (
+	groupNameArg = "GROUP_NAME"
+
+	instanceIdFlag = "instance-id"
+)
+
+type inputModel struct {
+	*globalflags.GlobalFlagModel
+	GroupName  string
+	InstanceId string
+}
+
+func NewCmd(params *params.CmdParams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   fmt.Sprintf("describe %s", groupNameArg),
+		Short: "Shows details of an alert group from an Observability instance",
+		Long:  "Shows details of an alert group from an Observability instance, including its rules.",
+		Args:  args.SingleArg(groupNameArg, nil),
+		Example: examples.Build(
+			examples.NewExample(
+				`Get details of an alert group with name "my-group" from Observability instance "xxx"`,
+				"$ stackit observability alert-group describe my-group --instance-id xxx"),
+			examples.NewExample(
+				`Get details of an alert group with name "my-group" from Observability instance "xxx" in YAML format`,
+				"$ stackit observability alert-group describe my-group --instance-id xxx --output-format yaml"),
+		),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.Background()
+			model, err := parseInput(params.Printer, cmd, args)
+			if err != nil {
+				return err
+			}
+			// Configure API client
+			apiClient, err := client.ConfigureClient(params.Printer, params.CliVersion)
+			if err != nil {
+				return err
+			}
+
+			// Call API
+			req := buildRequest(ctx, model, apiClient)
+			resp, err := req.Execute()
+			if err != nil {
+				return fmt.Errorf("read alert group: %w", err)
+			}
+
+			return outputResult(params.Printer, model.OutputFormat, resp.Data)
+		},
+	}
+	configureFlags(cmd)
+	return cmd
+}
+
+func configureFlags(cmd *cobra.Command) {
+	cmd.Flags().Var(flags.UUIDFlag(), instanceIdFlag, "Instance ID")
+
+	err := flags.MarkFlagsRequired(cmd, instanceIdFlag)
+	cobra.CheckErr(err)
+}
+
+func parseInput(p *print.Printer, cmd *cobra.Command, inputArgs []string) (*inputModel, error) {
+	groupName := inputArgs[0]
+
+	globalFlags := globalflags.Parse(p, cmd)
+	if globalFlags.ProjectId == "" {
+		return nil, &errors.ProjectIdError{}
+	}
+
+	return &inputModel{
+		GlobalFlagModel: globalFlags,
+		GroupName:       groupName,
+		InstanceId:      flags.FlagToStringValue(p, cmd, instanceIdFlag),
+	}, nil
+}
+
+func buildRequest(ctx context.Context, model *inputModel, apiClient *observability.APIClient) observability.ApiGetAlertgroupRequest {
+	req := apiClient.GetAlertgroup(ctx, model.GroupName, model.InstanceId, model.ProjectId)
+	return req
+}
+
+func outputResult(p *print.Printer, outputFormat string, group *observability.AlertGroup) error {
+	if group == nil {
+		return fmt.Errorf("alert group is nil")
+	}
+
+	switch outputFormat {
+	case print.JSONOutputFormat:
+		details, err := json.MarshalIndent(group, "", "  ")
+		if err != nil {
+			return fmt.Errorf("marshal alert group: %w", err)
+		}
+		p.Outputln(string(details))
+
+		return nil
+	case print.YAMLOutputFormat:
+		details, err := yaml.MarshalWithOptions(group, yaml.IndentSequence(true), yaml.UseJSONMarshaler())
+		if err != nil {
+			return fmt.Errorf("marshal alert group: %w", err)
+		}
+		p.Outputln(string(details))
+
+		return nil
+	default:
+		table := tables.NewTable()
+		table.AddRow("NAME", utils.PtrString(group.Name))
+		table.AddSeparator()
+		table.AddRow("INTERVAL", utils.PtrString(group.Interval))
+		table.AddSeparator()
+		if group.Rules != nil {
+			for i, rule := range *group.Rules {
+				table.AddRow(fmt.Sprintf("RULE #%d", i+1), formatRule(&rule))
+				table.AddSeparator()
+			}
+		}
+
+		err := table.Display(p)
+		if err != nil {
+			return fmt.Errorf("render table: %w", err)
+		}
+
+		return nil
+	}
+}
+
+func formatRule(rule *observability.AlertRuleRecord) string {
+	var lines []string
+	if rule.Alert != nil {
+		lines = append(lines, fmt.Sprintf("alert: %s", *rule.Alert))
+	}
+	if rule.Record != nil {
+		lines = append(lines, fmt.Sprintf("record: %s", *rule.Record))
+	}
+	lines = append(lines, fmt.Sprintf("expr: %s", utils.PtrString(rule.Expr)))
+	if rule.For != nil {
+		lines = append(lines, fmt.Sprintf("for: %s", *rule.For))
+	}
+	if rule.Labels != nil && len(*rule.Labels) > 0 {
+		lines = append(lines, fmt.Sprintf("labels: %s", formatMap(*rule.Labels)))
+	}
+	if rule.Annotations != nil && len(*rule.Annotations) > 0 {
+		lines = append(lines, fmt.Sprintf("annotations: %s", formatMap(*rule.Annotations)))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// formatMap returns the entries of m as "key=value", sorted by key so the output is stable
+func formatMap(m map[string]string) string {
+	entries := make([]string, 0, len(m))
+	for k, v := range m {
+		entries = append(entries, fmt.Sprintf("%s=%s", k, v))
+	}
+	sort.Strings(entries)
+	return strings.Join(entries, ",")
+}
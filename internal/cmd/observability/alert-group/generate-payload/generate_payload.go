@@ -0,0 +1,157 @@
+package generatepayload
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/stackitcloud/stackit-cli/internal/cmd/params"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/args"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/examples"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/fileutils"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/flags"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/globalflags"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/print"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/services/observability/client"
+	observabilityUtils "github.com/stackitcloud/stackit-cli/internal/pkg/services/observability/utils"
+
+	"github.com/spf13/cobra"
+	"github.com/stackitcloud/stackit-sdk-go/services/observability"
+)
+
+const (
+	groupNameFlag  = "group-name"
+	instanceIdFlag = "instance-id"
+	filePathFlag   = "file-path"
+)
+
+type inputModel struct {
+	*globalflags.GlobalFlagModel
+	GroupName  *string
+	InstanceId string
+	FilePath   *string
+}
+
+func NewCmd(params *params.CmdParams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "generate-payload",
+		Short: "Generates a payload to create/update alert groups for an Observability instance",
+		Long: fmt.Sprintf("%s\n%s\n%s\n%s\n%s",
+			"Generates a JSON payload with values to be used as --payload input for alert group creation or update.",
+			"To update an existing alert group, provide the group name and the instance ID of the Observability instance.",
+			"To obtain a default payload to create a new alert group, run the command with no flags.",
+			"Note that the default rule is only an example and should be adapted to your use case.",
+			"See https://docs.api.stackit.cloud/documentation/argus/version/v1#tag/alert-groups for information regarding the payload structure.",
+		),
+		Args: args.NoArgs,
+		Example: examples.Build(
+			examples.NewExample(
+				`Generate a Create payload with default values, and adapt it with custom values for the different configuration options`,
+				`$ stackit observability alert-group generate-payload --file-path ./payload.json`,
+				`<Modify payload in file, if needed>`,
+				`$ stackit observability alert-group create --payload @./payload.json --instance-id xxx`),
+			examples.NewExample(
+				`Generate an Update payload with the values of an existing alert group named "my-group" for Observability instance xxx, and adapt it with custom values for the different configuration options`,
+				`$ stackit observability alert-group generate-payload --group-name my-group --instance-id xxx --file-path ./payload.json`,
+				`<Modify payload in file>`,
+				`$ stackit observability alert-group update my-group --payload @./payload.json --instance-id xxx`),
+			examples.NewExample(
+				`Generate an Update payload with the values of an existing alert group named "my-group" for Observability instance xxx, and preview it in the terminal`,
+				`$ stackit observability alert-group generate-payload --group-name my-group --instance-id xxx`),
+		),
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx := context.Background()
+			model, err := parseInput(params.Printer, cmd)
+			if err != nil {
+				return err
+			}
+
+			if model.GroupName == nil {
+				createPayload := observabilityUtils.DefaultCreateAlertGroupPayload
+				return outputCreateResult(params.Printer, model.FilePath, &createPayload)
+			}
+
+			// Configure API client
+			apiClient, err := client.ConfigureClient(params.Printer, params.CliVersion)
+			if err != nil {
+				return err
+			}
+
+			req := buildRequest(ctx, model, apiClient)
+			resp, err := req.Execute()
+			if err != nil {
+				return fmt.Errorf("read Observability alert group: %w", err)
+			}
+
+			payload, err := observabilityUtils.MapToUpdateAlertGroupPayload(resp)
+			if err != nil {
+				return fmt.Errorf("map update alert group payload: %w", err)
+			}
+
+			return outputUpdateResult(params.Printer, model.FilePath, payload)
+		},
+	}
+	configureFlags(cmd)
+	return cmd
+}
+
+func configureFlags(cmd *cobra.Command) {
+	cmd.Flags().Var(flags.UUIDFlag(), instanceIdFlag, "Instance ID")
+	cmd.Flags().StringP(groupNameFlag, "n", "", "If set, generates an update payload with the current state of the given alert group. If unset, generates a create payload with default values")
+	cmd.Flags().StringP(filePathFlag, "f", "", "If set, writes the payload to the given file. If unset, writes the payload to the standard output")
+}
+
+func parseInput(p *print.Printer, cmd *cobra.Command) (*inputModel, error) {
+	globalFlags := globalflags.Parse(p, cmd)
+
+	groupName := flags.FlagToStringPointer(p, cmd, groupNameFlag)
+	instanceId := flags.FlagToStringValue(p, cmd, instanceIdFlag)
+
+	if groupName != nil && (globalFlags.ProjectId == "" || instanceId == "") {
+		return nil, fmt.Errorf("if a group-name is provided then instance-id and project-id must be provided")
+	}
+
+	return &inputModel{
+		GlobalFlagModel: globalFlags,
+		GroupName:       groupName,
+		InstanceId:      instanceId,
+		FilePath:        flags.FlagToStringPointer(p, cmd, filePathFlag),
+	}, nil
+}
+
+func buildRequest(ctx context.Context, model *inputModel, apiClient *observability.APIClient) observability.ApiGetAlertgroupRequest {
+	req := apiClient.GetAlertgroup(ctx, *model.GroupName, model.InstanceId, model.ProjectId)
+	return req
+}
+
+func outputCreateResult(p *print.Printer, filePath *string, payload *observability.CreateAlertgroupsPayload) error {
+	if payload == nil {
+		return fmt.Errorf("payload is nil")
+	}
+	return writePayload(p, filePath, payload)
+}
+
+func outputUpdateResult(p *print.Printer, filePath *string, payload *observability.UpdateAlertgroupPayload) error {
+	if payload == nil {
+		return fmt.Errorf("payload is nil")
+	}
+	return writePayload(p, filePath, payload)
+}
+
+func writePayload(p *print.Printer, filePath *string, payload any) error {
+	payloadBytes, err := json.MarshalIndent(payload, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal payload: %w", err)
+	}
+
+	if filePath != nil {
+		err = fileutils.WriteToFile(*filePath, string(payloadBytes))
+		if err != nil {
+			return fmt.Errorf("write payload to the file: %w", err)
+		}
+	} else {
+		p.Outputln(string(payloadBytes))
+	}
+
+	return nil
+}
@@ -0,0 +1,143 @@
+package update
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/stackitcloud/stackit-cli/internal/cmd/params"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/args"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/errors"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/examples"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/flags"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/globalflags"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/print"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/services/observability/client"
+	observabilityUtils "github.com/stackitcloud/stackit-cli/internal/pkg/services/observability/utils"
+
+	"github.com/spf13/cobra"
+	"github.com/stackitcloud/stackit-sdk-go/services/observability"
+)
+
+const (
+	groupNameArg = "GROUP_NAME"
+
+	instanceIdFlag = "instance-id"
+	payloadFlag    = "payload"
+)
+
+type inputModel struct {
+	*globalflags.GlobalFlagModel
+	GroupName  string
+	InstanceId string
+	Payload    observability.UpdateAlertgroupPayload
+}
+
+func NewCmd(params *params.CmdParams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   fmt.Sprintf("update %s", groupNameArg),
+		Short: "Updates an alert group of an Observability instance",
+		Long: fmt.Sprintf("%s\n%s\n%s\n%s",
+			"Updates an alert group of an Observability instance, replacing its interval and rules.",
+			"The payload can be provided as a JSON or YAML string or a file path prefixed with \"@\".",
+			"The interval of the group is validated and the PromQL expressions of its rules are parsed with the Prometheus parser before the alert group is updated.",
+			"See https://docs.api.stackit.cloud/documentation/argus/version/v1#tag/alert-groups for information regarding the payload structure.",
+		),
+		Args: args.SingleArg(groupNameArg, nil),
+		Example: examples.Build(
+			examples.NewExample(
+				`Update an alert group with name "my-group" from Observability instance "xxx", using an API payload sourced from the file "./payload.yaml"`,
+				"$ stackit observability alert-group update my-group --payload @./payload.yaml --instance-id xxx"),
+			examples.NewExample(
+				`Update an alert group with name "my-group" from Observability instance "xxx", using an API payload provided as a JSON string`,
+				`$ stackit observability alert-group update my-group --payload "{...}" --instance-id xxx`),
+			examples.NewExample(
+				`Generate a payload with the current values of an alert group, and adapt it with custom values for the different configuration options`,
+				`$ stackit observability alert-group generate-payload --group-name my-group --instance-id xxx > ./payload.json`,
+				`<Modify payload in file>`,
+				`$ stackit observability alert-group update my-group --payload @./payload.json --instance-id xxx`),
+		),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.Background()
+			model, err := parseInput(params.Printer, cmd, args)
+			if err != nil {
+				return err
+			}
+
+			// Configure API client
+			apiClient, err := client.ConfigureClient(params.Printer, params.CliVersion)
+			if err != nil {
+				return err
+			}
+
+			instanceLabel, err := observabilityUtils.GetInstanceName(ctx, apiClient, model.InstanceId, model.ProjectId)
+			if err != nil {
+				params.Printer.Debug(print.ErrorLevel, "get instance name: %v", err)
+				instanceLabel = model.InstanceId
+			}
+
+			if !model.AssumeYes {
+				prompt := fmt.Sprintf("Are you sure you want to update alert group %q on Observability instance %q?", model.GroupName, instanceLabel)
+				err = params.Printer.PromptForConfirmation(prompt)
+				if err != nil {
+					return err
+				}
+			}
+
+			// Call API
+			req := buildRequest(ctx, model, apiClient)
+			_, err = req.Execute()
+			if err != nil {
+				return fmt.Errorf("update alert group: %w", err)
+			}
+
+			// The API has no status to wait on, so async mode is default
+			params.Printer.Info("Updated alert group with name %q for Observability instance %q\n", model.GroupName, instanceLabel)
+			return nil
+		},
+	}
+	configureFlags(cmd)
+	return cmd
+}
+
+func configureFlags(cmd *cobra.Command) {
+	cmd.Flags().Var(flags.ReadFromFileFlag(), payloadFlag, `Request payload (JSON or YAML). Can be a string or a file path, if prefixed with "@". Example: @./payload.yaml`)
+	cmd.Flags().Var(flags.UUIDFlag(), instanceIdFlag, "Instance ID")
+
+	err := flags.MarkFlagsRequired(cmd, instanceIdFlag, payloadFlag)
+	cobra.CheckErr(err)
+}
+
+func parseInput(p *print.Printer, cmd *cobra.Command, inputArgs []string) (*inputModel, error) {
+	groupName := inputArgs[0]
+
+	globalFlags := globalflags.Parse(p, cmd)
+	if globalFlags.ProjectId == "" {
+		return nil, &errors.ProjectIdError{}
+	}
+
+	var payload observability.UpdateAlertgroupPayload
+	err := observabilityUtils.ParsePayload(flags.FlagToStringValue(p, cmd, payloadFlag), &payload)
+	if err == nil {
+		err = observabilityUtils.ValidateAlertGroup(payload.Interval, payload.Rules)
+	}
+	if err != nil {
+		return nil, &errors.FlagValidationError{
+			Flag:    payloadFlag,
+			Details: err.Error(),
+		}
+	}
+
+	return &inputModel{
+		GlobalFlagModel: globalFlags,
+		GroupName:       groupName,
+		InstanceId:      flags.FlagToStringValue(p, cmd, instanceIdFlag),
+		Payload:         payload,
+	}, nil
+}
+
+func buildRequest(ctx context.Context, model *inputModel, apiClient *observability.APIClient) observability.ApiUpdateAlertgroupRequest {
+	req := apiClient.UpdateAlertgroup(ctx, model.GroupName, model.InstanceId, model.ProjectId)
+
+	req = req.UpdateAlertgroupPayload(model.Payload)
+	return req
+}
@@ -0,0 +1,201 @@
+package logs
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/stackitcloud/stackit-cli/internal/cmd/params"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/errors"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/examples"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/flags"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/globalflags"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/print"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/services/observability/client"
+	observabilityQuery "github.com/stackitcloud/stackit-cli/internal/pkg/services/observability/query"
+	observabilityUtils "github.com/stackitcloud/stackit-cli/internal/pkg/services/observability/utils"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/utils"
+
+	prometheusModel "github.com/prometheus/common/model"
+	"github.com/spf13/cobra"
+)
+
+const (
+	instanceIdArg = "INSTANCE_ID"
+	queryArg      = "QUERY"
+
+	sinceFlag = "since"
+	timeFlag  = "time"
+	limitFlag = "limit"
+
+	sinceDefault = "1h"
+	limitDefault = 100
+)
+
+type inputModel struct {
+	*globalflags.GlobalFlagModel
+	InstanceId string
+	Query      string
+	Since      time.Duration
+	// End of the queried period, the current time if not set
+	Time                 *time.Time
+	Limit                int64
+	Username             *string
+	TemporaryCredentials bool
+}
+
+func NewCmd(params *params.CmdParams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   fmt.Sprintf("logs %s %s", instanceIdArg, queryArg),
+		Short: "Queries the logs of an Observability instance",
+		Long: fmt.Sprintf("%s\n%s\n%s",
+			"Queries the logs of an Observability instance with a LogQL expression, using its Loki-compatible endpoint.",
+			"The newest log entries of the period given with --since are shown, oldest first. Metric queries, e.g. with rate(), are shown as a table.",
+			fmt.Sprintf("The instance is queried with the credentials given with --%s or, with --%s, with temporary credentials that are deleted afterwards.", observabilityQuery.UsernameFlag, observabilityQuery.TemporaryCredentialsFlag),
+		),
+		Args: cobra.ExactArgs(2),
+		Example: examples.Build(
+			examples.NewExample(
+				`Get the log entries of the last hour with the label "app" set to "my-app" of Observability instance with ID "xxx" with the credentials of user "my-user", prompting for the password`,
+				`$ stackit observability logs xxx '{app="my-app"}' --username my-user`),
+			examples.NewExample(
+				`Get the last 500 log entries of the last day containing "error", with temporary credentials`,
+				`$ stackit observability logs xxx '{app="my-app"} |= "error"' --since 1d --limit 500 --temporary-credentials`),
+			examples.NewExample(
+				`Get the number of log entries per minute of the last hour, in JSON format, reading the password of user "my-user" from the environment`,
+				`$ STACKIT_OBSERVABILITY_PASSWORD=... stackit observability logs xxx 'sum by (app) (count_over_time({app="my-app"}[1m]))' --username my-user --output-format json`),
+		),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.Background()
+			model, err := parseInput(params.Printer, cmd, args)
+			if err != nil {
+				return err
+			}
+
+			// Configure API client
+			apiClient, err := client.ConfigureClient(params.Printer, params.CliVersion)
+			if err != nil {
+				return err
+			}
+
+			instance, err := apiClient.GetInstanceExecute(ctx, model.InstanceId, model.ProjectId)
+			if err != nil {
+				return fmt.Errorf("get Observability instance: %w", err)
+			}
+			instanceLabel := utils.PtrString(instance.Name)
+			if instanceLabel == "" {
+				instanceLabel = model.InstanceId
+			}
+			if instance.Instance == nil {
+				return fmt.Errorf("instance %q has no endpoints", instanceLabel)
+			}
+
+			endpoint, err := observabilityQuery.JoinEndpoint(instance.Instance.LogsUrl, observabilityQuery.LogsRangePath)
+			if err != nil {
+				return fmt.Errorf("query logs of instance %q: %w", instanceLabel, err)
+			}
+
+			credentials, cleanup, err := observabilityQuery.GetCredentials(ctx, params.Printer, apiClient, model.ProjectId, model.InstanceId, instanceLabel, model.Username, model.TemporaryCredentials, model.AssumeYes)
+			if err != nil {
+				return err
+			}
+			defer cleanup()
+
+			result, err := observabilityQuery.NewClient(params.Printer, credentials).Query(ctx, endpoint, buildRequest(model, time.Now()))
+			if err != nil {
+				return fmt.Errorf("query logs of instance %q: %w", instanceLabel, err)
+			}
+
+			return observabilityQuery.OutputResult(params.Printer, model.OutputFormat, false, result)
+		},
+	}
+	configureFlags(cmd)
+	return cmd
+}
+
+func configureFlags(cmd *cobra.Command) {
+	cmd.Flags().String(sinceFlag, sinceDefault, "Period to query up to the end time, e.g. 30m or 7d")
+	cmd.Flags().String(timeFlag, "", "End of the queried period in RFC3339 format, e.g. 2024-01-01T00:00:00Z. Defaults to now")
+	cmd.Flags().Int64(limitFlag, limitDefault, "Maximum number of log entries to show")
+	observabilityQuery.ConfigureFlags(cmd)
+}
+
+func parseInput(p *print.Printer, cmd *cobra.Command, inputArgs []string) (*inputModel, error) {
+	instanceId := inputArgs[0]
+	query := inputArgs[1]
+
+	globalFlags := globalflags.Parse(p, cmd)
+	if globalFlags.ProjectId == "" {
+		return nil, &errors.ProjectIdError{}
+	}
+
+	err := utils.ValidateUUID(instanceId)
+	if err != nil {
+		return nil, &errors.ArgValidationError{
+			Arg:     instanceIdArg,
+			Details: err.Error(),
+		}
+	}
+	err = observabilityUtils.CheckLogQLSyntaxShallow(query)
+	if err != nil {
+		return nil, &errors.ArgValidationError{
+			Arg:     queryArg,
+			Details: err.Error(),
+		}
+	}
+
+	since, err := prometheusModel.ParseDuration(flags.FlagWithDefaultToStringValue(p, cmd, sinceFlag))
+	if err == nil && since == 0 {
+		err = fmt.Errorf("duration must be positive")
+	}
+	if err != nil {
+		return nil, &errors.FlagValidationError{
+			Flag:    sinceFlag,
+			Details: err.Error(),
+		}
+	}
+	endTime, err := flags.FlagToDateTimePointer(p, cmd, timeFlag, time.RFC3339)
+	if err != nil {
+		return nil, &errors.FlagValidationError{
+			Flag:    timeFlag,
+			Details: err.Error(),
+		}
+	}
+	limit := flags.FlagWithDefaultToInt64Value(p, cmd, limitFlag)
+	if limit < 1 {
+		return nil, &errors.FlagValidationError{
+			Flag:    limitFlag,
+			Details: "must be greater than 0",
+		}
+	}
+
+	return &inputModel{
+		GlobalFlagModel:      globalFlags,
+		InstanceId:           instanceId,
+		Query:                query,
+		Since:                time.Duration(since),
+		Time:                 endTime,
+		Limit:                limit,
+		Username:             flags.FlagToStringPointer(p, cmd, observabilityQuery.UsernameFlag),
+		TemporaryCredentials: flags.FlagToBoolValue(p, cmd, observabilityQuery.TemporaryCredentialsFlag),
+	}, nil
+}
+
+// buildRequest returns the parameters of the query, ending at now if no time is given.
+// The newest entries are requested, as entries beyond the limit are dropped.
+func buildRequest(model *inputModel, now time.Time) url.Values {
+	endTime := now
+	if model.Time != nil {
+		endTime = *model.Time
+	}
+
+	params := url.Values{}
+	params.Set("query", model.Query)
+	params.Set("start", endTime.Add(-model.Since).Format(time.RFC3339Nano))
+	params.Set("end", endTime.Format(time.RFC3339Nano))
+	params.Set("limit", strconv.FormatInt(model.Limit, 10))
+	params.Set("direction", "backward")
+	return params
+}
@@ -0,0 +1,311 @@
+package logs
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stackitcloud/stackit-cli/internal/cmd/params"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/globalflags"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/print"
+	observabilityQuery "github.com/stackitcloud/stackit-cli/internal/pkg/services/observability/query"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/utils"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/uuid"
+)
+
+var testProjectId = uuid.NewString()
+var testInstanceId = uuid.NewString()
+var testTime = time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+const testUsername = "my-user"
+
+const testQuery = `{app="my-app"} |= "error" |~ "status=[45].."`
+
+func fixtureArgValues(mods ...func(argValues []string)) []string {
+	argValues := []string{
+		testInstanceId,
+		testQuery,
+	}
+	for _, mod := range mods {
+		mod(argValues)
+	}
+	return argValues
+}
+
+func fixtureFlagValues(mods ...func(flagValues map[string]string)) map[string]string {
+	flagValues := map[string]string{
+		globalflags.ProjectIdFlag:       testProjectId,
+		observabilityQuery.UsernameFlag: testUsername,
+	}
+	for _, mod := range mods {
+		mod(flagValues)
+	}
+	return flagValues
+}
+
+func fixtureInputModel(mods ...func(model *inputModel)) *inputModel {
+	model := &inputModel{
+		GlobalFlagModel: &globalflags.GlobalFlagModel{
+			ProjectId: testProjectId,
+			Verbosity: globalflags.VerbosityDefault,
+		},
+		InstanceId: testInstanceId,
+		Query:      testQuery,
+		Username:   utils.Ptr(testUsername),
+		Since:      time.Hour,
+		Limit:      limitDefault,
+	}
+	for _, mod := range mods {
+		mod(model)
+	}
+	return model
+}
+
+func TestParseInput(t *testing.T) {
+	tests := []struct {
+		description   string
+		argValues     []string
+		flagValues    map[string]string
+		isValid       bool
+		expectedModel *inputModel
+	}{
+		{
+			description:   "base",
+			argValues:     fixtureArgValues(),
+			flagValues:    fixtureFlagValues(),
+			isValid:       true,
+			expectedModel: fixtureInputModel(),
+		},
+		{
+			description: "all flags",
+			argValues:   fixtureArgValues(),
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				flagValues[sinceFlag] = "7d"
+				flagValues[timeFlag] = "2024-01-01T12:00:00Z"
+				flagValues[limitFlag] = "500"
+			}),
+			isValid: true,
+			expectedModel: fixtureInputModel(func(model *inputModel) {
+				model.Since = 7 * 24 * time.Hour
+				model.Time = utils.Ptr(testTime)
+				model.Limit = 500
+			}),
+		},
+		{
+			description: "metric query",
+			argValues: fixtureArgValues(func(argValues []string) {
+				argValues[1] = `sum by (app) (count_over_time({app="my-app"}[1m]))`
+			}),
+			flagValues: fixtureFlagValues(),
+			isValid:    true,
+			expectedModel: fixtureInputModel(func(model *inputModel) {
+				model.Query = `sum by (app) (count_over_time({app="my-app"}[1m]))`
+			}),
+		},
+		{
+			description: "temporary credentials",
+			argValues:   fixtureArgValues(),
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				delete(flagValues, observabilityQuery.UsernameFlag)
+				flagValues[observabilityQuery.TemporaryCredentialsFlag] = "true"
+			}),
+			isValid: true,
+			expectedModel: fixtureInputModel(func(model *inputModel) {
+				model.Username = nil
+				model.TemporaryCredentials = true
+			}),
+		},
+		{
+			description: "username and temporary credentials",
+			argValues:   fixtureArgValues(),
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				flagValues[observabilityQuery.TemporaryCredentialsFlag] = "true"
+			}),
+			isValid: false,
+		},
+		{
+			description: "credentials missing",
+			argValues:   fixtureArgValues(),
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				delete(flagValues, observabilityQuery.UsernameFlag)
+			}),
+			isValid: false,
+		},
+		{
+			description: "no values",
+			argValues:   []string{},
+			flagValues:  map[string]string{},
+			isValid:     false,
+		},
+		{
+			description: "query missing",
+			argValues:   []string{testInstanceId},
+			flagValues:  fixtureFlagValues(),
+			isValid:     false,
+		},
+		{
+			description: "project id missing",
+			argValues:   fixtureArgValues(),
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				delete(flagValues, globalflags.ProjectIdFlag)
+			}),
+			isValid: false,
+		},
+		{
+			description: "instance id invalid",
+			argValues: fixtureArgValues(func(argValues []string) {
+				argValues[0] = "invalid-uuid"
+			}),
+			flagValues: fixtureFlagValues(),
+			isValid:    false,
+		},
+		{
+			description: "query invalid",
+			argValues: fixtureArgValues(func(argValues []string) {
+				argValues[1] = `{app="my-app"`
+			}),
+			flagValues: fixtureFlagValues(),
+			isValid:    false,
+		},
+		{
+			description: "since invalid",
+			argValues:   fixtureArgValues(),
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				flagValues[sinceFlag] = "yesterday"
+			}),
+			isValid: false,
+		},
+		{
+			description: "since zero",
+			argValues:   fixtureArgValues(),
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				flagValues[sinceFlag] = "0s"
+			}),
+			isValid: false,
+		},
+		{
+			description: "time invalid",
+			argValues:   fixtureArgValues(),
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				flagValues[timeFlag] = "2024-01-01"
+			}),
+			isValid: false,
+		},
+		{
+			description: "limit invalid",
+			argValues:   fixtureArgValues(),
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				flagValues[limitFlag] = "0"
+			}),
+			isValid: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			p := print.NewPrinter()
+			cmd := NewCmd(&params.CmdParams{Printer: p})
+			err := globalflags.Configure(cmd.Flags())
+			if err != nil {
+				t.Fatalf("configure global flags: %v", err)
+			}
+
+			for flag, value := range tt.flagValues {
+				err := cmd.Flags().Set(flag, value)
+				if err != nil {
+					if !tt.isValid {
+						return
+					}
+					t.Fatalf("setting flag --%s=%s: %v", flag, value, err)
+				}
+			}
+
+			err = cmd.ValidateArgs(tt.argValues)
+			if err != nil {
+				if !tt.isValid {
+					return
+				}
+				t.Fatalf("error validating args: %v", err)
+			}
+
+			err = cmd.ValidateRequiredFlags()
+			if err != nil {
+				if !tt.isValid {
+					return
+				}
+				t.Fatalf("error validating flags: %v", err)
+			}
+
+			err = cmd.ValidateFlagGroups()
+			if err != nil {
+				if !tt.isValid {
+					return
+				}
+				t.Fatalf("error validating flag groups: %v", err)
+			}
+
+			model, err := parseInput(p, cmd, tt.argValues)
+			if err != nil {
+				if !tt.isValid {
+					return
+				}
+				t.Fatalf("error parsing input: %v", err)
+			}
+
+			if !tt.isValid {
+				t.Fatalf("did not fail on invalid input")
+			}
+			diff := cmp.Diff(model, tt.expectedModel)
+			if diff != "" {
+				t.Fatalf("Data does not match: %s", diff)
+			}
+		})
+	}
+}
+
+func TestBuildRequest(t *testing.T) {
+	tests := []struct {
+		description    string
+		model          *inputModel
+		expectedParams url.Values
+	}{
+		{
+			description: "base",
+			model:       fixtureInputModel(),
+			expectedParams: url.Values{
+				"query":     []string{testQuery},
+				"start":     []string{"2024-01-01T11:00:00Z"},
+				"end":       []string{"2024-01-01T12:00:00Z"},
+				"limit":     []string{"100"},
+				"direction": []string{"backward"},
+			},
+		},
+		{
+			description: "end time given",
+			model: fixtureInputModel(func(model *inputModel) {
+				model.Time = utils.Ptr(testTime.Add(-24 * time.Hour))
+				model.Since = 30 * time.Minute
+				model.Limit = 10
+			}),
+			expectedParams: url.Values{
+				"query":     []string{testQuery},
+				"start":     []string{"2023-12-31T11:30:00Z"},
+				"end":       []string{"2023-12-31T12:00:00Z"},
+				"limit":     []string{"10"},
+				"direction": []string{"backward"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			params := buildRequest(tt.model, testTime)
+			diff := cmp.Diff(params, tt.expectedParams)
+			if diff != "" {
+				t.Fatalf("Data does not match: %s", diff)
+			}
+		})
+	}
+}
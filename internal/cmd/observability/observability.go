@@ -1,10 +1,14 @@
 package observability
 
 import (
+	alertconfig "github.com/stackitcloud/stackit-cli/internal/cmd/observability/alert-config"
+	alertgroup "github.com/stackitcloud/stackit-cli/internal/cmd/observability/alert-group"
 	"github.com/stackitcloud/stackit-cli/internal/cmd/observability/credentials"
 	"github.com/stackitcloud/stackit-cli/internal/cmd/observability/grafana"
 	"github.com/stackitcloud/stackit-cli/internal/cmd/observability/instance"
+	"github.com/stackitcloud/stackit-cli/internal/cmd/observability/logs"
 	"github.com/stackitcloud/stackit-cli/internal/cmd/observability/plans"
+	"github.com/stackitcloud/stackit-cli/internal/cmd/observability/query"
 	scrapeconfig "github.com/stackitcloud/stackit-cli/internal/cmd/observability/scrape-config"
 	"github.com/stackitcloud/stackit-cli/internal/cmd/params"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/args"
@@ -31,4 +35,8 @@ func addSubcommands(cmd *cobra.Command, params *params.CmdParams) {
 	cmd.AddCommand(credentials.NewCmd(params))
 	cmd.AddCommand(scrapeconfig.NewCmd(params))
 	cmd.AddCommand(plans.NewCmd(params))
+	cmd.AddCommand(alertgroup.NewCmd(params))
+	cmd.AddCommand(alertconfig.NewCmd(params))
+	cmd.AddCommand(query.NewCmd(params))
+	cmd.AddCommand(logs.NewCmd(params))
 }
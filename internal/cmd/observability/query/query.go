@@ -0,0 +1,236 @@
+package query
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/stackitcloud/stackit-cli/internal/cmd/params"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/errors"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/examples"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/flags"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/globalflags"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/print"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/services/observability/client"
+	observabilityQuery "github.com/stackitcloud/stackit-cli/internal/pkg/services/observability/query"
+	observabilityUtils "github.com/stackitcloud/stackit-cli/internal/pkg/services/observability/utils"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/utils"
+
+	prometheusModel "github.com/prometheus/common/model"
+	"github.com/spf13/cobra"
+)
+
+const (
+	instanceIdArg = "INSTANCE_ID"
+	queryArg      = "QUERY"
+
+	rangeFlag     = "range"
+	stepFlag      = "step"
+	timeFlag      = "time"
+	sparklineFlag = "sparkline"
+
+	// Number of points of a range query if no step is given
+	defaultRangePoints = 60
+)
+
+type inputModel struct {
+	*globalflags.GlobalFlagModel
+	InstanceId string
+	Query      string
+	Range      *time.Duration
+	Step       *time.Duration
+	// Evaluation time of instant queries and end of range queries, the current time if not set
+	Time                 *time.Time
+	Sparkline            bool
+	Username             *string
+	TemporaryCredentials bool
+}
+
+func NewCmd(params *params.CmdParams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   fmt.Sprintf("query %s %s", instanceIdArg, queryArg),
+		Short: "Queries the metrics of an Observability instance",
+		Long: fmt.Sprintf("%s\n%s\n%s",
+			"Queries the metrics of an Observability instance with a PromQL expression, using its Prometheus-compatible endpoint.",
+			"Without --range, the expression is evaluated at a single point in time. With --range, it is evaluated over the given period up to now (or --time), every --step.",
+			fmt.Sprintf("The instance is queried with the credentials given with --%s or, with --%s, with temporary credentials that are deleted afterwards.", observabilityQuery.UsernameFlag, observabilityQuery.TemporaryCredentialsFlag),
+		),
+		Args: cobra.ExactArgs(2),
+		Example: examples.Build(
+			examples.NewExample(
+				`Get the current value of the "up" metric of Observability instance with ID "xxx" with the credentials of user "my-user", prompting for the password`,
+				"$ stackit observability query xxx 'up' --username my-user"),
+			examples.NewExample(
+				`Get the request rate of the last hour, in 30 second steps, as sparklines, with temporary credentials`,
+				"$ stackit observability query xxx 'sum by (job) (rate(http_requests_total[5m]))' --range 1h --step 30s --sparkline --temporary-credentials"),
+			examples.NewExample(
+				`Query with the credentials of user "my-user", whose password is read from the environment`,
+				"$ STACKIT_OBSERVABILITY_PASSWORD=... stackit observability query xxx 'up' --username my-user --output-format json"),
+		),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.Background()
+			model, err := parseInput(params.Printer, cmd, args)
+			if err != nil {
+				return err
+			}
+
+			// Configure API client
+			apiClient, err := client.ConfigureClient(params.Printer, params.CliVersion)
+			if err != nil {
+				return err
+			}
+
+			instance, err := apiClient.GetInstanceExecute(ctx, model.InstanceId, model.ProjectId)
+			if err != nil {
+				return fmt.Errorf("get Observability instance: %w", err)
+			}
+			instanceLabel := utils.PtrString(instance.Name)
+			if instanceLabel == "" {
+				instanceLabel = model.InstanceId
+			}
+			if instance.Instance == nil {
+				return fmt.Errorf("instance %q has no endpoints", instanceLabel)
+			}
+
+			path, queryParams := buildRequest(model, time.Now())
+			endpoint, err := observabilityQuery.JoinEndpoint(instance.Instance.MetricsUrl, path)
+			if err != nil {
+				return fmt.Errorf("query metrics of instance %q: %w", instanceLabel, err)
+			}
+
+			credentials, cleanup, err := observabilityQuery.GetCredentials(ctx, params.Printer, apiClient, model.ProjectId, model.InstanceId, instanceLabel, model.Username, model.TemporaryCredentials, model.AssumeYes)
+			if err != nil {
+				return err
+			}
+			defer cleanup()
+
+			result, err := observabilityQuery.NewClient(params.Printer, credentials).Query(ctx, endpoint, queryParams)
+			if err != nil {
+				return fmt.Errorf("query metrics of instance %q: %w", instanceLabel, err)
+			}
+
+			return observabilityQuery.OutputResult(params.Printer, model.OutputFormat, model.Sparkline, result)
+		},
+	}
+	configureFlags(cmd)
+	return cmd
+}
+
+func configureFlags(cmd *cobra.Command) {
+	cmd.Flags().String(rangeFlag, "", "Period to query up to the evaluation time, e.g. 1h or 7d. If not specified, the expression is evaluated at a single point in time")
+	cmd.Flags().String(stepFlag, "", fmt.Sprintf("Resolution of a range query, e.g. 30s. Defaults to the range divided by %d", defaultRangePoints))
+	cmd.Flags().String(timeFlag, "", "Evaluation time, or end of the range, in RFC3339 format, e.g. 2024-01-01T00:00:00Z. Defaults to now")
+	cmd.Flags().Bool(sparklineFlag, false, "Show the time series of a range query as sparklines")
+	observabilityQuery.ConfigureFlags(cmd)
+}
+
+func parseInput(p *print.Printer, cmd *cobra.Command, inputArgs []string) (*inputModel, error) {
+	instanceId := inputArgs[0]
+	query := inputArgs[1]
+
+	globalFlags := globalflags.Parse(p, cmd)
+	if globalFlags.ProjectId == "" {
+		return nil, &errors.ProjectIdError{}
+	}
+
+	err := utils.ValidateUUID(instanceId)
+	if err != nil {
+		return nil, &errors.ArgValidationError{
+			Arg:     instanceIdArg,
+			Details: err.Error(),
+		}
+	}
+	err = observabilityUtils.ValidatePromQL(query)
+	if err != nil {
+		return nil, &errors.ArgValidationError{
+			Arg:     queryArg,
+			Details: err.Error(),
+		}
+	}
+
+	queryRange, err := parseDurationFlag(p, cmd, rangeFlag)
+	if err != nil {
+		return nil, err
+	}
+	step, err := parseDurationFlag(p, cmd, stepFlag)
+	if err != nil {
+		return nil, err
+	}
+	evaluationTime, err := flags.FlagToDateTimePointer(p, cmd, timeFlag, time.RFC3339)
+	if err != nil {
+		return nil, &errors.FlagValidationError{
+			Flag:    timeFlag,
+			Details: err.Error(),
+		}
+	}
+	sparkline := flags.FlagToBoolValue(p, cmd, sparklineFlag)
+
+	if queryRange == nil {
+		if step != nil {
+			return nil, &errors.FlagValidationError{
+				Flag:    stepFlag,
+				Details: fmt.Sprintf("can only be set with --%s", rangeFlag),
+			}
+		}
+		if sparkline {
+			return nil, &errors.FlagValidationError{
+				Flag:    sparklineFlag,
+				Details: fmt.Sprintf("can only be set with --%s", rangeFlag),
+			}
+		}
+	} else if step == nil {
+		step = utils.Ptr(max(*queryRange/defaultRangePoints, time.Second))
+	}
+
+	return &inputModel{
+		GlobalFlagModel:      globalFlags,
+		InstanceId:           instanceId,
+		Query:                query,
+		Range:                queryRange,
+		Step:                 step,
+		Time:                 evaluationTime,
+		Sparkline:            sparkline,
+		Username:             flags.FlagToStringPointer(p, cmd, observabilityQuery.UsernameFlag),
+		TemporaryCredentials: flags.FlagToBoolValue(p, cmd, observabilityQuery.TemporaryCredentialsFlag),
+	}, nil
+}
+
+// parseDurationFlag parses a flag holding a positive duration in the Prometheus format, e.g. "30s" or "7d"
+func parseDurationFlag(p *print.Printer, cmd *cobra.Command, flagName string) (*time.Duration, error) {
+	value := flags.FlagToStringPointer(p, cmd, flagName)
+	if value == nil {
+		return nil, nil
+	}
+	duration, err := prometheusModel.ParseDuration(*value)
+	if err == nil && duration == 0 {
+		err = fmt.Errorf("duration must be positive")
+	}
+	if err != nil {
+		return nil, &errors.FlagValidationError{
+			Flag:    flagName,
+			Details: err.Error(),
+		}
+	}
+	return utils.Ptr(time.Duration(duration)), nil
+}
+
+// buildRequest returns the API path and the parameters of the query, evaluated at now if no time is given
+func buildRequest(model *inputModel, now time.Time) (path string, params url.Values) {
+	evaluationTime := now
+	if model.Time != nil {
+		evaluationTime = *model.Time
+	}
+
+	params = url.Values{}
+	params.Set("query", model.Query)
+	if model.Range == nil {
+		params.Set("time", evaluationTime.Format(time.RFC3339Nano))
+		return observabilityQuery.MetricsInstantPath, params
+	}
+	params.Set("start", evaluationTime.Add(-*model.Range).Format(time.RFC3339Nano))
+	params.Set("end", evaluationTime.Format(time.RFC3339Nano))
+	params.Set("step", strconv.FormatFloat(model.Step.Seconds(), 'f', -1, 64))
+	return observabilityQuery.MetricsRangePath, params
+}
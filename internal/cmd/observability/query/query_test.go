@@ -0,0 +1,353 @@
+package query
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stackitcloud/stackit-cli/internal/cmd/params"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/globalflags"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/print"
+	observabilityQuery "github.com/stackitcloud/stackit-cli/internal/pkg/services/observability/query"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/utils"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/uuid"
+)
+
+var testProjectId = uuid.NewString()
+var testInstanceId = uuid.NewString()
+var testTime = time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+const testUsername = "my-user"
+
+const testQuery = `sum by (job) (rate(http_requests_total{code="500"}[5m]))`
+
+func fixtureArgValues(mods ...func(argValues []string)) []string {
+	argValues := []string{
+		testInstanceId,
+		testQuery,
+	}
+	for _, mod := range mods {
+		mod(argValues)
+	}
+	return argValues
+}
+
+func fixtureFlagValues(mods ...func(flagValues map[string]string)) map[string]string {
+	flagValues := map[string]string{
+		globalflags.ProjectIdFlag:       testProjectId,
+		observabilityQuery.UsernameFlag: testUsername,
+	}
+	for _, mod := range mods {
+		mod(flagValues)
+	}
+	return flagValues
+}
+
+func fixtureInputModel(mods ...func(model *inputModel)) *inputModel {
+	model := &inputModel{
+		GlobalFlagModel: &globalflags.GlobalFlagModel{
+			ProjectId: testProjectId,
+			Verbosity: globalflags.VerbosityDefault,
+		},
+		InstanceId: testInstanceId,
+		Query:      testQuery,
+		Username:   utils.Ptr(testUsername),
+	}
+	for _, mod := range mods {
+		mod(model)
+	}
+	return model
+}
+
+func TestParseInput(t *testing.T) {
+	tests := []struct {
+		description   string
+		argValues     []string
+		flagValues    map[string]string
+		isValid       bool
+		expectedModel *inputModel
+	}{
+		{
+			description:   "base",
+			argValues:     fixtureArgValues(),
+			flagValues:    fixtureFlagValues(),
+			isValid:       true,
+			expectedModel: fixtureInputModel(),
+		},
+		{
+			description: "range query",
+			argValues:   fixtureArgValues(),
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				flagValues[rangeFlag] = "1d"
+				flagValues[stepFlag] = "30s"
+				flagValues[timeFlag] = "2024-01-01T12:00:00Z"
+				flagValues[sparklineFlag] = "true"
+			}),
+			isValid: true,
+			expectedModel: fixtureInputModel(func(model *inputModel) {
+				model.Range = utils.Ptr(24 * time.Hour)
+				model.Step = utils.Ptr(30 * time.Second)
+				model.Time = utils.Ptr(testTime)
+				model.Sparkline = true
+			}),
+		},
+		{
+			description: "range query with default step",
+			argValues:   fixtureArgValues(),
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				flagValues[rangeFlag] = "1h"
+			}),
+			isValid: true,
+			expectedModel: fixtureInputModel(func(model *inputModel) {
+				model.Range = utils.Ptr(time.Hour)
+				model.Step = utils.Ptr(time.Minute)
+			}),
+		},
+		{
+			description: "short range query with default step",
+			argValues:   fixtureArgValues(),
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				flagValues[rangeFlag] = "10s"
+			}),
+			isValid: true,
+			expectedModel: fixtureInputModel(func(model *inputModel) {
+				model.Range = utils.Ptr(10 * time.Second)
+				model.Step = utils.Ptr(time.Second)
+			}),
+		},
+		{
+			description: "temporary credentials",
+			argValues:   fixtureArgValues(),
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				delete(flagValues, observabilityQuery.UsernameFlag)
+				flagValues[observabilityQuery.TemporaryCredentialsFlag] = "true"
+			}),
+			isValid: true,
+			expectedModel: fixtureInputModel(func(model *inputModel) {
+				model.Username = nil
+				model.TemporaryCredentials = true
+			}),
+		},
+		{
+			description: "username and temporary credentials",
+			argValues:   fixtureArgValues(),
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				flagValues[observabilityQuery.TemporaryCredentialsFlag] = "true"
+			}),
+			isValid: false,
+		},
+		{
+			description: "credentials missing",
+			argValues:   fixtureArgValues(),
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				delete(flagValues, observabilityQuery.UsernameFlag)
+			}),
+			isValid: false,
+		},
+		{
+			description: "no values",
+			argValues:   []string{},
+			flagValues:  map[string]string{},
+			isValid:     false,
+		},
+		{
+			description: "query missing",
+			argValues:   []string{testInstanceId},
+			flagValues:  fixtureFlagValues(),
+			isValid:     false,
+		},
+		{
+			description: "project id missing",
+			argValues:   fixtureArgValues(),
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				delete(flagValues, globalflags.ProjectIdFlag)
+			}),
+			isValid: false,
+		},
+		{
+			description: "instance id invalid",
+			argValues: fixtureArgValues(func(argValues []string) {
+				argValues[0] = "invalid-uuid"
+			}),
+			flagValues: fixtureFlagValues(),
+			isValid:    false,
+		},
+		{
+			description: "query invalid",
+			argValues: fixtureArgValues(func(argValues []string) {
+				argValues[1] = "rate(http_requests_total[5m]"
+			}),
+			flagValues: fixtureFlagValues(),
+			isValid:    false,
+		},
+		{
+			description: "range invalid",
+			argValues:   fixtureArgValues(),
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				flagValues[rangeFlag] = "one hour"
+			}),
+			isValid: false,
+		},
+		{
+			description: "range zero",
+			argValues:   fixtureArgValues(),
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				flagValues[rangeFlag] = "0s"
+			}),
+			isValid: false,
+		},
+		{
+			description: "step invalid",
+			argValues:   fixtureArgValues(),
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				flagValues[rangeFlag] = "1h"
+				flagValues[stepFlag] = "-1s"
+			}),
+			isValid: false,
+		},
+		{
+			description: "step without range",
+			argValues:   fixtureArgValues(),
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				flagValues[stepFlag] = "30s"
+			}),
+			isValid: false,
+		},
+		{
+			description: "sparkline without range",
+			argValues:   fixtureArgValues(),
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				flagValues[sparklineFlag] = "true"
+			}),
+			isValid: false,
+		},
+		{
+			description: "time invalid",
+			argValues:   fixtureArgValues(),
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				flagValues[timeFlag] = "2024-01-01"
+			}),
+			isValid: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			p := print.NewPrinter()
+			cmd := NewCmd(&params.CmdParams{Printer: p})
+			err := globalflags.Configure(cmd.Flags())
+			if err != nil {
+				t.Fatalf("configure global flags: %v", err)
+			}
+
+			for flag, value := range tt.flagValues {
+				err := cmd.Flags().Set(flag, value)
+				if err != nil {
+					if !tt.isValid {
+						return
+					}
+					t.Fatalf("setting flag --%s=%s: %v", flag, value, err)
+				}
+			}
+
+			err = cmd.ValidateArgs(tt.argValues)
+			if err != nil {
+				if !tt.isValid {
+					return
+				}
+				t.Fatalf("error validating args: %v", err)
+			}
+
+			err = cmd.ValidateRequiredFlags()
+			if err != nil {
+				if !tt.isValid {
+					return
+				}
+				t.Fatalf("error validating flags: %v", err)
+			}
+
+			err = cmd.ValidateFlagGroups()
+			if err != nil {
+				if !tt.isValid {
+					return
+				}
+				t.Fatalf("error validating flag groups: %v", err)
+			}
+
+			model, err := parseInput(p, cmd, tt.argValues)
+			if err != nil {
+				if !tt.isValid {
+					return
+				}
+				t.Fatalf("error parsing input: %v", err)
+			}
+
+			if !tt.isValid {
+				t.Fatalf("did not fail on invalid input")
+			}
+			diff := cmp.Diff(model, tt.expectedModel)
+			if diff != "" {
+				t.Fatalf("Data does not match: %s", diff)
+			}
+		})
+	}
+}
+
+func TestBuildRequest(t *testing.T) {
+	tests := []struct {
+		description    string
+		model          *inputModel
+		expectedPath   string
+		expectedParams url.Values
+	}{
+		{
+			description:  "instant query",
+			model:        fixtureInputModel(),
+			expectedPath: observabilityQuery.MetricsInstantPath,
+			expectedParams: url.Values{
+				"query": []string{testQuery},
+				"time":  []string{"2024-01-01T12:00:00Z"},
+			},
+		},
+		{
+			description: "instant query at given time",
+			model: fixtureInputModel(func(model *inputModel) {
+				model.Time = utils.Ptr(testTime.Add(-time.Hour))
+			}),
+			expectedPath: observabilityQuery.MetricsInstantPath,
+			expectedParams: url.Values{
+				"query": []string{testQuery},
+				"time":  []string{"2024-01-01T11:00:00Z"},
+			},
+		},
+		{
+			description: "range query",
+			model: fixtureInputModel(func(model *inputModel) {
+				model.Range = utils.Ptr(time.Hour)
+				model.Step = utils.Ptr(1500 * time.Millisecond)
+			}),
+			expectedPath: observabilityQuery.MetricsRangePath,
+			expectedParams: url.Values{
+				"query": []string{testQuery},
+				"start": []string{"2024-01-01T11:00:00Z"},
+				"end":   []string{"2024-01-01T12:00:00Z"},
+				"step":  []string{"1.5"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			path, params := buildRequest(tt.model, testTime)
+			if path != tt.expectedPath {
+				t.Fatalf("expected path %q, got %q", tt.expectedPath, path)
+			}
+			diff := cmp.Diff(params, tt.expectedParams)
+			if diff != "" {
+				t.Fatalf("Data does not match: %s", diff)
+			}
+		})
+	}
+}
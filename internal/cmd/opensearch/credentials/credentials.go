@@ -5,6 +5,7 @@ import (
 	"github.com/stackitcloud/stackit-cli/internal/cmd/opensearch/credentials/delete"
 	"github.com/stackitcloud/stackit-cli/internal/cmd/opensearch/credentials/describe"
 	"github.com/stackitcloud/stackit-cli/internal/cmd/opensearch/credentials/list"
+	"github.com/stackitcloud/stackit-cli/internal/cmd/opensearch/credentials/rotate"
 	"github.com/stackitcloud/stackit-cli/internal/cmd/params"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/args"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/utils"
@@ -29,4 +30,5 @@ func addSubcommands(cmd *cobra.Command, params *params.CmdParams) {
 	cmd.AddCommand(delete.NewCmd(params))
 	cmd.AddCommand(describe.NewCmd(params))
 	cmd.AddCommand(list.NewCmd(params))
+	cmd.AddCommand(rotate.NewCmd(params))
 }
@@ -0,0 +1,122 @@
+package list
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/goccy/go-yaml"
+	"github.com/stackitcloud/stackit-cli/internal/cmd/params"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/args"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/examples"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/globalflags"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/plugins"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/print"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/tables"
+
+	"github.com/spf13/cobra"
+)
+
+const (
+	statusActive            = "active"
+	statusShadowedByBuiltIn = "shadowed by built-in command"
+	statusShadowedByPlugin  = "shadowed by plugin earlier in PATH"
+)
+
+type inputModel struct {
+	*globalflags.GlobalFlagModel
+}
+
+type pluginWithStatus struct {
+	Name   string `json:"name"`
+	Path   string `json:"path"`
+	Status string `json:"status"`
+}
+
+func NewCmd(params *params.CmdParams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "Lists all CLI plugins",
+		Long: fmt.Sprintf("%s\n%s",
+			`Lists all CLI plugins, which are executables named "stackit-<name>" found in PATH.`,
+			"Plugins with the same name as a built-in command or as a plugin found earlier in PATH are shown as shadowed, as they can't be run.",
+		),
+		Args: args.NoArgs,
+		Example: examples.Build(
+			examples.NewExample(
+				`List all CLI plugins`,
+				"$ stackit plugin list"),
+			examples.NewExample(
+				`List all CLI plugins in JSON format`,
+				"$ stackit plugin list --output-format json"),
+		),
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			model := parseInput(params.Printer, cmd)
+
+			pluginList := getPluginsWithStatus(cmd.Root(), plugins.List())
+			if len(pluginList) == 0 && model.OutputFormat != print.JSONOutputFormat && model.OutputFormat != print.YAMLOutputFormat {
+				params.Printer.Info("No plugins found\n")
+				return nil
+			}
+
+			return outputResult(params.Printer, model.OutputFormat, pluginList)
+		},
+	}
+	return cmd
+}
+
+func parseInput(p *print.Printer, cmd *cobra.Command) *inputModel {
+	globalFlags := globalflags.Parse(p, cmd)
+
+	return &inputModel{
+		GlobalFlagModel: globalFlags,
+	}
+}
+
+func getPluginsWithStatus(rootCmd *cobra.Command, pluginList []plugins.Plugin) []pluginWithStatus {
+	result := make([]pluginWithStatus, 0, len(pluginList))
+	for _, plugin := range pluginList {
+		status := statusActive
+		if cmd, _, err := rootCmd.Find([]string{plugin.Name}); err == nil && cmd != rootCmd {
+			status = statusShadowedByBuiltIn
+		} else if plugin.Shadowed {
+			status = statusShadowedByPlugin
+		}
+		result = append(result, pluginWithStatus{
+			Name:   plugin.Name,
+			Path:   plugin.Path,
+			Status: status,
+		})
+	}
+	return result
+}
+
+func outputResult(p *print.Printer, outputFormat string, pluginList []pluginWithStatus) error {
+	switch outputFormat {
+	case print.JSONOutputFormat:
+		details, err := json.MarshalIndent(pluginList, "", "  ")
+		if err != nil {
+			return fmt.Errorf("marshal plugin list: %w", err)
+		}
+		p.Outputln(string(details))
+		return nil
+	case print.YAMLOutputFormat:
+		details, err := yaml.MarshalWithOptions(pluginList, yaml.IndentSequence(true), yaml.UseJSONMarshaler())
+		if err != nil {
+			return fmt.Errorf("marshal plugin list: %w", err)
+		}
+		p.Outputln(string(details))
+		return nil
+	default:
+		table := tables.NewTable()
+		table.SetHeader("NAME", "PATH", "STATUS")
+		for _, plugin := range pluginList {
+			table.AddRow(plugin.Name, plugin.Path, plugin.Status)
+			table.AddSeparator()
+		}
+		err := table.Display(p)
+		if err != nil {
+			return fmt.Errorf("render table: %w", err)
+		}
+		return nil
+	}
+}
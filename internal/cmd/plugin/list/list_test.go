@@ -0,0 +1,80 @@
+package list
+
+import (
+	"testing"
+
+	"github.com/stackitcloud/stackit-cli/internal/cmd/params"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/plugins"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/print"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/spf13/cobra"
+)
+
+func TestGetPluginsWithStatus(t *testing.T) {
+	rootCmd := &cobra.Command{Use: "stackit"}
+	rootCmd.AddCommand(&cobra.Command{Use: "dns"})
+
+	pluginList := []plugins.Plugin{
+		{Name: "dns", Path: "/bin/stackit-dns"},
+		{Name: "foo", Path: "/bin/stackit-foo"},
+		{Name: "foo", Path: "/usr/bin/stackit-foo", Shadowed: true},
+	}
+	expected := []pluginWithStatus{
+		{Name: "dns", Path: "/bin/stackit-dns", Status: statusShadowedByBuiltIn},
+		{Name: "foo", Path: "/bin/stackit-foo", Status: statusActive},
+		{Name: "foo", Path: "/usr/bin/stackit-foo", Status: statusShadowedByPlugin},
+	}
+
+	actual := getPluginsWithStatus(rootCmd, pluginList)
+	diff := cmp.Diff(actual, expected)
+	if diff != "" {
+		t.Fatalf("Data does not match: %s", diff)
+	}
+}
+
+func TestOutputResult(t *testing.T) {
+	type args struct {
+		outputFormat string
+		pluginList   []pluginWithStatus
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name:    "empty",
+			args:    args{},
+			wantErr: false,
+		},
+		{
+			name: "plugins",
+			args: args{
+				pluginList: []pluginWithStatus{
+					{Name: "foo", Path: "/bin/stackit-foo", Status: statusActive},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "plugins as yaml",
+			args: args{
+				outputFormat: print.YAMLOutputFormat,
+				pluginList: []pluginWithStatus{
+					{Name: "foo", Path: "/bin/stackit-foo", Status: statusActive},
+				},
+			},
+			wantErr: false,
+		},
+	}
+	p := print.NewPrinter()
+	p.Cmd = NewCmd(&params.CmdParams{Printer: p})
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := outputResult(p, tt.args.outputFormat, tt.args.pluginList); (err != nil) != tt.wantErr {
+				t.Errorf("outputResult() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,32 @@
+package plugin
+
+import (
+	"fmt"
+
+	"github.com/stackitcloud/stackit-cli/internal/cmd/params"
+	"github.com/stackitcloud/stackit-cli/internal/cmd/plugin/list"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/args"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/utils"
+
+	"github.com/spf13/cobra"
+)
+
+func NewCmd(params *params.CmdParams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "plugin",
+		Short: "Provides functionality for CLI plugins",
+		Long: fmt.Sprintf("%s\n%s\n%s",
+			`Provides functionality for CLI plugins, which are executables named "stackit-<name>" found in PATH.`,
+			`Running "stackit <name>" runs the plugin with the remaining arguments. Plugins never override the built-in commands.`,
+			"The active profile, project ID, region and a valid access token are passed to the plugin in the STACKIT_CLI_PROFILE, STACKIT_PROJECT_ID, STACKIT_REGION and STACKIT_ACCESS_TOKEN environment variables.",
+		),
+		Args: args.NoArgs,
+		Run:  utils.CmdHelp,
+	}
+	addSubcommands(cmd, params)
+	return cmd
+}
+
+func addSubcommands(cmd *cobra.Command, params *params.CmdParams) {
+	cmd.AddCommand(list.NewCmd(params))
+}
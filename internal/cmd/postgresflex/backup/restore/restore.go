@@ -0,0 +1,390 @@
+package restore
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/goccy/go-yaml"
+	"github.com/stackitcloud/stackit-cli/internal/cmd/params"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/args"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/errors"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/examples"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/flags"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/globalflags"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/history"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/print"
+	dnsClient "github.com/stackitcloud/stackit-cli/internal/pkg/services/dns/client"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/services/postgresflex/client"
+	postgresflexUtils "github.com/stackitcloud/stackit-cli/internal/pkg/services/postgresflex/utils"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/spinner"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/utils"
+
+	"github.com/spf13/cobra"
+	"github.com/stackitcloud/stackit-sdk-go/services/dns"
+	dnsWait "github.com/stackitcloud/stackit-sdk-go/services/dns/wait"
+	"github.com/stackitcloud/stackit-sdk-go/services/postgresflex"
+	"github.com/stackitcloud/stackit-sdk-go/services/postgresflex/wait"
+)
+
+const (
+	instanceIdFlag     = "instance-id"
+	timestampFlag      = "timestamp"
+	backupIdFlag       = "backup-id"
+	nameFlag           = "name"
+	dnsZoneIdFlag      = "dns-zone-id"
+	dnsRecordSetIdFlag = "dns-record-set-id"
+
+	recoveryDateFormat = "2006-01-02T15:04:05-07:00"
+	// Layout of the recovery timestamp in the generated instance names
+	nameTimestampFormat = "20060102-1504"
+)
+
+type inputModel struct {
+	*globalflags.GlobalFlagModel
+
+	InstanceId     string
+	RecoveryDate   *string
+	BackupId       *string
+	Name           *string
+	DNSZoneId      *string
+	DNSRecordSetId *string
+}
+
+type postgresFlexClient interface {
+	GetBackupExecute(ctx context.Context, projectId, region, instanceId, backupId string) (*postgresflex.GetBackupResponse, error)
+	ListUsersExecute(ctx context.Context, projectId, region, instanceId string) (*postgresflex.ListUsersResponse, error)
+	GetUserExecute(ctx context.Context, projectId, region, instanceId, userId string) (*postgresflex.GetUserResponse, error)
+}
+
+type restoreResult struct {
+	SourceInstanceId string  `json:"sourceInstanceId"`
+	InstanceId       string  `json:"instanceId"`
+	Name             string  `json:"name"`
+	RecoveryDate     string  `json:"recoveryTimestamp"`
+	Host             *string `json:"host,omitempty"`
+	DNSRecordSetId   *string `json:"dnsRecordSetId,omitempty"`
+}
+
+func NewCmd(params *params.CmdParams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "restore",
+		Short: "Restores a PostgreSQL Flex instance to a new instance",
+		Long: fmt.Sprintf("%s\n%s\n%s",
+			"Restores a PostgreSQL Flex instance, as it was at a point in time or at the end of a backup, to a new instance, which is named after the original instance and the recovery timestamp unless a name is given.",
+			"The users and passwords of the original instance are restored as well, so applications can be moved to the new instance by only changing its host.",
+			fmt.Sprintf("To do so, use --%s and --%s to point a DNS record set used by the applications to the host of the new instance once it is ready.", dnsZoneIdFlag, dnsRecordSetIdFlag),
+		),
+		Args: args.NoArgs,
+		Example: examples.Build(
+			examples.NewExample(
+				`Restore a PostgreSQL Flex instance with ID "xxx" as it was at a point in time`,
+				"$ stackit postgresflex backup restore --instance-id xxx --timestamp 2024-04-17T09:28:00+00:00"),
+			examples.NewExample(
+				`Restore a PostgreSQL Flex instance with ID "xxx" from the backup with ID "yyy", to a new instance named "my-instance-restored"`,
+				"$ stackit postgresflex backup restore --instance-id xxx --backup-id yyy --name my-instance-restored"),
+			examples.NewExample(
+				`Restore a PostgreSQL Flex instance with ID "xxx" and point the DNS record set with ID "zzz" of zone with ID "www" to the new instance`,
+				"$ stackit postgresflex backup restore --instance-id xxx --timestamp 2024-04-17T09:28:00+00:00 --dns-zone-id www --dns-record-set-id zzz"),
+		),
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx := context.Background()
+			model, err := parseInput(params.Printer, cmd)
+			if err != nil {
+				return err
+			}
+
+			// Configure API client
+			apiClient, err := client.ConfigureClient(params.Printer, params.CliVersion)
+			if err != nil {
+				return err
+			}
+
+			instanceLabel, err := postgresflexUtils.GetInstanceName(ctx, apiClient, model.ProjectId, model.Region, model.InstanceId)
+			if err != nil {
+				return err
+			}
+
+			if model.BackupId != nil {
+				model.RecoveryDate, err = getBackupRecoveryDate(ctx, apiClient, model)
+				if err != nil {
+					return err
+				}
+			}
+			if model.Name == nil {
+				model.Name, err = generateName(instanceLabel, *model.RecoveryDate)
+				if err != nil {
+					return err
+				}
+			}
+
+			if !model.AssumeYes {
+				prompt := fmt.Sprintf("Are you sure you want to restore instance %q as of %s to new instance %q?", instanceLabel, *model.RecoveryDate, *model.Name)
+				if model.DNSRecordSetId != nil {
+					prompt = fmt.Sprintf("Are you sure you want to restore instance %q as of %s to new instance %q, and point DNS record set %q to it?", instanceLabel, *model.RecoveryDate, *model.Name, *model.DNSRecordSetId)
+				}
+				err = params.Printer.PromptForConfirmation(prompt)
+				if err != nil {
+					return err
+				}
+			}
+
+			// Call API
+			req := buildCloneRequest(ctx, model, apiClient)
+			resp, err := req.Execute()
+			if err != nil {
+				return fmt.Errorf("restore PostgreSQL Flex instance: %w", err)
+			}
+			if resp == nil || resp.InstanceId == nil {
+				return fmt.Errorf("no instance ID returned")
+			}
+			result := &restoreResult{
+				SourceInstanceId: model.InstanceId,
+				InstanceId:       *resp.InstanceId,
+				Name:             *model.Name,
+				RecoveryDate:     *model.RecoveryDate,
+			}
+
+			// The new instance can only be renamed and its host is only known once it is ready
+			if model.Async {
+				params.Printer.Warn("The new instance is not renamed and no DNS record set is updated in async mode\n")
+				return outputResult(params.Printer, model.OutputFormat, model.Async, instanceLabel, result)
+			}
+
+			s := spinner.New(params.Printer)
+			s.Start("Restoring instance")
+			_, err = wait.CreateInstanceWaitHandler(ctx, apiClient, model.ProjectId, model.Region, result.InstanceId).WaitWithContext(ctx)
+			if err != nil {
+				return fmt.Errorf("wait for PostgreSQL Flex instance restore: %w", err)
+			}
+			s.Stop()
+
+			_, err = buildRenameRequest(ctx, model, apiClient, result.InstanceId).Execute()
+			if err != nil {
+				return fmt.Errorf("rename restored PostgreSQL Flex instance: %w", err)
+			}
+			s = spinner.New(params.Printer)
+			s.Start("Renaming instance")
+			_, err = wait.PartialUpdateInstanceWaitHandler(ctx, apiClient, model.ProjectId, model.Region, result.InstanceId).WaitWithContext(ctx)
+			if err != nil {
+				return fmt.Errorf("wait for PostgreSQL Flex instance rename: %w", err)
+			}
+			s.Stop()
+
+			if model.DNSRecordSetId != nil {
+				host, err := getInstanceHost(ctx, apiClient, model.ProjectId, model.Region, result.InstanceId)
+				if err != nil {
+					return err
+				}
+				result.Host = &host
+				result.DNSRecordSetId = model.DNSRecordSetId
+
+				err = updateDNSRecordSet(ctx, params, model, host)
+				if err != nil {
+					return err
+				}
+			}
+
+			return outputResult(params.Printer, model.OutputFormat, model.Async, instanceLabel, result)
+		},
+	}
+	configureFlags(cmd)
+	return cmd
+}
+
+func configureFlags(cmd *cobra.Command) {
+	cmd.Flags().Var(flags.UUIDFlag(), instanceIdFlag, "ID of the instance to restore")
+	cmd.Flags().String(timestampFlag, "", "Point in time to restore the instance to, in a date-time with the layout format YYYY-MM-DDTHH:mm:ss±HH:mm, e.g. 2006-01-02T15:04:05-07:00")
+	cmd.Flags().String(backupIdFlag, "", "ID of the backup to restore the instance from")
+	cmd.Flags().String(nameFlag, "", `Name of the new instance. If not specified, it is named "<instance name>-restore-<YYYYMMDD-hhmm of the recovery timestamp>"`)
+	cmd.Flags().Var(flags.UUIDFlag(), dnsZoneIdFlag, "ID of the DNS zone of the record set to point to the new instance")
+	cmd.Flags().Var(flags.UUIDFlag(), dnsRecordSetIdFlag, "ID of a CNAME record set to point to the host of the new instance once it is ready")
+
+	cmd.MarkFlagsMutuallyExclusive(timestampFlag, backupIdFlag)
+	cmd.MarkFlagsOneRequired(timestampFlag, backupIdFlag)
+	cmd.MarkFlagsRequiredTogether(dnsZoneIdFlag, dnsRecordSetIdFlag)
+	err := flags.MarkFlagsRequired(cmd, instanceIdFlag)
+	cobra.CheckErr(err)
+}
+
+func parseInput(p *print.Printer, cmd *cobra.Command) (*inputModel, error) {
+	globalFlags := globalflags.Parse(p, cmd)
+	if globalFlags.ProjectId == "" {
+		return nil, &errors.ProjectIdError{}
+	}
+
+	var recoveryDate *string
+	recoveryTimestamp, err := flags.FlagToDateTimePointer(p, cmd, timestampFlag, recoveryDateFormat)
+	if err != nil {
+		return nil, &errors.FlagValidationError{
+			Flag:    timestampFlag,
+			Details: err.Error(),
+		}
+	}
+	if recoveryTimestamp != nil {
+		recoveryDate = utils.Ptr(recoveryTimestamp.Format(recoveryDateFormat))
+	}
+
+	model := inputModel{
+		GlobalFlagModel: globalFlags,
+		InstanceId:      flags.FlagToStringValue(p, cmd, instanceIdFlag),
+		RecoveryDate:    recoveryDate,
+		BackupId:        flags.FlagToStringPointer(p, cmd, backupIdFlag),
+		Name:            flags.FlagToStringPointer(p, cmd, nameFlag),
+		DNSZoneId:       flags.FlagToStringPointer(p, cmd, dnsZoneIdFlag),
+		DNSRecordSetId:  flags.FlagToStringPointer(p, cmd, dnsRecordSetIdFlag),
+	}
+
+	if p.IsVerbosityDebug() {
+		modelStr, err := print.BuildDebugStrFromInputModel(model)
+		if err != nil {
+			p.Debug(print.ErrorLevel, "convert model to string for debugging: %v", err)
+		} else {
+			p.Debug(print.DebugLevel, "parsed input values: %s", modelStr)
+		}
+	}
+
+	return &model, nil
+}
+
+// getBackupRecoveryDate returns the recovery timestamp of the backup, which is its end time
+func getBackupRecoveryDate(ctx context.Context, apiClient postgresFlexClient, model *inputModel) (*string, error) {
+	resp, err := apiClient.GetBackupExecute(ctx, model.ProjectId, model.Region, model.InstanceId, *model.BackupId)
+	if err != nil {
+		return nil, fmt.Errorf("get PostgreSQL Flex backup: %w", err)
+	}
+	if resp.Item == nil || resp.Item.EndTime == nil || *resp.Item.EndTime == "" {
+		return nil, fmt.Errorf("backup %q has not finished", *model.BackupId)
+	}
+	endTime, err := time.Parse(time.RFC3339, *resp.Item.EndTime)
+	if err != nil {
+		return nil, fmt.Errorf("parse end time of backup: %w", err)
+	}
+	return utils.Ptr(endTime.Format(recoveryDateFormat)), nil
+}
+
+// generateName returns the name of the new instance, after the original instance and the recovery timestamp
+func generateName(instanceName, recoveryDate string) (*string, error) {
+	recoveryTimestamp, err := time.Parse(recoveryDateFormat, recoveryDate)
+	if err != nil {
+		return nil, fmt.Errorf("parse recovery timestamp: %w", err)
+	}
+	name := fmt.Sprintf("%s-restore-%s", instanceName, recoveryTimestamp.UTC().Format(nameTimestampFormat))
+	return utils.Ptr(strings.ToLower(name)), nil
+}
+
+func buildCloneRequest(ctx context.Context, model *inputModel, apiClient *postgresflex.APIClient) postgresflex.ApiCloneInstanceRequest {
+	req := apiClient.CloneInstance(ctx, model.ProjectId, model.Region, model.InstanceId)
+	req = req.CloneInstancePayload(postgresflex.CloneInstancePayload{
+		Timestamp: model.RecoveryDate,
+	})
+	return req
+}
+
+func buildRenameRequest(ctx context.Context, model *inputModel, apiClient *postgresflex.APIClient, instanceId string) postgresflex.ApiPartialUpdateInstanceRequest {
+	req := apiClient.PartialUpdateInstance(ctx, model.ProjectId, model.Region, instanceId)
+	req = req.PartialUpdateInstancePayload(postgresflex.PartialUpdateInstancePayload{
+		Name: model.Name,
+	})
+	return req
+}
+
+// getInstanceHost returns the host of the instance, which is only returned with its users
+func getInstanceHost(ctx context.Context, apiClient postgresFlexClient, projectId, region, instanceId string) (string, error) {
+	resp, err := apiClient.ListUsersExecute(ctx, projectId, region, instanceId)
+	if err != nil {
+		return "", fmt.Errorf("get PostgreSQL Flex users: %w", err)
+	}
+	if resp.Items == nil || len(*resp.Items) == 0 {
+		return "", fmt.Errorf("no users found in the restored instance to get its host")
+	}
+	user, err := apiClient.GetUserExecute(ctx, projectId, region, instanceId, utils.PtrString((*resp.Items)[0].Id))
+	if err != nil {
+		return "", fmt.Errorf("get PostgreSQL Flex user: %w", err)
+	}
+	if user.Item == nil || user.Item.Host == nil || *user.Item.Host == "" {
+		return "", fmt.Errorf("no host of the restored instance returned")
+	}
+	return *user.Item.Host, nil
+}
+
+func updateDNSRecordSet(ctx context.Context, params *params.CmdParams, model *inputModel, host string) error {
+	apiClient, err := dnsClient.ConfigureClient(params.Printer, params.CliVersion)
+	if err != nil {
+		return err
+	}
+
+	// Snapshot the record set, so that the update can be undone (see "stackit undo")
+	recordSet, err := apiClient.GetRecordSetExecute(ctx, model.ProjectId, *model.DNSZoneId, *model.DNSRecordSetId)
+	if err != nil {
+		return fmt.Errorf("get DNS record set: %w", err)
+	}
+	if recordSet.Rrset == nil || utils.PtrString(recordSet.Rrset.Type) != "CNAME" {
+		return fmt.Errorf("DNS record set %q is not a CNAME record set", *model.DNSRecordSetId)
+	}
+	history.AddSnapshot(params.Printer, history.DNSRecordSetResourceType, history.UpdateOperation, &history.DNSRecordSetSnapshot{
+		ProjectId: model.ProjectId,
+		ZoneId:    *model.DNSZoneId,
+		RecordSet: recordSet.Rrset,
+	})
+
+	_, err = buildDNSRecordSetRequest(ctx, model, apiClient, host).Execute()
+	if err != nil {
+		return fmt.Errorf("update DNS record set: %w", err)
+	}
+	s := spinner.New(params.Printer)
+	s.Start("Updating DNS record set")
+	_, err = dnsWait.PartialUpdateRecordSetWaitHandler(ctx, apiClient, model.ProjectId, *model.DNSZoneId, *model.DNSRecordSetId).WaitWithContext(ctx)
+	if err != nil {
+		return fmt.Errorf("wait for DNS record set update: %w", err)
+	}
+	s.Stop()
+	return nil
+}
+
+func buildDNSRecordSetRequest(ctx context.Context, model *inputModel, apiClient *dns.APIClient, host string) dns.ApiPartialUpdateRecordSetRequest {
+	req := apiClient.PartialUpdateRecordSet(ctx, model.ProjectId, *model.DNSZoneId, *model.DNSRecordSetId)
+	req = req.PartialUpdateRecordSetPayload(dns.PartialUpdateRecordSetPayload{
+		Records: &[]dns.RecordPayload{
+			// CNAME records point to fully qualified domain names
+			{Content: utils.Ptr(strings.TrimSuffix(host, ".") + ".")},
+		},
+	})
+	return req
+}
+
+func outputResult(p *print.Printer, outputFormat string, async bool, instanceLabel string, result *restoreResult) error {
+	if result == nil {
+		return fmt.Errorf("no result passed")
+	}
+	switch outputFormat {
+	case print.JSONOutputFormat:
+		details, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			return fmt.Errorf("marshal PostgreSQL Flex instance restore: %w", err)
+		}
+		p.Outputln(string(details))
+
+		return nil
+	case print.YAMLOutputFormat:
+		details, err := yaml.MarshalWithOptions(result, yaml.IndentSequence(true), yaml.UseJSONMarshaler())
+		if err != nil {
+			return fmt.Errorf("marshal PostgreSQL Flex instance restore: %w", err)
+		}
+		p.Outputln(string(details))
+
+		return nil
+	default:
+		if async {
+			p.Outputf("Triggered restore of instance %q as of %s. New instance ID: %s\n", instanceLabel, result.RecoveryDate, result.InstanceId)
+			return nil
+		}
+		p.Outputf("Restored instance %q as of %s to new instance %q. New instance ID: %s\n", instanceLabel, result.RecoveryDate, result.Name, result.InstanceId)
+		if result.DNSRecordSetId != nil {
+			p.Outputf("DNS record set %q points to host %s\n", *result.DNSRecordSetId, utils.PtrString(result.Host))
+		}
+		return nil
+	}
+}
@@ -0,0 +1,557 @@
+package restore
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stackitcloud/stackit-cli/internal/cmd/params"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/globalflags"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/print"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/utils"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/uuid"
+	"github.com/stackitcloud/stackit-sdk-go/services/dns"
+	"github.com/stackitcloud/stackit-sdk-go/services/postgresflex"
+)
+
+type testCtxKey struct{}
+
+var testCtx = context.WithValue(context.Background(), testCtxKey{}, "foo")
+var testClient = &postgresflex.APIClient{}
+var testDNSClient = &dns.APIClient{}
+var testProjectId = uuid.NewString()
+var testInstanceId = uuid.NewString()
+var testNewInstanceId = uuid.NewString()
+var testBackupId = "backup-id"
+var testZoneId = uuid.NewString()
+var testRecordSetId = uuid.NewString()
+var testRegion = "eu01"
+var testRecoveryDate = "2024-04-17T09:28:00+00:00"
+
+type postgresFlexClientMocked struct {
+	getBackupFails bool
+	getBackupResp  *postgresflex.GetBackupResponse
+	listUsersFails bool
+	listUsersResp  *postgresflex.ListUsersResponse
+	getUserFails   bool
+	getUserResp    *postgresflex.GetUserResponse
+}
+
+func (m *postgresFlexClientMocked) GetBackupExecute(_ context.Context, _, _, _, _ string) (*postgresflex.GetBackupResponse, error) {
+	if m.getBackupFails {
+		return nil, fmt.Errorf("could not get backup")
+	}
+	return m.getBackupResp, nil
+}
+
+func (m *postgresFlexClientMocked) ListUsersExecute(_ context.Context, _, _, _ string) (*postgresflex.ListUsersResponse, error) {
+	if m.listUsersFails {
+		return nil, fmt.Errorf("could not list users")
+	}
+	return m.listUsersResp, nil
+}
+
+func (m *postgresFlexClientMocked) GetUserExecute(_ context.Context, _, _, _, _ string) (*postgresflex.GetUserResponse, error) {
+	if m.getUserFails {
+		return nil, fmt.Errorf("could not get user")
+	}
+	return m.getUserResp, nil
+}
+
+func fixtureFlagValues(mods ...func(flagValues map[string]string)) map[string]string {
+	flagValues := map[string]string{
+		globalflags.ProjectIdFlag: testProjectId,
+		globalflags.RegionFlag:    testRegion,
+		instanceIdFlag:            testInstanceId,
+		timestampFlag:             testRecoveryDate,
+	}
+	for _, mod := range mods {
+		mod(flagValues)
+	}
+	return flagValues
+}
+
+func fixtureInputModel(mods ...func(model *inputModel)) *inputModel {
+	model := &inputModel{
+		GlobalFlagModel: &globalflags.GlobalFlagModel{
+			ProjectId: testProjectId,
+			Region:    testRegion,
+			Verbosity: globalflags.VerbosityDefault,
+		},
+		InstanceId:   testInstanceId,
+		RecoveryDate: utils.Ptr(testRecoveryDate),
+	}
+	for _, mod := range mods {
+		mod(model)
+	}
+	return model
+}
+
+func TestParseInput(t *testing.T) {
+	tests := []struct {
+		description   string
+		flagValues    map[string]string
+		isValid       bool
+		expectedModel *inputModel
+	}{
+		{
+			description:   "base",
+			flagValues:    fixtureFlagValues(),
+			isValid:       true,
+			expectedModel: fixtureInputModel(),
+		},
+		{
+			description: "backup id",
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				delete(flagValues, timestampFlag)
+				flagValues[backupIdFlag] = testBackupId
+			}),
+			isValid: true,
+			expectedModel: fixtureInputModel(func(model *inputModel) {
+				model.RecoveryDate = nil
+				model.BackupId = utils.Ptr(testBackupId)
+			}),
+		},
+		{
+			description: "name and dns record set",
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				flagValues[nameFlag] = "my-instance"
+				flagValues[dnsZoneIdFlag] = testZoneId
+				flagValues[dnsRecordSetIdFlag] = testRecordSetId
+			}),
+			isValid: true,
+			expectedModel: fixtureInputModel(func(model *inputModel) {
+				model.Name = utils.Ptr("my-instance")
+				model.DNSZoneId = utils.Ptr(testZoneId)
+				model.DNSRecordSetId = utils.Ptr(testRecordSetId)
+			}),
+		},
+		{
+			description: "timestamp in other time zone",
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				flagValues[timestampFlag] = "2024-04-17T11:28:00+02:00"
+			}),
+			isValid: true,
+			expectedModel: fixtureInputModel(func(model *inputModel) {
+				model.RecoveryDate = utils.Ptr("2024-04-17T11:28:00+02:00")
+			}),
+		},
+		{
+			description: "no values",
+			flagValues:  map[string]string{},
+			isValid:     false,
+		},
+		{
+			description: "project id missing",
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				delete(flagValues, globalflags.ProjectIdFlag)
+			}),
+			isValid: false,
+		},
+		{
+			description: "instance id missing",
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				delete(flagValues, instanceIdFlag)
+			}),
+			isValid: false,
+		},
+		{
+			description: "timestamp and backup id missing",
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				delete(flagValues, timestampFlag)
+			}),
+			isValid: false,
+		},
+		{
+			description: "timestamp and backup id",
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				flagValues[backupIdFlag] = testBackupId
+			}),
+			isValid: false,
+		},
+		{
+			description: "timestamp invalid",
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				flagValues[timestampFlag] = "2024-04-17"
+			}),
+			isValid: false,
+		},
+		{
+			description: "dns record set without zone",
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				flagValues[dnsRecordSetIdFlag] = testRecordSetId
+			}),
+			isValid: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			p := print.NewPrinter()
+			cmd := NewCmd(&params.CmdParams{Printer: p})
+			err := globalflags.Configure(cmd.Flags())
+			if err != nil {
+				t.Fatalf("configure global flags: %v", err)
+			}
+
+			for flag, value := range tt.flagValues {
+				err := cmd.Flags().Set(flag, value)
+				if err != nil {
+					if !tt.isValid {
+						return
+					}
+					t.Fatalf("setting flag --%s=%s: %v", flag, value, err)
+				}
+			}
+
+			err = cmd.ValidateRequiredFlags()
+			if err == nil {
+				err = cmd.ValidateFlagGroups()
+			}
+			if err != nil {
+				if !tt.isValid {
+					return
+				}
+				t.Fatalf("error validating flags: %v", err)
+			}
+
+			model, err := parseInput(p, cmd)
+			if err != nil {
+				if !tt.isValid {
+					return
+				}
+				t.Fatalf("error parsing input: %v", err)
+			}
+
+			if !tt.isValid {
+				t.Fatalf("did not fail on invalid input")
+			}
+			diff := cmp.Diff(model, tt.expectedModel)
+			if diff != "" {
+				t.Fatalf("Data does not match: %s", diff)
+			}
+		})
+	}
+}
+
+func TestGetBackupRecoveryDate(t *testing.T) {
+	tests := []struct {
+		description    string
+		getBackupFails bool
+		getBackupResp  *postgresflex.GetBackupResponse
+		isValid        bool
+		expected       string
+	}{
+		{
+			description: "base",
+			getBackupResp: &postgresflex.GetBackupResponse{
+				Item: &postgresflex.Backup{
+					EndTime: utils.Ptr("2024-04-17T09:28:00Z"),
+				},
+			},
+			isValid:  true,
+			expected: testRecoveryDate,
+		},
+		{
+			description: "backup not finished",
+			getBackupResp: &postgresflex.GetBackupResponse{
+				Item: &postgresflex.Backup{},
+			},
+			isValid: false,
+		},
+		{
+			description: "end time invalid",
+			getBackupResp: &postgresflex.GetBackupResponse{
+				Item: &postgresflex.Backup{
+					EndTime: utils.Ptr("yesterday"),
+				},
+			},
+			isValid: false,
+		},
+		{
+			description:    "get backup fails",
+			getBackupFails: true,
+			isValid:        false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			client := &postgresFlexClientMocked{
+				getBackupFails: tt.getBackupFails,
+				getBackupResp:  tt.getBackupResp,
+			}
+			model := fixtureInputModel(func(model *inputModel) {
+				model.RecoveryDate = nil
+				model.BackupId = utils.Ptr(testBackupId)
+			})
+
+			recoveryDate, err := getBackupRecoveryDate(testCtx, client, model)
+			if !tt.isValid {
+				if err == nil {
+					t.Fatalf("did not fail on invalid input")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("failed on valid input: %v", err)
+			}
+			if *recoveryDate != tt.expected {
+				t.Fatalf("expected recovery date %q, got %q", tt.expected, *recoveryDate)
+			}
+		})
+	}
+}
+
+func TestGenerateName(t *testing.T) {
+	tests := []struct {
+		description  string
+		instanceName string
+		recoveryDate string
+		isValid      bool
+		expected     string
+	}{
+		{
+			description:  "base",
+			instanceName: "my-instance",
+			recoveryDate: testRecoveryDate,
+			isValid:      true,
+			expected:     "my-instance-restore-20240417-0928",
+		},
+		{
+			description:  "other time zone and upper case",
+			instanceName: "My-Instance",
+			recoveryDate: "2024-04-17T11:28:00+02:00",
+			isValid:      true,
+			expected:     "my-instance-restore-20240417-0928",
+		},
+		{
+			description:  "recovery date invalid",
+			instanceName: "my-instance",
+			recoveryDate: "2024-04-17",
+			isValid:      false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			name, err := generateName(tt.instanceName, tt.recoveryDate)
+			if !tt.isValid {
+				if err == nil {
+					t.Fatalf("did not fail on invalid input")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("failed on valid input: %v", err)
+			}
+			if *name != tt.expected {
+				t.Fatalf("expected name %q, got %q", tt.expected, *name)
+			}
+		})
+	}
+}
+
+func TestGetInstanceHost(t *testing.T) {
+	fixtureUsers := &postgresflex.ListUsersResponse{
+		Items: &[]postgresflex.ListUsersResponseItem{
+			{Id: utils.Ptr("1"), Username: utils.Ptr("user")},
+		},
+	}
+
+	tests := []struct {
+		description    string
+		listUsersFails bool
+		listUsersResp  *postgresflex.ListUsersResponse
+		getUserFails   bool
+		getUserResp    *postgresflex.GetUserResponse
+		isValid        bool
+		expected       string
+	}{
+		{
+			description:   "base",
+			listUsersResp: fixtureUsers,
+			getUserResp: &postgresflex.GetUserResponse{
+				Item: &postgresflex.UserResponse{Host: utils.Ptr("example.com")},
+			},
+			isValid:  true,
+			expected: "example.com",
+		},
+		{
+			description:   "no users",
+			listUsersResp: &postgresflex.ListUsersResponse{},
+			isValid:       false,
+		},
+		{
+			description:   "no host",
+			listUsersResp: fixtureUsers,
+			getUserResp: &postgresflex.GetUserResponse{
+				Item: &postgresflex.UserResponse{},
+			},
+			isValid: false,
+		},
+		{
+			description:    "list users fails",
+			listUsersFails: true,
+			isValid:        false,
+		},
+		{
+			description:   "get user fails",
+			listUsersResp: fixtureUsers,
+			getUserFails:  true,
+			isValid:       false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			client := &postgresFlexClientMocked{
+				listUsersFails: tt.listUsersFails,
+				listUsersResp:  tt.listUsersResp,
+				getUserFails:   tt.getUserFails,
+				getUserResp:    tt.getUserResp,
+			}
+
+			host, err := getInstanceHost(testCtx, client, testProjectId, testRegion, testNewInstanceId)
+			if !tt.isValid {
+				if err == nil {
+					t.Fatalf("did not fail on invalid input")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("failed on valid input: %v", err)
+			}
+			if host != tt.expected {
+				t.Fatalf("expected host %q, got %q", tt.expected, host)
+			}
+		})
+	}
+}
+
+func TestBuildCloneRequest(t *testing.T) {
+	expectedRequest := testClient.CloneInstance(testCtx, testProjectId, testRegion, testInstanceId).
+		CloneInstancePayload(postgresflex.CloneInstancePayload{
+			Timestamp: utils.Ptr(testRecoveryDate),
+		})
+
+	request := buildCloneRequest(testCtx, fixtureInputModel(), testClient)
+
+	diff := cmp.Diff(request, expectedRequest,
+		cmp.AllowUnexported(expectedRequest),
+		cmpopts.EquateComparable(testCtx),
+	)
+	if diff != "" {
+		t.Fatalf("Data does not match: %s", diff)
+	}
+}
+
+func TestBuildRenameRequest(t *testing.T) {
+	model := fixtureInputModel(func(model *inputModel) {
+		model.Name = utils.Ptr("my-instance")
+	})
+	expectedRequest := testClient.PartialUpdateInstance(testCtx, testProjectId, testRegion, testNewInstanceId).
+		PartialUpdateInstancePayload(postgresflex.PartialUpdateInstancePayload{
+			Name: utils.Ptr("my-instance"),
+		})
+
+	request := buildRenameRequest(testCtx, model, testClient, testNewInstanceId)
+
+	diff := cmp.Diff(request, expectedRequest,
+		cmp.AllowUnexported(expectedRequest),
+		cmpopts.EquateComparable(testCtx),
+	)
+	if diff != "" {
+		t.Fatalf("Data does not match: %s", diff)
+	}
+}
+
+func TestBuildDNSRecordSetRequest(t *testing.T) {
+	tests := []struct {
+		description string
+		host        string
+	}{
+		{
+			description: "base",
+			host:        "example.com",
+		},
+		{
+			description: "fully qualified host",
+			host:        "example.com.",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			model := fixtureInputModel(func(model *inputModel) {
+				model.DNSZoneId = utils.Ptr(testZoneId)
+				model.DNSRecordSetId = utils.Ptr(testRecordSetId)
+			})
+			expectedRequest := testDNSClient.PartialUpdateRecordSet(testCtx, testProjectId, testZoneId, testRecordSetId).
+				PartialUpdateRecordSetPayload(dns.PartialUpdateRecordSetPayload{
+					Records: &[]dns.RecordPayload{
+						{Content: utils.Ptr("example.com.")},
+					},
+				})
+
+			request := buildDNSRecordSetRequest(testCtx, model, testDNSClient, tt.host)
+
+			diff := cmp.Diff(request, expectedRequest,
+				cmp.AllowUnexported(expectedRequest),
+				cmpopts.EquateComparable(testCtx),
+			)
+			if diff != "" {
+				t.Fatalf("Data does not match: %s", diff)
+			}
+		})
+	}
+}
+
+func TestOutputResult(t *testing.T) {
+	tests := []struct {
+		description  string
+		outputFormat string
+		async        bool
+		result       *restoreResult
+		wantErr      bool
+	}{
+		{
+			description: "empty",
+			wantErr:     true,
+		},
+		{
+			description: "base",
+			result:      &restoreResult{},
+		},
+		{
+			description: "async",
+			async:       true,
+			result:      &restoreResult{},
+		},
+		{
+			description: "dns record set",
+			result: &restoreResult{
+				Host:           utils.Ptr("example.com"),
+				DNSRecordSetId: utils.Ptr(testRecordSetId),
+			},
+		},
+		{
+			description:  "json",
+			outputFormat: print.JSONOutputFormat,
+			result:       &restoreResult{},
+		},
+	}
+	p := print.NewPrinter()
+	p.Cmd = NewCmd(&params.CmdParams{Printer: p})
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			if err := outputResult(p, tt.outputFormat, tt.async, "instance", tt.result); (err != nil) != tt.wantErr {
+				t.Errorf("outputResult() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
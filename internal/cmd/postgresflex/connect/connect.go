@@ -0,0 +1,237 @@
+package connect
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/stackitcloud/stackit-cli/internal/cmd/params"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/args"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/connection"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/errors"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/examples"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/flags"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/globalflags"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/print"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/services/postgresflex/client"
+	postgresflexUtils "github.com/stackitcloud/stackit-cli/internal/pkg/services/postgresflex/utils"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/utils"
+
+	"github.com/spf13/cobra"
+	"github.com/stackitcloud/stackit-sdk-go/services/postgresflex"
+)
+
+const (
+	instanceIdArg = "INSTANCE_ID"
+
+	userFlag          = "user"
+	databaseFlag      = "database"
+	resetPasswordFlag = "reset-password"
+)
+
+type inputModel struct {
+	*globalflags.GlobalFlagModel
+	InstanceId    string
+	User          string
+	Database      *string
+	ResetPassword bool
+	Connection    *connection.Options
+}
+
+type postgresFlexClient interface {
+	ListUsersExecute(ctx context.Context, projectId, region, instanceId string) (*postgresflex.ListUsersResponse, error)
+}
+
+func NewCmd(params *params.CmdParams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   fmt.Sprintf("connect %s [-- CLIENT_ARGS...]", instanceIdArg),
+		Short: "Connects to a PostgreSQL Flex instance with the local client",
+		Long: fmt.Sprintf("%s\n%s\n%s",
+			"Connects to a PostgreSQL Flex instance with the local client (psql by default), as the given user. The client prompts for the password of the user.",
+			fmt.Sprintf("The password of a user cannot be retrieved after the user was created. Use --%s to reset it, pass the new password to the client through its environment and include it in the printed connection details. The current password then stops working.", resetPasswordFlag),
+			fmt.Sprintf("Use --%s or --%s to print the connection URI or environment variables instead, e.g. to pass them to other programs.", connection.PrintURIFlag, connection.PrintEnvFlag),
+		),
+		Args: args.SingleArgWithPassthrough(instanceIdArg, utils.ValidateUUID),
+		Example: examples.Build(
+			examples.NewExample(
+				`Connect to a PostgreSQL Flex instance with ID "xxx" as user "my-user" with psql`,
+				"$ stackit postgresflex connect xxx --user my-user"),
+			examples.NewExample(
+				`Connect to database "my-database" of a PostgreSQL Flex instance with ID "xxx" and run a query`,
+				`$ stackit postgresflex connect xxx --user my-user --database my-database -- -c "SELECT 1"`),
+			examples.NewExample(
+				`Write the connection environment variables of a PostgreSQL Flex instance with ID "xxx" to a file`,
+				"$ stackit postgresflex connect xxx --user my-user --print-env > .env"),
+			examples.NewExample(
+				`Reset the password of user "my-user" of a PostgreSQL Flex instance with ID "xxx" and connect with it`,
+				"$ stackit postgresflex connect xxx --user my-user --reset-password"),
+		),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.Background()
+			model, err := parseInput(params.Printer, cmd, args)
+			if err != nil {
+				return err
+			}
+
+			// Configure API client
+			apiClient, err := client.ConfigureClient(params.Printer, params.CliVersion)
+			if err != nil {
+				return err
+			}
+
+			instanceLabel, err := postgresflexUtils.GetInstanceName(ctx, apiClient, model.ProjectId, model.Region, model.InstanceId)
+			if err != nil {
+				params.Printer.Debug(print.ErrorLevel, "get instance name: %v", err)
+				instanceLabel = model.InstanceId
+			}
+
+			userId, userLabel, err := findUser(ctx, apiClient, model)
+			if err != nil {
+				return err
+			}
+
+			var user *postgresflex.User
+			if model.ResetPassword {
+				if !model.AssumeYes {
+					prompt := fmt.Sprintf("Are you sure you want to reset the password of user %q of instance %q to connect? (The current password will stop working)", userLabel, instanceLabel)
+					err = params.Printer.PromptForConfirmation(prompt)
+					if err != nil {
+						return err
+					}
+				}
+
+				// Call API
+				req := buildRequest(ctx, model, apiClient, userId)
+				resp, err := req.Execute()
+				if err != nil {
+					return fmt.Errorf("reset PostgreSQL Flex user password: %w", err)
+				}
+				if resp != nil {
+					user = resp.Item
+				}
+			} else {
+				// Without the password, the client prompts for it and the printed connection details don't include it
+				req := buildGetUserRequest(ctx, model, apiClient, userId)
+				resp, err := req.Execute()
+				if err != nil {
+					return fmt.Errorf("get PostgreSQL Flex user: %w", err)
+				}
+				user = userFromResponse(resp)
+			}
+
+			conn, err := buildConnection(model, user)
+			if err != nil {
+				return err
+			}
+			return connection.Connect(params.Printer, conn, model.Connection)
+		},
+	}
+	configureFlags(cmd)
+	return cmd
+}
+
+func configureFlags(cmd *cobra.Command) {
+	cmd.Flags().String(userFlag, "", "ID or username of the user to connect as")
+	cmd.Flags().String(databaseFlag, "", "Name of the database to connect to. If not specified, the database of the user is used")
+	cmd.Flags().Bool(resetPasswordFlag, false, "If set, resets the password of the user to connect with it. The current password stops working")
+	connection.ConfigureFlags(cmd, connection.PostgreSQL)
+
+	err := flags.MarkFlagsRequired(cmd, userFlag)
+	cobra.CheckErr(err)
+}
+
+func parseInput(p *print.Printer, cmd *cobra.Command, inputArgs []string) (*inputModel, error) {
+	instanceId := inputArgs[0]
+
+	globalFlags := globalflags.Parse(p, cmd)
+	if globalFlags.ProjectId == "" {
+		return nil, &errors.ProjectIdError{}
+	}
+
+	connectionOptions, err := connection.ParseFlags(cmd, inputArgs)
+	if err != nil {
+		return nil, err
+	}
+
+	model := inputModel{
+		GlobalFlagModel: globalFlags,
+		InstanceId:      instanceId,
+		User:            flags.FlagToStringValue(p, cmd, userFlag),
+		Database:        flags.FlagToStringPointer(p, cmd, databaseFlag),
+		ResetPassword:   flags.FlagToBoolValue(p, cmd, resetPasswordFlag),
+		Connection:      connectionOptions,
+	}
+
+	if p.IsVerbosityDebug() {
+		modelStr, err := print.BuildDebugStrFromInputModel(model)
+		if err != nil {
+			p.Debug(print.ErrorLevel, "convert model to string for debugging: %v", err)
+		} else {
+			p.Debug(print.DebugLevel, "parsed input values: %s", modelStr)
+		}
+	}
+
+	return &model, nil
+}
+
+// findUser returns the ID and username of the user with the given ID or username
+func findUser(ctx context.Context, apiClient postgresFlexClient, model *inputModel) (userId, username string, err error) {
+	resp, err := apiClient.ListUsersExecute(ctx, model.ProjectId, model.Region, model.InstanceId)
+	if err != nil {
+		return "", "", fmt.Errorf("get PostgreSQL Flex users: %w", err)
+	}
+	if resp.Items != nil {
+		for _, user := range *resp.Items {
+			if utils.PtrString(user.Id) == model.User || utils.PtrString(user.Username) == model.User {
+				return utils.PtrString(user.Id), utils.PtrString(user.Username), nil
+			}
+		}
+	}
+	return "", "", &errors.FlagValidationError{
+		Flag:    userFlag,
+		Details: fmt.Sprintf("user %q not found, create it with \"stackit postgresflex user create\"", model.User),
+	}
+}
+
+func buildRequest(ctx context.Context, model *inputModel, apiClient *postgresflex.APIClient, userId string) postgresflex.ApiResetUserRequest {
+	req := apiClient.ResetUser(ctx, model.ProjectId, model.Region, model.InstanceId, userId)
+	return req
+}
+
+func buildGetUserRequest(ctx context.Context, model *inputModel, apiClient *postgresflex.APIClient, userId string) postgresflex.ApiGetUserRequest {
+	req := apiClient.GetUser(ctx, model.ProjectId, model.Region, model.InstanceId, userId)
+	return req
+}
+
+// userFromResponse returns the user of the response, without a password
+func userFromResponse(resp *postgresflex.GetUserResponse) *postgresflex.User {
+	if resp == nil || resp.Item == nil {
+		return nil
+	}
+	return &postgresflex.User{
+		Host:     resp.Item.Host,
+		Port:     resp.Item.Port,
+		Username: resp.Item.Username,
+	}
+}
+
+func buildConnection(model *inputModel, user *postgresflex.User) (*connection.Connection, error) {
+	if user == nil {
+		return nil, fmt.Errorf("no user passed")
+	}
+
+	conn := &connection.Connection{
+		Engine:   connection.PostgreSQL,
+		Host:     utils.PtrString(user.Host),
+		Port:     utils.PtrValue(user.Port),
+		Username: utils.PtrString(user.Username),
+		Password: utils.PtrString(user.Password),
+		Database: utils.PtrString(user.Database),
+	}
+	if model.Database != nil {
+		conn.Database = *model.Database
+	}
+	if conn.Host == "" {
+		return nil, fmt.Errorf("no host of the instance returned")
+	}
+	return conn, nil
+}
@@ -2,6 +2,7 @@ package instance
 
 import (
 	"github.com/stackitcloud/stackit-cli/internal/cmd/params"
+	"github.com/stackitcloud/stackit-cli/internal/cmd/postgresflex/instance/acl"
 	"github.com/stackitcloud/stackit-cli/internal/cmd/postgresflex/instance/clone"
 	"github.com/stackitcloud/stackit-cli/internal/cmd/postgresflex/instance/create"
 	"github.com/stackitcloud/stackit-cli/internal/cmd/postgresflex/instance/delete"
@@ -33,4 +34,5 @@ func addSubcommands(cmd *cobra.Command, params *params.CmdParams) {
 	cmd.AddCommand(update.NewCmd(params))
 	cmd.AddCommand(delete.NewCmd(params))
 	cmd.AddCommand(clone.NewCmd(params))
+	cmd.AddCommand(acl.NewCmd(params))
 }
@@ -3,6 +3,7 @@ package postgresflex
 import (
 	"github.com/stackitcloud/stackit-cli/internal/cmd/params"
 	"github.com/stackitcloud/stackit-cli/internal/cmd/postgresflex/backup"
+	"github.com/stackitcloud/stackit-cli/internal/cmd/postgresflex/connect"
 	"github.com/stackitcloud/stackit-cli/internal/cmd/postgresflex/instance"
 	"github.com/stackitcloud/stackit-cli/internal/cmd/postgresflex/options"
 	"github.com/stackitcloud/stackit-cli/internal/cmd/postgresflex/user"
@@ -30,4 +31,5 @@ func addSubcommands(cmd *cobra.Command, params *params.CmdParams) {
 	cmd.AddCommand(user.NewCmd(params))
 	cmd.AddCommand(options.NewCmd(params))
 	cmd.AddCommand(backup.NewCmd(params))
+	cmd.AddCommand(connect.NewCmd(params))
 }
@@ -9,6 +9,7 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/stackitcloud/stackit-cli/internal/cmd/params"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/args"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/connection"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/errors"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/examples"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/flags"
@@ -33,9 +34,10 @@ var (
 type inputModel struct {
 	*globalflags.GlobalFlagModel
 
-	InstanceId string
-	Username   *string
-	Roles      *[]string
+	InstanceId        string
+	Username          *string
+	Roles             *[]string
+	CredentialsFormat *connection.FormatOptions
 }
 
 func NewCmd(params *params.CmdParams) *cobra.Command {
@@ -55,6 +57,9 @@ func NewCmd(params *params.CmdParams) *cobra.Command {
 			examples.NewExample(
 				`Create a PostgreSQL Flex user for instance with ID "xxx" and permission "createdb"`,
 				"$ stackit postgresflex user create --instance-id xxx --username johndoe --role createdb"),
+			examples.NewExample(
+				`Create a PostgreSQL Flex user for instance with ID "xxx" and apply its credentials as a Kubernetes Secret`,
+				"$ stackit postgresflex user create --instance-id xxx --username johndoe --format k8s-secret | kubectl apply -f -"),
 		),
 		Args: args.NoArgs,
 		RunE: func(cmd *cobra.Command, _ []string) error {
@@ -91,6 +96,14 @@ func NewCmd(params *params.CmdParams) *cobra.Command {
 				return fmt.Errorf("create PostgreSQL Flex user: %w", err)
 			}
 
+			if model.CredentialsFormat != nil {
+				conn, err := buildConnection(resp.Item)
+				if err != nil {
+					return err
+				}
+				return connection.PrintCredentials(params.Printer, conn, model.CredentialsFormat, fmt.Sprintf("postgresflex-%s", conn.Username), true)
+			}
+
 			return outputResult(params.Printer, model.OutputFormat, instanceLabel, resp)
 		},
 	}
@@ -105,6 +118,7 @@ func configureFlags(cmd *cobra.Command) {
 	cmd.Flags().Var(flags.UUIDFlag(), instanceIdFlag, "ID of the instance")
 	cmd.Flags().String(usernameFlag, "", "Username of the user")
 	cmd.Flags().Var(flags.EnumSliceFlag(false, rolesDefault, roleOptions...), roleFlag, fmt.Sprintf("Roles of the user, possible values are %q", roleOptions))
+	connection.ConfigureFormatFlags(cmd)
 
 	err := flags.MarkFlagsRequired(cmd, instanceIdFlag, usernameFlag)
 	cobra.CheckErr(err)
@@ -116,11 +130,17 @@ func parseInput(p *print.Printer, cmd *cobra.Command) (*inputModel, error) {
 		return nil, &errors.ProjectIdError{}
 	}
 
+	credentialsFormat, err := connection.ParseFormatFlags(p, cmd)
+	if err != nil {
+		return nil, err
+	}
+
 	model := inputModel{
-		GlobalFlagModel: globalFlags,
-		InstanceId:      flags.FlagToStringValue(p, cmd, instanceIdFlag),
-		Username:        flags.FlagToStringPointer(p, cmd, usernameFlag),
-		Roles:           flags.FlagWithDefaultToStringSlicePointer(p, cmd, roleFlag),
+		GlobalFlagModel:   globalFlags,
+		InstanceId:        flags.FlagToStringValue(p, cmd, instanceIdFlag),
+		Username:          flags.FlagToStringPointer(p, cmd, usernameFlag),
+		Roles:             flags.FlagWithDefaultToStringSlicePointer(p, cmd, roleFlag),
+		CredentialsFormat: credentialsFormat,
 	}
 
 	if p.IsVerbosityDebug() {
@@ -144,6 +164,26 @@ func buildRequest(ctx context.Context, model *inputModel, apiClient *postgresfle
 	return req
 }
 
+// buildConnection returns the connection to the instance as the created user
+func buildConnection(user *postgresflex.User) (*connection.Connection, error) {
+	if user == nil {
+		return nil, fmt.Errorf("no user passed")
+	}
+
+	conn := &connection.Connection{
+		Engine:   connection.PostgreSQL,
+		Host:     utils.PtrString(user.Host),
+		Port:     utils.PtrValue(user.Port),
+		Username: utils.PtrString(user.Username),
+		Password: utils.PtrString(user.Password),
+		Database: utils.PtrString(user.Database),
+	}
+	if conn.Host == "" {
+		return nil, fmt.Errorf("no host of the instance returned")
+	}
+	return conn, nil
+}
+
 func outputResult(p *print.Printer, outputFormat, instanceLabel string, resp *postgresflex.CreateUserResponse) error {
 	if resp == nil {
 		return fmt.Errorf("no response passed")
@@ -9,6 +9,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/spf13/cobra"
 	"github.com/stackitcloud/stackit-cli/internal/cmd/params"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/connection"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/globalflags"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/print"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/utils"
@@ -23,6 +24,9 @@ var testProjectId = uuid.NewString()
 var testInstanceId = uuid.NewString()
 var testRegion = "eu01"
 
+// Engines are compared by identity
+var engineComparer = cmp.Comparer(func(a, b *connection.Engine) bool { return a == b })
+
 func fixtureFlagValues(mods ...func(flagValues map[string]string)) map[string]string {
 	flagValues := map[string]string{
 		globalflags.ProjectIdFlag: testProjectId,
@@ -81,6 +85,27 @@ func TestParseInput(t *testing.T) {
 			isValid:       true,
 			expectedModel: fixtureInputModel(),
 		},
+		{
+			description: "credentials format",
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				flagValues[connection.FormatFlag] = "k8s-secret"
+				flagValues[connection.SecretNameFlag] = "my-app"
+			}),
+			isValid: true,
+			expectedModel: fixtureInputModel(func(model *inputModel) {
+				model.CredentialsFormat = &connection.FormatOptions{
+					Format:     connection.K8sSecretFormat,
+					SecretName: utils.Ptr("my-app"),
+				}
+			}),
+		},
+		{
+			description: "secret name without credentials format",
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				flagValues[connection.SecretNameFlag] = "my-app"
+			}),
+			isValid: false,
+		},
 		{
 			description: "no values",
 			flagValues:  map[string]string{},
@@ -232,6 +257,63 @@ func TestBuildRequest(t *testing.T) {
 	}
 }
 
+func TestBuildConnection(t *testing.T) {
+	tests := []struct {
+		description string
+		user        *postgresflex.User
+		isValid     bool
+		expected    *connection.Connection
+	}{
+		{
+			description: "base",
+			user: &postgresflex.User{
+				Host:     utils.Ptr("example.com"),
+				Port:     utils.Ptr(int64(5432)),
+				Username: utils.Ptr("johndoe"),
+				Password: utils.Ptr("password"),
+				Database: utils.Ptr("default"),
+			},
+			isValid: true,
+			expected: &connection.Connection{
+				Engine:   connection.PostgreSQL,
+				Host:     "example.com",
+				Port:     5432,
+				Username: "johndoe",
+				Password: "password",
+				Database: "default",
+			},
+		},
+		{
+			description: "no host",
+			user:        &postgresflex.User{},
+			isValid:     false,
+		},
+		{
+			description: "no user",
+			isValid:     false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			conn, err := buildConnection(tt.user)
+			if !tt.isValid {
+				if err == nil {
+					t.Fatalf("did not fail on invalid input")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("failed on valid input: %v", err)
+			}
+			diff := cmp.Diff(conn, tt.expected, engineComparer)
+			if diff != "" {
+				t.Fatalf("Data does not match: %s", diff)
+			}
+		})
+	}
+}
+
 func Test_outputResult(t *testing.T) {
 	type args struct {
 		outputFormat  string
@@ -9,6 +9,7 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/stackitcloud/stackit-cli/internal/cmd/params"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/args"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/connection"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/errors"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/examples"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/flags"
@@ -29,8 +30,9 @@ const (
 type inputModel struct {
 	*globalflags.GlobalFlagModel
 
-	InstanceId string
-	UserId     string
+	InstanceId        string
+	UserId            string
+	CredentialsFormat *connection.FormatOptions
 }
 
 func NewCmd(params *params.CmdParams) *cobra.Command {
@@ -49,6 +51,9 @@ func NewCmd(params *params.CmdParams) *cobra.Command {
 			examples.NewExample(
 				`Get details of a PostgreSQL Flex user with ID "xxx" of instance with ID "yyy" in JSON format`,
 				"$ stackit postgresflex user describe xxx --instance-id yyy --output-format json"),
+			examples.NewExample(
+				`Get the connection URI of a PostgreSQL Flex user with ID "xxx" of instance with ID "yyy"`,
+				"$ stackit postgresflex user describe xxx --instance-id yyy --format uri"),
 		),
 		Args: args.SingleArg(userIdArg, nil),
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -71,6 +76,14 @@ func NewCmd(params *params.CmdParams) *cobra.Command {
 				return fmt.Errorf("get MongoDB Flex user: %w", err)
 			}
 
+			if model.CredentialsFormat != nil {
+				conn, err := buildConnection(resp.Item)
+				if err != nil {
+					return err
+				}
+				return connection.PrintCredentials(params.Printer, conn, model.CredentialsFormat, fmt.Sprintf("postgresflex-%s", conn.Username), true)
+			}
+
 			return outputResult(params.Printer, model.OutputFormat, *resp.Item)
 		},
 	}
@@ -81,6 +94,7 @@ func NewCmd(params *params.CmdParams) *cobra.Command {
 
 func configureFlags(cmd *cobra.Command) {
 	cmd.Flags().Var(flags.UUIDFlag(), instanceIdFlag, "ID of the instance")
+	connection.ConfigureFormatFlags(cmd)
 
 	err := flags.MarkFlagsRequired(cmd, instanceIdFlag)
 	cobra.CheckErr(err)
@@ -94,10 +108,16 @@ func parseInput(p *print.Printer, cmd *cobra.Command, inputArgs []string) (*inpu
 		return nil, &errors.ProjectIdError{}
 	}
 
+	credentialsFormat, err := connection.ParseFormatFlags(p, cmd)
+	if err != nil {
+		return nil, err
+	}
+
 	model := inputModel{
-		GlobalFlagModel: globalFlags,
-		InstanceId:      flags.FlagToStringValue(p, cmd, instanceIdFlag),
-		UserId:          userId,
+		GlobalFlagModel:   globalFlags,
+		InstanceId:        flags.FlagToStringValue(p, cmd, instanceIdFlag),
+		UserId:            userId,
+		CredentialsFormat: credentialsFormat,
 	}
 
 	if p.IsVerbosityDebug() {
@@ -117,6 +137,24 @@ func buildRequest(ctx context.Context, model *inputModel, apiClient *postgresfle
 	return req
 }
 
+// buildConnection returns the connection to the instance as the user, without its password
+func buildConnection(user *postgresflex.UserResponse) (*connection.Connection, error) {
+	if user == nil {
+		return nil, fmt.Errorf("no user passed")
+	}
+
+	conn := &connection.Connection{
+		Engine:   connection.PostgreSQL,
+		Host:     utils.PtrString(user.Host),
+		Port:     utils.PtrValue(user.Port),
+		Username: utils.PtrString(user.Username),
+	}
+	if conn.Host == "" {
+		return nil, fmt.Errorf("no host of the instance returned")
+	}
+	return conn, nil
+}
+
 func outputResult(p *print.Printer, outputFormat string, user postgresflex.UserResponse) error {
 	switch outputFormat {
 	case print.JSONOutputFormat:
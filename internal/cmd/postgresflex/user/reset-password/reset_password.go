@@ -9,11 +9,13 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/stackitcloud/stackit-cli/internal/cmd/params"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/args"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/connection"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/errors"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/examples"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/flags"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/globalflags"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/print"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/rotation"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/services/postgresflex/client"
 	postgresflexUtils "github.com/stackitcloud/stackit-cli/internal/pkg/services/postgresflex/utils"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/utils"
@@ -31,20 +33,25 @@ type inputModel struct {
 
 	InstanceId string
 	UserId     string
+	Rotation   *rotation.Options
 }
 
 func NewCmd(params *params.CmdParams) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   fmt.Sprintf("reset-password %s", userIdArg),
 		Short: "Resets the password of a PostgreSQL Flex user",
-		Long: fmt.Sprintf("%s\ns%s",
+		Long: fmt.Sprintf("%s\n%s\n%s",
 			"Resets the password of a PostgreSQL Flex user.",
 			"The new password is visible after and cannot be retrieved later.",
+			fmt.Sprintf("With --%s, --%s or --%s, the connection with the new password is written there instead, e.g. to rotate the password of an application.", rotation.ToFileFlag, rotation.ToSecretsManagerFlag, rotation.ToK8sSecretFlag),
 		),
 		Example: examples.Build(
 			examples.NewExample(
 				`Reset the password of a PostgreSQL Flex user with ID "xxx" of instance with ID "yyy"`,
 				"$ stackit postgresflex user reset-password xxx --instance-id yyy"),
+			examples.NewExample(
+				`Reset the password of a PostgreSQL Flex user with ID "xxx" of instance with ID "yyy" and update the Kubernetes Secret "my-namespace/postgresflex" with the new connection`,
+				"$ stackit postgresflex user reset-password xxx --instance-id yyy --to-k8s-secret my-namespace/postgresflex"),
 		),
 		Args: args.SingleArg(userIdArg, nil),
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -87,6 +94,10 @@ func NewCmd(params *params.CmdParams) *cobra.Command {
 				return fmt.Errorf("reset PostgreSQL Flex user password: %w", err)
 			}
 
+			if len(model.Rotation.Targets) > 0 {
+				return writeTargets(ctx, params.Printer, model, userLabel, instanceLabel, user)
+			}
+
 			return outputResult(params.Printer, model.OutputFormat, userLabel, instanceLabel, user)
 		},
 	}
@@ -98,6 +109,8 @@ func NewCmd(params *params.CmdParams) *cobra.Command {
 func configureFlags(cmd *cobra.Command) {
 	cmd.Flags().Var(flags.UUIDFlag(), instanceIdFlag, "ID of the instance")
 
+	rotation.ConfigureFlags(cmd, false)
+
 	err := flags.MarkFlagsRequired(cmd, instanceIdFlag)
 	cobra.CheckErr(err)
 }
@@ -110,10 +123,16 @@ func parseInput(p *print.Printer, cmd *cobra.Command, inputArgs []string) (*inpu
 		return nil, &errors.ProjectIdError{}
 	}
 
+	rotationOptions, err := rotation.ParseFlags(p, cmd, false)
+	if err != nil {
+		return nil, err
+	}
+
 	model := inputModel{
 		GlobalFlagModel: globalFlags,
 		InstanceId:      flags.FlagToStringValue(p, cmd, instanceIdFlag),
 		UserId:          userId,
+		Rotation:        rotationOptions,
 	}
 
 	if p.IsVerbosityDebug() {
@@ -164,3 +183,44 @@ func outputResult(p *print.Printer, outputFormat, userLabel, instanceLabel strin
 		return nil
 	}
 }
+
+// writeTargets writes the connection with the new password to the rotation targets and outputs the rotation report.
+// If a target cannot be written, the new password is output instead, as it cannot be retrieved later.
+func writeTargets(ctx context.Context, p *print.Printer, model *inputModel, userLabel, instanceLabel string, user *postgresflex.ResetUserResponse) error {
+	report := &rotation.Report{
+		InstanceId: model.InstanceId,
+		UserId:     model.UserId,
+	}
+	conn, err := buildConnection(user.Item)
+	if err == nil {
+		report.Targets, err = rotation.WriteTargets(ctx, p, model.Rotation, conn)
+	}
+	if err != nil {
+		outputErr := outputResult(p, model.OutputFormat, userLabel, instanceLabel, user)
+		if outputErr != nil {
+			p.Debug(print.ErrorLevel, "output new password: %v", outputErr)
+		}
+		return err
+	}
+	return rotation.OutputReport(p, model.OutputFormat, instanceLabel, report)
+}
+
+// buildConnection returns the connection to the instance as the user with the new password
+func buildConnection(user *postgresflex.User) (*connection.Connection, error) {
+	if user == nil {
+		return nil, fmt.Errorf("no user passed")
+	}
+
+	conn := &connection.Connection{
+		Engine:   connection.PostgreSQL,
+		Host:     utils.PtrString(user.Host),
+		Port:     utils.PtrValue(user.Port),
+		Username: utils.PtrString(user.Username),
+		Password: utils.PtrString(user.Password),
+		Database: utils.PtrString(user.Database),
+	}
+	if conn.Host == "" {
+		return nil, fmt.Errorf("no host of the instance returned")
+	}
+	return conn, nil
+}
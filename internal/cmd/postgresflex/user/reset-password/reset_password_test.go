@@ -8,8 +8,11 @@ import (
 	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/google/uuid"
 	"github.com/stackitcloud/stackit-cli/internal/cmd/params"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/connection"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/globalflags"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/print"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/rotation"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/utils"
 	"github.com/stackitcloud/stackit-sdk-go/services/postgresflex"
 )
 
@@ -22,6 +25,18 @@ var testInstanceId = uuid.NewString()
 var testUserId = "12345"
 var testRegion = "eu01"
 
+// Engines are compared by identity
+var engineComparer = cmp.Comparer(func(a, b *connection.Engine) bool { return a == b })
+
+// Targets are compared by their description
+type testTarget string
+
+func (t testTarget) String() string { return string(t) }
+
+func (testTarget) Write(context.Context, *connection.Connection) error { return nil }
+
+var targetComparer = cmp.Comparer(func(a, b rotation.Target) bool { return a.String() == b.String() })
+
 func fixtureArgValues(mods ...func(argValues []string)) []string {
 	argValues := []string{
 		testUserId,
@@ -53,6 +68,9 @@ func fixtureInputModel(mods ...func(model *inputModel)) *inputModel {
 		},
 		InstanceId: testInstanceId,
 		UserId:     testUserId,
+		Rotation: &rotation.Options{
+			Targets: []rotation.Target{},
+		},
 	}
 	for _, mod := range mods {
 		mod(model)
@@ -83,6 +101,29 @@ func TestParseInput(t *testing.T) {
 			isValid:       true,
 			expectedModel: fixtureInputModel(),
 		},
+		{
+			description: "with targets",
+			argValues:   fixtureArgValues(),
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				flagValues[rotation.ToFileFlag] = ".env"
+				flagValues[rotation.ToK8sSecretFlag] = "postgresflex"
+			}),
+			isValid: true,
+			expectedModel: fixtureInputModel(func(model *inputModel) {
+				model.Rotation.Targets = []rotation.Target{
+					testTarget("file .env"),
+					testTarget("Kubernetes Secret postgresflex"),
+				}
+			}),
+		},
+		{
+			description: "kubeconfig without Kubernetes Secret",
+			argValues:   fixtureArgValues(),
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				flagValues[rotation.KubeconfigFlag] = "kubeconfig.yaml"
+			}),
+			isValid: false,
+		},
 		{
 			description: "no values",
 			argValues:   fixtureArgValues(),
@@ -203,7 +244,7 @@ func TestParseInput(t *testing.T) {
 			if !tt.isValid {
 				t.Fatalf("did not fail on invalid input")
 			}
-			diff := cmp.Diff(model, tt.expectedModel)
+			diff := cmp.Diff(model, tt.expectedModel, targetComparer)
 			if diff != "" {
 				t.Fatalf("Data does not match: %s", diff)
 			}
@@ -271,3 +312,60 @@ func Test_outputResult(t *testing.T) {
 		})
 	}
 }
+
+func TestBuildConnection(t *testing.T) {
+	tests := []struct {
+		description string
+		user        *postgresflex.User
+		isValid     bool
+		expected    *connection.Connection
+	}{
+		{
+			description: "base",
+			user: &postgresflex.User{
+				Host:     utils.Ptr("example.com"),
+				Port:     utils.Ptr(int64(5432)),
+				Username: utils.Ptr("johndoe"),
+				Password: utils.Ptr("password"),
+				Database: utils.Ptr("default"),
+			},
+			isValid: true,
+			expected: &connection.Connection{
+				Engine:   connection.PostgreSQL,
+				Host:     "example.com",
+				Port:     5432,
+				Username: "johndoe",
+				Password: "password",
+				Database: "default",
+			},
+		},
+		{
+			description: "no host",
+			user:        &postgresflex.User{},
+			isValid:     false,
+		},
+		{
+			description: "no user",
+			isValid:     false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			conn, err := buildConnection(tt.user)
+			if !tt.isValid {
+				if err == nil {
+					t.Fatalf("did not fail on invalid input")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("failed on valid input: %v", err)
+			}
+			diff := cmp.Diff(conn, tt.expected, engineComparer)
+			if diff != "" {
+				t.Fatalf("Data does not match: %s", diff)
+			}
+		})
+	}
+}
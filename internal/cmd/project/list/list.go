@@ -15,6 +15,7 @@ import (
 	"github.com/stackitcloud/stackit-cli/internal/pkg/examples"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/flags"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/globalflags"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/pagination"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/print"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/services/resourcemanager/client"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/tables"
@@ -27,8 +28,6 @@ const (
 	projectIdLikeFlag     = "project-id-like"
 	memberFlag            = "member"
 	creationTimeAfterFlag = "creation-time-after"
-	limitFlag             = "limit"
-	pageSizeFlag          = "page-size"
 
 	creationTimeAfterFormat = time.RFC3339
 	pageSizeDefault         = 50
@@ -63,6 +62,9 @@ func NewCmd(params *params.CmdParams) *cobra.Command {
 			examples.NewExample(
 				`List all STACKIT projects that a certain user is a member of`,
 				"$ stackit project list --member example@email.com"),
+			examples.NewExample(
+				`Stream all STACKIT projects that are children of a specific parent as JSON lines`,
+				"$ stackit project list --parent-id xxx --all --output-format jsonl"),
 		),
 		RunE: func(cmd *cobra.Command, _ []string) error {
 			ctx := context.Background()
@@ -77,8 +79,16 @@ func NewCmd(params *params.CmdParams) *cobra.Command {
 				return err
 			}
 
+			// Output the projects as the pages arrive
+			if model.OutputFormat == print.JSONLinesOutputFormat {
+				return fetchProjects(ctx, model, apiClient, func(projects []resourcemanager.Project) error {
+					return pagination.OutputJSONLines(params.Printer, projects)
+				})
+			}
+
 			// Fetch projects
-			projects, err := fetchProjects(ctx, model, apiClient)
+			projects := []resourcemanager.Project{}
+			err = fetchProjects(ctx, model, apiClient, pagination.AppendTo(&projects))
 			if err != nil {
 				return err
 			}
@@ -99,8 +109,7 @@ func configureFlags(cmd *cobra.Command) {
 	cmd.Flags().Var(flags.UUIDSliceFlag(), projectIdLikeFlag, "Filter by project identifier. Multiple project IDs can be provided, but they need to belong to the same parent resource")
 	cmd.Flags().String(memberFlag, "", "Filter by member. The list of projects of which the member is part of will be shown")
 	cmd.Flags().String(creationTimeAfterFlag, "", "Filter by creation timestamp, in a date-time with the RFC3339 layout format, e.g. 2023-01-01T00:00:00Z. The list of projects that were created after the given timestamp will be shown")
-	cmd.Flags().Int64(limitFlag, 0, "Maximum number of entries to list")
-	cmd.Flags().Int64(pageSizeFlag, pageSizeDefault, "Number of items fetched in each API call. Does not affect the number of items in the command output")
+	pagination.ConfigureFlags(cmd, pageSizeDefault)
 }
 
 func parseInput(p *print.Printer, cmd *cobra.Command) (*inputModel, error) {
@@ -114,20 +123,9 @@ func parseInput(p *print.Printer, cmd *cobra.Command) (*inputModel, error) {
 		}
 	}
 
-	limit := flags.FlagToInt64Pointer(p, cmd, limitFlag)
-	if limit != nil && *limit < 1 {
-		return nil, &errors.FlagValidationError{
-			Flag:    limitFlag,
-			Details: "must be greater than 0",
-		}
-	}
-
-	pageSize := flags.FlagWithDefaultToInt64Value(p, cmd, pageSizeFlag)
-	if pageSize < 1 {
-		return nil, &errors.FlagValidationError{
-			Flag:    pageSizeFlag,
-			Details: "must be greater than 0",
-		}
+	limit, pageSize, err := pagination.ParseFlags(p, cmd)
+	if err != nil {
+		return nil, err
 	}
 
 	model := inputModel{
@@ -183,16 +181,15 @@ type resourceManagerClient interface {
 	ListProjects(ctx context.Context) resourcemanager.ApiListProjectsRequest
 }
 
-func fetchProjects(ctx context.Context, model *inputModel, apiClient resourceManagerClient) ([]resourcemanager.Project, error) {
+// fetchProjects fetches the pages of projects and passes the projects of each page to handlePage
+func fetchProjects(ctx context.Context, model *inputModel, apiClient resourceManagerClient, handlePage func(projects []resourcemanager.Project) error) error {
 	if model.Limit != nil && *model.Limit < model.PageSize {
 		model.PageSize = *model.Limit
 	}
 
-	offset := 0
-	projects := []resourcemanager.Project{}
-	for {
+	return pagination.Fetch(model.PageSize, model.Limit, func(pageIndex int) ([]resourcemanager.Project, error) {
 		// Call API
-		req, err := buildRequest(ctx, model, apiClient, offset)
+		req, err := buildRequest(ctx, model, apiClient, pageIndex*int(model.PageSize))
 		if err != nil {
 			return nil, fmt.Errorf("build list projects request: %w", err)
 		}
@@ -200,24 +197,11 @@ func fetchProjects(ctx context.Context, model *inputModel, apiClient resourceMan
 		if err != nil {
 			return nil, fmt.Errorf("get projects: %w", err)
 		}
-		respProjects := *resp.Items
-		if len(respProjects) == 0 {
-			break
-		}
-		projects = append(projects, respProjects...)
-		// Stop if no more pages
-		if len(respProjects) < int(model.PageSize) {
-			break
+		if resp.Items == nil {
+			return nil, nil
 		}
-
-		// Stop and truncate if limit is reached
-		if model.Limit != nil && len(projects) >= int(*model.Limit) {
-			projects = projects[:*model.Limit]
-			break
-		}
-		offset += int(model.PageSize)
-	}
-	return projects, nil
+		return *resp.Items, nil
+	}, handlePage)
 }
 
 func outputResult(p *print.Printer, outputFormat string, projects []resourcemanager.Project) error {
@@ -15,6 +15,7 @@ import (
 	"github.com/stackitcloud/stackit-cli/internal/cmd/params"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/auth"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/globalflags"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/pagination"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/print"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/utils"
 	sdkConfig "github.com/stackitcloud/stackit-sdk-go/core/config"
@@ -158,14 +159,14 @@ func TestParseInput(t *testing.T) {
 		{
 			description: "limit invalid",
 			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
-				flagValues[limitFlag] = "invalid"
+				flagValues[pagination.LimitFlag] = "invalid"
 			}),
 			isValid: false,
 		},
 		{
 			description: "limit invalid 2",
 			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
-				flagValues[limitFlag] = "0"
+				flagValues[pagination.LimitFlag] = "0"
 			}),
 			isValid: false,
 		},
@@ -481,7 +482,8 @@ func TestFetchProjects(t *testing.T) {
 				t.Fatalf("Failed to initialize client: %v", err)
 			}
 
-			projects, err := fetchProjects(testCtx, tt.model, client)
+			projects := []resourcemanager.Project{}
+			err = fetchProjects(testCtx, tt.model, client, pagination.AppendTo(&projects))
 			if err != nil {
 				if !tt.apiCallFails {
 					t.Fatalf("did not fail on invalid input")
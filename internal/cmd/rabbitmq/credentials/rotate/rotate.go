@@ -0,0 +1,149 @@
+package rotate
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/stackitcloud/stackit-cli/internal/cmd/params"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/args"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/errors"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/examples"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/flags"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/globalflags"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/print"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/rotation"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/services/rabbitmq/client"
+	rabbitmqUtils "github.com/stackitcloud/stackit-cli/internal/pkg/services/rabbitmq/utils"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/utils"
+
+	"github.com/spf13/cobra"
+)
+
+const (
+	instanceIdArg = "INSTANCE_ID"
+
+	credentialsIdFlag = "credentials-id" //nolint:gosec // linter false positive
+)
+
+type inputModel struct {
+	*globalflags.GlobalFlagModel
+	InstanceId    string
+	CredentialsId string
+	Rotation      *rotation.Options
+}
+
+func NewCmd(params *params.CmdParams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   fmt.Sprintf("rotate %s", instanceIdArg),
+		Short: "Rotates credentials of a RabbitMQ instance",
+		Long: fmt.Sprintf("%s\n%s\n%s",
+			"Rotates credentials of a RabbitMQ instance: creates new credentials, writes them to the given files, Secrets Manager secrets or Kubernetes Secrets, and deletes the old credentials.",
+			fmt.Sprintf("The old credentials are only deleted once the hook given with --%s succeeds or, if no hook is given, once you confirm that the applications use the new credentials.", rotation.HookFlag),
+			"A report of the rotation is printed at the end.",
+		),
+		Args: args.SingleArg(instanceIdArg, utils.ValidateUUID),
+		Example: examples.Build(
+			examples.NewExample(
+				`Rotate the credentials with ID "yyy" of a RabbitMQ instance with ID "xxx", writing the new ones to a .env file`,
+				"$ stackit rabbitmq credentials rotate xxx --credentials-id yyy --to-file .env"),
+			examples.NewExample(
+				`Rotate the credentials with ID "yyy" of a RabbitMQ instance with ID "xxx", updating a Kubernetes Secret and restarting the application before deleting the old credentials`,
+				`$ stackit rabbitmq credentials rotate xxx --credentials-id yyy --to-k8s-secret my-namespace/rabbitmq --hook "kubectl rollout restart deployment/my-app -n my-namespace && kubectl rollout status deployment/my-app -n my-namespace"`),
+			examples.NewExample(
+				`Rotate the credentials with ID "yyy" of a RabbitMQ instance with ID "xxx", writing the new ones to the secret "apps/rabbitmq" of the Secrets Manager instance with ID "zzz"`,
+				"$ stackit rabbitmq credentials rotate xxx --credentials-id yyy --to-secrets-manager zzz/apps/rabbitmq --secrets-manager-user my-user"),
+		),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.Background()
+			model, err := parseInput(params.Printer, cmd, args)
+			if err != nil {
+				return err
+			}
+
+			// Configure API client
+			apiClient, err := client.ConfigureClient(params.Printer, params.CliVersion)
+			if err != nil {
+				return err
+			}
+
+			instanceLabel, err := rabbitmqUtils.GetInstanceName(ctx, apiClient, model.ProjectId, model.InstanceId)
+			if err != nil {
+				params.Printer.Debug(print.ErrorLevel, "get instance name: %v", err)
+				instanceLabel = model.InstanceId
+			}
+
+			credentialsClient := &rabbitmqUtils.CredentialsClient{
+				ApiClient:  apiClient,
+				ProjectId:  model.ProjectId,
+				InstanceId: model.InstanceId,
+			}
+
+			// The old credentials have to exist, so they can be deleted at the end
+			credentialsLabel, err := rotation.GetCredentialsLabel(ctx, credentialsClient, model.CredentialsId)
+			if err != nil {
+				return err
+			}
+
+			if !model.AssumeYes {
+				prompt := fmt.Sprintf("Are you sure you want to rotate credentials %q of instance %q? (New credentials are created and the old ones are deleted)", credentialsLabel, instanceLabel)
+				err = params.Printer.PromptForConfirmation(prompt)
+				if err != nil {
+					return err
+				}
+			}
+
+			// Call API
+			return rotation.RotateCredentials(ctx, params.Printer, credentialsClient, &rotation.CredentialsRotation{
+				InstanceId:       model.InstanceId,
+				InstanceLabel:    instanceLabel,
+				CredentialsId:    model.CredentialsId,
+				CredentialsLabel: credentialsLabel,
+				Options:          model.Rotation,
+				OutputFormat:     model.OutputFormat,
+				AssumeYes:        model.AssumeYes,
+			})
+		},
+	}
+	configureFlags(cmd)
+	return cmd
+}
+
+func configureFlags(cmd *cobra.Command) {
+	cmd.Flags().Var(flags.UUIDFlag(), credentialsIdFlag, "ID of the credentials to rotate")
+	rotation.ConfigureFlags(cmd, true)
+
+	err := flags.MarkFlagsRequired(cmd, credentialsIdFlag)
+	cobra.CheckErr(err)
+}
+
+func parseInput(p *print.Printer, cmd *cobra.Command, inputArgs []string) (*inputModel, error) {
+	instanceId := inputArgs[0]
+
+	globalFlags := globalflags.Parse(p, cmd)
+	if globalFlags.ProjectId == "" {
+		return nil, &errors.ProjectIdError{}
+	}
+
+	rotationOptions, err := rotation.ParseFlags(p, cmd, true)
+	if err != nil {
+		return nil, err
+	}
+
+	model := inputModel{
+		GlobalFlagModel: globalFlags,
+		InstanceId:      instanceId,
+		CredentialsId:   flags.FlagToStringValue(p, cmd, credentialsIdFlag),
+		Rotation:        rotationOptions,
+	}
+
+	if p.IsVerbosityDebug() {
+		modelStr, err := print.BuildDebugStrFromInputModel(model)
+		if err != nil {
+			p.Debug(print.ErrorLevel, "convert model to string for debugging: %v", err)
+		} else {
+			p.Debug(print.DebugLevel, "parsed input values: %s", modelStr)
+		}
+	}
+
+	return &model, nil
+}
@@ -2,6 +2,7 @@ package instance
 
 import (
 	"github.com/stackitcloud/stackit-cli/internal/cmd/params"
+	"github.com/stackitcloud/stackit-cli/internal/cmd/rabbitmq/instance/acl"
 	"github.com/stackitcloud/stackit-cli/internal/cmd/rabbitmq/instance/create"
 	"github.com/stackitcloud/stackit-cli/internal/cmd/rabbitmq/instance/delete"
 	"github.com/stackitcloud/stackit-cli/internal/cmd/rabbitmq/instance/describe"
@@ -31,4 +32,5 @@ func addSubcommands(cmd *cobra.Command, params *params.CmdParams) {
 	cmd.AddCommand(describe.NewCmd(params))
 	cmd.AddCommand(list.NewCmd(params))
 	cmd.AddCommand(update.NewCmd(params))
+	cmd.AddCommand(acl.NewCmd(params))
 }
@@ -2,6 +2,7 @@ package instance
 
 import (
 	"github.com/stackitcloud/stackit-cli/internal/cmd/params"
+	"github.com/stackitcloud/stackit-cli/internal/cmd/redis/instance/acl"
 	"github.com/stackitcloud/stackit-cli/internal/cmd/redis/instance/create"
 	"github.com/stackitcloud/stackit-cli/internal/cmd/redis/instance/delete"
 	"github.com/stackitcloud/stackit-cli/internal/cmd/redis/instance/describe"
@@ -31,4 +32,5 @@ func addSubcommands(cmd *cobra.Command, params *params.CmdParams) {
 	cmd.AddCommand(describe.NewCmd(params))
 	cmd.AddCommand(list.NewCmd(params))
 	cmd.AddCommand(update.NewCmd(params))
+	cmd.AddCommand(acl.NewCmd(params))
 }
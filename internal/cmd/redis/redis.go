@@ -2,6 +2,7 @@ package redis
 
 import (
 	"github.com/stackitcloud/stackit-cli/internal/cmd/params"
+	"github.com/stackitcloud/stackit-cli/internal/cmd/redis/connect"
 	"github.com/stackitcloud/stackit-cli/internal/cmd/redis/credentials"
 	"github.com/stackitcloud/stackit-cli/internal/cmd/redis/instance"
 	"github.com/stackitcloud/stackit-cli/internal/cmd/redis/plans"
@@ -27,4 +28,5 @@ func addSubcommands(cmd *cobra.Command, params *params.CmdParams) {
 	cmd.AddCommand(instance.NewCmd(params))
 	cmd.AddCommand(plans.NewCmd(params))
 	cmd.AddCommand(credentials.NewCmd(params))
+	cmd.AddCommand(connect.NewCmd(params))
 }
@@ -1,6 +1,8 @@
 package cmd
 
 import (
+	"encoding/json"
+	goerrors "errors"
 	"fmt"
 	"os"
 	"strings"
@@ -9,12 +11,16 @@ import (
 	"github.com/stackitcloud/stackit-cli/internal/cmd/params"
 
 	affinityGroups "github.com/stackitcloud/stackit-cli/internal/cmd/affinity-groups"
+	"github.com/stackitcloud/stackit-cli/internal/cmd/alias"
 	"github.com/stackitcloud/stackit-cli/internal/cmd/auth"
+	"github.com/stackitcloud/stackit-cli/internal/cmd/batch"
 	"github.com/stackitcloud/stackit-cli/internal/cmd/beta"
 	configCmd "github.com/stackitcloud/stackit-cli/internal/cmd/config"
 	"github.com/stackitcloud/stackit-cli/internal/cmd/curl"
+	"github.com/stackitcloud/stackit-cli/internal/cmd/db"
 	"github.com/stackitcloud/stackit-cli/internal/cmd/dns"
 	"github.com/stackitcloud/stackit-cli/internal/cmd/git"
+	historyCmd "github.com/stackitcloud/stackit-cli/internal/cmd/history"
 	"github.com/stackitcloud/stackit-cli/internal/cmd/image"
 	keypair "github.com/stackitcloud/stackit-cli/internal/cmd/key-pair"
 	loadbalancer "github.com/stackitcloud/stackit-cli/internal/cmd/load-balancer"
@@ -28,6 +34,7 @@ import (
 	"github.com/stackitcloud/stackit-cli/internal/cmd/observability"
 	"github.com/stackitcloud/stackit-cli/internal/cmd/opensearch"
 	"github.com/stackitcloud/stackit-cli/internal/cmd/organization"
+	"github.com/stackitcloud/stackit-cli/internal/cmd/plugin"
 	"github.com/stackitcloud/stackit-cli/internal/cmd/postgresflex"
 	"github.com/stackitcloud/stackit-cli/internal/cmd/project"
 	publicip "github.com/stackitcloud/stackit-cli/internal/cmd/public-ip"
@@ -39,13 +46,22 @@ import (
 	"github.com/stackitcloud/stackit-cli/internal/cmd/server"
 	serviceaccount "github.com/stackitcloud/stackit-cli/internal/cmd/service-account"
 	"github.com/stackitcloud/stackit-cli/internal/cmd/ske"
+	"github.com/stackitcloud/stackit-cli/internal/cmd/sqlserverflex"
+	"github.com/stackitcloud/stackit-cli/internal/cmd/undo"
 	"github.com/stackitcloud/stackit-cli/internal/cmd/volume"
+	"github.com/stackitcloud/stackit-cli/internal/cmd/wait"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/aliases"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/args"
+	authPkg "github.com/stackitcloud/stackit-cli/internal/pkg/auth"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/config"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/errors"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/flags"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/globalflags"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/history"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/plugins"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/print"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/transport"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/watch"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
@@ -62,14 +78,35 @@ func NewRootCmd(version, date string, p *print.Printer) *cobra.Command {
 		DisableAutoGenTag: true,
 		PersistentPreRunE: func(cmd *cobra.Command, _ []string) error {
 			p.Cmd = cmd
+			flagDefaults, err := globalflags.ApplyDefaults(p, cmd)
+			if err != nil {
+				return fmt.Errorf("apply flag defaults: %w", err)
+			}
 			p.Verbosity = print.Level(globalflags.Parse(p, cmd).Verbosity)
+			// The logger is process-wide, so commands run by the batch command use the one it configured
+			if p.IsVerbosityDebug() && !p.IsLoggerConfigured() {
+				err := p.ConfigureLogger(viper.GetString(config.LogFormatKey), viper.GetString(config.LogFileKey))
+				if err != nil {
+					return fmt.Errorf("configure logger: %w", err)
+				}
+			}
 
 			argsString := print.BuildDebugStrFromSlice(os.Args)
 			p.Debug(print.DebugLevel, "arguments: %s", argsString)
+			if len(flagDefaults) > 0 {
+				p.Debug(print.DebugLevel, "flag defaults: %s", print.BuildDebugStrFromSlice(flagDefaults))
+			}
 
 			configFilePath := viper.ConfigFileUsed()
 			p.Debug(print.DebugLevel, "configuration is persisted and read from: %s", configFilePath)
 
+			if directoryConfigFilePath := config.GetDirectoryConfigFilePath(); directoryConfigFilePath != "" {
+				p.Debug(print.DebugLevel, "directory configuration is read from: %s", directoryConfigFilePath)
+			}
+			if err := config.GetDirectoryConfigError(); err != nil {
+				p.Warn("ignoring directory configuration: %v (set %s=true to disable directory configuration files)\n", err, config.DisableDirectoryConfigEnv)
+			}
+
 			profileSet, activeProfile, configMethod, err := config.GetConfiguredProfile()
 			if err != nil {
 				return fmt.Errorf("get configured profile: %w", err)
@@ -117,9 +154,21 @@ func NewRootCmd(version, date string, p *print.Printer) *cobra.Command {
 	err := configureFlags(cmd)
 	cobra.CheckErr(err)
 
-	addSubcommands(cmd, &params.CmdParams{
+	cmdParams := &params.CmdParams{
 		Printer:    p,
 		CliVersion: version,
+	}
+	addSubcommands(cmd, cmdParams)
+	// Each command of a batch runs in its own command tree, as Cobra keeps the flag values of executed commands
+	cmd.AddCommand(batch.NewCmd(cmdParams, func(p *print.Printer) *cobra.Command {
+		return NewRootCmd(version, date, p)
+	}))
+
+	// List and describe commands can be re-run with --watch until interrupted
+	traverseCommands(cmd, func(c *cobra.Command) {
+		if c.Name() == "list" || c.Name() == "describe" {
+			watch.Configure(p, c)
+		}
 	})
 
 	// Cobra creates the help flag with "help for <command>" as the description
@@ -161,10 +210,12 @@ func configureFlags(cmd *cobra.Command) error {
 }
 
 func addSubcommands(cmd *cobra.Command, params *params.CmdParams) {
+	cmd.AddCommand(alias.NewCmd(params))
 	cmd.AddCommand(auth.NewCmd(params))
 	cmd.AddCommand(configCmd.NewCmd(params))
 	cmd.AddCommand(beta.NewCmd(params))
 	cmd.AddCommand(curl.NewCmd(params))
+	cmd.AddCommand(db.NewCmd(params))
 	cmd.AddCommand(dns.NewCmd(params))
 	cmd.AddCommand(loadbalancer.NewCmd(params))
 	cmd.AddCommand(logme.NewCmd(params))
@@ -174,7 +225,9 @@ func addSubcommands(cmd *cobra.Command, params *params.CmdParams) {
 	cmd.AddCommand(observability.NewCmd(params))
 	cmd.AddCommand(opensearch.NewCmd(params))
 	cmd.AddCommand(organization.NewCmd(params))
+	cmd.AddCommand(plugin.NewCmd(params))
 	cmd.AddCommand(postgresflex.NewCmd(params))
+	cmd.AddCommand(sqlserverflex.NewCmd(params))
 	cmd.AddCommand(project.NewCmd(params))
 	cmd.AddCommand(rabbitmq.NewCmd(params))
 	cmd.AddCommand(redis.NewCmd(params))
@@ -193,6 +246,9 @@ func addSubcommands(cmd *cobra.Command, params *params.CmdParams) {
 	cmd.AddCommand(quota.NewCmd(params))
 	cmd.AddCommand(affinityGroups.NewCmd(params))
 	cmd.AddCommand(git.NewCmd(params))
+	cmd.AddCommand(wait.NewCmd(params))
+	cmd.AddCommand(historyCmd.NewCmd(params))
+	cmd.AddCommand(undo.NewCmd(params))
 }
 
 // traverseCommands calls f for c and all of its children.
@@ -212,24 +268,126 @@ func Execute(version, date string) {
 	p.Cmd = cmd
 	p.Verbosity = print.InfoLevel
 
-	err := cmd.Execute()
+	cmdArgs, err := expandAlias(cmd, os.Args[1:])
 	if err != nil {
-		err := beautifyUnknownAndMissingCommandsError(cmd, err)
+		os.Exit(printError(p, err))
+	}
+	cmd.SetArgs(cmdArgs)
+
+	if pluginPath, ok := findPlugin(cmd, cmdArgs); ok {
+		exitCode, err := runPlugin(p, pluginPath, cmdArgs[1:])
+		if err != nil {
+			p.Error("%s", err.Error())
+		}
+		os.Exit(exitCode)
+	}
+
+	err = cmd.Execute()
+	if historyErr := history.Save(p, p.Cmd, err); historyErr != nil {
+		p.Warn("%v\n", historyErr)
+	}
+	if goerrors.Is(err, transport.ErrDryRun) {
+		p.Info("Dry run: no changes were made. Only the first request that would change resources is shown, as later requests may depend on its response\n")
+		return
+	}
+	if err != nil {
+		err := beautifyUnknownAndMissingCommandsError(cmd, cmdArgs, err)
 		p.Debug(print.ErrorLevel, "execute command: %v", err)
+		os.Exit(printError(p, err))
+	}
+}
+
+// Prints the error to stderr, as a JSON object if the output format is JSON or JSON lines, and returns the respective exit code
+func printError(p *print.Printer, err error) int {
+	outputFormat := viper.GetString(config.OutputFormatKey)
+	if outputFormat != print.JSONOutputFormat && outputFormat != print.JSONLinesOutputFormat {
 		p.Error("%s", err.Error())
-		os.Exit(1)
+		return errors.ExitCode(err)
 	}
+
+	errDetails := errors.NewErrorDetails(err, transport.LastFailedRequestId())
+	var details []byte
+	var marshalErr error
+	if outputFormat == print.JSONLinesOutputFormat {
+		details, marshalErr = json.Marshal(map[string]any{"error": errDetails})
+	} else {
+		details, marshalErr = json.MarshalIndent(map[string]any{"error": errDetails}, "", "  ")
+	}
+	if marshalErr != nil {
+		p.Error("%s", err.Error())
+		return errDetails.ExitCode
+	}
+	p.Cmd.PrintErrln(string(details))
+	return errDetails.ExitCode
+}
+
+// Expands the user-defined alias in the first argument, if any (see "stackit alias set")
+//
+// Aliases never shadow the built-in commands
+func expandAlias(rootCmd *cobra.Command, cmdArgs []string) ([]string, error) {
+	if len(cmdArgs) == 0 {
+		return cmdArgs, nil
+	}
+	if cmd, _, err := rootCmd.Find(cmdArgs[:1]); err == nil && cmd != rootCmd {
+		return cmdArgs, nil
+	}
+	expandedArgs, _, err := aliases.Expand(cmdArgs)
+	if err != nil {
+		return nil, fmt.Errorf("expand alias: %w", err)
+	}
+	return expandedArgs, nil
+}
+
+// Returns the path of the plugin executable to run, if the first argument is not a built-in command
+// but a plugin named "stackit-<first argument>" exists in PATH (see "stackit plugin list")
+func findPlugin(rootCmd *cobra.Command, cmdArgs []string) (string, bool) {
+	if len(cmdArgs) == 0 || strings.HasPrefix(cmdArgs[0], "-") {
+		return "", false
+	}
+	// Commands added by Cobra when executing
+	if cmdArgs[0] == "help" || cmdArgs[0] == "completion" || strings.HasPrefix(cmdArgs[0], "__") {
+		return "", false
+	}
+	if cmd, _, err := rootCmd.Find(cmdArgs[:1]); err == nil && cmd != rootCmd {
+		return "", false
+	}
+	return plugins.Find(cmdArgs[0])
+}
+
+// Runs the plugin with the given arguments, passing the active profile, project ID, region and
+// a valid access token in environment variables. Returns the exit code of the plugin.
+func runPlugin(p *print.Printer, pluginPath string, pluginArgs []string) (int, error) {
+	profile, err := config.GetProfile()
+	if err != nil {
+		return 1, fmt.Errorf("get profile: %w", err)
+	}
+
+	// Plugins that don't call the STACKIT APIs can be used without being authenticated
+	accessToken, err := authPkg.GetValidAccessToken(p)
+	if err != nil {
+		p.Debug(print.WarningLevel, "get access token for plugin: %v", err)
+		accessToken = ""
+	}
+
+	env := plugins.Env(&plugins.Context{
+		Profile:     profile,
+		ProjectId:   viper.GetString(config.ProjectIdKey),
+		Region:      viper.GetString(config.RegionKey),
+		AccessToken: accessToken,
+	})
+	p.Debug(print.DebugLevel, "running plugin %q with arguments: %s", pluginPath, print.BuildDebugStrFromSlice(pluginArgs))
+	return plugins.Run(pluginPath, pluginArgs, env)
 }
 
 // Returns a more user-friendly error if the input error is due to unknown/missing subcommands (issue: https://github.com/spf13/cobra/issues/706)
 //
 // Otherwise, returns the input error unchanged
-func beautifyUnknownAndMissingCommandsError(rootCmd *cobra.Command, cmdErr error) error {
+func beautifyUnknownAndMissingCommandsError(rootCmd *cobra.Command, cmdArgs []string, cmdErr error) error {
 	if !strings.HasPrefix(cmdErr.Error(), "unknown flag") {
 		return cmdErr
 	}
 
-	cmd, unparsedInputs, err := rootCmd.Traverse(os.Args[1:])
+	cmd, unparsedInputs, err := rootCmd.Traverse(cmdArgs)
 	if err != nil {
 		return cmdErr
 	}
@@ -1,11 +1,26 @@
 package cmd
 
 import (
+	"bytes"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync/atomic"
 	"testing"
 
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/uuid"
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/config"
 	pkgErrors "github.com/stackitcloud/stackit-cli/internal/pkg/errors"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/print"
 )
 
 var cmd *cobra.Command
@@ -35,6 +50,7 @@ func TestBeautifyUnknownAndMissingCommandsError(t *testing.T) {
 	tests := []struct {
 		description           string
 		inputError            error
+		inputArgs             []string
 		command               *cobra.Command
 		expectedMsg           string
 		isNotUnknownFlagError bool
@@ -42,6 +58,7 @@ func TestBeautifyUnknownAndMissingCommandsError(t *testing.T) {
 		{
 			description: "root command, extra input is a flag",
 			inputError:  errors.New("unknown flag: --something"),
+			inputArgs:   []string{"--something"},
 			command:     cmd,
 			expectedMsg: pkgErrors.SUBCOMMAND_MISSING,
 		},
@@ -57,7 +74,7 @@ func TestBeautifyUnknownAndMissingCommandsError(t *testing.T) {
 	setupCmd()
 	for _, tt := range tests {
 		t.Run(tt.description, func(t *testing.T) {
-			actualError := beautifyUnknownAndMissingCommandsError(cmd, tt.inputError)
+			actualError := beautifyUnknownAndMissingCommandsError(cmd, tt.inputArgs, tt.inputError)
 
 			if tt.isNotUnknownFlagError {
 				if actualError.Error() != tt.expectedMsg {
@@ -73,3 +90,209 @@ func TestBeautifyUnknownAndMissingCommandsError(t *testing.T) {
 		})
 	}
 }
+
+func TestExpandAlias(t *testing.T) {
+	tests := []struct {
+		description  string
+		aliases      map[string]string
+		inputArgs    []string
+		expectedArgs []string
+		isValid      bool
+	}{
+		{
+			description:  "no args",
+			inputArgs:    []string{},
+			expectedArgs: []string{},
+			isValid:      true,
+		},
+		{
+			description:  "not an alias",
+			inputArgs:    []string{"unknown", "--flag"},
+			expectedArgs: []string{"unknown", "--flag"},
+			isValid:      true,
+		},
+		{
+			description: "alias",
+			aliases: map[string]string{
+				"ops": "service resource operation --flag",
+			},
+			inputArgs:    []string{"ops", "extra"},
+			expectedArgs: []string{"service", "resource", "operation", "--flag", "extra"},
+			isValid:      true,
+		},
+		{
+			description: "alias with placeholders",
+			aliases: map[string]string{
+				"ops": "service resource operation $1 --name=$2",
+			},
+			inputArgs:    []string{"ops", "arg", "name", "extra"},
+			expectedArgs: []string{"service", "resource", "operation", "arg", "--name=name", "extra"},
+			isValid:      true,
+		},
+		{
+			description: "alias with missing placeholder argument",
+			aliases: map[string]string{
+				"ops": "service resource operation $1",
+			},
+			inputArgs: []string{"ops"},
+			isValid:   false,
+		},
+		{
+			description: "alias does not shadow existing command",
+			aliases: map[string]string{
+				"service": "service resource operation",
+			},
+			inputArgs:    []string{"service", "resource"},
+			expectedArgs: []string{"service", "resource"},
+			isValid:      true,
+		},
+	}
+
+	setupCmd()
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			viper.Set(config.AliasesKey, tt.aliases)
+			defer viper.Set(config.AliasesKey, nil)
+
+			actualArgs, err := expandAlias(cmd, tt.inputArgs)
+			if err != nil {
+				if !tt.isValid {
+					return
+				}
+				t.Fatalf("expand alias: %v", err)
+			}
+			if !tt.isValid {
+				t.Fatalf("did not fail on invalid input")
+			}
+			diff := cmp.Diff(actualArgs, tt.expectedArgs)
+			if diff != "" {
+				t.Fatalf("Data does not match: %s", diff)
+			}
+		})
+	}
+}
+
+func TestFindPlugin(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the fake plugins are shell scripts")
+	}
+
+	dir := t.TempDir()
+	for _, name := range []string{"stackit-foo", "stackit-service", "stackit-help"} {
+		err := os.WriteFile(filepath.Join(dir, name), []byte("#!/bin/sh\n"), 0o700) //nolint:gosec // test script must be executable
+		if err != nil {
+			t.Fatalf("Failed to write fake plugin: %v", err)
+		}
+	}
+	t.Setenv("PATH", dir)
+
+	tests := []struct {
+		description  string
+		inputArgs    []string
+		expectedPath string
+		isFound      bool
+	}{
+		{
+			description:  "plugin",
+			inputArgs:    []string{"foo", "--flag"},
+			expectedPath: filepath.Join(dir, "stackit-foo"),
+			isFound:      true,
+		},
+		{
+			description: "no args",
+			inputArgs:   []string{},
+		},
+		{
+			description: "flag",
+			inputArgs:   []string{"--foo"},
+		},
+		{
+			description: "plugin does not shadow existing command",
+			inputArgs:   []string{"service", "resource"},
+		},
+		{
+			description: "plugin does not shadow cobra commands",
+			inputArgs:   []string{"help"},
+		},
+		{
+			description: "plugin not found",
+			inputArgs:   []string{"bar"},
+		},
+	}
+
+	setupCmd()
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			path, found := findPlugin(cmd, tt.inputArgs)
+			if found != tt.isFound {
+				t.Fatalf("expected found to be %t, got %t", tt.isFound, found)
+			}
+			if path != tt.expectedPath {
+				t.Fatalf("expected path to be %q, got %q", tt.expectedPath, path)
+			}
+		})
+	}
+}
+
+func TestBatchParallel(t *testing.T) {
+	const commands = 8
+	projectId := uuid.NewString()
+	var projectRequests atomic.Int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/projects/"+projectId):
+			projectRequests.Add(1)
+			_, _ = fmt.Fprintf(w, `{"projectId":%q,"name":"my-project"}`, projectId)
+		case r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/projects/"+projectId+"/zones"):
+			w.WriteHeader(http.StatusAccepted)
+			_, _ = fmt.Fprintf(w, `{"zone":{"id":%q,"name":"my-zone","dnsName":"example.com"}}`, uuid.NewString())
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	configDir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", configDir)
+	t.Setenv("STACKIT_ACCESS_TOKEN", "token")
+	viper.Reset()
+	defer viper.Reset()
+	config.InitConfig()
+	viper.Set(config.ResourceManagerEndpointKey, server.URL)
+	viper.Set(config.DNSCustomEndpointKey, server.URL)
+	viper.Set(config.LogFileKey, filepath.Join(configDir, "cli.log"))
+	viper.Set(config.ProjectIdKey, projectId)
+
+	batchFile := &strings.Builder{}
+	for i := range commands {
+		fmt.Fprintf(batchFile, "dns zone create --name zone-%d --dns-name zone-%d.example.com\n", i, i)
+	}
+
+	p := print.NewPrinter()
+	rootCmd := NewRootCmd("version", "date", p)
+	output := &bytes.Buffer{}
+	rootCmd.SetOut(output)
+	rootCmd.SetErr(&bytes.Buffer{})
+	rootCmd.SetIn(strings.NewReader(batchFile.String()))
+	rootCmd.SetArgs([]string{"batch", "--parallel", "4", "--assume-yes", "--async", "--verbosity", "debug", "--output-format", "json"})
+	err := rootCmd.Execute()
+	if err != nil {
+		t.Fatalf("run batch: %v\n%s", err, output.String())
+	}
+
+	report := struct {
+		Succeeded int `json:"succeeded"`
+	}{}
+	err = json.Unmarshal(output.Bytes(), &report)
+	if err != nil {
+		t.Fatalf("parse batch report: %v\n%s", err, output.String())
+	}
+	if report.Succeeded != commands {
+		t.Errorf("expected %d succeeded commands, got %d:\n%s", commands, report.Succeeded, output.String())
+	}
+	// The project name is resolved once, before running the commands
+	if got := projectRequests.Load(); got != 1 {
+		t.Errorf("expected the project name to be requested once, got %d requests", got)
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/stackitcloud/stackit-cli/internal/pkg/examples"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/flags"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/globalflags"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/history"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/print"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/services/iaas/client"
 	iaasUtils "github.com/stackitcloud/stackit-cli/internal/pkg/services/iaas/utils"
@@ -64,10 +65,14 @@ func NewCmd(params *params.CmdParams) *cobra.Command {
 				securityGroupLabel = *model.SecurityGroupId
 			}
 
-			securityGroupRuleLabel, err := iaasUtils.GetSecurityGroupRuleName(ctx, apiClient, model.ProjectId, model.SecurityGroupRuleId, *model.SecurityGroupId)
+			// The rule is also snapshotted, so that the deletion can be undone (see "stackit undo")
+			securityGroupRuleLabel := model.SecurityGroupRuleId
+			rule, err := apiClient.GetSecurityGroupRuleExecute(ctx, model.ProjectId, *model.SecurityGroupId, model.SecurityGroupRuleId)
 			if err != nil {
-				params.Printer.Debug(print.ErrorLevel, "get security group rule name: %v", err)
-				securityGroupRuleLabel = model.SecurityGroupRuleId
+				params.Printer.Debug(print.ErrorLevel, "get security group rule: %v", err)
+				rule = nil
+			} else if rule.Ethertype != nil && rule.Direction != nil {
+				securityGroupRuleLabel = *rule.Ethertype + ", " + *rule.Direction
 			}
 
 			if !model.AssumeYes {
@@ -78,6 +83,14 @@ func NewCmd(params *params.CmdParams) *cobra.Command {
 				}
 			}
 
+			if rule != nil {
+				history.AddSnapshot(params.Printer, history.SecurityGroupRuleResourceType, history.DeleteOperation, &history.SecurityGroupRuleSnapshot{
+					ProjectId:       model.ProjectId,
+					SecurityGroupId: *model.SecurityGroupId,
+					Rule:            rule,
+				})
+			}
+
 			// Call API
 			req := buildRequest(ctx, model, apiClient)
 			err = req.Execute()
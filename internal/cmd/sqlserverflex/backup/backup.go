@@ -0,0 +1,32 @@
+package backup
+
+import (
+	"github.com/stackitcloud/stackit-cli/internal/cmd/params"
+	"github.com/stackitcloud/stackit-cli/internal/cmd/sqlserverflex/backup/describe"
+	"github.com/stackitcloud/stackit-cli/internal/cmd/sqlserverflex/backup/list"
+	"github.com/stackitcloud/stackit-cli/internal/cmd/sqlserverflex/backup/restore"
+	updateschedule "github.com/stackitcloud/stackit-cli/internal/cmd/sqlserverflex/backup/update-schedule"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/args"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/utils"
+
+	"github.com/spf13/cobra"
+)
+
+func NewCmd(params *params.CmdParams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "backup",
+		Short: "Provides functionality for SQLServer Flex instance backups",
+		Long:  "Provides functionality for SQLServer Flex instance backups, which are taken per database.",
+		Args:  args.NoArgs,
+		Run:   utils.CmdHelp,
+	}
+	addSubcommands(cmd, params)
+	return cmd
+}
+
+func addSubcommands(cmd *cobra.Command, params *params.CmdParams) {
+	cmd.AddCommand(list.NewCmd(params))
+	cmd.AddCommand(describe.NewCmd(params))
+	cmd.AddCommand(updateschedule.NewCmd(params))
+	cmd.AddCommand(restore.NewCmd(params))
+}
@@ -0,0 +1,216 @@
+package list
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/goccy/go-yaml"
+	"github.com/spf13/cobra"
+	"github.com/stackitcloud/stackit-cli/internal/cmd/params"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/args"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/errors"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/examples"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/flags"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/globalflags"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/print"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/services/sqlserverflex/client"
+	sqlserverflexUtils "github.com/stackitcloud/stackit-cli/internal/pkg/services/sqlserverflex/utils"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/tables"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/utils"
+	"github.com/stackitcloud/stackit-sdk-go/services/sqlserverflex"
+)
+
+const (
+	instanceIdFlag   = "instance-id"
+	databaseNameFlag = "database-name"
+	limitFlag        = "limit"
+)
+
+type inputModel struct {
+	*globalflags.GlobalFlagModel
+	InstanceId   string
+	DatabaseName *string
+	Limit        *int64
+}
+
+// The API groups the backups by database, they are listed with the name of their database instead
+type backup struct {
+	DatabaseName         string `json:"databaseName"`
+	sqlserverflex.Backup `yaml:",inline"`
+}
+
+func NewCmd(params *params.CmdParams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "Lists all backups which are available for a SQLServer Flex instance",
+		Long:  "Lists all backups which are available for the databases of a SQLServer Flex instance.",
+		Args:  args.NoArgs,
+		Example: examples.Build(
+			examples.NewExample(
+				`List all backups of instance with ID "xxx"`,
+				"$ stackit sqlserverflex backup list --instance-id xxx"),
+			examples.NewExample(
+				`List all backups of the database "my-database" of instance with ID "xxx"`,
+				"$ stackit sqlserverflex backup list --instance-id xxx --database-name my-database"),
+			examples.NewExample(
+				`List all backups of instance with ID "xxx" in JSON format`,
+				"$ stackit sqlserverflex backup list --instance-id xxx --output-format json"),
+			examples.NewExample(
+				`List up to 10 backups of instance with ID "xxx"`,
+				"$ stackit sqlserverflex backup list --instance-id xxx --limit 10"),
+		),
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx := context.Background()
+			model, err := parseInput(params.Printer, cmd)
+			if err != nil {
+				return err
+			}
+
+			// Configure API client
+			apiClient, err := client.ConfigureClient(params.Printer, params.CliVersion)
+			if err != nil {
+				return err
+			}
+
+			instanceLabel, err := sqlserverflexUtils.GetInstanceName(ctx, apiClient, model.ProjectId, model.InstanceId, model.Region)
+			if err != nil {
+				params.Printer.Debug(print.ErrorLevel, "get instance name: %v", err)
+				instanceLabel = model.InstanceId
+			}
+
+			// Call API
+			req := buildRequest(ctx, model, apiClient)
+			resp, err := req.Execute()
+			if err != nil {
+				return fmt.Errorf("get backups for SQLServer Flex instance %q: %w", instanceLabel, err)
+			}
+			backups := getBackups(resp, model.DatabaseName)
+			if len(backups) == 0 {
+				params.Printer.Info("No backups found for instance %q\n", instanceLabel)
+				return nil
+			}
+
+			// Truncate output
+			if model.Limit != nil && len(backups) > int(*model.Limit) {
+				backups = backups[:*model.Limit]
+			}
+
+			return outputResult(params.Printer, model.OutputFormat, backups)
+		},
+	}
+	configureFlags(cmd)
+	return cmd
+}
+
+func configureFlags(cmd *cobra.Command) {
+	cmd.Flags().Var(flags.UUIDFlag(), instanceIdFlag, "SQLServer Flex instance ID")
+	cmd.Flags().String(databaseNameFlag, "", "Name of the database to list the backups of. If not specified, the backups of all databases are listed")
+	cmd.Flags().Int64(limitFlag, 0, "Maximum number of entries to list")
+
+	err := flags.MarkFlagsRequired(cmd, instanceIdFlag)
+	cobra.CheckErr(err)
+}
+
+func parseInput(p *print.Printer, cmd *cobra.Command) (*inputModel, error) {
+	globalFlags := globalflags.Parse(p, cmd)
+	if globalFlags.ProjectId == "" {
+		return nil, &errors.ProjectIdError{}
+	}
+
+	limit := flags.FlagToInt64Pointer(p, cmd, limitFlag)
+	if limit != nil && *limit < 1 {
+		return nil, &errors.FlagValidationError{
+			Flag:    limitFlag,
+			Details: "must be greater than 0",
+		}
+	}
+
+	model := inputModel{
+		GlobalFlagModel: globalFlags,
+		InstanceId:      flags.FlagToStringValue(p, cmd, instanceIdFlag),
+		DatabaseName:    flags.FlagToStringPointer(p, cmd, databaseNameFlag),
+		Limit:           limit,
+	}
+
+	if p.IsVerbosityDebug() {
+		modelStr, err := print.BuildDebugStrFromInputModel(model)
+		if err != nil {
+			p.Debug(print.ErrorLevel, "convert model to string for debugging: %v", err)
+		} else {
+			p.Debug(print.DebugLevel, "parsed input values: %s", modelStr)
+		}
+	}
+
+	return &model, nil
+}
+
+func buildRequest(ctx context.Context, model *inputModel, apiClient *sqlserverflex.APIClient) sqlserverflex.ApiListBackupsRequest {
+	req := apiClient.ListBackups(ctx, model.ProjectId, model.InstanceId, model.Region)
+	return req
+}
+
+// getBackups flattens the backups of the databases, keeping only those of databaseName if set
+func getBackups(resp *sqlserverflex.ListBackupsResponse, databaseName *string) []backup {
+	backups := []backup{}
+	if resp == nil || resp.Databases == nil {
+		return backups
+	}
+	for _, database := range *resp.Databases {
+		name := utils.PtrString(database.Name)
+		if databaseName != nil && name != *databaseName {
+			continue
+		}
+		if database.Backups == nil {
+			continue
+		}
+		for _, b := range *database.Backups {
+			backups = append(backups, backup{
+				DatabaseName: name,
+				Backup:       b,
+			})
+		}
+	}
+	return backups
+}
+
+func outputResult(p *print.Printer, outputFormat string, backups []backup) error {
+	switch outputFormat {
+	case print.JSONOutputFormat:
+		details, err := json.MarshalIndent(backups, "", "  ")
+		if err != nil {
+			return fmt.Errorf("marshal SQLServer Flex backup list: %w", err)
+		}
+		p.Outputln(string(details))
+
+		return nil
+	case print.YAMLOutputFormat:
+		details, err := yaml.MarshalWithOptions(backups, yaml.IndentSequence(true), yaml.UseJSONMarshaler())
+		if err != nil {
+			return fmt.Errorf("marshal SQLServer Flex backup list: %w", err)
+		}
+		p.Outputln(string(details))
+
+		return nil
+	default:
+		table := tables.NewTable()
+		table.SetHeader("ID", "NAME", "DATABASE", "START TIME", "END TIME", "BACKUP SIZE")
+		for i := range backups {
+			b := backups[i]
+			table.AddRow(
+				utils.PtrString(b.Id),
+				utils.PtrString(b.Name),
+				b.DatabaseName,
+				utils.PtrString(b.StartTime),
+				utils.PtrString(b.EndTime),
+				utils.PtrByteSizeDefault(b.Size, "n/a"),
+			)
+		}
+		err := table.Display(p)
+		if err != nil {
+			return fmt.Errorf("render table: %w", err)
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,262 @@
+package restore
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/goccy/go-yaml"
+	"github.com/spf13/cobra"
+	"github.com/stackitcloud/stackit-cli/internal/cmd/params"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/args"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/errors"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/examples"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/flags"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/globalflags"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/print"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/services/sqlserverflex/client"
+	sqlserverflexUtils "github.com/stackitcloud/stackit-cli/internal/pkg/services/sqlserverflex/utils"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/spinner"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/utils"
+	"github.com/stackitcloud/stackit-sdk-go/services/sqlserverflex"
+)
+
+const (
+	instanceIdFlag   = "instance-id"
+	databaseNameFlag = "database-name"
+	timestampFlag    = "timestamp"
+	backupIdFlag     = "backup-id"
+	nameFlag         = "name"
+
+	// Layout of the recovery timestamp in the generated database names
+	nameTimestampFormat = "20060102_1504"
+)
+
+type inputModel struct {
+	*globalflags.GlobalFlagModel
+
+	InstanceId   string
+	DatabaseName string
+	RecoveryDate *string
+	BackupId     *string
+	Name         *string
+}
+
+type sqlServerFlexClient interface {
+	GetBackupExecute(ctx context.Context, projectId, instanceId, backupId, region string) (*sqlserverflex.GetBackupResponse, error)
+}
+
+type restoreResult struct {
+	InstanceId         string `json:"instanceId"`
+	SourceDatabaseName string `json:"sourceDatabaseName"`
+	DatabaseName       string `json:"databaseName"`
+	RecoveryDate       string `json:"recoveryTimestamp"`
+}
+
+func NewCmd(params *params.CmdParams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "restore",
+		Short: "Restores a database of a SQLServer Flex instance to a new database",
+		Long: fmt.Sprintf("%s\n%s",
+			"Restores a database of a SQLServer Flex instance, as it was at a point in time or at the end of a backup, to a new database of the same instance.",
+			"Existing databases cannot be overwritten, the new database is named after the original database and the recovery timestamp unless a name is given.",
+		),
+		Args: args.NoArgs,
+		Example: examples.Build(
+			examples.NewExample(
+				`Restore the database "my-database" of a SQLServer Flex instance with ID "xxx" as it was at a point in time`,
+				"$ stackit sqlserverflex backup restore --instance-id xxx --database-name my-database --timestamp 2024-04-17T09:28:00Z"),
+			examples.NewExample(
+				`Restore the database "my-database" of a SQLServer Flex instance with ID "xxx" from the backup with ID "yyy", to a new database named "my-database-restored"`,
+				"$ stackit sqlserverflex backup restore --instance-id xxx --database-name my-database --backup-id yyy --name my-database-restored"),
+		),
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx := context.Background()
+			model, err := parseInput(params.Printer, cmd)
+			if err != nil {
+				return err
+			}
+
+			// Configure API client
+			apiClient, err := client.ConfigureClient(params.Printer, params.CliVersion)
+			if err != nil {
+				return err
+			}
+
+			instanceLabel, err := sqlserverflexUtils.GetInstanceName(ctx, apiClient, model.ProjectId, model.InstanceId, model.Region)
+			if err != nil {
+				params.Printer.Debug(print.ErrorLevel, "get instance name: %v", err)
+				instanceLabel = model.InstanceId
+			}
+
+			if model.BackupId != nil {
+				model.RecoveryDate, err = getBackupRecoveryDate(ctx, apiClient, model)
+				if err != nil {
+					return err
+				}
+			}
+			if model.Name == nil {
+				model.Name, err = generateName(model.DatabaseName, *model.RecoveryDate)
+				if err != nil {
+					return err
+				}
+			}
+
+			if !model.AssumeYes {
+				prompt := fmt.Sprintf("Are you sure you want to restore database %q of instance %q as of %s to new database %q?", model.DatabaseName, instanceLabel, *model.RecoveryDate, *model.Name)
+				err = params.Printer.PromptForConfirmation(prompt)
+				if err != nil {
+					return err
+				}
+			}
+
+			// Call API
+			req := buildRequest(ctx, model, apiClient)
+			err = req.Execute()
+			if err != nil {
+				return fmt.Errorf("restore SQLServer Flex database: %w", err)
+			}
+			result := &restoreResult{
+				InstanceId:         model.InstanceId,
+				SourceDatabaseName: model.DatabaseName,
+				DatabaseName:       *model.Name,
+				RecoveryDate:       *model.RecoveryDate,
+			}
+
+			// Wait for async operation, if async mode not enabled
+			if !model.Async {
+				s := spinner.New(params.Printer)
+				s.Start("Restoring database")
+				_, err = sqlserverflexUtils.RestoreDatabaseWaitHandler(ctx, apiClient, model.ProjectId, model.InstanceId, *model.Name, model.Region).WaitWithContext(ctx)
+				if err != nil {
+					s.StopWithError()
+					return fmt.Errorf("wait for SQLServer Flex database restore: %w", err)
+				}
+				s.Stop()
+			}
+
+			return outputResult(params.Printer, model.OutputFormat, model.Async, instanceLabel, result)
+		},
+	}
+	configureFlags(cmd)
+	return cmd
+}
+
+func configureFlags(cmd *cobra.Command) {
+	cmd.Flags().Var(flags.UUIDFlag(), instanceIdFlag, "SQLServer Flex instance ID")
+	cmd.Flags().String(databaseNameFlag, "", "Name of the database to restore")
+	cmd.Flags().String(timestampFlag, "", "Point in time to restore the database to, in a date-time with the RFC3339 layout format, e.g. 2024-01-01T00:00:00Z")
+	cmd.Flags().String(backupIdFlag, "", "ID of the backup to restore the database from")
+	cmd.Flags().String(nameFlag, "", `Name of the new database. If not specified, it is named "<database name>_restore_<YYYYMMDD_hhmm of the recovery timestamp>"`)
+
+	cmd.MarkFlagsMutuallyExclusive(timestampFlag, backupIdFlag)
+	cmd.MarkFlagsOneRequired(timestampFlag, backupIdFlag)
+	err := flags.MarkFlagsRequired(cmd, instanceIdFlag, databaseNameFlag)
+	cobra.CheckErr(err)
+}
+
+func parseInput(p *print.Printer, cmd *cobra.Command) (*inputModel, error) {
+	globalFlags := globalflags.Parse(p, cmd)
+	if globalFlags.ProjectId == "" {
+		return nil, &errors.ProjectIdError{}
+	}
+
+	var recoveryDate *string
+	recoveryTimestamp, err := flags.FlagToDateTimePointer(p, cmd, timestampFlag, time.RFC3339)
+	if err != nil {
+		return nil, &errors.FlagValidationError{
+			Flag:    timestampFlag,
+			Details: err.Error(),
+		}
+	}
+	if recoveryTimestamp != nil {
+		recoveryDate = utils.Ptr(recoveryTimestamp.Format(time.RFC3339))
+	}
+
+	model := inputModel{
+		GlobalFlagModel: globalFlags,
+		InstanceId:      flags.FlagToStringValue(p, cmd, instanceIdFlag),
+		DatabaseName:    flags.FlagToStringValue(p, cmd, databaseNameFlag),
+		RecoveryDate:    recoveryDate,
+		BackupId:        flags.FlagToStringPointer(p, cmd, backupIdFlag),
+		Name:            flags.FlagToStringPointer(p, cmd, nameFlag),
+	}
+
+	if p.IsVerbosityDebug() {
+		modelStr, err := print.BuildDebugStrFromInputModel(model)
+		if err != nil {
+			p.Debug(print.ErrorLevel, "convert model to string for debugging: %v", err)
+		} else {
+			p.Debug(print.DebugLevel, "parsed input values: %s", modelStr)
+		}
+	}
+
+	return &model, nil
+}
+
+// getBackupRecoveryDate returns the recovery timestamp of the backup, which is its end time
+func getBackupRecoveryDate(ctx context.Context, apiClient sqlServerFlexClient, model *inputModel) (*string, error) {
+	resp, err := apiClient.GetBackupExecute(ctx, model.ProjectId, model.InstanceId, *model.BackupId, model.Region)
+	if err != nil {
+		return nil, fmt.Errorf("get SQLServer Flex backup: %w", err)
+	}
+	if resp.EndTime == nil || *resp.EndTime == "" {
+		return nil, fmt.Errorf("backup %q has not finished", *model.BackupId)
+	}
+	endTime, err := time.Parse(time.RFC3339, *resp.EndTime)
+	if err != nil {
+		return nil, fmt.Errorf("parse end time of backup: %w", err)
+	}
+	return utils.Ptr(endTime.Format(time.RFC3339)), nil
+}
+
+// generateName returns the name of the new database, after the original database and the recovery timestamp
+func generateName(databaseName, recoveryDate string) (*string, error) {
+	recoveryTimestamp, err := time.Parse(time.RFC3339, recoveryDate)
+	if err != nil {
+		return nil, fmt.Errorf("parse recovery timestamp: %w", err)
+	}
+	name := fmt.Sprintf("%s_restore_%s", databaseName, recoveryTimestamp.UTC().Format(nameTimestampFormat))
+	return &name, nil
+}
+
+func buildRequest(ctx context.Context, model *inputModel, apiClient *sqlserverflex.APIClient) sqlserverflex.ApiTriggerDatabaseRestoreRequest {
+	req := apiClient.TriggerDatabaseRestore(ctx, model.ProjectId, model.InstanceId, model.DatabaseName, model.Region)
+	req = req.TriggerDatabaseRestorePayload(sqlserverflex.TriggerDatabaseRestorePayload{
+		Name:            model.Name,
+		RestoreDateTime: model.RecoveryDate,
+	})
+	return req
+}
+
+func outputResult(p *print.Printer, outputFormat string, async bool, instanceLabel string, result *restoreResult) error {
+	if result == nil {
+		return fmt.Errorf("no result passed")
+	}
+	switch outputFormat {
+	case print.JSONOutputFormat:
+		details, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			return fmt.Errorf("marshal SQLServer Flex database restore: %w", err)
+		}
+		p.Outputln(string(details))
+
+		return nil
+	case print.YAMLOutputFormat:
+		details, err := yaml.MarshalWithOptions(result, yaml.IndentSequence(true), yaml.UseJSONMarshaler())
+		if err != nil {
+			return fmt.Errorf("marshal SQLServer Flex database restore: %w", err)
+		}
+		p.Outputln(string(details))
+
+		return nil
+	default:
+		if async {
+			p.Outputf("Triggered restore of database %q of instance %q as of %s to new database %q\n", result.SourceDatabaseName, instanceLabel, result.RecoveryDate, result.DatabaseName)
+			return nil
+		}
+		p.Outputf("Restored database %q of instance %q as of %s to new database %q\n", result.SourceDatabaseName, instanceLabel, result.RecoveryDate, result.DatabaseName)
+		return nil
+	}
+}
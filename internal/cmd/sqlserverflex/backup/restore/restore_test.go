@@ -0,0 +1,385 @@
+package restore
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stackitcloud/stackit-cli/internal/cmd/params"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/globalflags"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/print"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/utils"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/uuid"
+	"github.com/stackitcloud/stackit-sdk-go/services/sqlserverflex"
+)
+
+type testCtxKey struct{}
+
+var testCtx = context.WithValue(context.Background(), testCtxKey{}, "foo")
+var testClient = &sqlserverflex.APIClient{}
+var testProjectId = uuid.NewString()
+var testInstanceId = uuid.NewString()
+var testBackupId = "backup-id"
+var testDatabaseName = "my-database"
+var testRegion = "eu01"
+var testRecoveryDate = "2024-04-17T09:28:00Z"
+
+type sqlServerFlexClientMocked struct {
+	getBackupFails bool
+	getBackupResp  *sqlserverflex.GetBackupResponse
+}
+
+func (m *sqlServerFlexClientMocked) GetBackupExecute(_ context.Context, _, _, _, _ string) (*sqlserverflex.GetBackupResponse, error) {
+	if m.getBackupFails {
+		return nil, fmt.Errorf("could not get backup")
+	}
+	return m.getBackupResp, nil
+}
+
+func fixtureFlagValues(mods ...func(flagValues map[string]string)) map[string]string {
+	flagValues := map[string]string{
+		globalflags.ProjectIdFlag: testProjectId,
+		globalflags.RegionFlag:    testRegion,
+		instanceIdFlag:            testInstanceId,
+		databaseNameFlag:          testDatabaseName,
+		timestampFlag:             testRecoveryDate,
+	}
+	for _, mod := range mods {
+		mod(flagValues)
+	}
+	return flagValues
+}
+
+func fixtureInputModel(mods ...func(model *inputModel)) *inputModel {
+	model := &inputModel{
+		GlobalFlagModel: &globalflags.GlobalFlagModel{
+			ProjectId: testProjectId,
+			Region:    testRegion,
+			Verbosity: globalflags.VerbosityDefault,
+		},
+		InstanceId:   testInstanceId,
+		DatabaseName: testDatabaseName,
+		RecoveryDate: utils.Ptr(testRecoveryDate),
+	}
+	for _, mod := range mods {
+		mod(model)
+	}
+	return model
+}
+
+func TestParseInput(t *testing.T) {
+	tests := []struct {
+		description   string
+		flagValues    map[string]string
+		isValid       bool
+		expectedModel *inputModel
+	}{
+		{
+			description:   "base",
+			flagValues:    fixtureFlagValues(),
+			isValid:       true,
+			expectedModel: fixtureInputModel(),
+		},
+		{
+			description: "timestamp with offset",
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				flagValues[timestampFlag] = "2024-04-17T11:28:00+02:00"
+			}),
+			isValid: true,
+			expectedModel: fixtureInputModel(func(model *inputModel) {
+				model.RecoveryDate = utils.Ptr("2024-04-17T11:28:00+02:00")
+			}),
+		},
+		{
+			description: "backup id and name",
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				delete(flagValues, timestampFlag)
+				flagValues[backupIdFlag] = testBackupId
+				flagValues[nameFlag] = "my-database-restored"
+			}),
+			isValid: true,
+			expectedModel: fixtureInputModel(func(model *inputModel) {
+				model.RecoveryDate = nil
+				model.BackupId = utils.Ptr(testBackupId)
+				model.Name = utils.Ptr("my-database-restored")
+			}),
+		},
+		{
+			description: "no values",
+			flagValues:  map[string]string{},
+			isValid:     false,
+		},
+		{
+			description: "project id missing",
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				delete(flagValues, globalflags.ProjectIdFlag)
+			}),
+			isValid: false,
+		},
+		{
+			description: "instance id missing",
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				delete(flagValues, instanceIdFlag)
+			}),
+			isValid: false,
+		},
+		{
+			description: "instance id invalid",
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				flagValues[instanceIdFlag] = "invalid-uuid"
+			}),
+			isValid: false,
+		},
+		{
+			description: "database name missing",
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				delete(flagValues, databaseNameFlag)
+			}),
+			isValid: false,
+		},
+		{
+			description: "timestamp and backup id missing",
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				delete(flagValues, timestampFlag)
+			}),
+			isValid: false,
+		},
+		{
+			description: "timestamp and backup id both set",
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				flagValues[backupIdFlag] = testBackupId
+			}),
+			isValid: false,
+		},
+		{
+			description: "timestamp invalid",
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				flagValues[timestampFlag] = "2024-04-17"
+			}),
+			isValid: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			p := print.NewPrinter()
+			cmd := NewCmd(&params.CmdParams{Printer: p})
+			err := globalflags.Configure(cmd.Flags())
+			if err != nil {
+				t.Fatalf("configure global flags: %v", err)
+			}
+
+			for flag, value := range tt.flagValues {
+				err := cmd.Flags().Set(flag, value)
+				if err != nil {
+					if !tt.isValid {
+						return
+					}
+					t.Fatalf("setting flag --%s=%s: %v", flag, value, err)
+				}
+			}
+
+			err = cmd.ValidateRequiredFlags()
+			if err == nil {
+				err = cmd.ValidateFlagGroups()
+			}
+			if err != nil {
+				if !tt.isValid {
+					return
+				}
+				t.Fatalf("error validating flags: %v", err)
+			}
+
+			model, err := parseInput(p, cmd)
+			if err != nil {
+				if !tt.isValid {
+					return
+				}
+				t.Fatalf("error parsing input: %v", err)
+			}
+
+			if !tt.isValid {
+				t.Fatalf("did not fail on invalid input")
+			}
+			diff := cmp.Diff(model, tt.expectedModel)
+			if diff != "" {
+				t.Fatalf("Data does not match: %s", diff)
+			}
+		})
+	}
+}
+
+func TestGetBackupRecoveryDate(t *testing.T) {
+	tests := []struct {
+		description    string
+		getBackupFails bool
+		getBackupResp  *sqlserverflex.GetBackupResponse
+		isValid        bool
+		expected       string
+	}{
+		{
+			description: "base",
+			getBackupResp: &sqlserverflex.GetBackupResponse{
+				EndTime: utils.Ptr(testRecoveryDate),
+			},
+			isValid:  true,
+			expected: testRecoveryDate,
+		},
+		{
+			description:   "backup not finished",
+			getBackupResp: &sqlserverflex.GetBackupResponse{},
+			isValid:       false,
+		},
+		{
+			description: "end time invalid",
+			getBackupResp: &sqlserverflex.GetBackupResponse{
+				EndTime: utils.Ptr("yesterday"),
+			},
+			isValid: false,
+		},
+		{
+			description:    "get backup fails",
+			getBackupFails: true,
+			isValid:        false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			client := &sqlServerFlexClientMocked{
+				getBackupFails: tt.getBackupFails,
+				getBackupResp:  tt.getBackupResp,
+			}
+			model := fixtureInputModel(func(model *inputModel) {
+				model.RecoveryDate = nil
+				model.BackupId = utils.Ptr(testBackupId)
+			})
+
+			recoveryDate, err := getBackupRecoveryDate(testCtx, client, model)
+			if !tt.isValid {
+				if err == nil {
+					t.Fatalf("did not fail on invalid input")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("failed on valid input: %v", err)
+			}
+			if *recoveryDate != tt.expected {
+				t.Fatalf("expected recovery date %q, got %q", tt.expected, *recoveryDate)
+			}
+		})
+	}
+}
+
+func TestGenerateName(t *testing.T) {
+	tests := []struct {
+		description  string
+		databaseName string
+		recoveryDate string
+		isValid      bool
+		expected     string
+	}{
+		{
+			description:  "base",
+			databaseName: testDatabaseName,
+			recoveryDate: testRecoveryDate,
+			isValid:      true,
+			expected:     "my-database_restore_20240417_0928",
+		},
+		{
+			description:  "other time zone",
+			databaseName: testDatabaseName,
+			recoveryDate: "2024-04-17T11:28:00+02:00",
+			isValid:      true,
+			expected:     "my-database_restore_20240417_0928",
+		},
+		{
+			description:  "recovery date invalid",
+			databaseName: testDatabaseName,
+			recoveryDate: "2024-04-17",
+			isValid:      false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			name, err := generateName(tt.databaseName, tt.recoveryDate)
+			if !tt.isValid {
+				if err == nil {
+					t.Fatalf("did not fail on invalid input")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("failed on valid input: %v", err)
+			}
+			if *name != tt.expected {
+				t.Fatalf("expected name %q, got %q", tt.expected, *name)
+			}
+		})
+	}
+}
+
+func TestBuildRequest(t *testing.T) {
+	expectedRequest := testClient.TriggerDatabaseRestore(testCtx, testProjectId, testInstanceId, testDatabaseName, testRegion).
+		TriggerDatabaseRestorePayload(sqlserverflex.TriggerDatabaseRestorePayload{
+			Name:            utils.Ptr("my-database-restored"),
+			RestoreDateTime: utils.Ptr(testRecoveryDate),
+		})
+
+	model := fixtureInputModel(func(model *inputModel) {
+		model.Name = utils.Ptr("my-database-restored")
+	})
+	request := buildRequest(testCtx, model, testClient)
+
+	diff := cmp.Diff(request, expectedRequest,
+		cmp.AllowUnexported(expectedRequest),
+		cmpopts.EquateComparable(testCtx),
+	)
+	if diff != "" {
+		t.Fatalf("Data does not match: %s", diff)
+	}
+}
+
+func TestOutputResult(t *testing.T) {
+	tests := []struct {
+		description  string
+		outputFormat string
+		async        bool
+		result       *restoreResult
+		wantErr      bool
+	}{
+		{
+			description: "empty",
+			wantErr:     true,
+		},
+		{
+			description: "base",
+			result:      &restoreResult{},
+		},
+		{
+			description: "async",
+			async:       true,
+			result:      &restoreResult{},
+		},
+		{
+			description:  "json",
+			outputFormat: print.JSONOutputFormat,
+			result:       &restoreResult{},
+		},
+	}
+	p := print.NewPrinter()
+	p.Cmd = NewCmd(&params.CmdParams{Printer: p})
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			if err := outputResult(p, tt.outputFormat, tt.async, "instance", tt.result); (err != nil) != tt.wantErr {
+				t.Errorf("outputResult() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
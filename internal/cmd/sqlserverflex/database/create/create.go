@@ -46,7 +46,7 @@ func NewCmd(params *params.CmdParams) *cobra.Command {
 		Example: examples.Build(
 			examples.NewExample(
 				`Create a SQLServer Flex database with name "my-database" on instance with ID "xxx"`,
-				"$ stackit beta sqlserverflex database create my-database --instance-id xxx --owner some-username"),
+				"$ stackit sqlserverflex database create my-database --instance-id xxx --owner some-username"),
 		),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			ctx := context.Background()
@@ -1,11 +1,11 @@
 package database
 
 import (
-	"github.com/stackitcloud/stackit-cli/internal/cmd/beta/sqlserverflex/database/create"
-	"github.com/stackitcloud/stackit-cli/internal/cmd/beta/sqlserverflex/database/delete"
-	"github.com/stackitcloud/stackit-cli/internal/cmd/beta/sqlserverflex/database/describe"
-	"github.com/stackitcloud/stackit-cli/internal/cmd/beta/sqlserverflex/database/list"
 	"github.com/stackitcloud/stackit-cli/internal/cmd/params"
+	"github.com/stackitcloud/stackit-cli/internal/cmd/sqlserverflex/database/create"
+	"github.com/stackitcloud/stackit-cli/internal/cmd/sqlserverflex/database/delete"
+	"github.com/stackitcloud/stackit-cli/internal/cmd/sqlserverflex/database/describe"
+	"github.com/stackitcloud/stackit-cli/internal/cmd/sqlserverflex/database/list"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/args"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/utils"
 
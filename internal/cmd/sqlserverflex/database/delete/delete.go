@@ -42,7 +42,7 @@ func NewCmd(params *params.CmdParams) *cobra.Command {
 		Example: examples.Build(
 			examples.NewExample(
 				`Delete a SQLServer Flex database with name "my-database" of instance with ID "xxx"`,
-				"$ stackit beta sqlserverflex database delete my-database --instance-id xxx"),
+				"$ stackit sqlserverflex database delete my-database --instance-id xxx"),
 		),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			ctx := context.Background()
@@ -41,10 +41,10 @@ func NewCmd(params *params.CmdParams) *cobra.Command {
 		Example: examples.Build(
 			examples.NewExample(
 				`Get details of an SQLServer Flex database with name "my-database" of instance with ID "xxx"`,
-				"$ stackit beta sqlserverflex database describe my-database --instance-id xxx"),
+				"$ stackit sqlserverflex database describe my-database --instance-id xxx"),
 			examples.NewExample(
 				`Get details of an SQLServer Flex database with name "my-database" of instance with ID "xxx" in JSON format`,
-				"$ stackit beta sqlserverflex database describe my-database --instance-id xxx --output-format json"),
+				"$ stackit sqlserverflex database describe my-database --instance-id xxx --output-format json"),
 		),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			ctx := context.Background()
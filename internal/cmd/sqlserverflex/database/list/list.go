@@ -41,13 +41,13 @@ func NewCmd(params *params.CmdParams) *cobra.Command {
 		Example: examples.Build(
 			examples.NewExample(
 				`List all SQLServer Flex databases of instance with ID "xxx"`,
-				"$ stackit beta sqlserverflex database list --instance-id xxx"),
+				"$ stackit sqlserverflex database list --instance-id xxx"),
 			examples.NewExample(
 				`List all SQLServer Flex databases of instance with ID "xxx" in JSON format`,
-				"$ stackit beta sqlserverflex database list --instance-id xxx --output-format json"),
+				"$ stackit sqlserverflex database list --instance-id xxx --output-format json"),
 			examples.NewExample(
 				`List up to 10 SQLServer Flex databases of instance with ID "xxx"`,
-				"$ stackit beta sqlserverflex database list --instance-id xxx --limit 10"),
+				"$ stackit sqlserverflex database list --instance-id xxx --limit 10"),
 		),
 		RunE: func(cmd *cobra.Command, _ []string) error {
 			ctx := context.Background()
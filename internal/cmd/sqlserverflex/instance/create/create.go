@@ -75,14 +75,14 @@ func NewCmd(params *params.CmdParams) *cobra.Command {
 		Example: examples.Build(
 			examples.NewExample(
 				`Create a SQLServer Flex instance with name "my-instance" and specify flavor by CPU and RAM. Other parameters are set to default values`,
-				`$ stackit beta sqlserverflex instance create --name my-instance --cpu 1 --ram 4`),
+				`$ stackit sqlserverflex instance create --name my-instance --cpu 1 --ram 4`),
 			examples.NewExample(
 				`Create a SQLServer Flex instance with name "my-instance" and specify flavor by ID. Other parameters are set to default values.
-  The flavor ID can be retrieved by running "$ stackit beta sqlserverflex options --flavors"`,
-				`$ stackit beta sqlserverflex instance create --name my-instance --flavor-id xxx`),
+  The flavor ID can be retrieved by running "$ stackit sqlserverflex options --flavors"`,
+				`$ stackit sqlserverflex instance create --name my-instance --flavor-id xxx`),
 			examples.NewExample(
 				`Create a SQLServer Flex instance with name "my-instance", specify flavor by CPU and RAM, set storage size to 20 GB, and restrict access to a specific range of IP addresses. Other parameters are set to default values`,
-				`$ stackit beta sqlserverflex instance create --name my-instance --cpu 1 --ram 4 --storage-size 20  --acl 1.2.3.0/24`),
+				`$ stackit sqlserverflex instance create --name my-instance --cpu 1 --ram 4 --storage-size 20  --acl 1.2.3.0/24`),
 		),
 		RunE: func(cmd *cobra.Command, _ []string) error {
 			ctx := context.Background()
@@ -38,7 +38,7 @@ func NewCmd(params *params.CmdParams) *cobra.Command {
 		Example: examples.Build(
 			examples.NewExample(
 				`Delete a SQLServer Flex instance with ID "xxx"`,
-				"$ stackit beta sqlserverflex instance delete xxx"),
+				"$ stackit sqlserverflex instance delete xxx"),
 		),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			ctx := context.Background()
@@ -39,10 +39,10 @@ func NewCmd(params *params.CmdParams) *cobra.Command {
 		Example: examples.Build(
 			examples.NewExample(
 				`Get details of a SQLServer Flex instance with ID "xxx"`,
-				"$ stackit beta sqlserverflex instance describe xxx"),
+				"$ stackit sqlserverflex instance describe xxx"),
 			examples.NewExample(
 				`Get details of a SQLServer Flex instance with ID "xxx" in JSON format`,
-				"$ stackit beta sqlserverflex instance describe xxx --output-format json"),
+				"$ stackit sqlserverflex instance describe xxx --output-format json"),
 		),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			ctx := context.Background()
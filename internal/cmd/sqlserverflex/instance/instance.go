@@ -1,12 +1,13 @@
 package instance
 
 import (
-	"github.com/stackitcloud/stackit-cli/internal/cmd/beta/sqlserverflex/instance/create"
-	"github.com/stackitcloud/stackit-cli/internal/cmd/beta/sqlserverflex/instance/delete"
-	"github.com/stackitcloud/stackit-cli/internal/cmd/beta/sqlserverflex/instance/describe"
-	"github.com/stackitcloud/stackit-cli/internal/cmd/beta/sqlserverflex/instance/list"
-	"github.com/stackitcloud/stackit-cli/internal/cmd/beta/sqlserverflex/instance/update"
 	"github.com/stackitcloud/stackit-cli/internal/cmd/params"
+	"github.com/stackitcloud/stackit-cli/internal/cmd/sqlserverflex/instance/acl"
+	"github.com/stackitcloud/stackit-cli/internal/cmd/sqlserverflex/instance/create"
+	"github.com/stackitcloud/stackit-cli/internal/cmd/sqlserverflex/instance/delete"
+	"github.com/stackitcloud/stackit-cli/internal/cmd/sqlserverflex/instance/describe"
+	"github.com/stackitcloud/stackit-cli/internal/cmd/sqlserverflex/instance/list"
+	"github.com/stackitcloud/stackit-cli/internal/cmd/sqlserverflex/instance/update"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/args"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/utils"
 
@@ -31,4 +32,5 @@ func addSubcommands(cmd *cobra.Command, params *params.CmdParams) {
 	cmd.AddCommand(describe.NewCmd(params))
 	cmd.AddCommand(list.NewCmd(params))
 	cmd.AddCommand(update.NewCmd(params))
+	cmd.AddCommand(acl.NewCmd(params))
 }
@@ -39,13 +39,13 @@ func NewCmd(params *params.CmdParams) *cobra.Command {
 		Example: examples.Build(
 			examples.NewExample(
 				`List all SQLServer Flex instances`,
-				"$ stackit beta sqlserverflex instance list"),
+				"$ stackit sqlserverflex instance list"),
 			examples.NewExample(
 				`List all SQLServer Flex instances in JSON format`,
-				"$ stackit beta sqlserverflex instance list --output-format json"),
+				"$ stackit sqlserverflex instance list --output-format json"),
 			examples.NewExample(
 				`List up to 10 SQLServer Flex instances`,
-				"$ stackit beta sqlserverflex instance list --limit 10"),
+				"$ stackit sqlserverflex instance list --limit 10"),
 		),
 		RunE: func(cmd *cobra.Command, _ []string) error {
 			ctx := context.Background()
@@ -69,10 +69,10 @@ func NewCmd(params *params.CmdParams) *cobra.Command {
 		Example: examples.Build(
 			examples.NewExample(
 				`Update the name of a SQLServer Flex instance with ID "xxx"`,
-				"$ stackit beta sqlserverflex instance update xxx --name my-new-name"),
+				"$ stackit sqlserverflex instance update xxx --name my-new-name"),
 			examples.NewExample(
 				`Update the backup schedule of a SQLServer Flex instance with ID "xxx"`,
-				`$ stackit beta sqlserverflex instance update xxx --backup-schedule "30 0 * * *"`),
+				`$ stackit sqlserverflex instance update xxx --backup-schedule "30 0 * * *"`),
 		),
 		Args: args.SingleArg(instanceIdArg, utils.ValidateUUID),
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -97,16 +97,16 @@ func NewCmd(params *params.CmdParams) *cobra.Command {
 		Example: examples.Build(
 			examples.NewExample(
 				`List SQL Server Flex flavors options`,
-				"$ stackit beta sqlserverflex options --flavors"),
+				"$ stackit sqlserverflex options --flavors"),
 			examples.NewExample(
 				`List SQL Server Flex available versions`,
-				"$ stackit beta sqlserverflex options --versions"),
+				"$ stackit sqlserverflex options --versions"),
 			examples.NewExample(
-				`List SQL Server Flex storage options for a given flavor. The flavor ID can be retrieved by running "$ stackit beta sqlserverflex options --flavors"`,
-				"$ stackit beta sqlserverflex options --storages --flavor-id <FLAVOR_ID>"),
+				`List SQL Server Flex storage options for a given flavor. The flavor ID can be retrieved by running "$ stackit sqlserverflex options --flavors"`,
+				"$ stackit sqlserverflex options --storages --flavor-id <FLAVOR_ID>"),
 			examples.NewExample(
-				`List SQL Server Flex user roles and database compatibilities for a given instance. The IDs of existing instances can be obtained by running "$ stackit beta sqlserverflex instance list"`,
-				"$ stackit beta sqlserverflex options --user-roles --db-compatibilities --instance-id <INSTANCE_ID>"),
+				`List SQL Server Flex user roles and database compatibilities for a given instance. The IDs of existing instances can be obtained by running "$ stackit sqlserverflex instance list"`,
+				"$ stackit sqlserverflex options --user-roles --db-compatibilities --instance-id <INSTANCE_ID>"),
 		),
 		RunE: func(cmd *cobra.Command, _ []string) error {
 			ctx := context.Background()
@@ -168,14 +168,14 @@ func parseInput(p *print.Printer, cmd *cobra.Command) (*inputModel, error) {
 		return nil, fmt.Errorf("%s\n\n%s\n%s",
 			`please specify a flavor ID to show storages for by setting the flag "--flavor-id <FLAVOR_ID>".`,
 			"You can get the available flavor IDs by running:",
-			"  $ stackit beta sqlserverflex options --flavors")
+			"  $ stackit sqlserverflex options --flavors")
 	}
 
 	if (userRoles || dbCollations || dbCompatibilities) && instanceId == nil {
 		return nil, fmt.Errorf("%s\n\n%s\n%s",
 			`please specify an instance ID to show user roles, database collations or database compatibilities for by setting the flag "--instance-id <INSTANCE_ID>".`,
 			"You can get the available instances and their IDs by running:",
-			"  $ stackit beta sqlserverflex instance list")
+			"  $ stackit sqlserverflex instance list")
 	}
 
 	model := inputModel{
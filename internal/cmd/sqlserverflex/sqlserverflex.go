@@ -1,11 +1,13 @@
 package sqlserverflex
 
 import (
-	"github.com/stackitcloud/stackit-cli/internal/cmd/beta/sqlserverflex/database"
-	"github.com/stackitcloud/stackit-cli/internal/cmd/beta/sqlserverflex/instance"
-	"github.com/stackitcloud/stackit-cli/internal/cmd/beta/sqlserverflex/options"
-	"github.com/stackitcloud/stackit-cli/internal/cmd/beta/sqlserverflex/user"
 	"github.com/stackitcloud/stackit-cli/internal/cmd/params"
+	"github.com/stackitcloud/stackit-cli/internal/cmd/sqlserverflex/backup"
+	"github.com/stackitcloud/stackit-cli/internal/cmd/sqlserverflex/connect"
+	"github.com/stackitcloud/stackit-cli/internal/cmd/sqlserverflex/database"
+	"github.com/stackitcloud/stackit-cli/internal/cmd/sqlserverflex/instance"
+	"github.com/stackitcloud/stackit-cli/internal/cmd/sqlserverflex/options"
+	"github.com/stackitcloud/stackit-cli/internal/cmd/sqlserverflex/user"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/args"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/utils"
 
@@ -25,6 +27,8 @@ func NewCmd(params *params.CmdParams) *cobra.Command {
 }
 
 func addSubcommands(cmd *cobra.Command, params *params.CmdParams) {
+	cmd.AddCommand(backup.NewCmd(params))
+	cmd.AddCommand(connect.NewCmd(params))
 	cmd.AddCommand(database.NewCmd(params))
 	cmd.AddCommand(instance.NewCmd(params))
 	cmd.AddCommand(options.NewCmd(params))
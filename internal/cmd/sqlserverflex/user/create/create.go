@@ -10,6 +10,7 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/stackitcloud/stackit-cli/internal/cmd/params"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/args"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/connection"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/errors"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/examples"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/flags"
@@ -30,9 +31,10 @@ const (
 type inputModel struct {
 	*globalflags.GlobalFlagModel
 
-	InstanceId string
-	Username   *string
-	Roles      *[]string
+	InstanceId        string
+	Username          *string
+	Roles             *[]string
+	CredentialsFormat *connection.FormatOptions
 }
 
 func NewCmd(params *params.CmdParams) *cobra.Command {
@@ -43,18 +45,21 @@ func NewCmd(params *params.CmdParams) *cobra.Command {
 			"Creates a SQLServer Flex user for an instance.",
 			"The password is only visible upon creation and cannot be retrieved later.",
 			"Alternatively, you can reset the password and access the new one by running:",
-			"  $ stackit beta sqlserverflex user reset-password USER_ID --instance-id INSTANCE_ID",
+			"  $ stackit sqlserverflex user reset-password USER_ID --instance-id INSTANCE_ID",
 			"Please refer to https://docs.stackit.cloud/stackit/en/creating-logins-and-users-in-sqlserver-flex-instances-210862358.html for additional information.",
 			"The allowed user roles for your instance can be obtained by running:",
-			"  $ stackit beta sqlserverflex options --user-roles --instance-id INSTANCE_ID",
+			"  $ stackit sqlserverflex options --user-roles --instance-id INSTANCE_ID",
 		),
 		Example: examples.Build(
 			examples.NewExample(
 				`Create a SQLServer Flex user for instance with ID "xxx" and specify the username, role and database`,
-				`$ stackit beta sqlserverflex user create --instance-id xxx --username johndoe --roles "##STACKIT_DatabaseManager##"`),
+				`$ stackit sqlserverflex user create --instance-id xxx --username johndoe --roles "##STACKIT_DatabaseManager##"`),
 			examples.NewExample(
 				`Create a SQLServer Flex user for instance with ID "xxx", specifying multiple roles`,
-				`$ stackit beta sqlserverflex user create --instance-id xxx --username johndoe --roles "##STACKIT_LoginManager##,##STACKIT_DatabaseManager##"`),
+				`$ stackit sqlserverflex user create --instance-id xxx --username johndoe --roles "##STACKIT_LoginManager##,##STACKIT_DatabaseManager##"`),
+			examples.NewExample(
+				`Create a SQLServer Flex user for instance with ID "xxx" and apply its credentials as a Kubernetes Secret`,
+				`$ stackit sqlserverflex user create --instance-id xxx --username johndoe --roles "##STACKIT_DatabaseManager##" --format k8s-secret | kubectl apply -f -`),
 		),
 		Args: args.NoArgs,
 		RunE: func(cmd *cobra.Command, _ []string) error {
@@ -92,6 +97,14 @@ func NewCmd(params *params.CmdParams) *cobra.Command {
 			}
 			user := resp.Item
 
+			if model.CredentialsFormat != nil {
+				conn, err := buildConnection(user)
+				if err != nil {
+					return err
+				}
+				return connection.PrintCredentials(params.Printer, conn, model.CredentialsFormat, fmt.Sprintf("sqlserverflex-%s", conn.Username), true)
+			}
+
 			return outputResult(params.Printer, model, instanceLabel, user)
 		},
 	}
@@ -104,6 +117,7 @@ func configureFlags(cmd *cobra.Command) {
 	cmd.Flags().Var(flags.UUIDFlag(), instanceIdFlag, "ID of the instance")
 	cmd.Flags().String(usernameFlag, "", "Username of the user")
 	cmd.Flags().StringSlice(rolesFlag, []string{}, "Roles of the user")
+	connection.ConfigureFormatFlags(cmd)
 
 	err := flags.MarkFlagsRequired(cmd, instanceIdFlag, usernameFlag, rolesFlag)
 	cobra.CheckErr(err)
@@ -115,11 +129,17 @@ func parseInput(p *print.Printer, cmd *cobra.Command) (*inputModel, error) {
 		return nil, &errors.ProjectIdError{}
 	}
 
+	credentialsFormat, err := connection.ParseFormatFlags(p, cmd)
+	if err != nil {
+		return nil, err
+	}
+
 	model := inputModel{
-		GlobalFlagModel: globalFlags,
-		InstanceId:      flags.FlagToStringValue(p, cmd, instanceIdFlag),
-		Username:        flags.FlagToStringPointer(p, cmd, usernameFlag),
-		Roles:           flags.FlagToStringSlicePointer(p, cmd, rolesFlag),
+		GlobalFlagModel:   globalFlags,
+		InstanceId:        flags.FlagToStringValue(p, cmd, instanceIdFlag),
+		Username:          flags.FlagToStringPointer(p, cmd, usernameFlag),
+		Roles:             flags.FlagToStringSlicePointer(p, cmd, rolesFlag),
+		CredentialsFormat: credentialsFormat,
 	}
 
 	if p.IsVerbosityDebug() {
@@ -144,6 +164,26 @@ func buildRequest(ctx context.Context, model *inputModel, apiClient *sqlserverfl
 	return req
 }
 
+// buildConnection returns the connection to the instance as the created user
+func buildConnection(user *sqlserverflex.SingleUser) (*connection.Connection, error) {
+	if user == nil {
+		return nil, fmt.Errorf("no user passed")
+	}
+
+	conn := &connection.Connection{
+		Engine:   connection.SQLServer,
+		Host:     utils.PtrString(user.Host),
+		Port:     utils.PtrValue(user.Port),
+		Username: utils.PtrString(user.Username),
+		Password: utils.PtrString(user.Password),
+		Database: utils.PtrString(user.DefaultDatabase),
+	}
+	if conn.Host == "" {
+		return nil, fmt.Errorf("no host of the instance returned")
+	}
+	return conn, nil
+}
+
 func outputResult(p *print.Printer, model *inputModel, instanceLabel string, user *sqlserverflex.SingleUser) error {
 	if user == nil {
 		return fmt.Errorf("user response is empty")
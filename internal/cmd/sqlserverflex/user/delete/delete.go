@@ -37,12 +37,12 @@ func NewCmd(params *params.CmdParams) *cobra.Command {
 		Short: "Deletes a SQLServer Flex user",
 		Long: fmt.Sprintf("%s\n%s",
 			"Deletes a SQLServer Flex user by ID. You can get the IDs of users for an instance by running:",
-			"  $ stackit beta sqlserverflex user list --instance-id <INSTANCE_ID>",
+			"  $ stackit sqlserverflex user list --instance-id <INSTANCE_ID>",
 		),
 		Example: examples.Build(
 			examples.NewExample(
 				`Delete a SQLServer Flex user with ID "xxx" for instance with ID "yyy"`,
-				"$ stackit beta sqlserverflex user delete xxx --instance-id yyy"),
+				"$ stackit sqlserverflex user delete xxx --instance-id yyy"),
 		),
 		Args: args.SingleArg(userIdArg, nil),
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -9,6 +9,7 @@ import (
 	"github.com/goccy/go-yaml"
 	"github.com/stackitcloud/stackit-cli/internal/cmd/params"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/args"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/connection"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/errors"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/examples"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/flags"
@@ -31,8 +32,9 @@ const (
 type inputModel struct {
 	*globalflags.GlobalFlagModel
 
-	InstanceId string
-	UserId     string
+	InstanceId        string
+	UserId            string
+	CredentialsFormat *connection.FormatOptions
 }
 
 func NewCmd(params *params.CmdParams) *cobra.Command {
@@ -42,15 +44,18 @@ func NewCmd(params *params.CmdParams) *cobra.Command {
 		Long: fmt.Sprintf("%s\n%s\n%s",
 			"Shows details of a SQLServer Flex user.",
 			`The user password is only visible upon creation. You can reset it by running:`,
-			"  $ stackit beta sqlserverflex user reset-password USER_ID --instance-id INSTANCE_ID",
+			"  $ stackit sqlserverflex user reset-password USER_ID --instance-id INSTANCE_ID",
 		),
 		Example: examples.Build(
 			examples.NewExample(
 				`Get details of a SQLServer Flex user with ID "xxx" of instance with ID "yyy"`,
-				"$ stackit beta sqlserverflex user describe xxx --instance-id yyy"),
+				"$ stackit sqlserverflex user describe xxx --instance-id yyy"),
 			examples.NewExample(
 				`Get details of a SQLServer Flex user with ID "xxx" of instance with ID "yyy" in JSON format`,
-				"$ stackit beta sqlserverflex user describe xxx --instance-id yyy --output-format json"),
+				"$ stackit sqlserverflex user describe xxx --instance-id yyy --output-format json"),
+			examples.NewExample(
+				`Get the connection URI of a SQLServer Flex user with ID "xxx" of instance with ID "yyy"`,
+				"$ stackit sqlserverflex user describe xxx --instance-id yyy --format uri"),
 		),
 		Args: args.SingleArg(userIdArg, nil),
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -73,6 +78,14 @@ func NewCmd(params *params.CmdParams) *cobra.Command {
 				return fmt.Errorf("get SQLServer Flex user: %w", err)
 			}
 
+			if model.CredentialsFormat != nil {
+				conn, err := buildConnection(resp.Item)
+				if err != nil {
+					return err
+				}
+				return connection.PrintCredentials(params.Printer, conn, model.CredentialsFormat, fmt.Sprintf("sqlserverflex-%s", conn.Username), true)
+			}
+
 			return outputResult(params.Printer, model.OutputFormat, resp.Item)
 		},
 	}
@@ -83,6 +96,7 @@ func NewCmd(params *params.CmdParams) *cobra.Command {
 
 func configureFlags(cmd *cobra.Command) {
 	cmd.Flags().Var(flags.UUIDFlag(), instanceIdFlag, "ID of the instance")
+	connection.ConfigureFormatFlags(cmd)
 
 	err := flags.MarkFlagsRequired(cmd, instanceIdFlag)
 	cobra.CheckErr(err)
@@ -96,10 +110,16 @@ func parseInput(p *print.Printer, cmd *cobra.Command, inputArgs []string) (*inpu
 		return nil, &errors.ProjectIdError{}
 	}
 
+	credentialsFormat, err := connection.ParseFormatFlags(p, cmd)
+	if err != nil {
+		return nil, err
+	}
+
 	model := inputModel{
-		GlobalFlagModel: globalFlags,
-		InstanceId:      flags.FlagToStringValue(p, cmd, instanceIdFlag),
-		UserId:          userId,
+		GlobalFlagModel:   globalFlags,
+		InstanceId:        flags.FlagToStringValue(p, cmd, instanceIdFlag),
+		UserId:            userId,
+		CredentialsFormat: credentialsFormat,
 	}
 
 	if p.IsVerbosityDebug() {
@@ -119,6 +139,25 @@ func buildRequest(ctx context.Context, model *inputModel, apiClient *sqlserverfl
 	return req
 }
 
+// buildConnection returns the connection to the instance as the user, without its password
+func buildConnection(user *sqlserverflex.UserResponseUser) (*connection.Connection, error) {
+	if user == nil {
+		return nil, fmt.Errorf("no user passed")
+	}
+
+	conn := &connection.Connection{
+		Engine:   connection.SQLServer,
+		Host:     utils.PtrString(user.Host),
+		Port:     utils.PtrValue(user.Port),
+		Username: utils.PtrString(user.Username),
+		Database: utils.PtrString(user.DefaultDatabase),
+	}
+	if conn.Host == "" {
+		return nil, fmt.Errorf("no host of the instance returned")
+	}
+	return conn, nil
+}
+
 func outputResult(p *print.Printer, outputFormat string, user *sqlserverflex.UserResponseUser) error {
 	if user == nil {
 		return fmt.Errorf("user response is empty")
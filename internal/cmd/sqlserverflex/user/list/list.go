@@ -41,13 +41,13 @@ func NewCmd(params *params.CmdParams) *cobra.Command {
 		Example: examples.Build(
 			examples.NewExample(
 				`List all SQLServer Flex users of instance with ID "xxx"`,
-				"$ stackit beta sqlserverflex user list --instance-id xxx"),
+				"$ stackit sqlserverflex user list --instance-id xxx"),
 			examples.NewExample(
 				`List all SQLServer Flex users of instance with ID "xxx" in JSON format`,
-				"$ stackit beta sqlserverflex user list --instance-id xxx --output-format json"),
+				"$ stackit sqlserverflex user list --instance-id xxx --output-format json"),
 			examples.NewExample(
 				`List up to 10 SQLServer Flex users of instance with ID "xxx"`,
-				"$ stackit beta sqlserverflex user list --instance-id xxx --limit 10"),
+				"$ stackit sqlserverflex user list --instance-id xxx --limit 10"),
 		),
 		Args: args.NoArgs,
 		RunE: func(cmd *cobra.Command, _ []string) error {
@@ -45,7 +45,7 @@ func NewCmd(params *params.CmdParams) *cobra.Command {
 		Example: examples.Build(
 			examples.NewExample(
 				`Reset the password of a SQLServer Flex user with ID "xxx" of instance with ID "yyy"`,
-				"$ stackit beta sqlserverflex user reset-password xxx --instance-id yyy"),
+				"$ stackit sqlserverflex user reset-password xxx --instance-id yyy"),
 		),
 		Args: args.SingleArg(userIdArg, nil),
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -1,12 +1,12 @@
 package user
 
 import (
-	"github.com/stackitcloud/stackit-cli/internal/cmd/beta/sqlserverflex/user/create"
-	"github.com/stackitcloud/stackit-cli/internal/cmd/beta/sqlserverflex/user/delete"
-	"github.com/stackitcloud/stackit-cli/internal/cmd/beta/sqlserverflex/user/describe"
-	"github.com/stackitcloud/stackit-cli/internal/cmd/beta/sqlserverflex/user/list"
-	resetpassword "github.com/stackitcloud/stackit-cli/internal/cmd/beta/sqlserverflex/user/reset-password"
 	"github.com/stackitcloud/stackit-cli/internal/cmd/params"
+	"github.com/stackitcloud/stackit-cli/internal/cmd/sqlserverflex/user/create"
+	"github.com/stackitcloud/stackit-cli/internal/cmd/sqlserverflex/user/delete"
+	"github.com/stackitcloud/stackit-cli/internal/cmd/sqlserverflex/user/describe"
+	"github.com/stackitcloud/stackit-cli/internal/cmd/sqlserverflex/user/list"
+	resetpassword "github.com/stackitcloud/stackit-cli/internal/cmd/sqlserverflex/user/reset-password"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/args"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/utils"
 
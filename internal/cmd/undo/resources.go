@@ -0,0 +1,237 @@
+package undo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/stackitcloud/stackit-cli/internal/pkg/history"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/print"
+	dnsClient "github.com/stackitcloud/stackit-cli/internal/pkg/services/dns/client"
+	iaasClient "github.com/stackitcloud/stackit-cli/internal/pkg/services/iaas/client"
+	loadBalancerClient "github.com/stackitcloud/stackit-cli/internal/pkg/services/load-balancer/client"
+	loadBalancerUtils "github.com/stackitcloud/stackit-cli/internal/pkg/services/load-balancer/utils"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/spinner"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/utils"
+
+	"github.com/stackitcloud/stackit-sdk-go/services/dns"
+	dnsWait "github.com/stackitcloud/stackit-sdk-go/services/dns/wait"
+	"github.com/stackitcloud/stackit-sdk-go/services/iaas"
+	"github.com/stackitcloud/stackit-sdk-go/services/loadbalancer"
+)
+
+// restorer restores the state of a resource from a snapshot and returns a description of what it did
+type restorer func(ctx context.Context, p *print.Printer, cliVersion string, data json.RawMessage, async bool) (string, error)
+
+// Restorers by resource type and operation
+var restorers = map[string]map[string]restorer{
+	history.SecurityGroupRuleResourceType: {
+		history.DeleteOperation: recreateSecurityGroupRule,
+	},
+	history.DNSRecordSetResourceType: {
+		history.DeleteOperation: recreateDNSRecordSet,
+		history.UpdateOperation: revertDNSRecordSet,
+	},
+	history.LoadBalancerTargetResourceType: {
+		history.DeleteOperation: readdLoadBalancerTarget,
+	},
+}
+
+func getRestorer(snapshot history.Snapshot) (restorer, bool) {
+	r, ok := restorers[snapshot.ResourceType][snapshot.Operation]
+	return r, ok
+}
+
+func recreateSecurityGroupRule(ctx context.Context, p *print.Printer, cliVersion string, data json.RawMessage, _ bool) (string, error) {
+	var snapshot history.SecurityGroupRuleSnapshot
+	err := json.Unmarshal(data, &snapshot)
+	if err != nil {
+		return "", fmt.Errorf("parse snapshot: %w", err)
+	}
+	if snapshot.Rule == nil {
+		return "", fmt.Errorf("snapshot has no security group rule")
+	}
+
+	apiClient, err := iaasClient.ConfigureClient(p, cliVersion)
+	if err != nil {
+		return "", err
+	}
+	req := apiClient.CreateSecurityGroupRule(ctx, snapshot.ProjectId, snapshot.SecurityGroupId)
+	resp, err := req.CreateSecurityGroupRulePayload(buildSecurityGroupRulePayload(snapshot.Rule)).Execute()
+	if err != nil {
+		return "", fmt.Errorf("create security group rule: %w", err)
+	}
+	return fmt.Sprintf("Recreated security group rule in security group %q, the new rule ID is %q", snapshot.SecurityGroupId, utils.PtrString(resp.Id)), nil
+}
+
+func buildSecurityGroupRulePayload(rule *iaas.SecurityGroupRule) iaas.CreateSecurityGroupRulePayload {
+	payload := iaas.CreateSecurityGroupRulePayload{
+		Description:           rule.Description,
+		Direction:             rule.Direction,
+		Ethertype:             rule.Ethertype,
+		IcmpParameters:        rule.IcmpParameters,
+		IpRange:               rule.IpRange,
+		PortRange:             rule.PortRange,
+		RemoteSecurityGroupId: rule.RemoteSecurityGroupId,
+	}
+	// The protocol is given either by name or by number
+	if rule.Protocol != nil {
+		if rule.Protocol.Name != nil {
+			payload.Protocol = &iaas.CreateProtocol{String: rule.Protocol.Name}
+		} else if rule.Protocol.Number != nil {
+			payload.Protocol = &iaas.CreateProtocol{Int64: rule.Protocol.Number}
+		}
+	}
+	return payload
+}
+
+func recreateDNSRecordSet(ctx context.Context, p *print.Printer, cliVersion string, data json.RawMessage, async bool) (string, error) {
+	var snapshot history.DNSRecordSetSnapshot
+	err := json.Unmarshal(data, &snapshot)
+	if err != nil {
+		return "", fmt.Errorf("parse snapshot: %w", err)
+	}
+	if snapshot.RecordSet == nil {
+		return "", fmt.Errorf("snapshot has no record set")
+	}
+
+	apiClient, err := dnsClient.ConfigureClient(p, cliVersion)
+	if err != nil {
+		return "", err
+	}
+	req := apiClient.CreateRecordSet(ctx, snapshot.ProjectId, snapshot.ZoneId)
+	resp, err := req.CreateRecordSetPayload(buildCreateRecordSetPayload(snapshot.RecordSet)).Execute()
+	if err != nil {
+		return "", fmt.Errorf("create DNS record set: %w", err)
+	}
+	if resp.Rrset == nil || resp.Rrset.Id == nil {
+		return "", fmt.Errorf("create DNS record set: empty response")
+	}
+	recordSetId := *resp.Rrset.Id
+
+	if !async {
+		s := spinner.New(p)
+		s.Start("Creating record set")
+		_, err = dnsWait.CreateRecordSetWaitHandler(ctx, apiClient, snapshot.ProjectId, snapshot.ZoneId, recordSetId).WaitWithContext(ctx)
+		if err != nil {
+			return "", fmt.Errorf("wait for DNS record set creation: %w", err)
+		}
+		s.Stop()
+	}
+	return fmt.Sprintf("Recreated record set %q in zone %q, the new record set ID is %q", utils.PtrString(snapshot.RecordSet.Name), snapshot.ZoneId, recordSetId), nil
+}
+
+func buildCreateRecordSetPayload(recordSet *dns.RecordSet) dns.CreateRecordSetPayload {
+	payload := dns.CreateRecordSetPayload{
+		Comment: recordSet.Comment,
+		Name:    recordSet.Name,
+		Records: buildRecordPayloads(recordSet.Records),
+		Ttl:     recordSet.Ttl,
+	}
+	if recordSet.Type != nil {
+		payload.Type = utils.Ptr(dns.CreateRecordSetPayloadTypes(*recordSet.Type))
+	}
+	return payload
+}
+
+func revertDNSRecordSet(ctx context.Context, p *print.Printer, cliVersion string, data json.RawMessage, async bool) (string, error) {
+	var snapshot history.DNSRecordSetSnapshot
+	err := json.Unmarshal(data, &snapshot)
+	if err != nil {
+		return "", fmt.Errorf("parse snapshot: %w", err)
+	}
+	if snapshot.RecordSet == nil || snapshot.RecordSet.Id == nil {
+		return "", fmt.Errorf("snapshot has no record set")
+	}
+	recordSetId := *snapshot.RecordSet.Id
+
+	apiClient, err := dnsClient.ConfigureClient(p, cliVersion)
+	if err != nil {
+		return "", err
+	}
+	req := apiClient.PartialUpdateRecordSet(ctx, snapshot.ProjectId, snapshot.ZoneId, recordSetId)
+	_, err = req.PartialUpdateRecordSetPayload(buildPartialUpdateRecordSetPayload(snapshot.RecordSet)).Execute()
+	if err != nil {
+		return "", fmt.Errorf("update DNS record set: %w", err)
+	}
+
+	if !async {
+		s := spinner.New(p)
+		s.Start("Updating record set")
+		_, err = dnsWait.PartialUpdateRecordSetWaitHandler(ctx, apiClient, snapshot.ProjectId, snapshot.ZoneId, recordSetId).WaitWithContext(ctx)
+		if err != nil {
+			return "", fmt.Errorf("wait for DNS record set update: %w", err)
+		}
+		s.Stop()
+	}
+	return fmt.Sprintf("Reverted the update of record set %q in zone %q", utils.PtrString(snapshot.RecordSet.Name), snapshot.ZoneId), nil
+}
+
+func buildPartialUpdateRecordSetPayload(recordSet *dns.RecordSet) dns.PartialUpdateRecordSetPayload {
+	return dns.PartialUpdateRecordSetPayload{
+		Comment: recordSet.Comment,
+		Name:    recordSet.Name,
+		Records: buildRecordPayloads(recordSet.Records),
+		Ttl:     recordSet.Ttl,
+	}
+}
+
+func buildRecordPayloads(records *[]dns.Record) *[]dns.RecordPayload {
+	if records == nil {
+		return nil
+	}
+	payloads := make([]dns.RecordPayload, 0, len(*records))
+	for _, r := range *records {
+		payloads = append(payloads, dns.RecordPayload{Content: r.Content})
+	}
+	return &payloads
+}
+
+func readdLoadBalancerTarget(ctx context.Context, p *print.Printer, cliVersion string, data json.RawMessage, _ bool) (string, error) {
+	var snapshot history.LoadBalancerTargetSnapshot
+	err := json.Unmarshal(data, &snapshot)
+	if err != nil {
+		return "", fmt.Errorf("parse snapshot: %w", err)
+	}
+
+	apiClient, err := loadBalancerClient.ConfigureClient(p, cliVersion)
+	if err != nil {
+		return "", err
+	}
+	req, err := buildUpdateTargetPoolRequest(ctx, apiClient, &snapshot)
+	if err != nil {
+		return "", err
+	}
+	_, err = req.Execute()
+	if err != nil {
+		return "", fmt.Errorf("add target to target pool: %w", err)
+	}
+	return fmt.Sprintf("Added target %q back to target pool %q of load balancer %q", utils.PtrString(snapshot.Target.Ip), snapshot.TargetPoolName, snapshot.LoadBalancerName), nil
+}
+
+func buildUpdateTargetPoolRequest(ctx context.Context, apiClient loadBalancerUtils.LoadBalancerClient, snapshot *history.LoadBalancerTargetSnapshot) (loadbalancer.ApiUpdateTargetPoolRequest, error) {
+	req := apiClient.UpdateTargetPool(ctx, snapshot.ProjectId, snapshot.Region, snapshot.LoadBalancerName, snapshot.TargetPoolName)
+
+	// The current target pool is updated, to keep the changes made since the target was removed
+	targetPool, err := loadBalancerUtils.GetLoadBalancerTargetPool(ctx, apiClient, snapshot.ProjectId, snapshot.Region, snapshot.LoadBalancerName, snapshot.TargetPoolName)
+	if err != nil {
+		return req, fmt.Errorf("get load balancer target pool: %w", err)
+	}
+	if targetPool.Targets != nil {
+		for _, target := range *targetPool.Targets {
+			if target.Ip != nil && snapshot.Target.Ip != nil && *target.Ip == *snapshot.Target.Ip {
+				return req, fmt.Errorf("target pool %q already has a target with IP %q", snapshot.TargetPoolName, *target.Ip)
+			}
+		}
+	}
+
+	err = loadBalancerUtils.AddTargetToTargetPool(targetPool, &snapshot.Target)
+	if err != nil {
+		return req, fmt.Errorf("add target to target pool: %w", err)
+	}
+	payload := loadBalancerUtils.ToPayloadTargetPool(targetPool)
+	if payload == nil {
+		return req, fmt.Errorf("nil payload")
+	}
+	return req.UpdateTargetPoolPayload(*payload), nil
+}
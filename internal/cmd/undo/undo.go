@@ -0,0 +1,168 @@
+package undo
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/stackitcloud/stackit-cli/internal/cmd/params"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/args"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/examples"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/globalflags"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/history"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/print"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/utils"
+
+	"github.com/spf13/cobra"
+)
+
+const (
+	historyIdArg = "HISTORY_ID"
+)
+
+type inputModel struct {
+	*globalflags.GlobalFlagModel
+	HistoryId *string
+}
+
+func NewCmd(params *params.CmdParams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   fmt.Sprintf("undo [%s]", historyIdArg),
+		Short: "Undoes a command that deleted or updated resources",
+		Long: fmt.Sprintf("%s\n%s\n%s\n%s\n%s",
+			"Undoes a command that deleted or updated resources, using the state of the resources saved in the history before the command changed them.",
+			`If no history ID is given, undoes the last command that can be undone (see "stackit history list").`,
+			"Supported commands: security-group rule delete, dns record-set delete, dns record-set update and load-balancer target-pool remove-target.",
+			"Deleted resources are recreated with a new ID.",
+			"If undoing a command fails partway, running the undo again only restores the changes that were not restored yet.",
+		),
+		Args: args.SingleOptionalArg(historyIdArg, utils.ValidateUUID),
+		Example: examples.Build(
+			examples.NewExample(
+				`Undo the last command that can be undone`,
+				"$ stackit undo"),
+			examples.NewExample(
+				`Undo the command with history ID "xxx"`,
+				"$ stackit undo xxx"),
+		),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.Background()
+			model := parseInput(params.Printer, cmd, args)
+
+			records, err := history.List()
+			if err != nil {
+				return fmt.Errorf("list history records: %w", err)
+			}
+			record, err := findRecord(records, model.HistoryId)
+			if err != nil {
+				return err
+			}
+
+			// Snapshots restored by earlier undo commands that failed are skipped, so that resources aren't restored twice
+			restored := history.RestoredSnapshots(records, record.Id)
+
+			if !model.AssumeYes {
+				prompt := fmt.Sprintf("Are you sure you want to undo %q, run at %s?", commandLine(record), record.Time.Local().Format(time.DateTime))
+				if len(restored) > 0 {
+					prompt = fmt.Sprintf("Are you sure you want to undo the remaining changes of %q, run at %s? %d of its %d changes were already undone", commandLine(record), record.Time.Local().Format(time.DateTime), len(restored), len(record.Snapshots))
+				}
+				err = params.Printer.PromptForConfirmation(prompt)
+				if err != nil {
+					return err
+				}
+			}
+
+			history.SetUndone(params.Printer, record.Id)
+			// Snapshots are restored in reverse order, in case a command changed a resource more than once
+			for i := len(record.Snapshots) - 1; i >= 0; i-- {
+				snapshot := record.Snapshots[i]
+				if slices.Contains(restored, i) {
+					params.Printer.Info("Skipped %s, as it was already restored\n", snapshot.ResourceType)
+					continue
+				}
+				restore, _ := getRestorer(snapshot)
+				result, err := restore(ctx, params.Printer, params.CliVersion, snapshot.Data, model.Async)
+				if err != nil {
+					return fmt.Errorf("restore %s: %w", snapshot.ResourceType, err)
+				}
+				history.AddRestoredSnapshot(params.Printer, i)
+				params.Printer.Info("%s\n", result)
+			}
+			return nil
+		},
+	}
+	return cmd
+}
+
+func parseInput(p *print.Printer, cmd *cobra.Command, inputArgs []string) *inputModel {
+	var historyId *string
+	if len(inputArgs) > 0 {
+		historyId = &inputArgs[0]
+	}
+
+	globalFlags := globalflags.Parse(p, cmd)
+
+	model := inputModel{
+		GlobalFlagModel: globalFlags,
+		HistoryId:       historyId,
+	}
+
+	if p.IsVerbosityDebug() {
+		modelStr, err := print.BuildDebugStrFromInputModel(model)
+		if err != nil {
+			p.Debug(print.ErrorLevel, "convert model to string for debugging: %v", err)
+		} else {
+			p.Debug(print.DebugLevel, "parsed input values: %s", modelStr)
+		}
+	}
+
+	return &model
+}
+
+// findRecord returns the record with the given ID, or the last one that can be undone if no ID is given.
+// Returns an error if the record can't be undone.
+func findRecord(records []history.Record, historyId *string) (*history.Record, error) {
+	var record *history.Record
+	if historyId == nil {
+		var err error
+		record, err = history.LastUndoable(records)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		for i := range records {
+			if records[i].Id == *historyId {
+				record = &records[i]
+				break
+			}
+		}
+		if record == nil {
+			return nil, fmt.Errorf("history record %q not found", *historyId)
+		}
+	}
+
+	if record.Status() != history.StatusSucceeded {
+		return nil, fmt.Errorf("command %q failed, so there is nothing to undo", record.Command)
+	}
+	if len(record.Snapshots) == 0 {
+		return nil, fmt.Errorf("command %q can't be undone", record.Command)
+	}
+	for _, snapshot := range record.Snapshots {
+		if _, ok := getRestorer(snapshot); !ok {
+			return nil, fmt.Errorf("%s of %s can't be undone", snapshot.Operation, snapshot.ResourceType)
+		}
+	}
+	if undo, ok := history.FindUndo(records, record.Id); ok {
+		return nil, fmt.Errorf("command %q was already undone (history ID %q)", record.Command, undo.Id)
+	}
+	return record, nil
+}
+
+func commandLine(record *history.Record) string {
+	line := record.Command
+	for _, arg := range record.Args {
+		line += " " + arg
+	}
+	return line
+}
@@ -0,0 +1,480 @@
+package undo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/stackitcloud/stackit-cli/internal/cmd/params"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/globalflags"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/history"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/print"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/utils"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/uuid"
+	"github.com/stackitcloud/stackit-sdk-go/services/dns"
+	"github.com/stackitcloud/stackit-sdk-go/services/iaas"
+	"github.com/stackitcloud/stackit-sdk-go/services/loadbalancer"
+)
+
+type testCtxKey struct{}
+
+var (
+	testCtx       = context.WithValue(context.Background(), testCtxKey{}, "foo")
+	testClient    = &loadbalancer.APIClient{}
+	testProjectId = uuid.NewString()
+	testHistoryId = uuid.NewString()
+)
+
+const (
+	testRegion         = "eu01"
+	testLBName         = "my-load-balancer"
+	testTargetPoolName = "target-pool-1"
+)
+
+type loadBalancerClientMocked struct {
+	getLoadBalancerFails bool
+	getLoadBalancerResp  *loadbalancer.LoadBalancer
+}
+
+func (m *loadBalancerClientMocked) GetCredentialsExecute(_ context.Context, _, _, _ string) (*loadbalancer.GetCredentialsResponse, error) {
+	return nil, nil
+}
+
+func (m *loadBalancerClientMocked) GetLoadBalancerExecute(_ context.Context, _, _, _ string) (*loadbalancer.LoadBalancer, error) {
+	if m.getLoadBalancerFails {
+		return nil, fmt.Errorf("could not get load balancer")
+	}
+	return m.getLoadBalancerResp, nil
+}
+
+func (m *loadBalancerClientMocked) UpdateTargetPool(ctx context.Context, projectId, region, loadBalancerName, targetPoolName string) loadbalancer.ApiUpdateTargetPoolRequest {
+	return testClient.UpdateTargetPool(ctx, projectId, region, loadBalancerName, targetPoolName)
+}
+
+func (m *loadBalancerClientMocked) ListLoadBalancersExecute(_ context.Context, _, _ string) (*loadbalancer.ListLoadBalancersResponse, error) {
+	return nil, nil
+}
+
+func fixtureInputModel(mods ...func(model *inputModel)) *inputModel {
+	model := &inputModel{
+		GlobalFlagModel: &globalflags.GlobalFlagModel{Verbosity: globalflags.VerbosityDefault},
+		HistoryId:       utils.Ptr(testHistoryId),
+	}
+	for _, mod := range mods {
+		mod(model)
+	}
+	return model
+}
+
+func fixtureTargets() *[]loadbalancer.Target {
+	return &[]loadbalancer.Target{
+		{
+			DisplayName: utils.Ptr("target-1"),
+			Ip:          utils.Ptr("1.2.3.4"),
+		},
+	}
+}
+
+func fixtureLoadBalancer(mods ...func(*loadbalancer.LoadBalancer)) *loadbalancer.LoadBalancer {
+	lb := loadbalancer.LoadBalancer{
+		Name: utils.Ptr(testLBName),
+		TargetPools: &[]loadbalancer.TargetPool{
+			{
+				Name:       utils.Ptr(testTargetPoolName),
+				Targets:    fixtureTargets(),
+				TargetPort: utils.Ptr(int64(80)),
+			},
+		},
+	}
+	for _, mod := range mods {
+		mod(&lb)
+	}
+	return &lb
+}
+
+func fixtureTargetSnapshot(mods ...func(snapshot *history.LoadBalancerTargetSnapshot)) *history.LoadBalancerTargetSnapshot {
+	snapshot := &history.LoadBalancerTargetSnapshot{
+		ProjectId:        testProjectId,
+		Region:           testRegion,
+		LoadBalancerName: testLBName,
+		TargetPoolName:   testTargetPoolName,
+		Target: loadbalancer.Target{
+			DisplayName: utils.Ptr("target-2"),
+			Ip:          utils.Ptr("4.3.2.1"),
+		},
+	}
+	for _, mod := range mods {
+		mod(snapshot)
+	}
+	return snapshot
+}
+
+func fixtureRecords() []history.Record {
+	snapshots := []history.Snapshot{{
+		ResourceType: history.DNSRecordSetResourceType,
+		Operation:    history.DeleteOperation,
+		Data:         json.RawMessage(`{}`),
+	}}
+	// Sorted from the most recent
+	return []history.Record{
+		{Id: "5", Command: "stackit dns zone create"},
+		{Id: "4", Command: "stackit undo", UndoneId: "3"},
+		{Id: "3", Command: "stackit dns record-set delete", Snapshots: snapshots},
+		{Id: "2", Command: "stackit dns record-set delete", Snapshots: snapshots, Error: "not found"},
+		{Id: "1", Command: "stackit dns record-set delete", Snapshots: snapshots},
+		{Id: "0", Command: "stackit dns zone delete", Snapshots: []history.Snapshot{{
+			ResourceType: "dns-zone",
+			Operation:    history.DeleteOperation,
+		}}},
+	}
+}
+
+func TestParseInput(t *testing.T) {
+	tests := []struct {
+		description   string
+		argValues     []string
+		isValid       bool
+		expectedModel *inputModel
+	}{
+		{
+			description:   "base",
+			argValues:     []string{testHistoryId},
+			isValid:       true,
+			expectedModel: fixtureInputModel(),
+		},
+		{
+			description: "no arg values",
+			argValues:   []string{},
+			isValid:     true,
+			expectedModel: fixtureInputModel(func(model *inputModel) {
+				model.HistoryId = nil
+			}),
+		},
+		{
+			description: "history id invalid",
+			argValues:   []string{"invalid-uuid"},
+			isValid:     false,
+		},
+		{
+			description: "too many arg values",
+			argValues:   []string{testHistoryId, testHistoryId},
+			isValid:     false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			p := print.NewPrinter()
+			cmd := NewCmd(&params.CmdParams{Printer: p})
+			err := globalflags.Configure(cmd.Flags())
+			if err != nil {
+				t.Fatalf("configure global flags: %v", err)
+			}
+
+			err = cmd.ValidateArgs(tt.argValues)
+			if err != nil {
+				if !tt.isValid {
+					return
+				}
+				t.Fatalf("error validating args: %v", err)
+			}
+
+			model := parseInput(p, cmd, tt.argValues)
+
+			if !tt.isValid {
+				t.Fatalf("did not fail on invalid input")
+			}
+			diff := cmp.Diff(model, tt.expectedModel)
+			if diff != "" {
+				t.Fatalf("Data does not match: %s", diff)
+			}
+		})
+	}
+}
+
+func TestFindRecord(t *testing.T) {
+	tests := []struct {
+		description string
+		records     []history.Record
+		historyId   *string
+		isValid     bool
+		expectedId  string
+	}{
+		{
+			description: "last undoable",
+			records:     fixtureRecords(),
+			isValid:     true,
+			expectedId:  "1",
+		},
+		{
+			description: "by id",
+			records:     fixtureRecords(),
+			historyId:   utils.Ptr("1"),
+			isValid:     true,
+			expectedId:  "1",
+		},
+		{
+			description: "nothing to undo",
+			records:     fixtureRecords()[:3],
+			isValid:     false,
+		},
+		{
+			description: "not found",
+			records:     fixtureRecords(),
+			historyId:   utils.Ptr("6"),
+			isValid:     false,
+		},
+		{
+			description: "partially undone",
+			records: []history.Record{
+				{Id: "2", Command: "stackit undo", UndoneId: "1", RestoredSnapshots: []int{0}, Error: "not found"},
+				fixtureRecords()[4],
+			},
+			isValid:    true,
+			expectedId: "1",
+		},
+		{
+			description: "already undone",
+			records:     fixtureRecords(),
+			historyId:   utils.Ptr("3"),
+			isValid:     false,
+		},
+		{
+			description: "failed",
+			records:     fixtureRecords(),
+			historyId:   utils.Ptr("2"),
+			isValid:     false,
+		},
+		{
+			description: "no snapshots",
+			records:     fixtureRecords(),
+			historyId:   utils.Ptr("5"),
+			isValid:     false,
+		},
+		{
+			description: "unsupported resource type",
+			records:     fixtureRecords(),
+			historyId:   utils.Ptr("0"),
+			isValid:     false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			record, err := findRecord(tt.records, tt.historyId)
+			if !tt.isValid {
+				if err == nil {
+					t.Fatalf("did not fail on invalid input")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if record.Id != tt.expectedId {
+				t.Fatalf("expected record %q, got %q", tt.expectedId, record.Id)
+			}
+		})
+	}
+}
+
+func TestBuildSecurityGroupRulePayload(t *testing.T) {
+	tests := []struct {
+		description     string
+		rule            *iaas.SecurityGroupRule
+		expectedPayload iaas.CreateSecurityGroupRulePayload
+	}{
+		{
+			description: "protocol name",
+			rule: &iaas.SecurityGroupRule{
+				Id:          utils.Ptr("xxx"),
+				Description: utils.Ptr("allow ssh"),
+				Direction:   utils.Ptr("ingress"),
+				Ethertype:   utils.Ptr("IPv4"),
+				IpRange:     utils.Ptr("10.0.0.0/8"),
+				PortRange: &iaas.PortRange{
+					Min: utils.Ptr(int64(22)),
+					Max: utils.Ptr(int64(22)),
+				},
+				Protocol: &iaas.Protocol{
+					Name:   utils.Ptr("tcp"),
+					Number: utils.Ptr(int64(6)),
+				},
+			},
+			expectedPayload: iaas.CreateSecurityGroupRulePayload{
+				Description: utils.Ptr("allow ssh"),
+				Direction:   utils.Ptr("ingress"),
+				Ethertype:   utils.Ptr("IPv4"),
+				IpRange:     utils.Ptr("10.0.0.0/8"),
+				PortRange: &iaas.PortRange{
+					Min: utils.Ptr(int64(22)),
+					Max: utils.Ptr(int64(22)),
+				},
+				Protocol: &iaas.CreateProtocol{String: utils.Ptr("tcp")},
+			},
+		},
+		{
+			description: "protocol number",
+			rule: &iaas.SecurityGroupRule{
+				Direction: utils.Ptr("egress"),
+				Protocol:  &iaas.Protocol{Number: utils.Ptr(int64(1))},
+			},
+			expectedPayload: iaas.CreateSecurityGroupRulePayload{
+				Direction: utils.Ptr("egress"),
+				Protocol:  &iaas.CreateProtocol{Int64: utils.Ptr(int64(1))},
+			},
+		},
+		{
+			description: "no protocol",
+			rule: &iaas.SecurityGroupRule{
+				Direction: utils.Ptr("egress"),
+			},
+			expectedPayload: iaas.CreateSecurityGroupRulePayload{
+				Direction: utils.Ptr("egress"),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			payload := buildSecurityGroupRulePayload(tt.rule)
+			diff := cmp.Diff(payload, tt.expectedPayload)
+			if diff != "" {
+				t.Fatalf("Data does not match: %s", diff)
+			}
+		})
+	}
+}
+
+func TestBuildRecordSetPayloads(t *testing.T) {
+	recordSet := &dns.RecordSet{
+		Id:      utils.Ptr("xxx"),
+		Name:    utils.Ptr("www.example.com."),
+		Comment: utils.Ptr("comment"),
+		Records: &[]dns.Record{
+			{Content: utils.Ptr("1.2.3.4"), Id: utils.Ptr("yyy")},
+			{Content: utils.Ptr("4.3.2.1"), Id: utils.Ptr("zzz")},
+		},
+		Ttl:  utils.Ptr(int64(3600)),
+		Type: utils.Ptr(dns.RECORDSETTYPE_A),
+	}
+	records := &[]dns.RecordPayload{
+		{Content: utils.Ptr("1.2.3.4")},
+		{Content: utils.Ptr("4.3.2.1")},
+	}
+
+	createPayload := buildCreateRecordSetPayload(recordSet)
+	diff := cmp.Diff(createPayload, dns.CreateRecordSetPayload{
+		Comment: utils.Ptr("comment"),
+		Name:    utils.Ptr("www.example.com."),
+		Records: records,
+		Ttl:     utils.Ptr(int64(3600)),
+		Type:    utils.Ptr(dns.CREATERECORDSETPAYLOADTYPE_A),
+	})
+	if diff != "" {
+		t.Fatalf("Create payload does not match: %s", diff)
+	}
+
+	updatePayload := buildPartialUpdateRecordSetPayload(recordSet)
+	diff = cmp.Diff(updatePayload, dns.PartialUpdateRecordSetPayload{
+		Comment: utils.Ptr("comment"),
+		Name:    utils.Ptr("www.example.com."),
+		Records: records,
+		Ttl:     utils.Ptr(int64(3600)),
+	})
+	if diff != "" {
+		t.Fatalf("Update payload does not match: %s", diff)
+	}
+}
+
+func TestBuildUpdateTargetPoolRequest(t *testing.T) {
+	tests := []struct {
+		description          string
+		snapshot             *history.LoadBalancerTargetSnapshot
+		getLoadBalancerFails bool
+		getLoadBalancerResp  *loadbalancer.LoadBalancer
+		isValid              bool
+		expectedTargets      *[]loadbalancer.Target
+	}{
+		{
+			description:         "base",
+			snapshot:            fixtureTargetSnapshot(),
+			getLoadBalancerResp: fixtureLoadBalancer(),
+			isValid:             true,
+			expectedTargets: &[]loadbalancer.Target{
+				{DisplayName: utils.Ptr("target-1"), Ip: utils.Ptr("1.2.3.4")},
+				{DisplayName: utils.Ptr("target-2"), Ip: utils.Ptr("4.3.2.1")},
+			},
+		},
+		{
+			description: "nil targets",
+			snapshot:    fixtureTargetSnapshot(),
+			getLoadBalancerResp: fixtureLoadBalancer(func(lb *loadbalancer.LoadBalancer) {
+				(*lb.TargetPools)[0].Targets = nil
+			}),
+			isValid: true,
+			expectedTargets: &[]loadbalancer.Target{
+				{DisplayName: utils.Ptr("target-2"), Ip: utils.Ptr("4.3.2.1")},
+			},
+		},
+		{
+			description: "target already in pool",
+			snapshot: fixtureTargetSnapshot(func(snapshot *history.LoadBalancerTargetSnapshot) {
+				snapshot.Target.Ip = utils.Ptr("1.2.3.4")
+			}),
+			getLoadBalancerResp: fixtureLoadBalancer(),
+			isValid:             false,
+		},
+		{
+			description: "target pool not found",
+			snapshot: fixtureTargetSnapshot(func(snapshot *history.LoadBalancerTargetSnapshot) {
+				snapshot.TargetPoolName = "not-existent"
+			}),
+			getLoadBalancerResp: fixtureLoadBalancer(),
+			isValid:             false,
+		},
+		{
+			description:          "get load balancer fails",
+			snapshot:             fixtureTargetSnapshot(),
+			getLoadBalancerFails: true,
+			isValid:              false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			client := &loadBalancerClientMocked{
+				getLoadBalancerFails: tt.getLoadBalancerFails,
+				getLoadBalancerResp:  tt.getLoadBalancerResp,
+			}
+			request, err := buildUpdateTargetPoolRequest(testCtx, client, tt.snapshot)
+			if err != nil {
+				if !tt.isValid {
+					return
+				}
+				t.Fatalf("error building request: %v", err)
+			}
+			if !tt.isValid {
+				t.Fatalf("did not fail on invalid input")
+			}
+
+			expectedRequest := testClient.UpdateTargetPool(testCtx, testProjectId, testRegion, testLBName, testTargetPoolName).
+				UpdateTargetPoolPayload(loadbalancer.UpdateTargetPoolPayload{
+					Name:       utils.Ptr(testTargetPoolName),
+					TargetPort: utils.Ptr(int64(80)),
+					Targets:    tt.expectedTargets,
+				})
+			diff := cmp.Diff(request, expectedRequest,
+				cmp.AllowUnexported(expectedRequest),
+				cmpopts.EquateComparable(testCtx),
+			)
+			if diff != "" {
+				t.Fatalf("Data does not match: %s", diff)
+			}
+		})
+	}
+}
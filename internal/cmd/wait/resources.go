@@ -0,0 +1,432 @@
+package wait
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/stackitcloud/stackit-cli/internal/cmd/params"
+	dnsClient "github.com/stackitcloud/stackit-cli/internal/pkg/services/dns/client"
+	iaasClient "github.com/stackitcloud/stackit-cli/internal/pkg/services/iaas/client"
+	loadBalancerClient "github.com/stackitcloud/stackit-cli/internal/pkg/services/load-balancer/client"
+	logmeClient "github.com/stackitcloud/stackit-cli/internal/pkg/services/logme/client"
+	mariadbClient "github.com/stackitcloud/stackit-cli/internal/pkg/services/mariadb/client"
+	mongoDBFlexClient "github.com/stackitcloud/stackit-cli/internal/pkg/services/mongodbflex/client"
+	openSearchClient "github.com/stackitcloud/stackit-cli/internal/pkg/services/opensearch/client"
+	postgresFlexClient "github.com/stackitcloud/stackit-cli/internal/pkg/services/postgresflex/client"
+	rabbitMQClient "github.com/stackitcloud/stackit-cli/internal/pkg/services/rabbitmq/client"
+	redisClient "github.com/stackitcloud/stackit-cli/internal/pkg/services/redis/client"
+	skeClient "github.com/stackitcloud/stackit-cli/internal/pkg/services/ske/client"
+	sqlServerFlexClient "github.com/stackitcloud/stackit-cli/internal/pkg/services/sqlserverflex/client"
+
+	sdkWait "github.com/stackitcloud/stackit-sdk-go/core/wait"
+	"github.com/stackitcloud/stackit-sdk-go/services/dns"
+	dnsWait "github.com/stackitcloud/stackit-sdk-go/services/dns/wait"
+	iaasWait "github.com/stackitcloud/stackit-sdk-go/services/iaas/wait"
+	"github.com/stackitcloud/stackit-sdk-go/services/loadbalancer"
+	loadBalancerWait "github.com/stackitcloud/stackit-sdk-go/services/loadbalancer/wait"
+	"github.com/stackitcloud/stackit-sdk-go/services/logme"
+	logmeWait "github.com/stackitcloud/stackit-sdk-go/services/logme/wait"
+	"github.com/stackitcloud/stackit-sdk-go/services/mariadb"
+	mariadbWait "github.com/stackitcloud/stackit-sdk-go/services/mariadb/wait"
+	"github.com/stackitcloud/stackit-sdk-go/services/mongodbflex"
+	mongoDBFlexWait "github.com/stackitcloud/stackit-sdk-go/services/mongodbflex/wait"
+	"github.com/stackitcloud/stackit-sdk-go/services/opensearch"
+	openSearchWait "github.com/stackitcloud/stackit-sdk-go/services/opensearch/wait"
+	postgresFlexWait "github.com/stackitcloud/stackit-sdk-go/services/postgresflex/wait"
+	"github.com/stackitcloud/stackit-sdk-go/services/rabbitmq"
+	rabbitMQWait "github.com/stackitcloud/stackit-sdk-go/services/rabbitmq/wait"
+	"github.com/stackitcloud/stackit-sdk-go/services/redis"
+	redisWait "github.com/stackitcloud/stackit-sdk-go/services/redis/wait"
+	"github.com/stackitcloud/stackit-sdk-go/services/ske"
+	skeWait "github.com/stackitcloud/stackit-sdk-go/services/ske/wait"
+	sqlServerFlexWait "github.com/stackitcloud/stackit-sdk-go/services/sqlserverflex/wait"
+)
+
+// waiter checks the state of a single resource
+type waiter struct {
+	// Returns the current state of the resource
+	getState func(ctx context.Context) (string, error)
+	// States from which the resource doesn't recover without user action
+	failedStates []string
+	// Wait handlers of the SDK, keyed by the state they wait for. Other states are polled with getState
+	waitStates map[string]func(ctx context.Context, timeout time.Duration) error
+	// Waits for the deletion of the resource, using the wait handler of the SDK
+	waitDeleted func(ctx context.Context, timeout time.Duration) error
+}
+
+type resourceType struct {
+	newWaiter func(params *params.CmdParams, model *inputModel) (*waiter, error)
+}
+
+var resourceTypes = map[string]resourceType{
+	"server":                 {newWaiter: newServerWaiter},
+	"volume":                 {newWaiter: newVolumeWaiter},
+	"ske-cluster":            {newWaiter: newSKEClusterWaiter},
+	"postgresflex-instance":  {newWaiter: newPostgresFlexInstanceWaiter},
+	"mongodbflex-instance":   {newWaiter: newMongoDBFlexInstanceWaiter},
+	"sqlserverflex-instance": {newWaiter: newSQLServerFlexInstanceWaiter},
+	"load-balancer":          {newWaiter: newLoadBalancerWaiter},
+	"dns-zone":               {newWaiter: newDNSZoneWaiter},
+	"logme-instance":         {newWaiter: newLogMeInstanceWaiter},
+	"mariadb-instance":       {newWaiter: newMariaDBInstanceWaiter},
+	"opensearch-instance":    {newWaiter: newOpenSearchInstanceWaiter},
+	"rabbitmq-instance":      {newWaiter: newRabbitMQInstanceWaiter},
+	"redis-instance":         {newWaiter: newRedisInstanceWaiter},
+}
+
+func waitWithTimeout[T any](ctx context.Context, handler *sdkWait.AsyncActionHandler[T], timeout time.Duration) error {
+	_, err := handler.SetTimeout(timeout).WaitWithContext(ctx)
+	return err
+}
+
+func newServerWaiter(params *params.CmdParams, model *inputModel) (*waiter, error) {
+	apiClient, err := iaasClient.ConfigureClient(params.Printer, params.CliVersion)
+	if err != nil {
+		return nil, err
+	}
+	return &waiter{
+		getState: func(ctx context.Context) (string, error) {
+			resp, err := apiClient.GetServerExecute(ctx, model.ProjectId, model.ResourceId)
+			if err != nil {
+				return "", err
+			}
+			return resp.GetStatus(), nil
+		},
+		failedStates: []string{iaasWait.ErrorStatus},
+		waitStates: map[string]func(ctx context.Context, timeout time.Duration) error{
+			iaasWait.ServerActiveStatus: func(ctx context.Context, timeout time.Duration) error {
+				return waitWithTimeout(ctx, iaasWait.StartServerWaitHandler(ctx, apiClient, model.ProjectId, model.ResourceId), timeout)
+			},
+			iaasWait.ServerInactiveStatus: func(ctx context.Context, timeout time.Duration) error {
+				return waitWithTimeout(ctx, iaasWait.StopServerWaitHandler(ctx, apiClient, model.ProjectId, model.ResourceId), timeout)
+			},
+			iaasWait.ServerDeallocatedStatus: func(ctx context.Context, timeout time.Duration) error {
+				return waitWithTimeout(ctx, iaasWait.DeallocateServerWaitHandler(ctx, apiClient, model.ProjectId, model.ResourceId), timeout)
+			},
+			iaasWait.ServerRescueStatus: func(ctx context.Context, timeout time.Duration) error {
+				return waitWithTimeout(ctx, iaasWait.RescueServerWaitHandler(ctx, apiClient, model.ProjectId, model.ResourceId), timeout)
+			},
+		},
+		waitDeleted: func(ctx context.Context, timeout time.Duration) error {
+			return waitWithTimeout(ctx, iaasWait.DeleteServerWaitHandler(ctx, apiClient, model.ProjectId, model.ResourceId), timeout)
+		},
+	}, nil
+}
+
+func newVolumeWaiter(params *params.CmdParams, model *inputModel) (*waiter, error) {
+	apiClient, err := iaasClient.ConfigureClient(params.Printer, params.CliVersion)
+	if err != nil {
+		return nil, err
+	}
+	return &waiter{
+		getState: func(ctx context.Context) (string, error) {
+			resp, err := apiClient.GetVolumeExecute(ctx, model.ProjectId, model.ResourceId)
+			if err != nil {
+				return "", err
+			}
+			return resp.GetStatus(), nil
+		},
+		failedStates: []string{iaasWait.ErrorStatus},
+		waitStates: map[string]func(ctx context.Context, timeout time.Duration) error{
+			iaasWait.VolumeAvailableStatus: func(ctx context.Context, timeout time.Duration) error {
+				return waitWithTimeout(ctx, iaasWait.CreateVolumeWaitHandler(ctx, apiClient, model.ProjectId, model.ResourceId), timeout)
+			},
+		},
+		waitDeleted: func(ctx context.Context, timeout time.Duration) error {
+			return waitWithTimeout(ctx, iaasWait.DeleteVolumeWaitHandler(ctx, apiClient, model.ProjectId, model.ResourceId), timeout)
+		},
+	}, nil
+}
+
+func newSKEClusterWaiter(params *params.CmdParams, model *inputModel) (*waiter, error) {
+	apiClient, err := skeClient.ConfigureClient(params.Printer, params.CliVersion)
+	if err != nil {
+		return nil, err
+	}
+	return &waiter{
+		getState: func(ctx context.Context) (string, error) {
+			resp, err := apiClient.GetClusterExecute(ctx, model.ProjectId, model.Region, model.ResourceId)
+			if err != nil {
+				return "", err
+			}
+			status := resp.GetStatus()
+			return string(status.GetAggregated()), nil
+		},
+		failedStates: []string{skeWait.StateFailed},
+		waitStates: map[string]func(ctx context.Context, timeout time.Duration) error{
+			string(ske.CLUSTERSTATUSSTATE_HEALTHY): func(ctx context.Context, timeout time.Duration) error {
+				// The handler also finishes for hibernated clusters
+				cluster, err := skeWait.CreateOrUpdateClusterWaitHandler(ctx, apiClient, model.ProjectId, model.Region, model.ResourceId).SetTimeout(timeout).WaitWithContext(ctx)
+				if err != nil {
+					return err
+				}
+				status := cluster.GetStatus()
+				if state := status.GetAggregated(); state != ske.CLUSTERSTATUSSTATE_HEALTHY {
+					return fmt.Errorf("resource reached the state %q", state)
+				}
+				return nil
+			},
+		},
+		waitDeleted: func(ctx context.Context, timeout time.Duration) error {
+			return waitWithTimeout(ctx, skeWait.DeleteClusterWaitHandler(ctx, apiClient, model.ProjectId, model.Region, model.ResourceId), timeout)
+		},
+	}, nil
+}
+
+func newPostgresFlexInstanceWaiter(params *params.CmdParams, model *inputModel) (*waiter, error) {
+	apiClient, err := postgresFlexClient.ConfigureClient(params.Printer, params.CliVersion)
+	if err != nil {
+		return nil, err
+	}
+	return &waiter{
+		getState: func(ctx context.Context) (string, error) {
+			resp, err := apiClient.GetInstanceExecute(ctx, model.ProjectId, model.Region, model.ResourceId)
+			if err != nil {
+				return "", err
+			}
+			instance := resp.GetItem()
+			return instance.GetStatus(), nil
+		},
+		failedStates: []string{postgresFlexWait.InstanceStateFailed},
+		waitStates: map[string]func(ctx context.Context, timeout time.Duration) error{
+			postgresFlexWait.InstanceStateSuccess: func(ctx context.Context, timeout time.Duration) error {
+				return waitWithTimeout(ctx, postgresFlexWait.PartialUpdateInstanceWaitHandler(ctx, apiClient, model.ProjectId, model.Region, model.ResourceId), timeout)
+			},
+		},
+		waitDeleted: func(ctx context.Context, timeout time.Duration) error {
+			return waitWithTimeout(ctx, postgresFlexWait.DeleteInstanceWaitHandler(ctx, apiClient, model.ProjectId, model.Region, model.ResourceId), timeout)
+		},
+	}, nil
+}
+
+func newMongoDBFlexInstanceWaiter(params *params.CmdParams, model *inputModel) (*waiter, error) {
+	apiClient, err := mongoDBFlexClient.ConfigureClient(params.Printer, params.CliVersion)
+	if err != nil {
+		return nil, err
+	}
+	return &waiter{
+		getState: func(ctx context.Context) (string, error) {
+			resp, err := apiClient.GetInstanceExecute(ctx, model.ProjectId, model.ResourceId, model.Region)
+			if err != nil {
+				return "", err
+			}
+			instance := resp.GetItem()
+			return string(instance.GetStatus()), nil
+		},
+		failedStates: []string{string(mongodbflex.INSTANCESTATUS_FAILED)},
+		waitStates: map[string]func(ctx context.Context, timeout time.Duration) error{
+			string(mongodbflex.INSTANCESTATUS_READY): func(ctx context.Context, timeout time.Duration) error {
+				return waitWithTimeout(ctx, mongoDBFlexWait.UpdateInstanceWaitHandler(ctx, apiClient, model.ProjectId, model.ResourceId, model.Region), timeout)
+			},
+		},
+		waitDeleted: func(ctx context.Context, timeout time.Duration) error {
+			return waitWithTimeout(ctx, mongoDBFlexWait.DeleteInstanceWaitHandler(ctx, apiClient, model.ProjectId, model.ResourceId, model.Region), timeout)
+		},
+	}, nil
+}
+
+func newSQLServerFlexInstanceWaiter(params *params.CmdParams, model *inputModel) (*waiter, error) {
+	apiClient, err := sqlServerFlexClient.ConfigureClient(params.Printer, params.CliVersion)
+	if err != nil {
+		return nil, err
+	}
+	return &waiter{
+		getState: func(ctx context.Context) (string, error) {
+			resp, err := apiClient.GetInstanceExecute(ctx, model.ProjectId, model.ResourceId, model.Region)
+			if err != nil {
+				return "", err
+			}
+			instance := resp.GetItem()
+			return instance.GetStatus(), nil
+		},
+		failedStates: []string{sqlServerFlexWait.InstanceStateFailed},
+		waitStates: map[string]func(ctx context.Context, timeout time.Duration) error{
+			sqlServerFlexWait.InstanceStateSuccess: func(ctx context.Context, timeout time.Duration) error {
+				return waitWithTimeout(ctx, sqlServerFlexWait.UpdateInstanceWaitHandler(ctx, apiClient, model.ProjectId, model.ResourceId, model.Region), timeout)
+			},
+		},
+		waitDeleted: func(ctx context.Context, timeout time.Duration) error {
+			return waitWithTimeout(ctx, sqlServerFlexWait.DeleteInstanceWaitHandler(ctx, apiClient, model.ProjectId, model.ResourceId, model.Region), timeout)
+		},
+	}, nil
+}
+
+func newLoadBalancerWaiter(params *params.CmdParams, model *inputModel) (*waiter, error) {
+	apiClient, err := loadBalancerClient.ConfigureClient(params.Printer, params.CliVersion)
+	if err != nil {
+		return nil, err
+	}
+	return &waiter{
+		getState: func(ctx context.Context) (string, error) {
+			resp, err := apiClient.GetLoadBalancerExecute(ctx, model.ProjectId, model.Region, model.ResourceId)
+			if err != nil {
+				return "", err
+			}
+			return string(resp.GetStatus()), nil
+		},
+		failedStates: []string{string(loadbalancer.LOADBALANCERSTATUS_ERROR)},
+		waitStates: map[string]func(ctx context.Context, timeout time.Duration) error{
+			string(loadbalancer.LOADBALANCERSTATUS_READY): func(ctx context.Context, timeout time.Duration) error {
+				return waitWithTimeout(ctx, loadBalancerWait.CreateLoadBalancerWaitHandler(ctx, apiClient, model.ProjectId, model.Region, model.ResourceId), timeout)
+			},
+		},
+		waitDeleted: func(ctx context.Context, timeout time.Duration) error {
+			return waitWithTimeout(ctx, loadBalancerWait.DeleteLoadBalancerWaitHandler(ctx, apiClient, model.ProjectId, model.Region, model.ResourceId), timeout)
+		},
+	}, nil
+}
+
+func newDNSZoneWaiter(params *params.CmdParams, model *inputModel) (*waiter, error) {
+	apiClient, err := dnsClient.ConfigureClient(params.Printer, params.CliVersion)
+	if err != nil {
+		return nil, err
+	}
+	return &waiter{
+		getState: func(ctx context.Context) (string, error) {
+			resp, err := apiClient.GetZoneExecute(ctx, model.ProjectId, model.ResourceId)
+			if err != nil {
+				return "", err
+			}
+			zone := resp.GetZone()
+			return string(zone.GetState()), nil
+		},
+		failedStates: []string{string(dns.ZONESTATE_CREATE_FAILED), string(dns.ZONESTATE_UPDATE_FAILED), string(dns.ZONESTATE_DELETE_FAILED)},
+		waitStates: map[string]func(ctx context.Context, timeout time.Duration) error{
+			string(dns.ZONESTATE_CREATE_SUCCEEDED): func(ctx context.Context, timeout time.Duration) error {
+				return waitWithTimeout(ctx, dnsWait.CreateZoneWaitHandler(ctx, apiClient, model.ProjectId, model.ResourceId), timeout)
+			},
+			string(dns.ZONESTATE_UPDATE_SUCCEEDED): func(ctx context.Context, timeout time.Duration) error {
+				return waitWithTimeout(ctx, dnsWait.PartialUpdateZoneWaitHandler(ctx, apiClient, model.ProjectId, model.ResourceId), timeout)
+			},
+		},
+		waitDeleted: func(ctx context.Context, timeout time.Duration) error {
+			return waitWithTimeout(ctx, dnsWait.DeleteZoneWaitHandler(ctx, apiClient, model.ProjectId, model.ResourceId), timeout)
+		},
+	}, nil
+}
+
+func newLogMeInstanceWaiter(params *params.CmdParams, model *inputModel) (*waiter, error) {
+	apiClient, err := logmeClient.ConfigureClient(params.Printer, params.CliVersion)
+	if err != nil {
+		return nil, err
+	}
+	return &waiter{
+		getState: func(ctx context.Context) (string, error) {
+			resp, err := apiClient.GetInstanceExecute(ctx, model.ProjectId, model.ResourceId)
+			if err != nil {
+				return "", err
+			}
+			return string(resp.GetStatus()), nil
+		},
+		failedStates: []string{string(logme.INSTANCESTATUS_FAILED)},
+		waitStates: map[string]func(ctx context.Context, timeout time.Duration) error{
+			string(logme.INSTANCESTATUS_ACTIVE): func(ctx context.Context, timeout time.Duration) error {
+				return waitWithTimeout(ctx, logmeWait.PartialUpdateInstanceWaitHandler(ctx, apiClient, model.ProjectId, model.ResourceId), timeout)
+			},
+		},
+		waitDeleted: func(ctx context.Context, timeout time.Duration) error {
+			return waitWithTimeout(ctx, logmeWait.DeleteInstanceWaitHandler(ctx, apiClient, model.ProjectId, model.ResourceId), timeout)
+		},
+	}, nil
+}
+
+func newMariaDBInstanceWaiter(params *params.CmdParams, model *inputModel) (*waiter, error) {
+	apiClient, err := mariadbClient.ConfigureClient(params.Printer, params.CliVersion)
+	if err != nil {
+		return nil, err
+	}
+	return &waiter{
+		getState: func(ctx context.Context) (string, error) {
+			resp, err := apiClient.GetInstanceExecute(ctx, model.ProjectId, model.ResourceId)
+			if err != nil {
+				return "", err
+			}
+			return string(resp.GetStatus()), nil
+		},
+		failedStates: []string{string(mariadb.INSTANCESTATUS_FAILED)},
+		waitStates: map[string]func(ctx context.Context, timeout time.Duration) error{
+			string(mariadb.INSTANCESTATUS_ACTIVE): func(ctx context.Context, timeout time.Duration) error {
+				return waitWithTimeout(ctx, mariadbWait.PartialUpdateInstanceWaitHandler(ctx, apiClient, model.ProjectId, model.ResourceId), timeout)
+			},
+		},
+		waitDeleted: func(ctx context.Context, timeout time.Duration) error {
+			return waitWithTimeout(ctx, mariadbWait.DeleteInstanceWaitHandler(ctx, apiClient, model.ProjectId, model.ResourceId), timeout)
+		},
+	}, nil
+}
+
+func newOpenSearchInstanceWaiter(params *params.CmdParams, model *inputModel) (*waiter, error) {
+	apiClient, err := openSearchClient.ConfigureClient(params.Printer, params.CliVersion)
+	if err != nil {
+		return nil, err
+	}
+	return &waiter{
+		getState: func(ctx context.Context) (string, error) {
+			resp, err := apiClient.GetInstanceExecute(ctx, model.ProjectId, model.ResourceId)
+			if err != nil {
+				return "", err
+			}
+			return string(resp.GetStatus()), nil
+		},
+		failedStates: []string{string(opensearch.INSTANCESTATUS_FAILED)},
+		waitStates: map[string]func(ctx context.Context, timeout time.Duration) error{
+			string(opensearch.INSTANCESTATUS_ACTIVE): func(ctx context.Context, timeout time.Duration) error {
+				return waitWithTimeout(ctx, openSearchWait.PartialUpdateInstanceWaitHandler(ctx, apiClient, model.ProjectId, model.ResourceId), timeout)
+			},
+		},
+		waitDeleted: func(ctx context.Context, timeout time.Duration) error {
+			return waitWithTimeout(ctx, openSearchWait.DeleteInstanceWaitHandler(ctx, apiClient, model.ProjectId, model.ResourceId), timeout)
+		},
+	}, nil
+}
+
+func newRabbitMQInstanceWaiter(params *params.CmdParams, model *inputModel) (*waiter, error) {
+	apiClient, err := rabbitMQClient.ConfigureClient(params.Printer, params.CliVersion)
+	if err != nil {
+		return nil, err
+	}
+	return &waiter{
+		getState: func(ctx context.Context) (string, error) {
+			resp, err := apiClient.GetInstanceExecute(ctx, model.ProjectId, model.ResourceId)
+			if err != nil {
+				return "", err
+			}
+			return string(resp.GetStatus()), nil
+		},
+		failedStates: []string{string(rabbitmq.INSTANCESTATUS_FAILED)},
+		waitStates: map[string]func(ctx context.Context, timeout time.Duration) error{
+			string(rabbitmq.INSTANCESTATUS_ACTIVE): func(ctx context.Context, timeout time.Duration) error {
+				return waitWithTimeout(ctx, rabbitMQWait.PartialUpdateInstanceWaitHandler(ctx, apiClient, model.ProjectId, model.ResourceId), timeout)
+			},
+		},
+		waitDeleted: func(ctx context.Context, timeout time.Duration) error {
+			return waitWithTimeout(ctx, rabbitMQWait.DeleteInstanceWaitHandler(ctx, apiClient, model.ProjectId, model.ResourceId), timeout)
+		},
+	}, nil
+}
+
+func newRedisInstanceWaiter(params *params.CmdParams, model *inputModel) (*waiter, error) {
+	apiClient, err := redisClient.ConfigureClient(params.Printer, params.CliVersion)
+	if err != nil {
+		return nil, err
+	}
+	return &waiter{
+		getState: func(ctx context.Context) (string, error) {
+			resp, err := apiClient.GetInstanceExecute(ctx, model.ProjectId, model.ResourceId)
+			if err != nil {
+				return "", err
+			}
+			return string(resp.GetStatus()), nil
+		},
+		failedStates: []string{string(redis.INSTANCESTATUS_FAILED)},
+		waitStates: map[string]func(ctx context.Context, timeout time.Duration) error{
+			string(redis.INSTANCESTATUS_ACTIVE): func(ctx context.Context, timeout time.Duration) error {
+				return waitWithTimeout(ctx, redisWait.PartialUpdateInstanceWaitHandler(ctx, apiClient, model.ProjectId, model.ResourceId), timeout)
+			},
+		},
+		waitDeleted: func(ctx context.Context, timeout time.Duration) error {
+			return waitWithTimeout(ctx, redisWait.DeleteInstanceWaitHandler(ctx, apiClient, model.ProjectId, model.ResourceId), timeout)
+		},
+	}, nil
+}
@@ -0,0 +1,210 @@
+package wait
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/stackitcloud/stackit-cli/internal/cmd/params"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/errors"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/examples"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/flags"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/globalflags"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/print"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/spinner"
+
+	"github.com/spf13/cobra"
+	sdkWait "github.com/stackitcloud/stackit-sdk-go/core/wait"
+)
+
+const (
+	resourceTypeArg = "RESOURCE_TYPE"
+	resourceIdArg   = "RESOURCE_ID"
+
+	forFlag     = "for"
+	timeoutFlag = "timeout"
+
+	deletedCondition     = "deleted"
+	stateConditionPrefix = "state="
+
+	timeoutDefault = 30 * time.Minute
+)
+
+// Interval between the requests checking the state of the resource
+var pollInterval = 5 * time.Second
+
+type inputModel struct {
+	*globalflags.GlobalFlagModel
+	ResourceType string
+	ResourceId   string
+	// State to wait for, empty if waiting for the deletion of the resource
+	State   string
+	Timeout time.Duration
+}
+
+func NewCmd(params *params.CmdParams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   fmt.Sprintf("wait %s %s", resourceTypeArg, resourceIdArg),
+		Short: "Waits for a resource to reach a state or to be deleted",
+		Long: fmt.Sprintf("%s\n%s\n%s\n%s",
+			"Waits for a resource to reach a state or to be deleted.",
+			"This can be used to resume waiting for an operation started with the --async flag, or whose command was interrupted.",
+			"The resource ID is the name of the resource for SKE clusters and load balancers.",
+			fmt.Sprintf("Supported resource types: %s", strings.Join(resourceTypeNames(), ", ")),
+		),
+		Args: cobra.ExactArgs(2),
+		Example: examples.Build(
+			examples.NewExample(
+				`Wait for the server with ID "xxx" to become active`,
+				"$ stackit wait server xxx --for state=ACTIVE"),
+			examples.NewExample(
+				`Wait for the SKE cluster with name "my-cluster" to become healthy, for at most 45 minutes`,
+				"$ stackit wait ske-cluster my-cluster --for state=STATE_HEALTHY --timeout 45m"),
+			examples.NewExample(
+				`Wait for the PostgreSQL Flex instance with ID "xxx" to be deleted`,
+				"$ stackit wait postgresflex-instance xxx --for deleted"),
+		),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.Background()
+			model, err := parseInput(params.Printer, cmd, args)
+			if err != nil {
+				return err
+			}
+
+			w, err := resourceTypes[model.ResourceType].newWaiter(params, model)
+			if err != nil {
+				return err
+			}
+
+			s := spinner.New(params.Printer)
+			if model.State == "" {
+				s.Start(fmt.Sprintf("Waiting for %s %q to be deleted", model.ResourceType, model.ResourceId))
+				err = w.waitDeleted(ctx, model.Timeout)
+				if err != nil {
+					s.StopWithError()
+					return fmt.Errorf("wait for %s deletion: %w", model.ResourceType, err)
+				}
+				s.Stop()
+				params.Printer.Info("Deleted %s %q\n", model.ResourceType, model.ResourceId)
+				return nil
+			}
+
+			s.Start(fmt.Sprintf("Waiting for %s %q to reach state %q", model.ResourceType, model.ResourceId, model.State))
+			err = waitForState(ctx, w, model.State, model.Timeout)
+			if err != nil {
+				s.StopWithError()
+				return fmt.Errorf("wait for %s state: %w", model.ResourceType, err)
+			}
+			s.Stop()
+			params.Printer.Info("%s %q reached state %q\n", model.ResourceType, model.ResourceId, model.State)
+			return nil
+		},
+	}
+	configureFlags(cmd)
+	return cmd
+}
+
+func configureFlags(cmd *cobra.Command) {
+	cmd.Flags().String(forFlag, "", fmt.Sprintf(`Condition to wait for, either "%s<STATE>" or %q`, stateConditionPrefix, deletedCondition))
+	cmd.Flags().Duration(timeoutFlag, timeoutDefault, "Maximum time to wait, e.g. 30m or 1h")
+
+	err := flags.MarkFlagsRequired(cmd, forFlag)
+	cobra.CheckErr(err)
+}
+
+func parseInput(p *print.Printer, cmd *cobra.Command, inputArgs []string) (*inputModel, error) {
+	resourceType := inputArgs[0]
+	resourceId := inputArgs[1]
+
+	globalFlags := globalflags.Parse(p, cmd)
+	if globalFlags.ProjectId == "" {
+		return nil, &errors.ProjectIdError{}
+	}
+
+	if _, ok := resourceTypes[resourceType]; !ok {
+		return nil, &errors.ArgValidationError{
+			Arg:     resourceTypeArg,
+			Details: fmt.Sprintf("unsupported resource type %q, must be one of: %s", resourceType, strings.Join(resourceTypeNames(), ", ")),
+		}
+	}
+
+	condition := flags.FlagToStringValue(p, cmd, forFlag)
+	state := ""
+	switch {
+	case condition == deletedCondition:
+	case strings.HasPrefix(condition, stateConditionPrefix) && len(condition) > len(stateConditionPrefix):
+		state = strings.TrimPrefix(condition, stateConditionPrefix)
+	default:
+		return nil, &errors.FlagValidationError{
+			Flag:    forFlag,
+			Details: fmt.Sprintf(`must be either "%s<STATE>" or %q`, stateConditionPrefix, deletedCondition),
+		}
+	}
+
+	timeout := flags.FlagWithDefaultToDurationValue(p, cmd, timeoutFlag)
+	if timeout <= 0 {
+		return nil, &errors.FlagValidationError{
+			Flag:    timeoutFlag,
+			Details: "must be a positive duration, e.g. 30m or 1h",
+		}
+	}
+
+	model := inputModel{
+		GlobalFlagModel: globalFlags,
+		ResourceType:    resourceType,
+		ResourceId:      resourceId,
+		State:           state,
+		Timeout:         timeout,
+	}
+
+	if p.IsVerbosityDebug() {
+		modelStr, err := print.BuildDebugStrFromInputModel(model)
+		if err != nil {
+			p.Debug(print.ErrorLevel, "convert model to string for debugging: %v", err)
+		} else {
+			p.Debug(print.DebugLevel, "parsed input values: %s", modelStr)
+		}
+	}
+
+	return &model, nil
+}
+
+// waitForState waits until the state of the resource matches the given one (case-insensitive).
+// The wait handler of the SDK is used if there is one for the state, otherwise the state is polled until it matches.
+// Waiting stops with an error if the resource reaches a failed state.
+func waitForState(ctx context.Context, w *waiter, state string, timeout time.Duration) error {
+	for handlerState, waitState := range w.waitStates {
+		if strings.EqualFold(handlerState, state) {
+			return waitState(ctx, timeout)
+		}
+	}
+
+	handler := sdkWait.New(func() (waitFinished bool, response *string, err error) {
+		currentState, err := w.getState(ctx)
+		if err != nil {
+			return false, nil, err
+		}
+		if strings.EqualFold(currentState, state) {
+			return true, &currentState, nil
+		}
+		if slices.ContainsFunc(w.failedStates, func(s string) bool { return strings.EqualFold(s, currentState) }) {
+			return true, &currentState, fmt.Errorf("resource reached the failed state %q", currentState)
+		}
+		return false, &currentState, nil
+	})
+	handler.SetThrottle(pollInterval).SetTimeout(timeout)
+	_, err := handler.WaitWithContext(ctx)
+	return err
+}
+
+func resourceTypeNames() []string {
+	names := make([]string, 0, len(resourceTypes))
+	for name := range resourceTypes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,350 @@
+package wait
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stackitcloud/stackit-cli/internal/cmd/params"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/globalflags"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/print"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/uuid"
+)
+
+var testProjectId = uuid.NewString()
+var testResourceId = uuid.NewString()
+
+const testRegion = "eu01"
+
+func fixtureArgValues(mods ...func(argValues []string)) []string {
+	argValues := []string{
+		"server",
+		testResourceId,
+	}
+	for _, mod := range mods {
+		mod(argValues)
+	}
+	return argValues
+}
+
+func fixtureFlagValues(mods ...func(flagValues map[string]string)) map[string]string {
+	flagValues := map[string]string{
+		globalflags.ProjectIdFlag: testProjectId,
+		globalflags.RegionFlag:    testRegion,
+		forFlag:                   "state=ACTIVE",
+	}
+	for _, mod := range mods {
+		mod(flagValues)
+	}
+	return flagValues
+}
+
+func fixtureInputModel(mods ...func(model *inputModel)) *inputModel {
+	model := &inputModel{
+		GlobalFlagModel: &globalflags.GlobalFlagModel{
+			ProjectId: testProjectId,
+			Region:    testRegion,
+			Verbosity: globalflags.VerbosityDefault,
+		},
+		ResourceType: "server",
+		ResourceId:   testResourceId,
+		State:        "ACTIVE",
+		Timeout:      30 * time.Minute,
+	}
+	for _, mod := range mods {
+		mod(model)
+	}
+	return model
+}
+
+func TestParseInput(t *testing.T) {
+	tests := []struct {
+		description   string
+		argValues     []string
+		flagValues    map[string]string
+		isValid       bool
+		expectedModel *inputModel
+	}{
+		{
+			description:   "base",
+			argValues:     fixtureArgValues(),
+			flagValues:    fixtureFlagValues(),
+			isValid:       true,
+			expectedModel: fixtureInputModel(),
+		},
+		{
+			description: "wait for deletion",
+			argValues:   fixtureArgValues(),
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				flagValues[forFlag] = "deleted"
+			}),
+			isValid: true,
+			expectedModel: fixtureInputModel(func(model *inputModel) {
+				model.State = ""
+			}),
+		},
+		{
+			description: "timeout",
+			argValues:   fixtureArgValues(),
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				flagValues[timeoutFlag] = "1h"
+			}),
+			isValid: true,
+			expectedModel: fixtureInputModel(func(model *inputModel) {
+				model.Timeout = time.Hour
+			}),
+		},
+		{
+			description: "other resource type",
+			argValues: fixtureArgValues(func(argValues []string) {
+				argValues[0] = "ske-cluster"
+				argValues[1] = "my-cluster"
+			}),
+			flagValues: fixtureFlagValues(),
+			isValid:    true,
+			expectedModel: fixtureInputModel(func(model *inputModel) {
+				model.ResourceType = "ske-cluster"
+				model.ResourceId = "my-cluster"
+			}),
+		},
+		{
+			description: "no arg values",
+			argValues:   []string{},
+			flagValues:  fixtureFlagValues(),
+			isValid:     false,
+		},
+		{
+			description: "only resource type",
+			argValues:   []string{"server"},
+			flagValues:  fixtureFlagValues(),
+			isValid:     false,
+		},
+		{
+			description: "unsupported resource type",
+			argValues: fixtureArgValues(func(argValues []string) {
+				argValues[0] = "unknown"
+			}),
+			flagValues: fixtureFlagValues(),
+			isValid:    false,
+		},
+		{
+			description: "project id missing",
+			argValues:   fixtureArgValues(),
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				delete(flagValues, globalflags.ProjectIdFlag)
+			}),
+			isValid: false,
+		},
+		{
+			description: "condition missing",
+			argValues:   fixtureArgValues(),
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				delete(flagValues, forFlag)
+			}),
+			isValid: false,
+		},
+		{
+			description: "invalid condition",
+			argValues:   fixtureArgValues(),
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				flagValues[forFlag] = "ACTIVE"
+			}),
+			isValid: false,
+		},
+		{
+			description: "empty state",
+			argValues:   fixtureArgValues(),
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				flagValues[forFlag] = "state="
+			}),
+			isValid: false,
+		},
+		{
+			description: "invalid timeout",
+			argValues:   fixtureArgValues(),
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				flagValues[timeoutFlag] = "soon"
+			}),
+			isValid: false,
+		},
+		{
+			description: "negative timeout",
+			argValues:   fixtureArgValues(),
+			flagValues: fixtureFlagValues(func(flagValues map[string]string) {
+				flagValues[timeoutFlag] = "-5m"
+			}),
+			isValid: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			p := print.NewPrinter()
+			cmd := NewCmd(&params.CmdParams{Printer: p})
+			err := globalflags.Configure(cmd.Flags())
+			if err != nil {
+				t.Fatalf("configure global flags: %v", err)
+			}
+
+			for flag, value := range tt.flagValues {
+				err := cmd.Flags().Set(flag, value)
+				if err != nil {
+					if !tt.isValid {
+						return
+					}
+					t.Fatalf("setting flag --%s=%s: %v", flag, value, err)
+				}
+			}
+
+			err = cmd.ValidateArgs(tt.argValues)
+			if err != nil {
+				if !tt.isValid {
+					return
+				}
+				t.Fatalf("error validating args: %v", err)
+			}
+
+			err = cmd.ValidateRequiredFlags()
+			if err != nil {
+				if !tt.isValid {
+					return
+				}
+				t.Fatalf("error validating flags: %v", err)
+			}
+
+			model, err := parseInput(p, cmd, tt.argValues)
+			if err != nil {
+				if !tt.isValid {
+					return
+				}
+				t.Fatalf("error parsing input: %v", err)
+			}
+
+			if !tt.isValid {
+				t.Fatalf("did not fail on invalid input")
+			}
+			diff := cmp.Diff(model, tt.expectedModel)
+			if diff != "" {
+				t.Fatalf("Data does not match: %s", diff)
+			}
+		})
+	}
+}
+
+func TestWaitForState(t *testing.T) {
+	pollInterval = time.Millisecond
+	defer func() { pollInterval = 5 * time.Second }()
+
+	tests := []struct {
+		description string
+		states      []string
+		getStateErr error
+		// Error returned by the wait handler for the "READY" state
+		waitStateErr error
+		state        string
+		timeout      time.Duration
+		isValid      bool
+		// Whether the wait handler is expected to be used instead of polling the state
+		expectedWaitState bool
+	}{
+		{
+			description: "state reached",
+			states:      []string{"CREATING", "CREATING", "ACTIVE"},
+			state:       "ACTIVE",
+			timeout:     time.Second,
+			isValid:     true,
+		},
+		{
+			description: "state is case-insensitive",
+			states:      []string{"creating", "active"},
+			state:       "ACTIVE",
+			timeout:     time.Second,
+			isValid:     true,
+		},
+		{
+			description: "failed state",
+			states:      []string{"CREATING", "ERROR"},
+			state:       "ACTIVE",
+			timeout:     time.Second,
+			isValid:     false,
+		},
+		{
+			description: "waiting for failed state",
+			states:      []string{"CREATING", "ERROR"},
+			state:       "ERROR",
+			timeout:     time.Second,
+			isValid:     true,
+		},
+		{
+			description: "timeout",
+			states:      []string{"CREATING"},
+			state:       "ACTIVE",
+			timeout:     50 * time.Millisecond,
+			isValid:     false,
+		},
+		{
+			description: "get state fails",
+			getStateErr: fmt.Errorf("not found"),
+			state:       "ACTIVE",
+			timeout:     time.Second,
+			isValid:     false,
+		},
+		{
+			description:       "wait handler",
+			state:             "ready",
+			timeout:           time.Second,
+			isValid:           true,
+			expectedWaitState: true,
+		},
+		{
+			description:       "wait handler fails",
+			waitStateErr:      fmt.Errorf("update failed"),
+			state:             "READY",
+			timeout:           time.Second,
+			isValid:           false,
+			expectedWaitState: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			calls := 0
+			waitStateCalled := false
+			w := &waiter{
+				getState: func(_ context.Context) (string, error) {
+					if tt.getStateErr != nil {
+						return "", tt.getStateErr
+					}
+					// The last state is repeated once all states were returned
+					state := tt.states[min(calls, len(tt.states)-1)]
+					calls++
+					return state, nil
+				},
+				failedStates: []string{"ERROR"},
+				waitStates: map[string]func(ctx context.Context, timeout time.Duration) error{
+					"READY": func(_ context.Context, timeout time.Duration) error {
+						if timeout != tt.timeout {
+							t.Errorf("expected timeout %s, got %s", tt.timeout, timeout)
+						}
+						waitStateCalled = true
+						return tt.waitStateErr
+					},
+				},
+			}
+
+			err := waitForState(context.Background(), w, tt.state, tt.timeout)
+			if !tt.isValid && err == nil {
+				t.Fatalf("did not fail on invalid input")
+			}
+			if tt.isValid && err != nil {
+				t.Fatalf("wait for state: %v", err)
+			}
+			if waitStateCalled != tt.expectedWaitState {
+				t.Fatalf("expected wait handler to be used: %t, got %t", tt.expectedWaitState, waitStateCalled)
+			}
+		})
+	}
+}
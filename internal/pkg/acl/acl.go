@@ -0,0 +1,271 @@
+package acl
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/stackitcloud/stackit-cli/internal/pkg/config"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/errors"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/flags"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/print"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/tables"
+
+	"github.com/goccy/go-yaml"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+const (
+	CIDRFlag       = "cidr"
+	MyIPFlag       = "my-ip"
+	AllowEmptyFlag = "allow-empty"
+
+	// Endpoint used to detect the caller's IP address if none is configured
+	DefaultMyIPEndpoint = "https://api.ipify.org"
+
+	myIPTimeout = 10 * time.Second
+)
+
+// Options are the values of the flags selecting the IP networks added to or removed from an ACL
+type Options struct {
+	CIDRs []string
+	MyIP  bool
+	// Only set by the remove commands, see ConfigureRemoveFlags
+	AllowEmpty bool
+}
+
+// Result is the outcome of adding IP networks to or removing them from the ACL of an instance
+type Result struct {
+	InstanceId string   `json:"instanceId"`
+	Added      []string `json:"added,omitempty"`
+	Removed    []string `json:"removed,omitempty"`
+	Acl        []string `json:"acl"`
+}
+
+// ConfigureFlags adds the flags selecting the IP networks, of which at least one has to be set
+func ConfigureFlags(cmd *cobra.Command) {
+	cmd.Flags().Var(flags.CIDRSliceFlag(), CIDRFlag, "IP network in CIDR notation, e.g. 192.0.2.0/24. Can be repeated or given as a comma-separated list")
+	cmd.Flags().Bool(MyIPFlag, false, fmt.Sprintf(`Use the public IP address of this machine, as detected by the endpoint set with "stackit config set --my-ip-endpoint" (default %s)`, DefaultMyIPEndpoint))
+
+	cmd.MarkFlagsOneRequired(CIDRFlag, MyIPFlag)
+}
+
+// ConfigureRemoveFlags adds the flags of ConfigureFlags and the flag allowing to remove the last IP networks of the ACL
+func ConfigureRemoveFlags(cmd *cobra.Command) {
+	ConfigureFlags(cmd)
+	cmd.Flags().Bool(AllowEmptyFlag, false, "Allow removing the last IP networks of the ACL, after which no IP network can access the instance")
+}
+
+// ParseFlags returns the values of the flags added by ConfigureFlags or ConfigureRemoveFlags
+func ParseFlags(p *print.Printer, cmd *cobra.Command) (*Options, error) {
+	options := &Options{
+		CIDRs: flags.FlagToStringSliceValue(p, cmd, CIDRFlag),
+		MyIP:  flags.FlagToBoolValue(p, cmd, MyIPFlag),
+	}
+	if cmd.Flags().Lookup(AllowEmptyFlag) != nil {
+		options.AllowEmpty = flags.FlagToBoolValue(p, cmd, AllowEmptyFlag)
+	}
+	if options.CIDRs == nil {
+		options.CIDRs = []string{}
+	}
+	if len(options.CIDRs) == 0 && !options.MyIP {
+		return nil, &errors.FlagValidationError{
+			Flag:    CIDRFlag,
+			Details: fmt.Sprintf("at least one of --%s or --%s must be set", CIDRFlag, MyIPFlag),
+		}
+	}
+	return options, nil
+}
+
+// ValidateMyIPEndpoint returns an error if the caller's IP address can't be detected with the given endpoint
+func ValidateMyIPEndpoint(endpoint string) error {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return fmt.Errorf("parse URL: %w", err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("unsupported URL scheme %q, must be http or https", u.Scheme)
+	}
+	if u.Host == "" {
+		return fmt.Errorf("URL %q has no host", endpoint)
+	}
+	return nil
+}
+
+// Resolve returns the IP networks selected by the options.
+// If MyIP is set, the caller's IP address is detected and added as a single-address network.
+func Resolve(ctx context.Context, p *print.Printer, options *Options) ([]string, error) {
+	cidrs := slices.Clone(options.CIDRs)
+	if !options.MyIP {
+		return cidrs, nil
+	}
+
+	endpoint := viper.GetString(config.MyIpEndpointKey)
+	if endpoint == "" {
+		endpoint = DefaultMyIPEndpoint
+	}
+	addr, err := detectIP(ctx, endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("detect public IP address with %s: %w", endpoint, err)
+	}
+	p.Debug(print.DebugLevel, "detected public IP address %s", addr)
+	return append(cidrs, netip.PrefixFrom(addr, addr.BitLen()).String()), nil
+}
+
+func detectIP(ctx context.Context, endpoint string) (netip.Addr, error) {
+	ctx, cancel := context.WithTimeout(ctx, myIPTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, http.NoBody)
+	if err != nil {
+		return netip.Addr{}, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return netip.Addr{}, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return netip.Addr{}, fmt.Errorf("unexpected status %s", resp.Status)
+	}
+
+	// IP addresses are short, anything longer isn't a plain text response
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 64))
+	if err != nil {
+		return netip.Addr{}, fmt.Errorf("read response: %w", err)
+	}
+	addr, err := netip.ParseAddr(strings.TrimSpace(string(body)))
+	if err != nil {
+		return netip.Addr{}, fmt.Errorf("response is not an IP address: %w", err)
+	}
+	return addr.Unmap(), nil
+}
+
+// Add returns the ACL with the given IP networks appended, and the networks that weren't already in it.
+// Networks are compared by the addresses they contain, e.g. 10.0.0.1/24 is the same as 10.0.0.0/24.
+func Add(current, cidrs []string) (acl, added []string) {
+	acl = slices.Clone(current)
+	added = []string{}
+	for _, cidr := range cidrs {
+		if slices.ContainsFunc(acl, func(entry string) bool { return equal(entry, cidr) }) {
+			continue
+		}
+		acl = append(acl, cidr)
+		added = append(added, cidr)
+	}
+	return acl, added
+}
+
+// Remove returns the ACL without the given IP networks, and the entries that were removed from it.
+// Networks are compared like in Add.
+func Remove(current, cidrs []string) (acl, removed []string) {
+	acl = []string{}
+	removed = []string{}
+	for _, entry := range current {
+		if slices.ContainsFunc(cidrs, func(cidr string) bool { return equal(entry, cidr) }) {
+			removed = append(removed, entry)
+			continue
+		}
+		acl = append(acl, entry)
+	}
+	return acl, removed
+}
+
+// CheckRemoval returns an error if the ACL is empty after removing IP networks from it, unless allowed by the options.
+// No IP network can access an instance with an empty ACL.
+func CheckRemoval(instanceLabel string, updatedAcl []string, options *Options) error {
+	if len(updatedAcl) > 0 || options.AllowEmpty {
+		return nil
+	}
+	return fmt.Errorf("the ACL of instance %q would be empty, so no IP network could access it. Set --%s to remove the last IP networks anyway", instanceLabel, AllowEmptyFlag)
+}
+
+func equal(a, b string) bool {
+	prefixA, errA := netip.ParsePrefix(a)
+	prefixB, errB := netip.ParsePrefix(b)
+	if errA != nil || errB != nil {
+		return a == b
+	}
+	return prefixA.Masked() == prefixB.Masked()
+}
+
+// OutputResult prints the result of adding IP networks to or removing them from the ACL of an instance
+func OutputResult(p *print.Printer, outputFormat string, async bool, instanceLabel string, result *Result) error {
+	if result == nil {
+		return fmt.Errorf("no result passed")
+	}
+
+	switch outputFormat {
+	case print.JSONOutputFormat:
+		details, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			return fmt.Errorf("marshal ACL update: %w", err)
+		}
+		p.Outputln(string(details))
+
+		return nil
+	case print.YAMLOutputFormat:
+		details, err := yaml.MarshalWithOptions(result, yaml.IndentSequence(true), yaml.UseJSONMarshaler())
+		if err != nil {
+			return fmt.Errorf("marshal ACL update: %w", err)
+		}
+		p.Outputln(string(details))
+
+		return nil
+	default:
+		operationState := "Updated"
+		if async {
+			operationState = "Triggered update of"
+		}
+		p.Outputf("%s the ACL of instance %q\n", operationState, instanceLabel)
+		if len(result.Added) > 0 {
+			p.Outputf("Added: %s\n", strings.Join(result.Added, ", "))
+		}
+		if len(result.Removed) > 0 {
+			p.Outputf("Removed: %s\n", strings.Join(result.Removed, ", "))
+		}
+		return nil
+	}
+}
+
+// OutputList prints the IP networks in the ACL of an instance
+func OutputList(p *print.Printer, outputFormat string, acl []string) error {
+	switch outputFormat {
+	case print.JSONOutputFormat:
+		details, err := json.MarshalIndent(acl, "", "  ")
+		if err != nil {
+			return fmt.Errorf("marshal ACL: %w", err)
+		}
+		p.Outputln(string(details))
+
+		return nil
+	case print.YAMLOutputFormat:
+		details, err := yaml.MarshalWithOptions(acl, yaml.IndentSequence(true), yaml.UseJSONMarshaler())
+		if err != nil {
+			return fmt.Errorf("marshal ACL: %w", err)
+		}
+		p.Outputln(string(details))
+
+		return nil
+	default:
+		table := tables.NewTable()
+		table.SetHeader("CIDR")
+		for _, cidr := range acl {
+			table.AddRow(cidr)
+		}
+		err := table.Display(p)
+		if err != nil {
+			return fmt.Errorf("render table: %w", err)
+		}
+
+		return nil
+	}
+}
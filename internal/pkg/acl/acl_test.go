@@ -0,0 +1,493 @@
+package acl
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stackitcloud/stackit-cli/internal/pkg/config"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/print"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+func fixturePrinter(buf *bytes.Buffer) *print.Printer {
+	p := print.NewPrinter()
+	p.Cmd = &cobra.Command{}
+	p.Cmd.SetOut(buf)
+	p.Cmd.SetErr(&bytes.Buffer{})
+	return p
+}
+
+func TestParseFlags(t *testing.T) {
+	tests := []struct {
+		description     string
+		flagValues      map[string]string
+		isValid         bool
+		expectedOptions *Options
+	}{
+		{
+			description: "cidrs",
+			flagValues: map[string]string{
+				CIDRFlag: "10.0.0.0/24,192.0.2.1/32",
+			},
+			isValid: true,
+			expectedOptions: &Options{
+				CIDRs: []string{"10.0.0.0/24", "192.0.2.1/32"},
+			},
+		},
+		{
+			description: "my IP",
+			flagValues: map[string]string{
+				MyIPFlag: "true",
+			},
+			isValid: true,
+			expectedOptions: &Options{
+				CIDRs: []string{},
+				MyIP:  true,
+			},
+		},
+		{
+			description: "cidrs and my IP",
+			flagValues: map[string]string{
+				CIDRFlag: "2001:db8::/32",
+				MyIPFlag: "true",
+			},
+			isValid: true,
+			expectedOptions: &Options{
+				CIDRs: []string{"2001:db8::/32"},
+				MyIP:  true,
+			},
+		},
+		{
+			description: "cidr invalid",
+			flagValues: map[string]string{
+				CIDRFlag: "10.0.0.0",
+			},
+			isValid: false,
+		},
+		{
+			description: "nothing set",
+			flagValues:  map[string]string{},
+			isValid:     false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			p := print.NewPrinter()
+			cmd := &cobra.Command{}
+			ConfigureFlags(cmd)
+
+			for flag, value := range tt.flagValues {
+				err := cmd.Flags().Set(flag, value)
+				if err != nil {
+					if !tt.isValid {
+						return
+					}
+					t.Fatalf("setting flag --%s=%s: %v", flag, value, err)
+				}
+			}
+
+			err := cmd.ValidateFlagGroups()
+			if err != nil {
+				if !tt.isValid {
+					return
+				}
+				t.Fatalf("error validating flags: %v", err)
+			}
+
+			options, err := ParseFlags(p, cmd)
+			if err != nil {
+				if !tt.isValid {
+					return
+				}
+				t.Fatalf("error parsing flags: %v", err)
+			}
+
+			if !tt.isValid {
+				t.Fatalf("did not fail on invalid input")
+			}
+			diff := cmp.Diff(options, tt.expectedOptions)
+			if diff != "" {
+				t.Fatalf("Data does not match: %s", diff)
+			}
+		})
+	}
+}
+
+func TestParseRemoveFlags(t *testing.T) {
+	p := print.NewPrinter()
+	cmd := &cobra.Command{}
+	ConfigureRemoveFlags(cmd)
+
+	err := cmd.Flags().Set(CIDRFlag, "10.0.0.0/24")
+	if err != nil {
+		t.Fatalf("setting flag --%s: %v", CIDRFlag, err)
+	}
+	err = cmd.Flags().Set(AllowEmptyFlag, "true")
+	if err != nil {
+		t.Fatalf("setting flag --%s: %v", AllowEmptyFlag, err)
+	}
+
+	options, err := ParseFlags(p, cmd)
+	if err != nil {
+		t.Fatalf("error parsing flags: %v", err)
+	}
+	diff := cmp.Diff(options, &Options{
+		CIDRs:      []string{"10.0.0.0/24"},
+		AllowEmpty: true,
+	})
+	if diff != "" {
+		t.Fatalf("Data does not match: %s", diff)
+	}
+}
+
+func TestCheckRemoval(t *testing.T) {
+	tests := []struct {
+		description string
+		updatedAcl  []string
+		allowEmpty  bool
+		isValid     bool
+	}{
+		{
+			description: "entries left",
+			updatedAcl:  []string{"10.0.0.0/24"},
+			isValid:     true,
+		},
+		{
+			description: "empty",
+			updatedAcl:  []string{},
+			isValid:     false,
+		},
+		{
+			description: "empty allowed",
+			updatedAcl:  []string{},
+			allowEmpty:  true,
+			isValid:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			err := CheckRemoval("instance", tt.updatedAcl, &Options{AllowEmpty: tt.allowEmpty})
+			if !tt.isValid && err == nil {
+				t.Fatalf("did not fail on invalid input")
+			}
+			if tt.isValid && err != nil {
+				t.Fatalf("failed on valid input: %v", err)
+			}
+		})
+	}
+}
+
+func TestValidateMyIPEndpoint(t *testing.T) {
+	tests := []struct {
+		endpoint string
+		isValid  bool
+	}{
+		{"https://api.ipify.org", true},
+		{"http://localhost:8080/ip", true},
+		{"ftp://example.com", false},
+		{"example.com", false},
+		{"https://", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.endpoint, func(t *testing.T) {
+			err := ValidateMyIPEndpoint(tt.endpoint)
+			if tt.isValid && err != nil {
+				t.Fatalf("failed on valid input: %v", err)
+			}
+			if !tt.isValid && err == nil {
+				t.Fatalf("did not fail on invalid input")
+			}
+		})
+	}
+}
+
+func TestResolve(t *testing.T) {
+	tests := []struct {
+		description string
+		options     *Options
+		response    string
+		status      int
+		isValid     bool
+		expected    []string
+	}{
+		{
+			description: "cidrs only",
+			options:     &Options{CIDRs: []string{"10.0.0.0/24"}},
+			isValid:     true,
+			expected:    []string{"10.0.0.0/24"},
+		},
+		{
+			description: "IPv4",
+			options:     &Options{CIDRs: []string{"10.0.0.0/24"}, MyIP: true},
+			response:    "192.0.2.1\n",
+			status:      http.StatusOK,
+			isValid:     true,
+			expected:    []string{"10.0.0.0/24", "192.0.2.1/32"},
+		},
+		{
+			description: "IPv6",
+			options:     &Options{MyIP: true},
+			response:    "2001:db8::1",
+			status:      http.StatusOK,
+			isValid:     true,
+			expected:    []string{"2001:db8::1/128"},
+		},
+		{
+			description: "IPv4-mapped IPv6",
+			options:     &Options{MyIP: true},
+			response:    "::ffff:192.0.2.1",
+			status:      http.StatusOK,
+			isValid:     true,
+			expected:    []string{"192.0.2.1/32"},
+		},
+		{
+			description: "not an IP address",
+			options:     &Options{MyIP: true},
+			response:    "<html></html>",
+			status:      http.StatusOK,
+			isValid:     false,
+		},
+		{
+			description: "endpoint fails",
+			options:     &Options{MyIP: true},
+			status:      http.StatusServiceUnavailable,
+			isValid:     false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(tt.status)
+				_, err := w.Write([]byte(tt.response))
+				if err != nil {
+					t.Errorf("Failed to write response: %v", err)
+				}
+			}))
+			defer server.Close()
+			viper.Set(config.MyIpEndpointKey, server.URL)
+			defer viper.Reset()
+
+			cidrs, err := Resolve(context.Background(), print.NewPrinter(), tt.options)
+			if !tt.isValid {
+				if err == nil {
+					t.Fatalf("did not fail on invalid input")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("failed on valid input: %v", err)
+			}
+			diff := cmp.Diff(cidrs, tt.expected)
+			if diff != "" {
+				t.Fatalf("Data does not match: %s", diff)
+			}
+		})
+	}
+}
+
+func TestAdd(t *testing.T) {
+	tests := []struct {
+		description   string
+		current       []string
+		cidrs         []string
+		expectedAcl   []string
+		expectedAdded []string
+	}{
+		{
+			description:   "new networks",
+			current:       []string{"10.0.0.0/24"},
+			cidrs:         []string{"192.0.2.1/32", "2001:db8::/32"},
+			expectedAcl:   []string{"10.0.0.0/24", "192.0.2.1/32", "2001:db8::/32"},
+			expectedAdded: []string{"192.0.2.1/32", "2001:db8::/32"},
+		},
+		{
+			description:   "already in ACL",
+			current:       []string{"10.0.0.0/24"},
+			cidrs:         []string{"10.0.0.1/24"},
+			expectedAcl:   []string{"10.0.0.0/24"},
+			expectedAdded: []string{},
+		},
+		{
+			description:   "duplicates",
+			current:       []string{},
+			cidrs:         []string{"192.0.2.1/32", "192.0.2.1/32"},
+			expectedAcl:   []string{"192.0.2.1/32"},
+			expectedAdded: []string{"192.0.2.1/32"},
+		},
+		{
+			description:   "empty ACL",
+			cidrs:         []string{"192.0.2.1/32"},
+			expectedAcl:   []string{"192.0.2.1/32"},
+			expectedAdded: []string{"192.0.2.1/32"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			acl, added := Add(tt.current, tt.cidrs)
+			diff := cmp.Diff(acl, tt.expectedAcl)
+			if diff != "" {
+				t.Fatalf("ACL does not match: %s", diff)
+			}
+			diff = cmp.Diff(added, tt.expectedAdded)
+			if diff != "" {
+				t.Fatalf("added networks do not match: %s", diff)
+			}
+		})
+	}
+}
+
+func TestRemove(t *testing.T) {
+	tests := []struct {
+		description     string
+		current         []string
+		cidrs           []string
+		expectedAcl     []string
+		expectedRemoved []string
+	}{
+		{
+			description:     "in ACL",
+			current:         []string{"10.0.0.0/24", "192.0.2.1/32"},
+			cidrs:           []string{"192.0.2.1/32"},
+			expectedAcl:     []string{"10.0.0.0/24"},
+			expectedRemoved: []string{"192.0.2.1/32"},
+		},
+		{
+			description:     "same network",
+			current:         []string{"10.0.0.1/24"},
+			cidrs:           []string{"10.0.0.0/24"},
+			expectedAcl:     []string{},
+			expectedRemoved: []string{"10.0.0.1/24"},
+		},
+		{
+			description:     "not in ACL",
+			current:         []string{"10.0.0.0/24"},
+			cidrs:           []string{"10.0.0.0/16"},
+			expectedAcl:     []string{"10.0.0.0/24"},
+			expectedRemoved: []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			acl, removed := Remove(tt.current, tt.cidrs)
+			diff := cmp.Diff(acl, tt.expectedAcl)
+			if diff != "" {
+				t.Fatalf("ACL does not match: %s", diff)
+			}
+			diff = cmp.Diff(removed, tt.expectedRemoved)
+			if diff != "" {
+				t.Fatalf("removed networks do not match: %s", diff)
+			}
+		})
+	}
+}
+
+func TestOutputResult(t *testing.T) {
+	tests := []struct {
+		description  string
+		outputFormat string
+		async        bool
+		result       *Result
+		isValid      bool
+		expected     string
+	}{
+		{
+			description: "added",
+			result: &Result{
+				InstanceId: "instance-id",
+				Added:      []string{"192.0.2.1/32"},
+				Acl:        []string{"10.0.0.0/24", "192.0.2.1/32"},
+			},
+			isValid:  true,
+			expected: "Updated the ACL of instance \"instance\"\nAdded: 192.0.2.1/32\n",
+		},
+		{
+			description: "removed async",
+			async:       true,
+			result: &Result{
+				InstanceId: "instance-id",
+				Removed:    []string{"10.0.0.0/24", "192.0.2.1/32"},
+				Acl:        []string{},
+			},
+			isValid:  true,
+			expected: "Triggered update of the ACL of instance \"instance\"\nRemoved: 10.0.0.0/24, 192.0.2.1/32\n",
+		},
+		{
+			description:  "json",
+			outputFormat: print.JSONOutputFormat,
+			result: &Result{
+				InstanceId: "instance-id",
+				Added:      []string{"192.0.2.1/32"},
+				Acl:        []string{"192.0.2.1/32"},
+			},
+			isValid:  true,
+			expected: "{\n  \"instanceId\": \"instance-id\",\n  \"added\": [\n    \"192.0.2.1/32\"\n  ],\n  \"acl\": [\n    \"192.0.2.1/32\"\n  ]\n}\n",
+		},
+		{
+			description: "no result",
+			isValid:     false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			err := OutputResult(fixturePrinter(buf), tt.outputFormat, tt.async, "instance", tt.result)
+			if !tt.isValid {
+				if err == nil {
+					t.Fatalf("did not fail on invalid input")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("failed on valid input: %v", err)
+			}
+			diff := cmp.Diff(buf.String(), tt.expected)
+			if diff != "" {
+				t.Fatalf("Data does not match: %s", diff)
+			}
+		})
+	}
+}
+
+func TestOutputList(t *testing.T) {
+	tests := []struct {
+		description  string
+		outputFormat string
+		acl          []string
+	}{
+		{
+			description: "table",
+			acl:         []string{"10.0.0.0/24", "192.0.2.1/32"},
+		},
+		{
+			description: "empty",
+			acl:         []string{},
+		},
+		{
+			description:  "json",
+			outputFormat: print.JSONOutputFormat,
+			acl:          []string{"10.0.0.0/24"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			err := OutputList(fixturePrinter(&bytes.Buffer{}), tt.outputFormat, tt.acl)
+			if err != nil {
+				t.Fatalf("failed on valid input: %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,121 @@
+package aliases
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+
+	"github.com/stackitcloud/stackit-cli/internal/pkg/config"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/utils"
+
+	"github.com/spf13/viper"
+)
+
+var (
+	aliasNameRegex   = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]*$`)
+	placeholderRegex = regexp.MustCompile(`\$(\d+)`)
+)
+
+type Alias struct {
+	Name      string `json:"name"`
+	Expansion string `json:"expansion"`
+}
+
+// List returns the aliases of the active profile, sorted by name
+func List() []Alias {
+	aliasMap := viper.GetStringMapString(config.AliasesKey)
+	aliases := make([]Alias, 0, len(aliasMap))
+	for name, expansion := range aliasMap {
+		aliases = append(aliases, Alias{Name: name, Expansion: expansion})
+	}
+	sort.Slice(aliases, func(i, j int) bool {
+		return aliases[i].Name < aliases[j].Name
+	})
+	return aliases
+}
+
+// Get returns the expansion of the given alias of the active profile
+func Get(name string) (string, bool) {
+	expansion, ok := viper.GetStringMapString(config.AliasesKey)[name]
+	return expansion, ok
+}
+
+// Set creates or updates an alias in the active profile and persists it in the config file
+func Set(name, expansion string) error {
+	err := ValidateName(name)
+	if err != nil {
+		return err
+	}
+	if _, err := utils.SplitArgs(expansion); err != nil {
+		return fmt.Errorf("parse expansion: %w", err)
+	}
+
+	aliasMap := viper.GetStringMapString(config.AliasesKey)
+	aliasMap[name] = expansion
+	viper.Set(config.AliasesKey, aliasMap)
+	return config.Write()
+}
+
+// Delete removes an alias from the active profile and persists the change in the config file
+func Delete(name string) error {
+	aliasMap := viper.GetStringMapString(config.AliasesKey)
+	if _, ok := aliasMap[name]; !ok {
+		return fmt.Errorf("alias %q does not exist", name)
+	}
+	delete(aliasMap, name)
+	viper.Set(config.AliasesKey, aliasMap)
+	return config.Write()
+}
+
+// ValidateName checks if the given alias name is valid
+func ValidateName(name string) error {
+	if !aliasNameRegex.MatchString(name) {
+		return fmt.Errorf("alias name %q is invalid, it must start with a lowercase letter or digit and contain only lowercase letters, digits, dashes and underscores", name)
+	}
+	return nil
+}
+
+// Expand expands the alias in the first argument, if any.
+// Placeholders in the expansion ("$1", "$2", ...) are replaced by the respective arguments given after the alias,
+// and the remaining arguments are appended to the expanded command.
+// Returns the arguments unchanged and false if the first argument is not an alias.
+func Expand(args []string) (expandedArgs []string, isAlias bool, err error) {
+	if len(args) == 0 {
+		return args, false, nil
+	}
+	expansion, ok := Get(args[0])
+	if !ok {
+		return args, false, nil
+	}
+
+	expansionArgs, err := utils.SplitArgs(expansion)
+	if err != nil {
+		return nil, true, fmt.Errorf("parse expansion of alias %q: %w", args[0], err)
+	}
+
+	aliasArgs := args[1:]
+	used := make([]bool, len(aliasArgs))
+	for i, arg := range expansionArgs {
+		var replaceErr error
+		expansionArgs[i] = placeholderRegex.ReplaceAllStringFunc(arg, func(placeholder string) string {
+			index, _ := strconv.Atoi(placeholder[1:])
+			if index < 1 || index > len(aliasArgs) {
+				replaceErr = fmt.Errorf("not enough arguments for alias %q: expansion %q uses %s", args[0], expansion, placeholder)
+				return placeholder
+			}
+			used[index-1] = true
+			return aliasArgs[index-1]
+		})
+		if replaceErr != nil {
+			return nil, true, replaceErr
+		}
+	}
+
+	for i, arg := range aliasArgs {
+		if !used[i] {
+			expansionArgs = append(expansionArgs, arg)
+		}
+	}
+	return expansionArgs, true, nil
+}
@@ -0,0 +1,100 @@
+package aliases
+
+import (
+	"testing"
+
+	"github.com/stackitcloud/stackit-cli/internal/pkg/config"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/spf13/viper"
+)
+
+func TestExpand(t *testing.T) {
+	aliasMap := map[string]string{
+		"pgl":   "postgresflex instance list -o json",
+		"pgd":   "postgresflex instance describe $1",
+		"user":  "postgresflex user create --instance-id $1 --username=$2 --role login",
+		"quote": `dns record-set create --name "my record" --record '1.2.3.4'`,
+	}
+
+	tests := []struct {
+		description     string
+		args            []string
+		expectedArgs    []string
+		expectedIsAlias bool
+		isValid         bool
+	}{
+		{
+			description:     "no args",
+			args:            []string{},
+			expectedArgs:    []string{},
+			expectedIsAlias: false,
+			isValid:         true,
+		},
+		{
+			description:     "not an alias",
+			args:            []string{"dns", "zone", "list"},
+			expectedArgs:    []string{"dns", "zone", "list"},
+			expectedIsAlias: false,
+			isValid:         true,
+		},
+		{
+			description:     "simple alias",
+			args:            []string{"pgl"},
+			expectedArgs:    []string{"postgresflex", "instance", "list", "-o", "json"},
+			expectedIsAlias: true,
+			isValid:         true,
+		},
+		{
+			description:     "extra args are appended",
+			args:            []string{"pgl", "--limit", "5"},
+			expectedArgs:    []string{"postgresflex", "instance", "list", "-o", "json", "--limit", "5"},
+			expectedIsAlias: true,
+			isValid:         true,
+		},
+		{
+			description:     "placeholders",
+			args:            []string{"user", "xxx", "john", "-y"},
+			expectedArgs:    []string{"postgresflex", "user", "create", "--instance-id", "xxx", "--username=john", "--role", "login", "-y"},
+			expectedIsAlias: true,
+			isValid:         true,
+		},
+		{
+			description:     "quotes",
+			args:            []string{"quote"},
+			expectedArgs:    []string{"dns", "record-set", "create", "--name", "my record", "--record", "1.2.3.4"},
+			expectedIsAlias: true,
+			isValid:         true,
+		},
+		{
+			description: "missing placeholder argument",
+			args:        []string{"pgd"},
+			isValid:     false,
+		},
+	}
+
+	viper.Set(config.AliasesKey, aliasMap)
+	defer viper.Set(config.AliasesKey, nil)
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			args, isAlias, err := Expand(tt.args)
+			if err != nil {
+				if !tt.isValid {
+					return
+				}
+				t.Fatalf("expand: %v", err)
+			}
+			if !tt.isValid {
+				t.Fatalf("did not fail on invalid input")
+			}
+			if isAlias != tt.expectedIsAlias {
+				t.Errorf("expected isAlias to be %t, got %t", tt.expectedIsAlias, isAlias)
+			}
+			diff := cmp.Diff(args, tt.expectedArgs)
+			if diff != "" {
+				t.Fatalf("Data does not match: %s", diff)
+			}
+		})
+	}
+}
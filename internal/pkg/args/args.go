@@ -67,3 +67,16 @@ func SingleOptionalArg(argName string, validate func(value string) error) cobra.
 		return nil
 	}
 }
+
+// SingleArgWithPassthrough checks if only one non-empty argument was provided before "--" and validates it
+// using the validate function, as SingleArg does. The arguments after "--" are not checked, as they are
+// passed on to another program
+func SingleArgWithPassthrough(argName string, validate func(value string) error) cobra.PositionalArgs {
+	singleArg := SingleArg(argName, validate)
+	return func(cmd *cobra.Command, args []string) error {
+		if dash := cmd.ArgsLenAtDash(); dash >= 0 {
+			args = args[:dash]
+		}
+		return singleArg(cmd, args)
+	}
+}
@@ -178,3 +178,68 @@ func TestSingleOptionalArg(t *testing.T) {
 		})
 	}
 }
+
+func TestSingleArgWithPassthrough(t *testing.T) {
+	tests := []struct {
+		description  string
+		args         []string
+		validateFunc func(value string) error
+		isValid      bool
+	}{
+		{
+			description: "valid",
+			args:        []string{"arg"},
+			isValid:     true,
+		},
+		{
+			description: "passthrough args",
+			args:        []string{"arg", "--", "arg2", "--flag"},
+			isValid:     true,
+		},
+		{
+			description: "no passthrough args",
+			args:        []string{"arg", "--"},
+			isValid:     true,
+		},
+		{
+			description: "no_arg",
+			args:        []string{"--", "arg"},
+			isValid:     false,
+		},
+		{
+			description: "more_than_one_arg",
+			args:        []string{"arg", "arg2", "--", "arg3"},
+			isValid:     false,
+		},
+		{
+			description: "invalid_arg",
+			args:        []string{"arg", "--", "arg2"},
+			validateFunc: func(_ string) error {
+				return fmt.Errorf("error")
+			},
+			isValid: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			cmd := &cobra.Command{
+				Use:   "test",
+				Short: "Test command",
+			}
+			err := cmd.ParseFlags(tt.args)
+			if err != nil {
+				t.Fatalf("failed to parse args: %v", err)
+			}
+
+			argFunction := SingleArgWithPassthrough("test", tt.validateFunc)
+			err = argFunction(cmd, cmd.Flags().Args())
+
+			if tt.isValid && err != nil {
+				t.Fatalf("should not have failed: %v", err)
+			}
+			if !tt.isValid && err == nil {
+				t.Fatalf("should have failed")
+			}
+		})
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"net/http"
 	"os"
 	"strconv"
+	"sync"
 	"time"
 
 	"github.com/stackitcloud/stackit-cli/internal/pkg/config"
@@ -20,12 +21,37 @@ type tokenClaims struct {
 	jwt.RegisteredClaims
 }
 
+var (
+	reuseAuthCfgOption  bool
+	reusePrinter        *print.Printer
+	cachedAuthCfgOption sdkConfig.ConfigurationOption
+	authCfgOptionMutex  sync.Mutex
+)
+
+// ReuseAuthenticationConfig makes AuthenticationConfig return the same configuration option to all the clients
+// created afterwards by this process, e.g. so that they share the access token of a service account key.
+// With a user login, the clients share one user token flow, so that the tokens are refreshed and written to the storage once,
+// even by clients sending requests in parallel. The flow uses the given printer, e.g. to prompt the user to log in again.
+func ReuseAuthenticationConfig(p *print.Printer) {
+	authCfgOptionMutex.Lock()
+	defer authCfgOptionMutex.Unlock()
+	reuseAuthCfgOption = true
+	reusePrinter = p
+}
+
 // AuthenticationConfig reads the credentials from the storage and initializes the authentication flow.
 // It returns the configuration option that can be used to create an authenticated SDK client.
 //
 // If the user was logged in and the user session expired, reauthorizeUserRoutine is called to reauthenticate the user again.
 // If the environment variable STACKIT_ACCESS_TOKEN is set this token is used instead.
 func AuthenticationConfig(p *print.Printer, reauthorizeUserRoutine func(p *print.Printer, _ bool) error) (authCfgOption sdkConfig.ConfigurationOption, err error) {
+	authCfgOptionMutex.Lock()
+	defer authCfgOptionMutex.Unlock()
+	if reuseAuthCfgOption && cachedAuthCfgOption != nil {
+		p.Debug(print.DebugLevel, "reusing authentication configuration")
+		return cachedAuthCfgOption, nil
+	}
+
 	// Get access token from env and use this if present
 	accessToken := os.Getenv(envAccessTokenName)
 	if accessToken != "" {
@@ -67,6 +93,9 @@ func AuthenticationConfig(p *print.Printer, reauthorizeUserRoutine func(p *print
 			return nil, fmt.Errorf("initialize service account key flow: %w", err)
 		}
 		authCfgOption = sdkConfig.WithCustomAuth(keyFlow)
+		if reuseAuthCfgOption {
+			cachedAuthCfgOption = authCfgOption
+		}
 	case AUTH_FLOW_USER_TOKEN:
 		p.Debug(print.DebugLevel, "authenticating using user token")
 		if userSessionExpired {
@@ -75,8 +104,15 @@ func AuthenticationConfig(p *print.Printer, reauthorizeUserRoutine func(p *print
 				return nil, fmt.Errorf("user login: %w", err)
 			}
 		}
-		userTokenFlow := UserTokenFlow(p)
+		flowPrinter := p
+		if reuseAuthCfgOption {
+			flowPrinter = reusePrinter
+		}
+		userTokenFlow := UserTokenFlow(flowPrinter)
 		authCfgOption = sdkConfig.WithCustomAuth(userTokenFlow)
+		if reuseAuthCfgOption {
+			cachedAuthCfgOption = authCfgOption
+		}
 	default:
 		return nil, fmt.Errorf("the provided authentication flow (%s) is not supported", flow)
 	}
@@ -201,3 +237,57 @@ func RefreshAccessToken(p *print.Printer) (string, error) {
 	// Return the new access token
 	return utf.accessToken, nil
 }
+
+// GetValidAccessToken returns a valid access token of the active profile, refreshing it if needed.
+// Unlike AuthenticationConfig, it doesn't reauthenticate the user if the user session expired.
+// If the environment variable STACKIT_ACCESS_TOKEN is set this token is returned instead.
+func GetValidAccessToken(p *print.Printer) (string, error) {
+	accessToken := os.Getenv(envAccessTokenName)
+	if accessToken != "" {
+		return accessToken, nil
+	}
+
+	flow, err := GetAuthFlow()
+	if err != nil {
+		return "", fmt.Errorf("get authentication flow: %w", err)
+	}
+	if flow == "" {
+		return "", fmt.Errorf("authentication flow not set")
+	}
+
+	userSessionExpired, err := UserSessionExpired()
+	if err != nil {
+		return "", fmt.Errorf("check if user session expired: %w", err)
+	}
+	if userSessionExpired {
+		return "", fmt.Errorf("session expired")
+	}
+
+	switch flow {
+	case AUTH_FLOW_SERVICE_ACCOUNT_TOKEN:
+		return GetAccessToken()
+	case AUTH_FLOW_SERVICE_ACCOUNT_KEY:
+		keyFlow, err := initKeyFlowWithStorage()
+		if err != nil {
+			return "", fmt.Errorf("initialize service account key flow: %w", err)
+		}
+		// The key flow refreshes the access token if it's expired
+		accessToken, err := keyFlow.keyFlow.GetAccessToken()
+		if err != nil {
+			return "", fmt.Errorf("get service account access token: %w", err)
+		}
+		token := keyFlow.keyFlow.GetToken()
+		err = SetAuthFieldMap(map[authFieldKey]string{
+			ACCESS_TOKEN:  token.AccessToken,
+			REFRESH_TOKEN: token.RefreshToken,
+		})
+		if err != nil {
+			return "", fmt.Errorf("set access and refresh token in the storage: %w", err)
+		}
+		return accessToken, nil
+	case AUTH_FLOW_USER_TOKEN:
+		return RefreshAccessToken(p)
+	default:
+		return "", fmt.Errorf("the provided authentication flow (%s) is not supported", flow)
+	}
+}
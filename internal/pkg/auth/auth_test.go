@@ -7,6 +7,7 @@ import (
 	"encoding/pem"
 	"fmt"
 	"io"
+	"net/http"
 	"strconv"
 	"testing"
 	"time"
@@ -233,6 +234,61 @@ func TestAuthenticationConfig(t *testing.T) {
 	}
 }
 
+func TestReuseAuthenticationConfigUserToken(t *testing.T) {
+	keyring.MockInit()
+	accessToken, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.RegisteredClaims{
+		ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour))}).SignedString(testSigningKey)
+	if err != nil {
+		t.Fatalf("Get test access token as string: %s", err)
+	}
+	err = SetAuthFlow(AUTH_FLOW_USER_TOKEN)
+	if err != nil {
+		t.Fatalf("Failed to set auth flow: %s", err)
+	}
+	err = SetAuthFieldMap(map[authFieldKey]string{
+		SESSION_EXPIRES_AT_UNIX: strconv.FormatInt(time.Now().Add(time.Hour).Unix(), 10),
+		ACCESS_TOKEN:            accessToken,
+		REFRESH_TOKEN:           "refresh_token",
+	})
+	if err != nil {
+		t.Fatalf("Failed to set in auth storage: %v", err)
+	}
+
+	batchPrinter := &print.Printer{Cmd: &cobra.Command{}}
+	ReuseAuthenticationConfig(batchPrinter)
+	defer func() {
+		reuseAuthCfgOption = false
+		reusePrinter = nil
+		cachedAuthCfgOption = nil
+	}()
+
+	// Each command of a batch configures its clients with its own printer
+	flows := []http.RoundTripper{}
+	for range 2 {
+		authCfgOption, err := AuthenticationConfig(&print.Printer{Cmd: &cobra.Command{}}, AuthorizeUser)
+		if err != nil {
+			t.Fatalf("Expected no error but error was returned: %v", err)
+		}
+		cfg := &sdkConfig.Configuration{}
+		err = authCfgOption(cfg)
+		if err != nil {
+			t.Fatalf("Applying returned auth config option: %v", err)
+		}
+		flows = append(flows, cfg.CustomAuth)
+	}
+
+	if flows[0] != flows[1] {
+		t.Fatalf("The clients don't share the user token flow")
+	}
+	flow, ok := flows[0].(*userTokenFlow)
+	if !ok {
+		t.Fatalf("Expected a user token flow, got %T", flows[0])
+	}
+	if flow.printer != batchPrinter {
+		t.Fatalf("The shared user token flow doesn't use the printer of the batch")
+	}
+}
+
 func TestInitKeyFlow(t *testing.T) {
 	tests := []struct {
 		description    string
@@ -3,7 +3,6 @@ package auth
 import (
 	"encoding/base64"
 	"encoding/json"
-	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -95,14 +94,11 @@ func SetAuthField(key authFieldKey, value string) error {
 }
 
 func setAuthFieldWithProfile(profile string, key authFieldKey, value string) error {
-	err := setAuthFieldInKeyring(profile, key, value)
+	backend, err := getStorageBackendForProfile(profile)
 	if err != nil {
-		errFallback := setAuthFieldInEncodedTextFile(profile, key, value)
-		if errFallback != nil {
-			return fmt.Errorf("write to keyring failed (%w), try writing to encoded text file: %w", err, errFallback)
-		}
+		return fmt.Errorf("get auth storage backend: %w", err)
 	}
-	return nil
+	return backend.set(profile, key, value)
 }
 
 func setAuthFieldInKeyring(activeProfile string, key authFieldKey, value string) error {
@@ -122,17 +118,11 @@ func DeleteAuthField(key authFieldKey) error {
 }
 
 func deleteAuthFieldWithProfile(profile string, key authFieldKey) error {
-	err := deleteAuthFieldInKeyring(profile, key)
+	backend, err := getStorageBackendForProfile(profile)
 	if err != nil {
-		// if the key is not found, we can ignore the error
-		if !errors.Is(err, keyring.ErrNotFound) {
-			errFallback := deleteAuthFieldInEncodedTextFile(profile, key)
-			if errFallback != nil {
-				return fmt.Errorf("delete from keyring failed (%w), try deleting from encoded text file: %w", err, errFallback)
-			}
-		}
+		return fmt.Errorf("get auth storage backend: %w", err)
 	}
-	return nil
+	return backend.delete(profile, key)
 }
 
 func deleteAuthFieldInEncodedTextFile(activeProfile string, key authFieldKey) error {
@@ -243,15 +233,11 @@ func GetAuthField(key authFieldKey) (string, error) {
 }
 
 func getAuthFieldWithProfile(profile string, key authFieldKey) (string, error) {
-	value, err := getAuthFieldFromKeyring(profile, key)
+	backend, err := getStorageBackendForProfile(profile)
 	if err != nil {
-		var errFallback error
-		value, errFallback = getAuthFieldFromEncodedTextFile(profile, key)
-		if errFallback != nil {
-			return "", fmt.Errorf("read from keyring: %w, read from encoded file as fallback: %w", err, errFallback)
-		}
+		return "", fmt.Errorf("get auth storage backend: %w", err)
 	}
-	return value, nil
+	return backend.get(profile, key)
 }
 
 func getAuthFieldFromKeyring(activeProfile string, key authFieldKey) (string, error) {
@@ -286,7 +272,7 @@ func getAuthFieldFromEncodedTextFile(activeProfile string, key authFieldKey) (st
 	}
 	value, ok := content[key]
 	if !ok {
-		return "", fmt.Errorf("value not found")
+		return "", errAuthFieldNotFound
 	}
 	return value, nil
 }
@@ -0,0 +1,199 @@
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/stackitcloud/stackit-cli/internal/pkg/config"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/fileutils"
+
+	"github.com/zalando/go-keyring"
+)
+
+// Possible values of auth storage backends
+type StorageBackend string
+
+const (
+	STORAGE_BACKEND_KEYRING        StorageBackend = "keyring"
+	STORAGE_BACKEND_ENCRYPTED_FILE StorageBackend = "encrypted-file"
+	STORAGE_BACKEND_PASS           StorageBackend = "pass"
+
+	storageBackendFileName = "cli-auth-storage-backend.txt"
+	envStorageBackendName  = "STACKIT_AUTH_STORAGE_BACKEND"
+	defaultStorageBackend  = STORAGE_BACKEND_KEYRING
+)
+
+// All storage backends supported by the CLI
+var StorageBackends = []StorageBackend{
+	STORAGE_BACKEND_KEYRING,
+	STORAGE_BACKEND_ENCRYPTED_FILE,
+	STORAGE_BACKEND_PASS,
+}
+
+var errAuthFieldNotFound = errors.New("value not found")
+
+// storageBackend reads and writes auth fields of a profile
+type storageBackend interface {
+	get(profile string, key authFieldKey) (string, error)
+	set(profile string, key authFieldKey, value string) error
+	delete(profile string, key authFieldKey) error
+}
+
+// keyringStorageBackend stores auth fields in the OS keyring, falling back to an encoded text file if the keyring is unavailable
+type keyringStorageBackend struct{}
+
+func (keyringStorageBackend) get(profile string, key authFieldKey) (string, error) {
+	value, err := getAuthFieldFromKeyring(profile, key)
+	if err != nil {
+		var errFallback error
+		value, errFallback = getAuthFieldFromEncodedTextFile(profile, key)
+		if errFallback != nil {
+			return "", fmt.Errorf("read from keyring: %w, read from encoded file as fallback: %w", err, errFallback)
+		}
+	}
+	return value, nil
+}
+
+func (keyringStorageBackend) set(profile string, key authFieldKey, value string) error {
+	err := setAuthFieldInKeyring(profile, key, value)
+	if err != nil {
+		errFallback := setAuthFieldInEncodedTextFile(profile, key, value)
+		if errFallback != nil {
+			return fmt.Errorf("write to keyring failed (%w), try writing to encoded text file: %w", err, errFallback)
+		}
+	}
+	return nil
+}
+
+func (keyringStorageBackend) delete(profile string, key authFieldKey) error {
+	err := deleteAuthFieldInKeyring(profile, key)
+	if err != nil {
+		// if the key is not found, we can ignore the error
+		if !errors.Is(err, keyring.ErrNotFound) {
+			errFallback := deleteAuthFieldInEncodedTextFile(profile, key)
+			if errFallback != nil {
+				return fmt.Errorf("delete from keyring failed (%w), try deleting from encoded text file: %w", err, errFallback)
+			}
+		}
+	}
+	return nil
+}
+
+func newStorageBackend(backend StorageBackend) (storageBackend, error) {
+	switch backend {
+	case STORAGE_BACKEND_KEYRING:
+		return keyringStorageBackend{}, nil
+	case STORAGE_BACKEND_ENCRYPTED_FILE:
+		return encryptedFileStorageBackend{}, nil
+	case STORAGE_BACKEND_PASS:
+		return newPassStorageBackend(), nil
+	default:
+		return nil, fmt.Errorf("unsupported auth storage backend %q, must be one of %q", backend, StorageBackends)
+	}
+}
+
+// GetStorageBackend returns the auth storage backend configured for the given profile.
+// The STACKIT_AUTH_STORAGE_BACKEND environment variable takes precedence over the backend persisted for the profile.
+// If no backend is configured, the keyring backend is used.
+func GetStorageBackend(profile string) (StorageBackend, error) {
+	if backend, ok := os.LookupEnv(envStorageBackendName); ok && backend != "" {
+		return StorageBackend(backend), nil
+	}
+
+	backendFilePath := filepath.Join(config.GetProfileFolderPath(profile), storageBackendFileName)
+	contents, exists, err := fileutils.ReadFileIfExists(backendFilePath)
+	if err != nil {
+		return "", fmt.Errorf("read auth storage backend from file: %w", err)
+	}
+	if !exists || strings.TrimSpace(contents) == "" {
+		return defaultStorageBackend, nil
+	}
+	return StorageBackend(strings.TrimSpace(contents)), nil
+}
+
+func setStorageBackend(profile string, backend StorageBackend) error {
+	profileFolderPath := config.GetProfileFolderPath(profile)
+	err := os.MkdirAll(profileFolderPath, 0o750)
+	if err != nil {
+		return fmt.Errorf("create profile folder: %w", err)
+	}
+	err = os.WriteFile(filepath.Join(profileFolderPath, storageBackendFileName), []byte(backend), 0o600)
+	if err != nil {
+		return fmt.Errorf("write auth storage backend to file: %w", err)
+	}
+	return nil
+}
+
+func getStorageBackendForProfile(profile string) (storageBackend, error) {
+	backend, err := GetStorageBackend(profile)
+	if err != nil {
+		return nil, err
+	}
+	return newStorageBackend(backend)
+}
+
+// MigrateProfileAuthStorage moves all auth fields of the given profile from its current storage backend to the target backend.
+// The target backend is persisted for the profile only after all fields have been copied, and the fields are deleted from the source backend afterwards.
+// Fails if the STACKIT_AUTH_STORAGE_BACKEND environment variable is set, as it overrides both the source and the target backend.
+// Returns the number of auth fields that were migrated.
+func MigrateProfileAuthStorage(profile string, target StorageBackend) (int, error) {
+	err := config.ValidateProfile(profile)
+	if err != nil {
+		return 0, fmt.Errorf("validate profile: %w", err)
+	}
+	if backend, ok := os.LookupEnv(envStorageBackendName); ok && backend != "" {
+		return 0, fmt.Errorf("the %s environment variable is set to %q and overrides the auth storage backend of the profile, unset it to migrate", envStorageBackendName, backend)
+	}
+
+	source, err := GetStorageBackend(profile)
+	if err != nil {
+		return 0, fmt.Errorf("get current auth storage backend: %w", err)
+	}
+	if source == target {
+		return 0, fmt.Errorf("profile %q already uses the %q auth storage backend", profile, target)
+	}
+
+	sourceBackend, err := newStorageBackend(source)
+	if err != nil {
+		return 0, err
+	}
+	targetBackend, err := newStorageBackend(target)
+	if err != nil {
+		return 0, err
+	}
+
+	fields := map[authFieldKey]string{}
+	for _, key := range authFieldKeys {
+		value, err := sourceBackend.get(profile, key)
+		if err != nil {
+			if errors.Is(err, errAuthFieldNotFound) {
+				continue
+			}
+			return 0, fmt.Errorf("read auth field \"%s\" from %q backend: %w", key, source, err)
+		}
+		fields[key] = value
+	}
+
+	for key, value := range fields {
+		err := targetBackend.set(profile, key, value)
+		if err != nil {
+			return 0, fmt.Errorf("write auth field \"%s\" to %q backend: %w", key, target, err)
+		}
+	}
+
+	err = setStorageBackend(profile, target)
+	if err != nil {
+		return 0, err
+	}
+
+	for key := range fields {
+		err := sourceBackend.delete(profile, key)
+		if err != nil {
+			return 0, fmt.Errorf("delete auth field \"%s\" from %q backend: %w", key, source, err)
+		}
+	}
+	return len(fields), nil
+}
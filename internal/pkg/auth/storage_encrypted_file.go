@@ -0,0 +1,238 @@
+package auth
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/stackitcloud/stackit-cli/internal/pkg/config"
+
+	"golang.org/x/term"
+)
+
+const (
+	encryptedFileName        = "cli-auth-storage.enc"
+	envStoragePassphraseName = "STACKIT_AUTH_STORAGE_PASSPHRASE" //nolint:gosec // linter false positive
+
+	encryptedFileVersion       = 1
+	encryptedFileKDF           = "pbkdf2-sha256"
+	encryptedFileKDFIterations = 600000
+	encryptedFileSaltLength    = 16
+	encryptedFileKeyLength     = 32
+)
+
+// encryptedFile is the on-disk format of the encrypted file storage backend
+type encryptedFile struct {
+	Version    int    `json:"version"`
+	KDF        string `json:"kdf"`
+	Iterations int    `json:"iterations"`
+	Salt       []byte `json:"salt"`
+	Nonce      []byte `json:"nonce"`
+	Ciphertext []byte `json:"ciphertext"`
+}
+
+var (
+	// The passphrase and derived keys are cached because key derivation is intentionally slow
+	// and a single command can read several auth fields
+	encryptedFileMutex       sync.Mutex
+	encryptedFilePassphrase  *string
+	encryptedFileDerivedKeys = map[string][]byte{}
+)
+
+// encryptedFileStorageBackend stores auth fields in a file encrypted with AES-256-GCM,
+// using a key derived from a passphrase provided via the STACKIT_AUTH_STORAGE_PASSPHRASE environment variable or prompted for
+type encryptedFileStorageBackend struct{}
+
+func (encryptedFileStorageBackend) get(profile string, key authFieldKey) (string, error) {
+	content, _, err := readEncryptedFile(profile)
+	if err != nil {
+		return "", err
+	}
+	value, ok := content[key]
+	if !ok {
+		return "", errAuthFieldNotFound
+	}
+	return value, nil
+}
+
+func (encryptedFileStorageBackend) set(profile string, key authFieldKey, value string) error {
+	content, salt, err := readEncryptedFile(profile)
+	if err != nil {
+		return err
+	}
+	content[key] = value
+	return writeEncryptedFile(profile, content, salt)
+}
+
+func (encryptedFileStorageBackend) delete(profile string, key authFieldKey) error {
+	content, salt, err := readEncryptedFile(profile)
+	if err != nil {
+		return err
+	}
+	if _, ok := content[key]; !ok {
+		return nil
+	}
+	delete(content, key)
+	return writeEncryptedFile(profile, content, salt)
+}
+
+func getEncryptedFilePath(profile string) string {
+	return filepath.Join(config.GetProfileFolderPath(profile), encryptedFileName)
+}
+
+// Reads and decrypts the encrypted file of the given profile.
+// If the file doesn't exist, returns an empty content and a new random salt.
+func readEncryptedFile(profile string) (content map[authFieldKey]string, salt []byte, err error) {
+	fileBytes, err := os.ReadFile(getEncryptedFilePath(profile))
+	if err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			return nil, nil, fmt.Errorf("read file: %w", err)
+		}
+		salt = make([]byte, encryptedFileSaltLength)
+		_, err = rand.Read(salt)
+		if err != nil {
+			return nil, nil, fmt.Errorf("generate salt: %w", err)
+		}
+		return map[authFieldKey]string{}, salt, nil
+	}
+
+	file := encryptedFile{}
+	err = json.Unmarshal(fileBytes, &file)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unmarshal file: %w", err)
+	}
+	if file.Version != encryptedFileVersion || file.KDF != encryptedFileKDF {
+		return nil, nil, fmt.Errorf("unsupported encrypted file format (version %d, kdf %q)", file.Version, file.KDF)
+	}
+
+	gcm, err := newEncryptedFileCipher(file.Salt, file.Iterations)
+	if err != nil {
+		return nil, nil, err
+	}
+	plaintext, err := gcm.Open(nil, file.Nonce, file.Ciphertext, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("decrypt file (wrong passphrase?): %w", err)
+	}
+
+	content = map[authFieldKey]string{}
+	err = json.Unmarshal(plaintext, &content)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unmarshal decrypted file: %w", err)
+	}
+	return content, file.Salt, nil
+}
+
+// Encrypts the content with a key derived from the given salt and writes it to the encrypted file of the given profile.
+func writeEncryptedFile(profile string, content map[authFieldKey]string, salt []byte) error {
+	plaintext, err := json.Marshal(content)
+	if err != nil {
+		return fmt.Errorf("marshal file: %w", err)
+	}
+
+	gcm, err := newEncryptedFileCipher(salt, encryptedFileKDFIterations)
+	if err != nil {
+		return err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	_, err = rand.Read(nonce)
+	if err != nil {
+		return fmt.Errorf("generate nonce: %w", err)
+	}
+
+	fileBytes, err := json.Marshal(encryptedFile{
+		Version:    encryptedFileVersion,
+		KDF:        encryptedFileKDF,
+		Iterations: encryptedFileKDFIterations,
+		Salt:       salt,
+		Nonce:      nonce,
+		Ciphertext: gcm.Seal(nil, nonce, plaintext, nil),
+	})
+	if err != nil {
+		return fmt.Errorf("marshal encrypted file: %w", err)
+	}
+
+	err = os.MkdirAll(config.GetProfileFolderPath(profile), 0o750)
+	if err != nil {
+		return fmt.Errorf("create file dir: %w", err)
+	}
+	err = os.WriteFile(getEncryptedFilePath(profile), fileBytes, 0o600)
+	if err != nil {
+		return fmt.Errorf("write file: %w", err)
+	}
+	return nil
+}
+
+func newEncryptedFileCipher(salt []byte, iterations int) (cipher.AEAD, error) {
+	key, err := getEncryptedFileKey(salt, iterations)
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("create cipher: %w", err)
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("create GCM cipher: %w", err)
+	}
+	return gcm, nil
+}
+
+func getEncryptedFileKey(salt []byte, iterations int) ([]byte, error) {
+	encryptedFileMutex.Lock()
+	defer encryptedFileMutex.Unlock()
+
+	cacheKey := fmt.Sprintf("%x:%d", salt, iterations)
+	if key, ok := encryptedFileDerivedKeys[cacheKey]; ok {
+		return key, nil
+	}
+
+	passphrase, err := getEncryptedFilePassphrase()
+	if err != nil {
+		return nil, err
+	}
+	key, err := pbkdf2.Key(sha256.New, passphrase, salt, iterations, encryptedFileKeyLength)
+	if err != nil {
+		return nil, fmt.Errorf("derive key from passphrase: %w", err)
+	}
+	encryptedFileDerivedKeys[cacheKey] = key
+	return key, nil
+}
+
+// Returns the passphrase from the STACKIT_AUTH_STORAGE_PASSPHRASE environment variable.
+// If it is not set, prompts for the passphrase if the standard input is a terminal.
+// Must be called with encryptedFileMutex locked.
+func getEncryptedFilePassphrase() (string, error) {
+	if encryptedFilePassphrase != nil {
+		return *encryptedFilePassphrase, nil
+	}
+
+	passphrase, ok := os.LookupEnv(envStoragePassphraseName)
+	if !ok {
+		stdin := int(os.Stdin.Fd()) //nolint:gosec // file descriptors fit in an int
+		if !term.IsTerminal(stdin) {
+			return "", fmt.Errorf("the %s environment variable must be set to use the %q auth storage backend non-interactively", envStoragePassphraseName, STORAGE_BACKEND_ENCRYPTED_FILE)
+		}
+		fmt.Fprint(os.Stderr, "Enter passphrase for the STACKIT CLI auth storage: ")
+		passphraseBytes, err := term.ReadPassword(stdin)
+		fmt.Fprintln(os.Stderr)
+		if err != nil {
+			return "", fmt.Errorf("read passphrase: %w", err)
+		}
+		passphrase = string(passphraseBytes)
+	}
+	if passphrase == "" {
+		return "", fmt.Errorf("the passphrase for the %q auth storage backend can't be empty", STORAGE_BACKEND_ENCRYPTED_FILE)
+	}
+
+	encryptedFilePassphrase = &passphrase
+	return passphrase, nil
+}
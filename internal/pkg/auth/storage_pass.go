@@ -0,0 +1,82 @@
+package auth
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path"
+	"strings"
+)
+
+const (
+	envStoragePassCommandName = "STACKIT_AUTH_STORAGE_PASS_COMMAND"
+	defaultStoragePassCommand = "pass"
+
+	passNotFoundMessage = "is not in the password store"
+)
+
+// passStorageBackend stores auth fields in the "pass" password manager (https://www.passwordstore.org),
+// or any external command with a compatible interface (e.g. "gopass"), configured via the STACKIT_AUTH_STORAGE_PASS_COMMAND environment variable.
+// Each auth field is stored as a separate entry under "stackit-cli/<profile>/<key>".
+type passStorageBackend struct {
+	command string
+}
+
+func newPassStorageBackend() passStorageBackend {
+	command := os.Getenv(envStoragePassCommandName)
+	if command == "" {
+		command = defaultStoragePassCommand
+	}
+	return passStorageBackend{
+		command: command,
+	}
+}
+
+func (b passStorageBackend) get(profile string, key authFieldKey) (string, error) {
+	stdout, stderr, err := b.run(nil, "show", getPassEntryName(profile, key))
+	if err != nil {
+		if strings.Contains(stderr, passNotFoundMessage) {
+			return "", errAuthFieldNotFound
+		}
+		return "", fmt.Errorf("run %q: %w: %s", b.command, err, stderr)
+	}
+	// Values are inserted with "--multiline" and a trailing newline, so that multi-line values (e.g. PEM keys) are kept as a whole
+	return strings.TrimSuffix(stdout, "\n"), nil
+}
+
+func (b passStorageBackend) set(profile string, key authFieldKey, value string) error {
+	_, stderr, err := b.run(strings.NewReader(value+"\n"), "insert", "--multiline", "--force", getPassEntryName(profile, key))
+	if err != nil {
+		return fmt.Errorf("run %q: %w: %s", b.command, err, stderr)
+	}
+	return nil
+}
+
+func (b passStorageBackend) delete(profile string, key authFieldKey) error {
+	_, stderr, err := b.run(nil, "rm", "--force", getPassEntryName(profile, key))
+	if err != nil {
+		if strings.Contains(stderr, passNotFoundMessage) {
+			return nil
+		}
+		return fmt.Errorf("run %q: %w: %s", b.command, err, stderr)
+	}
+	return nil
+}
+
+func (b passStorageBackend) run(stdin io.Reader, args ...string) (stdout, stderr string, err error) {
+	cmd := exec.Command(b.command, args...) // #nosec G204
+	if stdin != nil {
+		cmd.Stdin = stdin
+	}
+	var stdoutBuf, stderrBuf bytes.Buffer
+	cmd.Stdout = &stdoutBuf
+	cmd.Stderr = &stderrBuf
+	err = cmd.Run()
+	return stdoutBuf.String(), strings.TrimSpace(stderrBuf.String()), err
+}
+
+func getPassEntryName(profile string, key authFieldKey) string {
+	return path.Join(keyringService, profile, string(key))
+}
@@ -6,6 +6,7 @@ import (
 	"io"
 	"net/http"
 	"net/url"
+	"sync"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
@@ -20,6 +21,8 @@ type userTokenFlow struct {
 	accessToken            string
 	refreshToken           string
 	tokenEndpoint          string
+	// Guards the fields above, as requests may be sent in parallel through the same round tripper
+	mu sync.Mutex
 }
 
 // Ensure the implementation satisfies the expected interface
@@ -35,18 +38,33 @@ func UserTokenFlow(p *print.Printer) *userTokenFlow {
 }
 
 func (utf *userTokenFlow) RoundTrip(req *http.Request) (*http.Response, error) {
-	err := loadVarsFromStorage(utf)
+	accessToken, err := utf.getAccessToken()
 	if err != nil {
 		return nil, err
 	}
+
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", accessToken))
+	return utf.client.Do(req)
+}
+
+// getAccessToken returns a valid access token, refreshing the tokens or reauthenticating the user if needed.
+// Requests sent in parallel wait for each other, so that the tokens are only refreshed once.
+func (utf *userTokenFlow) getAccessToken() (string, error) {
+	utf.mu.Lock()
+	defer utf.mu.Unlock()
+
+	err := loadVarsFromStorage(utf)
+	if err != nil {
+		return "", err
+	}
 	if utf.authFlow != AUTH_FLOW_USER_TOKEN {
-		return nil, fmt.Errorf("auth flow is not user token")
+		return "", fmt.Errorf("auth flow is not user token")
 	}
 
 	accessTokenValid := false
 	accessTokenExpired, err := TokenExpired(utf.accessToken)
 	if err != nil {
-		return nil, fmt.Errorf("check if access token has expired: %w", err)
+		return "", fmt.Errorf("check if access token has expired: %w", err)
 	} else if !accessTokenExpired {
 		accessTokenValid = true
 	} else {
@@ -63,12 +81,10 @@ func (utf *userTokenFlow) RoundTrip(req *http.Request) (*http.Response, error) {
 		utf.printer.Debug(print.DebugLevel, "user access token is not valid, reauthenticating...")
 		err = reauthenticateUser(utf)
 		if err != nil {
-			return nil, fmt.Errorf("reauthenticate user: %w", err)
+			return "", fmt.Errorf("reauthenticate user: %w", err)
 		}
 	}
-
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", utf.accessToken))
-	return utf.client.Do(req)
+	return utf.accessToken, nil
 }
 
 func loadVarsFromStorage(utf *userTokenFlow) error {
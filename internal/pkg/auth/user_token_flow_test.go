@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -336,6 +338,91 @@ func TestRoundTrip(t *testing.T) {
 	}
 }
 
+// Counts the requests and token refreshes sent in parallel
+type concurrentClientTransport struct {
+	requestsSent    atomic.Int32
+	tokensRefreshed atomic.Int32
+}
+
+func (rt *concurrentClientTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if fmt.Sprintf("https://%s", req.Host+req.URL.Path) != testTokenEndpoint {
+		rt.requestsSent.Add(1)
+		return &http.Response{
+			Status:     http.StatusText(http.StatusOK),
+			StatusCode: http.StatusOK,
+			Body:       http.NoBody,
+		}, nil
+	}
+
+	rt.tokensRefreshed.Add(1)
+	expirationTimestamp := time.Now().Add(time.Hour)
+	accessToken, refreshToken, err := createTokens(expirationTimestamp, expirationTimestamp)
+	if err != nil {
+		return nil, fmt.Errorf("create tokens: %w", err)
+	}
+	respBody := fmt.Sprintf(`{"access_token": %q, "refresh_token": %q}`, accessToken, refreshToken)
+	return &http.Response{
+		Status:     http.StatusText(http.StatusOK),
+		StatusCode: http.StatusOK,
+		Body:       io.NopCloser(bytes.NewReader([]byte(respBody))),
+	}, nil
+}
+
+func TestRoundTripParallel(t *testing.T) {
+	const requests = 8
+
+	keyring.MockInit()
+	err := setAuthStorage(time.Now().Add(-time.Hour), time.Now().Add(time.Hour), false, false)
+	if err != nil {
+		t.Fatalf("failed to set auth storage: %v", err)
+	}
+
+	transport := &concurrentClientTransport{}
+	cmd := &cobra.Command{}
+	cmd.SetOut(io.Discard) // Suppresses console prints
+	rt := &userTokenFlow{
+		printer: &print.Printer{Cmd: cmd},
+		reauthorizeUserRoutine: func(_ *print.Printer, _ bool) error {
+			return fmt.Errorf("user should not be reauthenticated")
+		},
+		client: &http.Client{Transport: transport},
+	}
+
+	var wg sync.WaitGroup
+	errs := make(chan error, requests)
+	for i := 0; i < requests; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			req, err := http.NewRequest(http.MethodGet, "https://request/url", http.NoBody)
+			if err != nil {
+				errs <- err
+				return
+			}
+			resp, err := rt.RoundTrip(req)
+			if err != nil {
+				errs <- err
+				return
+			}
+			errs <- resp.Body.Close()
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		if err != nil {
+			t.Errorf("request failed: %v", err)
+		}
+	}
+	if sent := transport.requestsSent.Load(); sent != requests {
+		t.Errorf("expected %d requests, got %d", requests, sent)
+	}
+	if refreshed := transport.tokensRefreshed.Load(); refreshed != 1 {
+		t.Errorf("expected tokens to be refreshed once, got %d", refreshed)
+	}
+}
+
 // Generates access and refresh tokens with the expiration timestamp provided, then sets the auth fields in storage appropriately
 func setAuthStorage(accessTokenExpiresAt, refreshTokenExpiresAt time.Time, accessTokenInvalid, refreshTokenInvalid bool) error {
 	accessToken, refreshToken, err := createTokens(accessTokenExpiresAt, refreshTokenExpiresAt)
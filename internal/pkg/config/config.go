@@ -17,10 +17,17 @@ const (
 	RegionKey           = "region"
 	SessionTimeLimitKey = "session_time_limit"
 	VerbosityKey        = "verbosity"
+	LogFormatKey        = "log_format"
+	LogFileKey          = "log_file"
+	RetriesKey          = "retries"
+	RetryMaxWaitKey     = "retry_max_wait"
+	RetryAllMethodsKey  = "retry_all_methods"
 
 	IdentityProviderCustomWellKnownConfigurationKey = "identity_provider_custom_well_known_configuration"
 	IdentityProviderCustomClientIdKey               = "identity_provider_custom_client_id"
 	AllowedUrlDomainKey                             = "allowed_url_domain"
+	HistoryForwardUrlKey                            = "history_forward_url"
+	MyIpEndpointKey                                 = "my_ip_endpoint"
 
 	AuthorizationCustomEndpointKey     = "authorization_custom_endpoint"
 	DNSCustomEndpointKey               = "dns_custom_endpoint"
@@ -47,6 +54,8 @@ const (
 	TokenCustomEndpointKey             = "token_custom_endpoint"
 	GitCustomEndpointKey               = "git_custom_endpoint"
 
+	AliasesKey = "aliases"
+
 	ProjectNameKey     = "project_name"
 	DefaultProfileName = "default"
 
@@ -75,10 +84,17 @@ var ConfigKeys = []string{
 	RegionKey,
 	SessionTimeLimitKey,
 	VerbosityKey,
+	LogFormatKey,
+	LogFileKey,
+	RetriesKey,
+	RetryMaxWaitKey,
+	RetryAllMethodsKey,
 
 	IdentityProviderCustomWellKnownConfigurationKey,
 	IdentityProviderCustomClientIdKey,
 	AllowedUrlDomainKey,
+	HistoryForwardUrlKey,
+	MyIpEndpointKey,
 
 	DNSCustomEndpointKey,
 	LoadBalancerCustomEndpointKey,
@@ -148,16 +164,34 @@ func initConfig(configPath string) {
 	setConfigDefaults()
 
 	viper.AutomaticEnv()
-	viper.SetEnvPrefix("stackit")
+	viper.SetEnvPrefix(envPrefix)
+
+	// Layer the directory config file (if any) over the profile configuration.
+	// A broken directory config file is ignored, so that it can't prevent commands such as "config unset" from running.
+	workingDir, err := os.Getwd()
+	if err == nil {
+		directoryConfigError = loadDirectoryConfig(workingDir)
+	}
 }
 
 // Write saves the config file (wrapping `viper.WriteConfig`) and ensures that its directory exists
+// Values that were only set in a directory config file are not persisted in the profile config file
 func Write() error {
 	err := os.MkdirAll(configFolderPath, 0o750)
 	if err != nil {
 		return fmt.Errorf("create config directory: %w", err)
 	}
-	return viper.WriteConfig()
+	if directoryConfigFilePath == "" {
+		return viper.WriteConfig()
+	}
+
+	profileViper := viper.New()
+	for key, value := range profileConfigSettings() {
+		profileViper.Set(key, value)
+	}
+	profileViper.SetConfigFile(viper.ConfigFileUsed())
+	profileViper.SetConfigType(configFileExtension)
+	return profileViper.WriteConfig()
 }
 
 // All config keys should be set to a default value so that they can be set as an environment variable
@@ -171,6 +205,8 @@ func setConfigDefaults() {
 	viper.SetDefault(IdentityProviderCustomWellKnownConfigurationKey, "")
 	viper.SetDefault(IdentityProviderCustomClientIdKey, "")
 	viper.SetDefault(AllowedUrlDomainKey, AllowedUrlDomainDefault)
+	viper.SetDefault(HistoryForwardUrlKey, "")
+	viper.SetDefault(MyIpEndpointKey, "")
 	viper.SetDefault(DNSCustomEndpointKey, "")
 	viper.SetDefault(ObservabilityCustomEndpointKey, "")
 	viper.SetDefault(AuthorizationCustomEndpointKey, "")
@@ -0,0 +1,240 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/goccy/go-yaml"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+)
+
+const (
+	DirectoryConfigFileName = ".stackit.yaml"
+
+	// If set to true, no directory config file is searched for
+	DisableDirectoryConfigEnv = "STACKIT_DISABLE_DIRECTORY_CONFIG"
+
+	envPrefix = "STACKIT"
+)
+
+// Possible origins of a configuration value, from highest to lowest precedence
+const (
+	FlagOrigin            = "flag"
+	EnvironmentOrigin     = "environment variable"
+	DirectoryConfigOrigin = "directory config"
+	ProfileConfigOrigin   = "profile config"
+	DefaultOrigin         = "default"
+)
+
+// Path of the directory config file in use, empty if none was found
+var directoryConfigFilePath string
+
+// Values set in the directory config file
+var directoryConfigValues = map[string]any{}
+
+// Values set in the profile config file, before the directory config is layered over it
+var profileConfigValues = map[string]any{}
+
+// Error of the directory config file that was found but ignored, nil if none
+var directoryConfigError error
+
+// Keys set explicitly after reading the config files, which are persisted even if they equal the value of the directory config file
+var changedKeys = map[string]bool{}
+
+// Flags bound to config keys, whose values are persisted if the flags were set
+var boundFlags = map[string]*pflag.Flag{}
+
+// DirectoryConfigKeys returns the config keys that can be set in a directory config file
+func DirectoryConfigKeys() []string {
+	keys := []string{ProjectIdKey, RegionKey, OutputFormatKey}
+	for _, key := range ConfigKeys {
+		if strings.HasSuffix(key, "_custom_endpoint") && !slices.Contains(keys, key) {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+// GetDirectoryConfigError returns the reason why the directory config file that was found is ignored, nil if none
+func GetDirectoryConfigError() error {
+	return directoryConfigError
+}
+
+// Set sets the value of a config key, marking it to be persisted by Write
+func Set(key string, value any) {
+	viper.Set(key, value)
+	changedKeys[key] = true
+}
+
+// BindFlag binds a flag to a config key, marking the key to be persisted by Write if the flag is set
+func BindFlag(key string, flag *pflag.Flag) error {
+	err := viper.BindPFlag(key, flag)
+	if err != nil {
+		return err
+	}
+	boundFlags[key] = flag
+	return nil
+}
+
+// isChanged returns whether the config key was set explicitly after reading the config files
+func isChanged(key string) bool {
+	if changedKeys[key] {
+		return true
+	}
+	flag, ok := boundFlags[key]
+	return ok && flag.Changed
+}
+
+// GetDirectoryConfigFilePath returns the path of the directory config file layered over the active profile.
+// Returns an empty string if no directory config file was found.
+func GetDirectoryConfigFilePath() string {
+	return directoryConfigFilePath
+}
+
+// loadDirectoryConfig searches for a directory config file in the given directory and its parents
+// and layers its values over the profile configuration already read by viper
+func loadDirectoryConfig(startDir string) error {
+	profileConfigValues = map[string]any{}
+	for _, key := range ConfigKeys {
+		if viper.InConfig(key) {
+			profileConfigValues[key] = viper.Get(key)
+		}
+	}
+
+	directoryConfigFilePath = ""
+	directoryConfigValues = map[string]any{}
+	directoryConfigError = nil
+
+	if disabled, _ := strconv.ParseBool(os.Getenv(DisableDirectoryConfigEnv)); disabled {
+		return nil
+	}
+	path, found := findDirectoryConfigFile(startDir)
+	if !found {
+		return nil
+	}
+	values, err := readDirectoryConfigFile(path)
+	if err != nil {
+		return fmt.Errorf("read directory config file %q: %w", path, err)
+	}
+
+	err = viper.MergeConfigMap(values)
+	if err != nil {
+		return fmt.Errorf("merge directory config file %q: %w", path, err)
+	}
+	directoryConfigFilePath = path
+	directoryConfigValues = values
+	return nil
+}
+
+// findDirectoryConfigFile searches for the directory config file in the given directory and its parents, similar to how git finds the ".git" folder
+func findDirectoryConfigFile(startDir string) (string, bool) {
+	if startDir == "" {
+		return "", false
+	}
+	dir := startDir
+	for {
+		path := filepath.Join(dir, DirectoryConfigFileName)
+		info, err := os.Stat(path)
+		if err == nil && !info.IsDir() {
+			return path, true
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", false
+		}
+		dir = parent
+	}
+}
+
+// readDirectoryConfigFile reads and validates the directory config file in the given path
+func readDirectoryConfigFile(path string) (map[string]any, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read file: %w", err)
+	}
+
+	values := map[string]any{}
+	err = yaml.Unmarshal(content, &values)
+	if err != nil {
+		return nil, fmt.Errorf("parse file: %w", err)
+	}
+
+	allowedKeys := DirectoryConfigKeys()
+	for key, value := range values {
+		if !slices.Contains(allowedKeys, key) {
+			return nil, fmt.Errorf("unsupported key %q, must be one of %q", key, allowedKeys)
+		}
+		valueString, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf("value of key %q must be a string", key)
+		}
+		if strings.HasSuffix(key, "_custom_endpoint") {
+			err = validateDirectoryConfigEndpoint(valueString)
+			if err != nil {
+				return nil, fmt.Errorf("invalid value of key %q: %w", key, err)
+			}
+		}
+	}
+	return values, nil
+}
+
+// validateDirectoryConfigEndpoint ensures custom endpoints set in a directory config file belong to the allowed URL domain,
+// so that a config file in a cloned repository can't send credentials to an arbitrary host
+func validateDirectoryConfigEndpoint(value string) error {
+	urlStruct, err := url.Parse(value)
+	if err != nil {
+		return fmt.Errorf("parse url: %w", err)
+	}
+	urlHost := urlStruct.Hostname()
+	if urlHost == "" {
+		return fmt.Errorf("bad url")
+	}
+
+	allowedUrlDomain := viper.GetString(AllowedUrlDomainKey)
+	if allowedUrlDomain == "" {
+		allowedUrlDomain = AllowedUrlDomainDefault
+	}
+	if !strings.HasSuffix(urlHost, allowedUrlDomain) {
+		return fmt.Errorf(`only urls belonging to domain %s are allowed`, allowedUrlDomain)
+	}
+	return nil
+}
+
+// profileConfigSettings returns the settings to be persisted in the profile config file,
+// leaving out the values that were only set in the directory config file
+func profileConfigSettings() map[string]any {
+	settings := viper.AllSettings()
+	for key := range directoryConfigValues {
+		if isChanged(key) {
+			continue
+		}
+		if profileValue, ok := profileConfigValues[key]; ok {
+			settings[key] = profileValue
+		} else {
+			delete(settings, key)
+		}
+	}
+	return settings
+}
+
+// GetOrigin returns where the current value of the given config key comes from, along with the respective source
+// (environment variable name or config file path). Flags are not taken into account, as they are specific to each command.
+func GetOrigin(key string) (origin, source string) {
+	envVar := fmt.Sprintf("%s_%s", envPrefix, strings.ToUpper(key))
+	if _, ok := os.LookupEnv(envVar); ok {
+		return EnvironmentOrigin, envVar
+	}
+	if _, ok := directoryConfigValues[key]; ok {
+		return DirectoryConfigOrigin, directoryConfigFilePath
+	}
+	if _, ok := profileConfigValues[key]; ok {
+		return ProfileConfigOrigin, viper.ConfigFileUsed()
+	}
+	return DefaultOrigin, ""
+}
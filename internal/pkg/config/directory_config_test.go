@@ -0,0 +1,335 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+)
+
+func TestFindDirectoryConfigFile(t *testing.T) {
+	rootDir := t.TempDir()
+	nestedDir := filepath.Join(rootDir, "a", "b", "c")
+	err := os.MkdirAll(nestedDir, 0o750)
+	if err != nil {
+		t.Fatalf("create nested dir: %v", err)
+	}
+
+	tests := []struct {
+		description  string
+		configDir    string
+		startDir     string
+		expectedPath string
+		expectFound  bool
+	}{
+		{
+			description:  "config in start dir",
+			configDir:    nestedDir,
+			startDir:     nestedDir,
+			expectedPath: filepath.Join(nestedDir, DirectoryConfigFileName),
+			expectFound:  true,
+		},
+		{
+			description:  "config in parent dir",
+			configDir:    filepath.Join(rootDir, "a"),
+			startDir:     nestedDir,
+			expectedPath: filepath.Join(rootDir, "a", DirectoryConfigFileName),
+			expectFound:  true,
+		},
+		{
+			description: "no config",
+			startDir:    nestedDir,
+			expectFound: false,
+		},
+		{
+			description: "empty start dir",
+			startDir:    "",
+			expectFound: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			if tt.configDir != "" {
+				configPath := filepath.Join(tt.configDir, DirectoryConfigFileName)
+				err := os.WriteFile(configPath, []byte("region: eu01\n"), 0o600)
+				if err != nil {
+					t.Fatalf("write config file: %v", err)
+				}
+				defer func() {
+					_ = os.Remove(configPath)
+				}()
+			}
+
+			path, found := findDirectoryConfigFile(tt.startDir)
+			if found != tt.expectFound {
+				t.Fatalf("expected found to be %t, got %t", tt.expectFound, found)
+			}
+			// The temporary directory may be located in a parent of a directory with a config file
+			if tt.expectFound && path != tt.expectedPath {
+				t.Fatalf("expected path %q, got %q", tt.expectedPath, path)
+			}
+		})
+	}
+}
+
+func TestReadDirectoryConfigFile(t *testing.T) {
+	tests := []struct {
+		description    string
+		content        string
+		isValid        bool
+		expectedValues map[string]any
+	}{
+		{
+			description: "base",
+			content:     "project_id: 00000000-0000-0000-0000-000000000000\nregion: eu02\noutput_format: json\ndns_custom_endpoint: https://dns.api.stackit.cloud\n",
+			isValid:     true,
+			expectedValues: map[string]any{
+				ProjectIdKey:         "00000000-0000-0000-0000-000000000000",
+				RegionKey:            "eu02",
+				OutputFormatKey:      "json",
+				DNSCustomEndpointKey: "https://dns.api.stackit.cloud",
+			},
+		},
+		{
+			description:    "empty file",
+			content:        "",
+			isValid:        true,
+			expectedValues: map[string]any{},
+		},
+		{
+			description: "unsupported key",
+			content:     "session_time_limit: 24h\n",
+			isValid:     false,
+		},
+		{
+			description: "non string value",
+			content:     "region:\n  - eu01\n",
+			isValid:     false,
+		},
+		{
+			description: "custom endpoint outside allowed domain",
+			content:     "dns_custom_endpoint: https://example.com\n",
+			isValid:     false,
+		},
+		{
+			description: "invalid yaml",
+			content:     "region: [eu01\n",
+			isValid:     false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			configPath := filepath.Join(t.TempDir(), DirectoryConfigFileName)
+			err := os.WriteFile(configPath, []byte(tt.content), 0o600)
+			if err != nil {
+				t.Fatalf("write config file: %v", err)
+			}
+
+			values, err := readDirectoryConfigFile(configPath)
+			if err != nil {
+				if !tt.isValid {
+					return
+				}
+				t.Fatalf("read config file: %v", err)
+			}
+			if !tt.isValid {
+				t.Fatalf("did not fail on invalid input")
+			}
+			if len(values) != len(tt.expectedValues) {
+				t.Fatalf("expected %d values, got %d", len(tt.expectedValues), len(values))
+			}
+			for key, expected := range tt.expectedValues {
+				if values[key] != expected {
+					t.Errorf("expected %q to be %v, got %v", key, expected, values[key])
+				}
+			}
+		})
+	}
+}
+
+func TestLoadDirectoryConfig(t *testing.T) {
+	defer func() {
+		viper.Reset()
+		directoryConfigFilePath = ""
+		directoryConfigValues = map[string]any{}
+		profileConfigValues = map[string]any{}
+		changedKeys = map[string]bool{}
+		boundFlags = map[string]*pflag.Flag{}
+	}()
+
+	dir := t.TempDir()
+	profileConfigPath := filepath.Join(dir, "cli-config.json")
+	err := os.WriteFile(profileConfigPath, []byte(`{"project_id": "profile-project", "region": "eu01"}`), 0o600)
+	if err != nil {
+		t.Fatalf("write profile config file: %v", err)
+	}
+	err = os.WriteFile(filepath.Join(dir, DirectoryConfigFileName), []byte("project_id: directory-project\n"), 0o600)
+	if err != nil {
+		t.Fatalf("write directory config file: %v", err)
+	}
+
+	viper.Reset()
+	viper.SetConfigFile(profileConfigPath)
+	viper.SetConfigType(configFileExtension)
+	err = viper.ReadInConfig()
+	if err != nil {
+		t.Fatalf("read profile config file: %v", err)
+	}
+
+	err = loadDirectoryConfig(dir)
+	if err != nil {
+		t.Fatalf("load directory config: %v", err)
+	}
+
+	if got := viper.GetString(ProjectIdKey); got != "directory-project" {
+		t.Errorf("expected project ID from directory config, got %q", got)
+	}
+	if got := viper.GetString(RegionKey); got != "eu01" {
+		t.Errorf("expected region from profile config, got %q", got)
+	}
+
+	if origin, _ := GetOrigin(ProjectIdKey); origin != DirectoryConfigOrigin {
+		t.Errorf("expected project ID origin %q, got %q", DirectoryConfigOrigin, origin)
+	}
+	if origin, _ := GetOrigin(RegionKey); origin != ProfileConfigOrigin {
+		t.Errorf("expected region origin %q, got %q", ProfileConfigOrigin, origin)
+	}
+	if origin, _ := GetOrigin(AsyncKey); origin != DefaultOrigin {
+		t.Errorf("expected async origin %q, got %q", DefaultOrigin, origin)
+	}
+
+	// Values only set in the directory config are not persisted in the profile
+	settings := profileConfigSettings()
+	if settings[ProjectIdKey] != "profile-project" {
+		t.Errorf("expected persisted project ID %q, got %v", "profile-project", settings[ProjectIdKey])
+	}
+
+	// Values set explicitly after loading are persisted, even if they equal the directory config value
+	Set(ProjectIdKey, "directory-project")
+	settings = profileConfigSettings()
+	if settings[ProjectIdKey] != "directory-project" {
+		t.Errorf("expected persisted project ID %q, got %v", "directory-project", settings[ProjectIdKey])
+	}
+
+	Set(ProjectIdKey, "new-project")
+	settings = profileConfigSettings()
+	if settings[ProjectIdKey] != "new-project" {
+		t.Errorf("expected persisted project ID %q, got %v", "new-project", settings[ProjectIdKey])
+	}
+}
+
+func TestLoadDirectoryConfigBoundFlag(t *testing.T) {
+	defer func() {
+		viper.Reset()
+		directoryConfigFilePath = ""
+		directoryConfigValues = map[string]any{}
+		profileConfigValues = map[string]any{}
+		changedKeys = map[string]bool{}
+		boundFlags = map[string]*pflag.Flag{}
+	}()
+
+	dir := t.TempDir()
+	err := os.WriteFile(filepath.Join(dir, DirectoryConfigFileName), []byte("region: eu02\n"), 0o600)
+	if err != nil {
+		t.Fatalf("write directory config file: %v", err)
+	}
+
+	viper.Reset()
+	err = loadDirectoryConfig(dir)
+	if err != nil {
+		t.Fatalf("load directory config: %v", err)
+	}
+
+	flagSet := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	flagSet.String("region", "", "")
+	err = BindFlag(RegionKey, flagSet.Lookup("region"))
+	if err != nil {
+		t.Fatalf("bind flag: %v", err)
+	}
+
+	// An unset flag doesn't persist the directory config value
+	if _, ok := profileConfigSettings()[RegionKey]; ok {
+		t.Errorf("expected region not to be persisted")
+	}
+
+	// A set flag is persisted, even if it equals the directory config value
+	err = flagSet.Parse([]string{"--region", "eu02"})
+	if err != nil {
+		t.Fatalf("parse flags: %v", err)
+	}
+	if got := profileConfigSettings()[RegionKey]; got != "eu02" {
+		t.Errorf("expected persisted region %q, got %v", "eu02", got)
+	}
+}
+
+func TestLoadDirectoryConfigInvalid(t *testing.T) {
+	defer func() {
+		viper.Reset()
+		directoryConfigFilePath = ""
+		directoryConfigValues = map[string]any{}
+		profileConfigValues = map[string]any{}
+	}()
+
+	tests := []struct {
+		description string
+		content     string
+		disabled    bool
+		isValid     bool
+	}{
+		{
+			description: "malformed",
+			content:     "project_id: [",
+		},
+		{
+			description: "unknown key",
+			content:     "unknown_key: value\n",
+		},
+		{
+			description: "malformed but disabled",
+			content:     "project_id: [",
+			disabled:    true,
+			isValid:     true,
+		},
+		{
+			description: "valid but disabled",
+			content:     "project_id: directory-project\n",
+			disabled:    true,
+			isValid:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			dir := t.TempDir()
+			err := os.WriteFile(filepath.Join(dir, DirectoryConfigFileName), []byte(tt.content), 0o600)
+			if err != nil {
+				t.Fatalf("write directory config file: %v", err)
+			}
+			if tt.disabled {
+				t.Setenv(DisableDirectoryConfigEnv, "true")
+			}
+
+			viper.Reset()
+			err = loadDirectoryConfig(dir)
+			if !tt.isValid {
+				if err == nil {
+					t.Fatalf("did not fail on invalid directory config file")
+				}
+			} else if err != nil {
+				t.Fatalf("load directory config: %v", err)
+			}
+
+			// Nothing of an invalid or disabled directory config file is used
+			if got := GetDirectoryConfigFilePath(); got != "" {
+				t.Errorf("expected no directory config file, got %q", got)
+			}
+			if got := viper.GetString(ProjectIdKey); got != "" {
+				t.Errorf("expected no project ID, got %q", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,487 @@
+package connection
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+
+	"github.com/stackitcloud/stackit-cli/internal/pkg/print"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+const (
+	ClientFlag   = "client"
+	PrintURIFlag = "print-uri"
+	PrintEnvFlag = "print-env"
+
+	// Shown instead of the password when the output is a terminal
+	maskedPassword = "********"
+)
+
+// Engine is a database engine, with its default local client and the names of the environment variables in env blocks.
+// Engines without a client can't be connected to, but their connection details can be printed.
+type Engine struct {
+	Name   string
+	Scheme string
+	Client string
+	Env    EnvNames
+	// If set, the credentials can't be given as a URI, e.g. because the server doesn't take them in the URI
+	NoURI bool
+	// Environment variable from which the client reads the password.
+	// If empty, the client prompts for the password.
+	ClientPasswordEnv string
+	// Returns the arguments of the client to connect to the database, never with the password
+	clientArgs func(c *Connection) []string
+}
+
+// EnvNames are the names of the environment variables of each field of a connection, empty if not included
+type EnvNames struct {
+	URI string
+	// URI of the server, without the credentials
+	Endpoint string
+	Host     string
+	Port     string
+	Username string
+	Password string
+	Database string
+}
+
+var (
+	PostgreSQL = &Engine{
+		Name:   "PostgreSQL",
+		Scheme: "postgresql",
+		Client: "psql",
+		// The variables read by libpq (and thus psql)
+		Env: EnvNames{
+			URI:      "DATABASE_URL",
+			Host:     "PGHOST",
+			Port:     "PGPORT",
+			Username: "PGUSER",
+			Password: "PGPASSWORD",
+			Database: "PGDATABASE",
+		},
+		ClientPasswordEnv: "PGPASSWORD",
+		clientArgs: func(c *Connection) []string {
+			return []string{c.URI(false)}
+		},
+	}
+	MongoDB = &Engine{
+		Name:   "MongoDB",
+		Scheme: "mongodb",
+		Client: "mongosh",
+		Env: EnvNames{
+			URI:      "MONGODB_URI",
+			Host:     "MONGODB_HOST",
+			Port:     "MONGODB_PORT",
+			Username: "MONGODB_USERNAME",
+			Password: "MONGODB_PASSWORD",
+			Database: "MONGODB_DATABASE",
+		},
+		// mongosh can't read the password from the environment, so it prompts for it.
+		// The username is given as a flag, as mongosh only prompts if the URI has no credentials.
+		clientArgs: func(c *Connection) []string {
+			server := *c
+			server.Username, server.Password = "", ""
+			args := []string{server.URI(false)}
+			if c.Username != "" {
+				args = append(args, "--username", c.Username)
+			}
+			return args
+		},
+	}
+	SQLServer = &Engine{
+		Name:   "SQL Server",
+		Scheme: "sqlserver",
+		Client: "sqlcmd",
+		Env: EnvNames{
+			URI:      "SQLSERVER_URI",
+			Host:     "SQLSERVER_HOST",
+			Port:     "SQLSERVER_PORT",
+			Username: "SQLSERVER_USERNAME",
+			Password: "SQLSERVER_PASSWORD",
+			Database: "SQLSERVER_DATABASE",
+		},
+		ClientPasswordEnv: "SQLCMDPASSWORD",
+		clientArgs: func(c *Connection) []string {
+			server := c.Host
+			if c.Port != 0 {
+				server = fmt.Sprintf("%s,%d", c.Host, c.Port)
+			}
+			args := []string{"-S", server, "-U", c.Username}
+			if c.Database != "" {
+				args = append(args, "-d", c.Database)
+			}
+			return args
+		},
+	}
+	Redis = &Engine{
+		Name:   "Redis",
+		Scheme: "redis",
+		Client: "redis-cli",
+		Env: EnvNames{
+			URI:      "REDIS_URL",
+			Host:     "REDIS_HOST",
+			Port:     "REDIS_PORT",
+			Username: "REDIS_USERNAME",
+			Password: "REDIS_PASSWORD",
+		},
+		ClientPasswordEnv: "REDISCLI_AUTH",
+		clientArgs: func(c *Connection) []string {
+			args := []string{"-h", c.Host}
+			if c.Port != 0 {
+				args = append(args, "-p", strconv.FormatInt(c.Port, 10))
+			}
+			if c.Username != "" {
+				args = append(args, "--user", c.Username)
+			}
+			if c.Scheme == "rediss" {
+				args = append(args, "--tls")
+			}
+			return args
+		},
+	}
+	MariaDB = &Engine{
+		Name:   "MariaDB",
+		Scheme: "mysql",
+		Client: "mysql",
+		Env: EnvNames{
+			URI:      "MARIADB_URI",
+			Host:     "MARIADB_HOST",
+			Port:     "MARIADB_PORT",
+			Username: "MARIADB_USERNAME",
+			Password: "MARIADB_PASSWORD",
+			Database: "MARIADB_DATABASE",
+		},
+		ClientPasswordEnv: "MYSQL_PWD",
+		clientArgs: func(c *Connection) []string {
+			args := []string{"-h", c.Host}
+			if c.Port != 0 {
+				args = append(args, "-P", strconv.FormatInt(c.Port, 10))
+			}
+			args = append(args, "-u", c.Username)
+			if c.Database != "" {
+				args = append(args, c.Database)
+			}
+			return args
+		},
+	}
+	RabbitMQ = &Engine{
+		Name:   "RabbitMQ",
+		Scheme: "amqp",
+		Env: EnvNames{
+			URI:      "RABBITMQ_URL",
+			Host:     "RABBITMQ_HOST",
+			Port:     "RABBITMQ_PORT",
+			Username: "RABBITMQ_USERNAME",
+			Password: "RABBITMQ_PASSWORD",
+		},
+	}
+	OpenSearch = &Engine{
+		Name:   "OpenSearch",
+		Scheme: "https",
+		Env: EnvNames{
+			URI:      "OPENSEARCH_URL",
+			Host:     "OPENSEARCH_HOST",
+			Port:     "OPENSEARCH_PORT",
+			Username: "OPENSEARCH_USERNAME",
+			Password: "OPENSEARCH_PASSWORD",
+		},
+	}
+	LogMe = &Engine{
+		Name:   "LogMe",
+		Scheme: "https",
+		Env: EnvNames{
+			URI:      "LOGME_URL",
+			Host:     "LOGME_HOST",
+			Port:     "LOGME_PORT",
+			Username: "LOGME_USERNAME",
+			Password: "LOGME_PASSWORD",
+		},
+	}
+	// The access key is the username and the secret access key the password.
+	// The variables are the ones read by the AWS SDKs and CLI.
+	ObjectStorage = &Engine{
+		Name:   "Object Storage",
+		Scheme: "https",
+		Env: EnvNames{
+			Endpoint: "AWS_ENDPOINT_URL_S3",
+			Username: "AWS_ACCESS_KEY_ID",
+			Password: "AWS_SECRET_ACCESS_KEY",
+		},
+		NoURI: true,
+	}
+)
+
+// Connection is the information needed to connect to a database
+type Connection struct {
+	Engine *Engine
+	// Defaults to the scheme of the engine
+	Scheme   string
+	Host     string
+	Port     int64
+	Username string
+	Password string
+	Database string
+	// Query parameters of the URI, e.g. "authSource=admin"
+	Query string
+}
+
+// FromURI returns the connection to the database with the given URI
+func FromURI(engine *Engine, uri string) (*Connection, error) {
+	u, err := url.Parse(uri)
+	if err != nil {
+		return nil, fmt.Errorf("parse connection URI: %w", err)
+	}
+	if u.Host == "" {
+		return nil, fmt.Errorf("parse connection URI: no host")
+	}
+
+	c := &Connection{
+		Engine:   engine,
+		Scheme:   u.Scheme,
+		Host:     u.Hostname(),
+		Database: strings.TrimPrefix(u.Path, "/"),
+		Query:    u.RawQuery,
+	}
+	if port := u.Port(); port != "" {
+		c.Port, err = strconv.ParseInt(port, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("parse connection URI: invalid port %q", port)
+		}
+	}
+	if u.User != nil {
+		c.Username = u.User.Username()
+		c.Password, _ = u.User.Password()
+	}
+	return c, nil
+}
+
+// URI returns the connection URI, with the password if includePassword is set
+func (c *Connection) URI(includePassword bool) string {
+	u := &url.URL{
+		Scheme:   c.Scheme,
+		Host:     c.Host,
+		RawQuery: c.Query,
+	}
+	if u.Scheme == "" {
+		u.Scheme = c.Engine.Scheme
+	}
+	if c.Port != 0 {
+		u.Host = net.JoinHostPort(c.Host, strconv.FormatInt(c.Port, 10))
+	}
+	if c.Database != "" {
+		u.Path = "/" + c.Database
+	}
+	switch {
+	case includePassword && c.Password != "":
+		u.User = url.UserPassword(c.Username, c.Password)
+	case c.Username != "":
+		u.User = url.User(c.Username)
+	}
+	return u.String()
+}
+
+// Env returns the env block of the connection, as "KEY=value" lines, with the password if includePassword is set
+func (c *Connection) Env(includePassword bool) []string {
+	env := []string{}
+	for _, v := range c.envValues(includePassword) {
+		env = append(env, fmt.Sprintf("%s=%s", v.name, quoteEnvValue(v.value)))
+	}
+	return env
+}
+
+// Values returns the environment variables of the connection with the password, by name, e.g. to store them as a secret
+func (c *Connection) Values() map[string]string {
+	values := map[string]string{}
+	for _, v := range c.envValues(true) {
+		values[v.name] = v.value
+	}
+	return values
+}
+
+type envValue struct {
+	name, value string
+}
+
+// envValues returns the non-empty environment variables of the connection, in the order of the env block
+func (c *Connection) envValues(includePassword bool) []envValue {
+	uri, password := c.URI(true), c.Password
+	if !includePassword {
+		uri, password = c.maskedURI(), maskedPassword
+	}
+	if c.Password == "" {
+		password = ""
+	}
+
+	names := c.Engine.Env
+	values := []envValue{}
+	for _, v := range []envValue{
+		{names.URI, uri},
+		{names.Endpoint, c.endpoint()},
+		{names.Host, c.Host},
+		{names.Port, portString(c.Port)},
+		{names.Username, c.Username},
+		{names.Password, password},
+		{names.Database, c.Database},
+	} {
+		if v.name == "" || v.value == "" {
+			continue
+		}
+		values = append(values, v)
+	}
+	return values
+}
+
+// endpoint returns the URI of the server, without the credentials, database and query
+func (c *Connection) endpoint() string {
+	server := *c
+	server.Username, server.Password, server.Database, server.Query = "", "", "", ""
+	return server.URI(false)
+}
+
+// maskedURI returns the connection URI with the password, if any, masked
+func (c *Connection) maskedURI() string {
+	if c.Password == "" {
+		return c.URI(false)
+	}
+	masked := *c
+	masked.Password = maskedPassword
+	// The mask is escaped in the URI
+	escapedMask := strings.TrimPrefix(url.UserPassword("", maskedPassword).String(), ":")
+	return strings.Replace(masked.URI(true), escapedMask, maskedPassword, 1)
+}
+
+func portString(port int64) string {
+	if port == 0 {
+		return ""
+	}
+	return strconv.FormatInt(port, 10)
+}
+
+// Values with characters that have a meaning to the shell are single-quoted, so the env block can be sourced
+func quoteEnvValue(value string) string {
+	if !strings.ContainsAny(value, " \t\n'\"\\$`!&|;<>()*?[]#~{}") {
+		return value
+	}
+	return "'" + strings.ReplaceAll(value, "'", `'\''`) + "'"
+}
+
+// Options are the values of the flags of the connect commands
+type Options struct {
+	Client     string
+	PrintURI   bool
+	PrintEnv   bool
+	ClientArgs []string
+}
+
+// ConfigureFlags adds the flags of the connect commands.
+// Engines without a client have no --client flag, and one of the print flags is required.
+func ConfigureFlags(cmd *cobra.Command, engine *Engine) {
+	if engine.Client == "" {
+		cmd.Flags().Bool(PrintURIFlag, false, "If set, prints the connection URI. The password is masked if the output is a terminal")
+		cmd.Flags().Bool(PrintEnvFlag, false, "If set, prints the connection details as environment variables. The password is masked if the output is a terminal")
+		cmd.MarkFlagsOneRequired(PrintURIFlag, PrintEnvFlag)
+	} else {
+		cmd.Flags().String(ClientFlag, engine.Client, "Path or name of the local client executable")
+		cmd.Flags().Bool(PrintURIFlag, false, "If set, prints the connection URI instead of starting the client. The password is masked if the output is a terminal")
+		cmd.Flags().Bool(PrintEnvFlag, false, "If set, prints the connection details as environment variables instead of starting the client. The password is masked if the output is a terminal")
+	}
+	cmd.MarkFlagsMutuallyExclusive(PrintURIFlag, PrintEnvFlag)
+}
+
+// ParseFlags returns the values of the flags of the connect commands.
+// The arguments after "--" are passed to the client.
+func ParseFlags(cmd *cobra.Command, inputArgs []string) (*Options, error) {
+	var client string
+	if cmd.Flags().Lookup(ClientFlag) != nil {
+		var err error
+		client, err = cmd.Flags().GetString(ClientFlag)
+		if err != nil {
+			return nil, err
+		}
+	}
+	printURI, err := cmd.Flags().GetBool(PrintURIFlag)
+	if err != nil {
+		return nil, err
+	}
+	printEnv, err := cmd.Flags().GetBool(PrintEnvFlag)
+	if err != nil {
+		return nil, err
+	}
+
+	options := &Options{
+		Client:     client,
+		PrintURI:   printURI,
+		PrintEnv:   printEnv,
+		ClientArgs: []string{},
+	}
+	if dash := cmd.ArgsLenAtDash(); dash >= 0 {
+		options.ClientArgs = inputArgs[dash:]
+	}
+	return options, nil
+}
+
+// Connect prints the connection URI or env block, or starts the local client connected to the database, attached to the terminal.
+// The password is never printed to a terminal, and is passed to the client through its environment if possible, otherwise the client prompts for it.
+func Connect(p *print.Printer, c *Connection, options *Options) error {
+	out := p.Cmd.OutOrStdout()
+	switch {
+	case options.PrintURI:
+		if isTerminal(out) {
+			p.Outputln(c.maskedURI())
+			return nil
+		}
+		p.Outputln(c.URI(true))
+		return nil
+	case options.PrintEnv:
+		p.Outputln(strings.Join(c.Env(!isTerminal(out)), "\n"))
+		return nil
+	}
+
+	if c.Engine.Client == "" {
+		return fmt.Errorf("%s has no local client, use --%s or --%s to print the connection details", c.Engine.Name, PrintURIFlag, PrintEnvFlag)
+	}
+	cmd := ClientCommand(c, options.Client, options.ClientArgs)
+	p.Debug(print.DebugLevel, "starting client %q", options.Client)
+	err := cmd.Run()
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			return fmt.Errorf("%s client %q exited with code %d", c.Engine.Name, options.Client, exitErr.ExitCode())
+		}
+		if errors.Is(err, exec.ErrNotFound) {
+			return fmt.Errorf("%s client %q not found, install it or set its path with --%s", c.Engine.Name, options.Client, ClientFlag)
+		}
+		return fmt.Errorf("run %s client: %w", c.Engine.Name, err)
+	}
+	return nil
+}
+
+// ClientCommand returns the command running the local client connected to the database, attached to the terminal
+func ClientCommand(c *Connection, client string, extraArgs []string) *exec.Cmd {
+	args := c.Engine.clientArgs(c)
+	args = append(args, extraArgs...)
+
+	cmd := exec.Command(client, args...) //nolint:gosec // the client is chosen by the user
+	cmd.Env = os.Environ()
+	if c.Engine.ClientPasswordEnv != "" && c.Password != "" {
+		cmd.Env = append(cmd.Env, fmt.Sprintf("%s=%s", c.Engine.ClientPasswordEnv, c.Password))
+	}
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return cmd
+}
+
+func isTerminal(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	return ok && term.IsTerminal(int(f.Fd()))
+}
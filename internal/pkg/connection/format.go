@@ -0,0 +1,143 @@
+package connection
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/stackitcloud/stackit-cli/internal/pkg/errors"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/flags"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/print"
+
+	"github.com/goccy/go-yaml"
+	"github.com/spf13/cobra"
+)
+
+const (
+	FormatFlag     = "format"
+	SecretNameFlag = "secret-name" //nolint:gosec // linter false positive
+
+	URIFormat       = "uri"
+	EnvFormat       = "env"
+	K8sSecretFormat = "k8s-secret" //nolint:gosec // linter false positive
+
+	// Kubernetes object names are limited to 253 characters
+	maxSecretNameLength = 253
+)
+
+var formats = []string{URIFormat, EnvFormat, K8sSecretFormat}
+
+// Kubernetes object names are DNS subdomains
+var secretNameRegex = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*$`)
+
+// FormatOptions are the values of the flags of the credentials commands selecting the format of the credentials
+type FormatOptions struct {
+	Format     string
+	SecretName *string
+}
+
+// ConfigureFormatFlags adds the flags selecting the format of the credentials
+func ConfigureFormatFlags(cmd *cobra.Command) {
+	cmd.Flags().Var(flags.EnumFlag(true, "", formats...), FormatFlag, fmt.Sprintf("Prints the credentials as a connection URI, as environment variables or as a Kubernetes Secret manifest instead of the output format, one of %q", formats))
+	cmd.Flags().String(SecretNameFlag, "", fmt.Sprintf("Name of the Kubernetes Secret, with --%s %s. If not specified, the name is derived from the credentials", FormatFlag, K8sSecretFormat))
+}
+
+// ParseFormatFlags returns the values of the flags selecting the format of the credentials, or nil if no format is selected
+func ParseFormatFlags(p *print.Printer, cmd *cobra.Command) (*FormatOptions, error) {
+	format := flags.FlagToStringValue(p, cmd, FormatFlag)
+	secretName := flags.FlagToStringPointer(p, cmd, SecretNameFlag)
+
+	if secretName != nil {
+		if format != K8sSecretFormat {
+			return nil, &errors.FlagValidationError{
+				Flag:    SecretNameFlag,
+				Details: fmt.Sprintf("can only be set with --%s %s", FormatFlag, K8sSecretFormat),
+			}
+		}
+		if len(*secretName) > maxSecretNameLength || !secretNameRegex.MatchString(*secretName) {
+			return nil, &errors.FlagValidationError{
+				Flag:    SecretNameFlag,
+				Details: "must consist of lower case alphanumeric characters, '-' or '.', and start and end with an alphanumeric character",
+			}
+		}
+	}
+	if format == "" {
+		return nil, nil
+	}
+	return &FormatOptions{
+		Format:     format,
+		SecretName: secretName,
+	}, nil
+}
+
+// PrintCredentials prints the connection in the selected format.
+// The password is included if showPassword is set or the output is not a terminal, e.g. when it is piped to kubectl.
+// The name of the Kubernetes Secret defaults to defaultSecretName, made a valid name.
+func PrintCredentials(p *print.Printer, c *Connection, options *FormatOptions, defaultSecretName string, showPassword bool) error {
+	includePassword := showPassword || !isTerminal(p.Cmd.OutOrStdout())
+
+	switch options.Format {
+	case URIFormat:
+		if c.Engine.NoURI {
+			return fmt.Errorf("%s credentials have no connection URI, use --%s %s or --%s %s", c.Engine.Name, FormatFlag, EnvFormat, FormatFlag, K8sSecretFormat)
+		}
+		if includePassword {
+			p.Outputln(c.URI(true))
+		} else {
+			p.Outputln(c.maskedURI())
+		}
+		return nil
+	case EnvFormat:
+		p.Outputln(strings.Join(c.Env(includePassword), "\n"))
+		return nil
+	case K8sSecretFormat:
+		secretName := SecretName(defaultSecretName)
+		if options.SecretName != nil {
+			secretName = *options.SecretName
+		}
+		manifest, err := c.secretManifest(secretName, includePassword)
+		if err != nil {
+			return err
+		}
+		p.Outputln(manifest)
+		return nil
+	default:
+		return fmt.Errorf("unsupported credentials format %q", options.Format)
+	}
+}
+
+// secretManifest returns the manifest of a Kubernetes Secret with the environment variables of the connection as keys
+func (c *Connection) secretManifest(name string, includePassword bool) (string, error) {
+	stringData := yaml.MapSlice{}
+	for _, v := range c.envValues(includePassword) {
+		stringData = append(stringData, yaml.MapItem{Key: v.name, Value: v.value})
+	}
+	manifest := yaml.MapSlice{
+		{Key: "apiVersion", Value: "v1"},
+		{Key: "kind", Value: "Secret"},
+		{Key: "metadata", Value: yaml.MapSlice{{Key: "name", Value: name}}},
+		{Key: "type", Value: "Opaque"},
+		{Key: "stringData", Value: stringData},
+	}
+	details, err := yaml.Marshal(manifest)
+	if err != nil {
+		return "", fmt.Errorf("marshal Kubernetes Secret: %w", err)
+	}
+	return strings.TrimSuffix(string(details), "\n"), nil
+}
+
+// SecretName returns a valid Kubernetes Secret name from the given name,
+// in lower case and with invalid characters replaced by "-"
+func SecretName(name string) string {
+	name = strings.ToLower(name)
+	name = strings.Map(func(r rune) rune {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') || r == '-' || r == '.' {
+			return r
+		}
+		return '-'
+	}, name)
+	if len(name) > maxSecretNameLength {
+		name = name[:maxSecretNameLength]
+	}
+	return strings.Trim(name, "-.")
+}
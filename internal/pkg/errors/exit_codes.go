@@ -0,0 +1,191 @@
+package errors
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/stackitcloud/stackit-sdk-go/core/oapierror"
+)
+
+// Exit codes of the CLI for each class of errors.
+// These are part of the public interface of the CLI, so existing values must not be changed.
+const (
+	ExitCodeSuccess          = 0
+	ExitCodeGeneric          = 1
+	ExitCodeValidation       = 2
+	ExitCodeAuth             = 3
+	ExitCodePermissionDenied = 4
+	ExitCodeNotFound         = 5
+	ExitCodeConflict         = 6
+	ExitCodeServiceDisabled  = 7
+	ExitCodeAPI              = 8
+)
+
+// Error codes included in the JSON error output, one for each exit code
+const (
+	ErrorCodeGeneric          = "generic_error"
+	ErrorCodeValidation       = "validation_error"
+	ErrorCodeAuth             = "auth_error"
+	ErrorCodePermissionDenied = "permission_denied"
+	ErrorCodeNotFound         = "not_found"
+	ErrorCodeConflict         = "conflict"
+	ErrorCodeServiceDisabled  = "service_disabled"
+	ErrorCodeAPI              = "api_error"
+)
+
+var errorCodes = map[int]string{
+	ExitCodeGeneric:          ErrorCodeGeneric,
+	ExitCodeValidation:       ErrorCodeValidation,
+	ExitCodeAuth:             ErrorCodeAuth,
+	ExitCodePermissionDenied: ErrorCodePermissionDenied,
+	ExitCodeNotFound:         ErrorCodeNotFound,
+	ExitCodeConflict:         ErrorCodeConflict,
+	ExitCodeServiceDisabled:  ErrorCodeServiceDisabled,
+	ExitCodeAPI:              ErrorCodeAPI,
+}
+
+// Prefixes of the usage errors returned by Cobra when parsing the command, args and flags
+var cobraUsageErrorPrefixes = []string{
+	"unknown command",
+	"unknown flag",
+	"unknown shorthand flag",
+	"required flag(s)",
+	"invalid argument",
+	"flag needs an argument",
+	"bad flag syntax",
+	"if any flags in the group",
+	"accepts ",
+}
+
+// ErrorDetails is the machine-readable representation of an error, printed when the output format is JSON
+type ErrorDetails struct {
+	Code       string `json:"code"`
+	ExitCode   int    `json:"exit_code"`
+	Message    string `json:"message"`
+	HTTPStatus int    `json:"http_status,omitempty"`
+	APIError   any    `json:"api_error,omitempty"`
+	RequestId  string `json:"request_id,omitempty"`
+}
+
+// ExitCode returns the exit code of the CLI for the given error
+func ExitCode(err error) int {
+	if err == nil {
+		return ExitCodeSuccess
+	}
+
+	var oapiErr *oapierror.GenericOpenAPIError
+	if errors.As(err, &oapiErr) {
+		return exitCodeFromHTTPStatus(oapiErr.StatusCode)
+	}
+
+	var (
+		authErr               *AuthError
+		sessionExpiredErr     *SessionExpiredError
+		accessTokenExpiredErr *AccessTokenExpiredError
+		activateSAErr         *ActivateServiceAccountError
+		serviceDisabledErr    *ServiceDisabledError
+	)
+	switch {
+	case errors.As(err, &authErr), errors.As(err, &sessionExpiredErr), errors.As(err, &accessTokenExpiredErr), errors.As(err, &activateSAErr):
+		return ExitCodeAuth
+	case errors.As(err, &serviceDisabledErr):
+		return ExitCodeServiceDisabled
+	case isValidationError(err):
+		return ExitCodeValidation
+	}
+	return ExitCodeGeneric
+}
+
+func exitCodeFromHTTPStatus(status int) int {
+	switch status {
+	case http.StatusUnauthorized:
+		return ExitCodeAuth
+	case http.StatusForbidden:
+		return ExitCodePermissionDenied
+	case http.StatusNotFound:
+		return ExitCodeNotFound
+	case http.StatusConflict:
+		return ExitCodeConflict
+	case http.StatusBadRequest, http.StatusUnprocessableEntity:
+		return ExitCodeValidation
+	}
+	return ExitCodeAPI
+}
+
+func isValidationError(err error) bool {
+	var (
+		projectIdErr                 *ProjectIdError
+		emptyUpdateErr               *EmptyUpdateError
+		flagValidationErr            *FlagValidationError
+		requiredMutuallyExclusiveErr *RequiredMutuallyExclusiveFlagsError
+		argValidationErr             *ArgValidationError
+		singleArgExpectedErr         *SingleArgExpectedError
+		singleOptionalArgExpectedErr *SingleOptionalArgExpectedError
+		inputUnknownErr              *InputUnknownError
+		subcommandMissingErr         *SubcommandMissingError
+		invalidProfileNameErr        *InvalidProfileNameError
+		observabilityInputPlanErr    *ObservabilityInputPlanError
+		observabilityInvalidPlanErr  *ObservabilityInvalidPlanError
+		dsaInputPlanErr              *DSAInputPlanError
+		dsaInvalidPlanErr            *DSAInvalidPlanError
+		databaseInputFlavorErr       *DatabaseInputFlavorError
+		databaseInvalidFlavorErr     *DatabaseInvalidFlavorError
+		databaseInvalidStorageErr    *DatabaseInvalidStorageError
+	)
+	switch {
+	case errors.As(err, &projectIdErr),
+		errors.As(err, &emptyUpdateErr),
+		errors.As(err, &flagValidationErr),
+		errors.As(err, &requiredMutuallyExclusiveErr),
+		errors.As(err, &argValidationErr),
+		errors.As(err, &singleArgExpectedErr),
+		errors.As(err, &singleOptionalArgExpectedErr),
+		errors.As(err, &inputUnknownErr),
+		errors.As(err, &subcommandMissingErr),
+		errors.As(err, &invalidProfileNameErr),
+		errors.As(err, &observabilityInputPlanErr),
+		errors.As(err, &observabilityInvalidPlanErr),
+		errors.As(err, &dsaInputPlanErr),
+		errors.As(err, &dsaInvalidPlanErr),
+		errors.As(err, &databaseInputFlavorErr),
+		errors.As(err, &databaseInvalidFlavorErr),
+		errors.As(err, &databaseInvalidStorageErr):
+		return true
+	}
+
+	msg := err.Error()
+	for _, prefix := range cobraUsageErrorPrefixes {
+		if strings.HasPrefix(msg, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// NewErrorDetails returns the machine-readable representation of the given error.
+// The request ID identifies the failed API request, if any.
+func NewErrorDetails(err error, requestId string) *ErrorDetails {
+	exitCode := ExitCode(err)
+	details := &ErrorDetails{
+		Code:     errorCodes[exitCode],
+		ExitCode: exitCode,
+		Message:  strings.TrimSpace(err.Error()),
+	}
+
+	var oapiErr *oapierror.GenericOpenAPIError
+	if errors.As(err, &oapiErr) {
+		details.HTTPStatus = oapiErr.StatusCode
+		details.RequestId = requestId
+		if len(oapiErr.Body) > 0 {
+			var apiError any
+			if json.Unmarshal(oapiErr.Body, &apiError) == nil {
+				details.APIError = apiError
+			} else {
+				details.APIError = string(oapiErr.Body)
+			}
+		}
+	}
+	return details
+}
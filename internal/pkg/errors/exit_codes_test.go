@@ -0,0 +1,153 @@
+package errors
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stackitcloud/stackit-sdk-go/core/oapierror"
+)
+
+func TestExitCode(t *testing.T) {
+	tests := []struct {
+		description      string
+		err              error
+		expectedExitCode int
+	}{
+		{
+			description:      "no error",
+			err:              nil,
+			expectedExitCode: ExitCodeSuccess,
+		},
+		{
+			description:      "generic error",
+			err:              errors.New("some error"),
+			expectedExitCode: ExitCodeGeneric,
+		},
+		{
+			description:      "session expired",
+			err:              &SessionExpiredError{},
+			expectedExitCode: ExitCodeAuth,
+		},
+		{
+			description:      "wrapped auth error",
+			err:              fmt.Errorf("configure client: %w", &AuthError{}),
+			expectedExitCode: ExitCodeAuth,
+		},
+		{
+			description:      "arg validation",
+			err:              &ArgValidationError{Arg: "arg", Details: "details"},
+			expectedExitCode: ExitCodeValidation,
+		},
+		{
+			description:      "flag validation",
+			err:              &FlagValidationError{Flag: "flag", Details: "details"},
+			expectedExitCode: ExitCodeValidation,
+		},
+		{
+			description:      "cobra required flags",
+			err:              errors.New(`required flag(s) "name" not set`),
+			expectedExitCode: ExitCodeValidation,
+		},
+		{
+			description:      "service disabled",
+			err:              &ServiceDisabledError{Service: "ske"},
+			expectedExitCode: ExitCodeServiceDisabled,
+		},
+		{
+			description:      "API not found",
+			err:              fmt.Errorf("get instance: %w", oapierror.NewError(http.StatusNotFound, "Not Found")),
+			expectedExitCode: ExitCodeNotFound,
+		},
+		{
+			description:      "API conflict",
+			err:              fmt.Errorf("create instance: %w", oapierror.NewError(http.StatusConflict, "Conflict")),
+			expectedExitCode: ExitCodeConflict,
+		},
+		{
+			description:      "API unauthorized",
+			err:              oapierror.NewError(http.StatusUnauthorized, "Unauthorized"),
+			expectedExitCode: ExitCodeAuth,
+		},
+		{
+			description:      "API forbidden",
+			err:              oapierror.NewError(http.StatusForbidden, "Forbidden"),
+			expectedExitCode: ExitCodePermissionDenied,
+		},
+		{
+			description:      "API bad request",
+			err:              oapierror.NewError(http.StatusBadRequest, "Bad Request"),
+			expectedExitCode: ExitCodeValidation,
+		},
+		{
+			description:      "API server error",
+			err:              oapierror.NewError(http.StatusInternalServerError, "Internal Server Error"),
+			expectedExitCode: ExitCodeAPI,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			exitCode := ExitCode(tt.err)
+			if exitCode != tt.expectedExitCode {
+				t.Fatalf("expected exit code %d, got %d", tt.expectedExitCode, exitCode)
+			}
+		})
+	}
+}
+
+func TestNewErrorDetails(t *testing.T) {
+	tests := []struct {
+		description     string
+		err             error
+		requestId       string
+		expectedDetails *ErrorDetails
+	}{
+		{
+			description: "generic error",
+			err:         errors.New("some error\n"),
+			requestId:   "request-id",
+			expectedDetails: &ErrorDetails{
+				Code:     ErrorCodeGeneric,
+				ExitCode: ExitCodeGeneric,
+				Message:  "some error",
+			},
+		},
+		{
+			description: "API error with JSON body",
+			err:         fmt.Errorf("get instance: %w", oapierror.NewErrorWithBody(http.StatusNotFound, "Not Found", []byte(`{"message":"instance not found"}`), nil)),
+			requestId:   "request-id",
+			expectedDetails: &ErrorDetails{
+				Code:       ErrorCodeNotFound,
+				ExitCode:   ExitCodeNotFound,
+				Message:    `get instance: Not Found, status code 404, Body: {"message":"instance not found"}`,
+				HTTPStatus: http.StatusNotFound,
+				APIError:   map[string]any{"message": "instance not found"},
+				RequestId:  "request-id",
+			},
+		},
+		{
+			description: "API error with text body",
+			err:         oapierror.NewErrorWithBody(http.StatusConflict, "Conflict", []byte("already exists"), nil),
+			expectedDetails: &ErrorDetails{
+				Code:       ErrorCodeConflict,
+				ExitCode:   ExitCodeConflict,
+				Message:    "Conflict, status code 409, Body: already exists",
+				HTTPStatus: http.StatusConflict,
+				APIError:   "already exists",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			details := NewErrorDetails(tt.err, tt.requestId)
+			diff := cmp.Diff(details, tt.expectedDetails)
+			if diff != "" {
+				t.Fatalf("Data does not match: %s", diff)
+			}
+		})
+	}
+}
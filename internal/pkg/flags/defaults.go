@@ -0,0 +1,184 @@
+package flags
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/goccy/go-yaml"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+const (
+	envVarPrefix = "STACKIT"
+	// Environment variable with the path of the flags file, used if the --flags-file flag is not set
+	FlagsFileEnvVar = envVarPrefix + "_FLAGS_FILE"
+)
+
+// Flags that never get a default value
+var flagsWithoutDefaults = []string{"help", "flags-file"}
+
+// FlagsFile holds default flag values by command path, e.g.:
+//
+//	dns record-set:
+//	  zone-id: xxx
+//	ske cluster describe:
+//	  output-format: json
+//
+// The command path is given without the "stackit" prefix. The values of a command path also apply to its
+// subcommands, with the values of more specific command paths taking precedence.
+type FlagsFile map[string]map[string]any
+
+// ReadFlagsFile reads the flags file with the given path
+func ReadFlagsFile(path string) (FlagsFile, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read flags file: %w", err)
+	}
+	flagsFile := FlagsFile{}
+	err = yaml.Unmarshal(content, &flagsFile)
+	if err != nil {
+		return nil, fmt.Errorf("parse flags file %q: %w", path, err)
+	}
+	return flagsFile, nil
+}
+
+// EnvVarName returns the name of the environment variable holding the default value of a flag of the given command,
+// which has the format STACKIT_<COMMAND>_<FLAG>, e.g. STACKIT_DNS_RECORD_SET_CREATE_ZONE_ID
+func EnvVarName(cmd *cobra.Command, flag string) string {
+	parts := append([]string{envVarPrefix}, strings.Fields(commandPath(cmd))...)
+	parts = append(parts, flag)
+	name := strings.Join(parts, "_")
+	return strings.ToUpper(strings.ReplaceAll(name, "-", "_"))
+}
+
+// ApplyDefaults sets the flags of the command that were not set in the command line,
+// from the STACKIT_<COMMAND>_<FLAG> environment variables or, if not set, from the flags file (if any).
+// Returns the names of the flags that were set, with the source of their values.
+func ApplyDefaults(cmd *cobra.Command, flagsFile FlagsFile) ([]string, error) {
+	fileValues, err := flagsFileValues(cmd, flagsFile)
+	if err != nil {
+		return nil, err
+	}
+
+	applied := []string{}
+	var applyErr error
+	cmd.Flags().VisitAll(func(f *pflag.Flag) {
+		if applyErr != nil || f.Changed || isFlagWithoutDefault(f.Name) {
+			return
+		}
+
+		envVar := EnvVarName(cmd, f.Name)
+		if value, ok := os.LookupEnv(envVar); ok && value != "" {
+			err := cmd.Flags().Set(f.Name, value)
+			if err != nil {
+				applyErr = fmt.Errorf("set --%s from environment variable %s: %w", f.Name, envVar, err)
+				return
+			}
+			applied = append(applied, fmt.Sprintf("--%s from environment variable %s", f.Name, envVar))
+			return
+		}
+
+		if value, ok := fileValues[f.Name]; ok && value != nil {
+			err := setFromFileValue(cmd.Flags(), f, value)
+			if err != nil {
+				applyErr = fmt.Errorf("set --%s from flags file: %w", f.Name, err)
+				return
+			}
+			applied = append(applied, fmt.Sprintf("--%s from flags file", f.Name))
+		}
+	})
+	if applyErr != nil {
+		return nil, applyErr
+	}
+	return applied, nil
+}
+
+// flagsFileValues returns the values of the flags file that apply to the given command
+func flagsFileValues(cmd *cobra.Command, flagsFile FlagsFile) (map[string]any, error) {
+	path := commandPath(cmd)
+	// Command paths that apply to the command, mapped to their keys in the flags file
+	keys := map[string]string{}
+	for key := range flagsFile {
+		keyPath := strings.Join(strings.Fields(key), " ")
+		if keyPath == path || strings.HasPrefix(path, keyPath+" ") {
+			keys[keyPath] = key
+		}
+	}
+	// Values of more specific command paths override the others
+	keyPaths := make([]string, 0, len(keys))
+	for keyPath := range keys {
+		keyPaths = append(keyPaths, keyPath)
+	}
+	sort.Slice(keyPaths, func(i, j int) bool { return len(keyPaths[i]) < len(keyPaths[j]) })
+
+	values := map[string]any{}
+	for _, key := range keyPaths {
+		for name, value := range flagsFile[keys[key]] {
+			// Values of command groups may only apply to some of their subcommands,
+			// but values of the command itself must be for its flags
+			if cmd.Flags().Lookup(name) == nil {
+				if key == path {
+					return nil, fmt.Errorf("flags file: command %q has no flag --%s", key, name)
+				}
+				continue
+			}
+			values[name] = value
+		}
+	}
+	return values, nil
+}
+
+func setFromFileValue(flagSet *pflag.FlagSet, f *pflag.Flag, value any) error {
+	switch v := value.(type) {
+	case []any:
+		items := make([]string, 0, len(v))
+		for _, item := range v {
+			items = append(items, fmt.Sprint(item))
+		}
+		if sliceValue, ok := f.Value.(pflag.SliceValue); ok {
+			err := sliceValue.Replace(items)
+			if err != nil {
+				return err
+			}
+			f.Changed = true
+			return nil
+		}
+		// Custom slice flags append the values of each call
+		for _, item := range items {
+			err := flagSet.Set(f.Name, item)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	case map[string]any:
+		pairs := make([]string, 0, len(v))
+		for key, item := range v {
+			pairs = append(pairs, fmt.Sprintf("%s=%v", key, item))
+		}
+		sort.Strings(pairs)
+		return flagSet.Set(f.Name, strings.Join(pairs, ","))
+	default:
+		return flagSet.Set(f.Name, fmt.Sprint(v))
+	}
+}
+
+func isFlagWithoutDefault(name string) bool {
+	for _, flag := range flagsWithoutDefaults {
+		if flag == name {
+			return true
+		}
+	}
+	return false
+}
+
+// commandPath returns the path of the command without the name of the root command, e.g. "dns record-set create"
+func commandPath(cmd *cobra.Command) string {
+	if !cmd.HasParent() {
+		return ""
+	}
+	return strings.TrimPrefix(cmd.CommandPath(), cmd.Root().Name()+" ")
+}
@@ -0,0 +1,214 @@
+package flags
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/spf13/cobra"
+)
+
+// newTestCmd returns the "stackit dns record-set create" command, with some flags of different types
+func newTestCmd(t *testing.T, args ...string) *cobra.Command {
+	t.Helper()
+
+	root := &cobra.Command{Use: "stackit"}
+	dns := &cobra.Command{Use: "dns"}
+	recordSet := &cobra.Command{Use: "record-set"}
+	cmd := &cobra.Command{Use: "create"}
+	root.AddCommand(dns)
+	dns.AddCommand(recordSet)
+	recordSet.AddCommand(cmd)
+
+	cmd.Flags().String("zone-id", "", "")
+	cmd.Flags().String("name", "", "")
+	cmd.Flags().Int64("ttl", 0, "")
+	cmd.Flags().Bool("active", false, "")
+	cmd.Flags().StringSlice("record", nil, "")
+	cmd.Flags().Var(UUIDSliceFlag(), "acl-ids", "")
+	cmd.Flags().StringToString("labels", nil, "")
+
+	err := cmd.Flags().Parse(args)
+	if err != nil {
+		t.Fatalf("parse flags: %v", err)
+	}
+	return cmd
+}
+
+func TestEnvVarName(t *testing.T) {
+	cmd := newTestCmd(t)
+	name := EnvVarName(cmd, "zone-id")
+	if name != "STACKIT_DNS_RECORD_SET_CREATE_ZONE_ID" {
+		t.Fatalf("unexpected environment variable name %q", name)
+	}
+}
+
+func TestReadFlagsFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "flags.yaml")
+	err := os.WriteFile(path, []byte("dns record-set:\n  zone-id: xxx\n  ttl: 60\n"), 0o600)
+	if err != nil {
+		t.Fatalf("write flags file: %v", err)
+	}
+
+	flagsFile, err := ReadFlagsFile(path)
+	if err != nil {
+		t.Fatalf("read flags file: %v", err)
+	}
+	expected := FlagsFile{
+		"dns record-set": {"zone-id": "xxx", "ttl": uint64(60)},
+	}
+	diff := cmp.Diff(flagsFile, expected)
+	if diff != "" {
+		t.Fatalf("Data does not match: %s", diff)
+	}
+
+	_, err = ReadFlagsFile(filepath.Join(t.TempDir(), "missing.yaml"))
+	if err == nil {
+		t.Fatalf("did not fail on missing file")
+	}
+}
+
+func TestApplyDefaults(t *testing.T) {
+	tests := []struct {
+		description     string
+		args            []string
+		envVars         map[string]string
+		flagsFile       FlagsFile
+		isValid         bool
+		expectedValues  map[string]string
+		expectedApplied []string
+	}{
+		{
+			description:     "no defaults",
+			isValid:         true,
+			expectedValues:  map[string]string{"zone-id": "", "name": ""},
+			expectedApplied: []string{},
+		},
+		{
+			description: "environment variables",
+			envVars: map[string]string{
+				"STACKIT_DNS_RECORD_SET_CREATE_ZONE_ID": "xxx",
+				"STACKIT_DNS_RECORD_SET_CREATE_RECORD":  "1.2.3.4,4.3.2.1",
+				"STACKIT_DNS_RECORD_SET_CREATE_NAME":    "",
+			},
+			isValid: true,
+			expectedValues: map[string]string{
+				"zone-id": "xxx",
+				"name":    "",
+				"record":  "[1.2.3.4,4.3.2.1]",
+			},
+			expectedApplied: []string{
+				"--record from environment variable STACKIT_DNS_RECORD_SET_CREATE_RECORD",
+				"--zone-id from environment variable STACKIT_DNS_RECORD_SET_CREATE_ZONE_ID",
+			},
+		},
+		{
+			description: "flags file",
+			flagsFile: FlagsFile{
+				"dns":                   {"zone-id": "xxx", "unknown": "ignored"},
+				"dns  record-set":       {"zone-id": "yyy", "ttl": uint64(60), "active": true},
+				"dns record-set create": {"record": []any{"1.2.3.4", "4.3.2.1"}, "labels": map[string]any{"b": 2, "a": "1"}},
+				"dns record-set delete": {"name": "other"},
+				"dns zone":              {"name": "other"},
+			},
+			isValid: true,
+			expectedValues: map[string]string{
+				"zone-id": "yyy",
+				"name":    "",
+				"ttl":     "60",
+				"active":  "true",
+				"record":  "[1.2.3.4,4.3.2.1]",
+				"labels":  "[a=1,b=2]",
+			},
+			expectedApplied: []string{
+				"--active from flags file",
+				"--labels from flags file",
+				"--record from flags file",
+				"--ttl from flags file",
+				"--zone-id from flags file",
+			},
+		},
+		{
+			description: "custom slice flag in flags file",
+			flagsFile: FlagsFile{
+				"dns record-set create": {"acl-ids": []any{"11111111-1111-1111-1111-111111111111", "22222222-2222-2222-2222-222222222222"}},
+			},
+			isValid: true,
+			expectedValues: map[string]string{
+				"acl-ids": "[11111111-1111-1111-1111-111111111111,22222222-2222-2222-2222-222222222222]",
+			},
+			expectedApplied: []string{"--acl-ids from flags file"},
+		},
+		{
+			description: "precedence",
+			args:        []string{"--name", "from-args"},
+			envVars: map[string]string{
+				"STACKIT_DNS_RECORD_SET_CREATE_NAME":    "from-env",
+				"STACKIT_DNS_RECORD_SET_CREATE_ZONE_ID": "from-env",
+			},
+			flagsFile: FlagsFile{
+				"dns record-set create": {"name": "from-file", "zone-id": "from-file", "ttl": 60},
+			},
+			isValid: true,
+			expectedValues: map[string]string{
+				"name":    "from-args",
+				"zone-id": "from-env",
+				"ttl":     "60",
+			},
+			expectedApplied: []string{
+				"--ttl from flags file",
+				"--zone-id from environment variable STACKIT_DNS_RECORD_SET_CREATE_ZONE_ID",
+			},
+		},
+		{
+			description: "invalid environment variable value",
+			envVars:     map[string]string{"STACKIT_DNS_RECORD_SET_CREATE_TTL": "sixty"},
+			isValid:     false,
+		},
+		{
+			description: "invalid flags file value",
+			flagsFile:   FlagsFile{"dns record-set create": {"active": "maybe"}},
+			isValid:     false,
+		},
+		{
+			description: "unknown flag of the command in flags file",
+			flagsFile:   FlagsFile{"dns record-set create": {"unknown": "value"}},
+			isValid:     false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			for name, value := range tt.envVars {
+				t.Setenv(name, value)
+			}
+			cmd := newTestCmd(t, tt.args...)
+
+			applied, err := ApplyDefaults(cmd, tt.flagsFile)
+			if !tt.isValid {
+				if err == nil {
+					t.Fatalf("did not fail on invalid input")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("failed on valid input: %v", err)
+			}
+
+			diff := cmp.Diff(applied, tt.expectedApplied)
+			if diff != "" {
+				t.Fatalf("Applied flags do not match: %s", diff)
+			}
+			for name, expectedValue := range tt.expectedValues {
+				value := cmd.Flags().Lookup(name).Value.String()
+				if value != expectedValue {
+					t.Errorf("flag --%s has value %q, expected %q", name, value, expectedValue)
+				}
+				if cmd.Flags().Changed(name) != (expectedValue != "") {
+					t.Errorf("flag --%s has changed %t", name, cmd.Flags().Changed(name))
+				}
+			}
+		})
+	}
+}
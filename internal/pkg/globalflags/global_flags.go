@@ -2,6 +2,9 @@ package globalflags
 
 import (
 	"fmt"
+	"os"
+	"sync"
+	"time"
 
 	"github.com/stackitcloud/stackit-cli/internal/pkg/config"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/flags"
@@ -13,12 +16,19 @@ import (
 )
 
 const (
-	AsyncFlag        = "async"
-	AssumeYesFlag    = "assume-yes"
-	OutputFormatFlag = "output-format"
-	ProjectIdFlag    = "project-id"
-	RegionFlag       = "region"
-	VerbosityFlag    = "verbosity"
+	AsyncFlag           = "async"
+	AssumeYesFlag       = "assume-yes"
+	DryRunFlag          = "dry-run"
+	FlagsFileFlag       = "flags-file"
+	OutputFormatFlag    = "output-format"
+	ProjectIdFlag       = "project-id"
+	RegionFlag          = "region"
+	VerbosityFlag       = "verbosity"
+	LogFormatFlag       = "log-format"
+	LogFileFlag         = "log-file"
+	RetriesFlag         = "retries"
+	RetryMaxWaitFlag    = "retry-max-wait"
+	RetryAllMethodsFlag = "retry-all-methods"
 
 	DebugVerbosity   = string(print.DebugLevel)
 	InfoVerbosity    = string(print.InfoLevel)
@@ -26,14 +36,44 @@ const (
 	ErrorVerbosity   = string(print.ErrorLevel)
 
 	VerbosityDefault = InfoVerbosity
+
+	LogFormatDefault = print.TextLogFormat
+
+	RetriesDefault      = 3
+	RetryMaxWaitDefault = 30 * time.Second
 )
 
-var outputFormatFlagOptions = []string{print.JSONOutputFormat, print.PrettyOutputFormat, print.NoneOutputFormat, print.YAMLOutputFormat}
+var outputFormatFlagOptions = []string{print.JSONOutputFormat, print.JSONLinesOutputFormat, print.PrettyOutputFormat, print.NoneOutputFormat, print.YAMLOutputFormat}
 var verbosityFlagOptions = []string{DebugVerbosity, InfoVerbosity, WarningVerbosity, ErrorVerbosity}
+var logFormatFlagOptions = []string{print.TextLogFormat, print.JSONLogFormat}
+
+// Flags files read by this process, by path
+var (
+	flagsFiles      = map[string]flags.FlagsFile{}
+	flagsFilesMutex sync.Mutex
+)
+
+// Global flags bound to configuration keys
+var configKeyFlags = []struct {
+	key  string
+	flag string
+}{
+	{config.ProjectIdKey, ProjectIdFlag},
+	{config.OutputFormatKey, OutputFormatFlag},
+	{config.AsyncKey, AsyncFlag},
+	{config.VerbosityKey, VerbosityFlag},
+	{config.LogFormatKey, LogFormatFlag},
+	{config.LogFileKey, LogFileFlag},
+	{config.RetriesKey, RetriesFlag},
+	{config.RetryMaxWaitKey, RetryMaxWaitFlag},
+	{config.RetryAllMethodsKey, RetryAllMethodsFlag},
+	{config.RegionKey, RegionFlag},
+}
 
 type GlobalFlagModel struct {
 	Async        bool
 	AssumeYes    bool
+	DryRun       bool
 	OutputFormat string
 	ProjectId    string
 	Region       string
@@ -42,45 +82,92 @@ type GlobalFlagModel struct {
 
 func Configure(flagSet *pflag.FlagSet) error {
 	flagSet.VarP(flags.UUIDFlag(), ProjectIdFlag, "p", "Project ID")
-	err := viper.BindPFlag(config.ProjectIdKey, flagSet.Lookup(ProjectIdFlag))
-	if err != nil {
-		return fmt.Errorf("bind --%s flag to config: %w", ProjectIdFlag, err)
-	}
-
 	flagSet.VarP(flags.EnumFlag(true, "", outputFormatFlagOptions...), OutputFormatFlag, "o", fmt.Sprintf("Output format, one of %q", outputFormatFlagOptions))
-	err = viper.BindPFlag(config.OutputFormatKey, flagSet.Lookup(OutputFormatFlag))
-	if err != nil {
-		return fmt.Errorf("bind --%s flag to config: %w", OutputFormatFlag, err)
+	flagSet.Bool(AsyncFlag, false, "If set, runs the command asynchronously")
+	flagSet.BoolP(AssumeYesFlag, "y", false, "If set, skips all confirmation prompts")
+	flagSet.Bool(DryRunFlag, false, "If set, prints the HTTP method, URL and body of the first request that would change resources and stops, instead of sending it. Implies --assume-yes")
+	flagSet.Var(flags.EnumFlag(true, VerbosityDefault, verbosityFlagOptions...), VerbosityFlag, fmt.Sprintf("Verbosity of the CLI, one of %q", verbosityFlagOptions))
+	flagSet.Var(flags.EnumFlag(true, LogFormatDefault, logFormatFlagOptions...), LogFormatFlag, fmt.Sprintf("Format of the debug logs, one of %q", logFormatFlagOptions))
+	flagSet.String(LogFileFlag, "", "If set, the debug logs are appended to this file instead of being written to stderr")
+	flagSet.Int(RetriesFlag, RetriesDefault, "Maximum number of retries of idempotent requests that failed due to transient errors (rate limits, unavailable servers or reset connections). Set to 0 to disable retries")
+	flagSet.Duration(RetryMaxWaitFlag, RetryMaxWaitDefault, "Maximum time to wait before retrying a request")
+	flagSet.Bool(RetryAllMethodsFlag, false, "If set, also retries requests that are not idempotent (POST and PATCH). A retried request may create a resource twice, if the failed attempt was processed by the API")
+	flagSet.String(RegionFlag, "", "Target region for region-specific requests")
+	flagSet.String(FlagsFileFlag, "", fmt.Sprintf("Path of a YAML file with default flag values by command, used for the flags that are not set in the command line or in STACKIT_<COMMAND>_<FLAG> environment variables. Can also be set with the %s environment variable", flags.FlagsFileEnvVar))
+
+	return Bind(flagSet)
+}
+
+// Bind binds the global flags of the given flag set to their configuration keys.
+// The values of the bound flags are read through viper, falling back to the configuration if the flags are not set.
+func Bind(flagSet *pflag.FlagSet) error {
+	for _, f := range configKeyFlags {
+		err := config.BindFlag(f.key, flagSet.Lookup(f.flag))
+		if err != nil {
+			return fmt.Errorf("bind --%s flag to config: %w", f.flag, err)
+		}
 	}
+	return nil
+}
 
-	flagSet.Bool(AsyncFlag, false, "If set, runs the command asynchronously")
-	err = viper.BindPFlag(config.AsyncKey, flagSet.Lookup(AsyncFlag))
-	if err != nil {
-		return fmt.Errorf("bind --%s flag to config: %w", AsyncFlag, err)
+// IsBound returns True if the global flag with the given name is bound to a configuration key, False otherwise
+func IsBound(flag string) bool {
+	for _, f := range configKeyFlags {
+		if f.flag == flag {
+			return true
+		}
 	}
+	return false
+}
 
-	flagSet.BoolP(AssumeYesFlag, "y", false, "If set, skips all confirmation prompts")
+// ApplyDefaults sets the flags of the command that were not set in the command line
+// from the STACKIT_<COMMAND>_<FLAG> environment variables or the flags file.
+// Returns the names of the flags that were set, with the source of their values.
+func ApplyDefaults(p *print.Printer, cmd *cobra.Command) ([]string, error) {
+	flagsFilePath := flags.FlagToStringValue(p, cmd, FlagsFileFlag)
+	if flagsFilePath == "" {
+		flagsFilePath = os.Getenv(flags.FlagsFileEnvVar)
+	}
 
-	flagSet.Var(flags.EnumFlag(true, VerbosityDefault, verbosityFlagOptions...), VerbosityFlag, fmt.Sprintf("Verbosity of the CLI, one of %q", verbosityFlagOptions))
-	err = viper.BindPFlag(config.VerbosityKey, flagSet.Lookup(VerbosityFlag))
-	if err != nil {
-		return fmt.Errorf("bind --%s flag to config: %w", VerbosityFlag, err)
+	var flagsFile flags.FlagsFile
+	if flagsFilePath != "" {
+		var err error
+		flagsFile, err = readFlagsFile(flagsFilePath)
+		if err != nil {
+			return nil, err
+		}
 	}
+	return flags.ApplyDefaults(cmd, flagsFile)
+}
 
-	flagSet.String(RegionFlag, "", "Target region for region-specific requests")
-	err = viper.BindPFlag(config.RegionKey, flagSet.Lookup(RegionFlag))
+// readFlagsFile reads the flags file with the given path once per process, e.g. for all the commands run by the batch command
+func readFlagsFile(path string) (flags.FlagsFile, error) {
+	flagsFilesMutex.Lock()
+	defer flagsFilesMutex.Unlock()
+	if flagsFile, ok := flagsFiles[path]; ok {
+		return flagsFile, nil
+	}
+	flagsFile, err := flags.ReadFlagsFile(path)
 	if err != nil {
-		return fmt.Errorf("bind --%s flag to config: %w", RegionFlag, err)
+		return nil, err
 	}
-
-	return nil
+	flagsFiles[path] = flagsFile
+	return flagsFile, nil
 }
 
 func Parse(p *print.Printer, cmd *cobra.Command) *GlobalFlagModel {
+	// Confirmation prompts are skipped in dry-run mode, as no changes are made
+	dryRun := flags.FlagToBoolValue(p, cmd, DryRunFlag)
+	// Only list commands stream JSON lines, the other commands output JSON
+	outputFormat := viper.GetString(config.OutputFormatKey)
+	if outputFormat == print.JSONLinesOutputFormat && cmd.Annotations[print.JSONLinesAnnotation] == "" {
+		outputFormat = print.JSONOutputFormat
+	}
 	return &GlobalFlagModel{
 		Async:        viper.GetBool(config.AsyncKey),
-		AssumeYes:    flags.FlagToBoolValue(p, cmd, AssumeYesFlag),
-		OutputFormat: viper.GetString(config.OutputFormatKey),
+		AssumeYes:    flags.FlagToBoolValue(p, cmd, AssumeYesFlag) || dryRun,
+		DryRun:       dryRun,
+		OutputFormat: outputFormat,
 		ProjectId:    viper.GetString(config.ProjectIdKey),
 		Region:       viper.GetString(config.RegionKey),
 		Verbosity:    viper.GetString(config.VerbosityKey),
@@ -0,0 +1,116 @@
+package history
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"slices"
+	"time"
+)
+
+var forwardUrlSchemes = []string{"http", "https", "syslog", "syslog+tcp"}
+
+const (
+	forwardTimeout = 5 * time.Second
+
+	defaultSyslogPort = "514"
+	// Facility "user" (1) and severity "notice" (5), see RFC 5424
+	syslogPriority = 1*8 + 5
+	syslogAppName  = "stackit-cli"
+)
+
+// Forward sends the record to the given endpoint:
+//   - "http://" and "https://" URLs receive the record as a JSON POST request
+//   - "syslog://host[:port]" (UDP) and "syslog+tcp://host[:port]" receive the record as an RFC 5424 message with a JSON body
+func Forward(endpoint string, record *Record) error {
+	u, err := parseForwardUrl(endpoint)
+	if err != nil {
+		return err
+	}
+	body, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("marshal record: %w", err)
+	}
+
+	switch u.Scheme {
+	case "http", "https":
+		return forwardHTTP(u.String(), body)
+	case "syslog":
+		return forwardSyslog("udp", u, record.Time, body)
+	case "syslog+tcp":
+		return forwardSyslog("tcp", u, record.Time, body)
+	default:
+		return fmt.Errorf("unsupported URL scheme %q", u.Scheme)
+	}
+}
+
+// ValidateForwardUrl returns an error if records can't be forwarded to the given endpoint
+func ValidateForwardUrl(endpoint string) error {
+	_, err := parseForwardUrl(endpoint)
+	return err
+}
+
+func parseForwardUrl(endpoint string) (*url.URL, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("parse URL: %w", err)
+	}
+	if !slices.Contains(forwardUrlSchemes, u.Scheme) {
+		return nil, fmt.Errorf("unsupported URL scheme %q, must be one of %q", u.Scheme, forwardUrlSchemes)
+	}
+	if u.Host == "" {
+		return nil, fmt.Errorf("URL %q has no host", endpoint)
+	}
+	return u, nil
+}
+
+func forwardHTTP(endpoint string, body []byte) error {
+	client := &http.Client{Timeout: forwardTimeout}
+	resp, err := client.Post(endpoint, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode >= http.StatusBadRequest {
+		return fmt.Errorf("endpoint responded with status %s", resp.Status)
+	}
+	return nil
+}
+
+func forwardSyslog(network string, u *url.URL, timestamp time.Time, body []byte) error {
+	address := u.Host
+	if u.Port() == "" {
+		address = net.JoinHostPort(u.Hostname(), defaultSyslogPort)
+	}
+	conn, err := net.DialTimeout(network, address, forwardTimeout)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = conn.Close() }()
+	err = conn.SetDeadline(time.Now().Add(forwardTimeout))
+	if err != nil {
+		return err
+	}
+
+	message := syslogMessage(timestamp, body)
+	if network == "tcp" {
+		// Octet counting framing, see RFC 6587
+		message = append([]byte(fmt.Sprintf("%d ", len(message))), message...)
+	}
+	_, err = conn.Write(message)
+	return err
+}
+
+// syslogMessage returns an RFC 5424 message with the given body
+func syslogMessage(timestamp time.Time, body []byte) []byte {
+	hostname, err := os.Hostname()
+	if err != nil || hostname == "" {
+		hostname = "-"
+	}
+	header := fmt.Sprintf("<%d>1 %s %s %s %d - - ", syslogPriority, timestamp.Format(time.RFC3339Nano), hostname, syslogAppName, os.Getpid())
+	return append([]byte(header), body...)
+}
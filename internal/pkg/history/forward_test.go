@@ -0,0 +1,99 @@
+package history
+
+import (
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+var testRecord = &Record{
+	Id:      "xxx",
+	Time:    time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+	Command: "stackit dns zone create",
+	Profile: "default",
+}
+
+func TestForwardHTTP(t *testing.T) {
+	var received Record
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.Header.Get("Content-Type") != "application/json" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		if json.Unmarshal(body, &received) != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	err := Forward(server.URL, testRecord)
+	if err != nil {
+		t.Fatalf("forward record: %v", err)
+	}
+	if received.Id != testRecord.Id {
+		t.Fatalf("expected record %q to be received, got %q", testRecord.Id, received.Id)
+	}
+}
+
+func TestForwardSyslog(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	defer func() { _ = conn.Close() }()
+
+	err = Forward("syslog://"+conn.LocalAddr().String(), testRecord)
+	if err != nil {
+		t.Fatalf("forward record: %v", err)
+	}
+
+	buf := make([]byte, 4096)
+	_ = conn.SetReadDeadline(time.Now().Add(time.Second))
+	n, _, err := conn.ReadFrom(buf)
+	if err != nil {
+		t.Fatalf("read message: %v", err)
+	}
+	message := string(buf[:n])
+	if !strings.HasPrefix(message, "<13>1 2024-01-01T00:00:00Z ") {
+		t.Fatalf("unexpected message header: %q", message)
+	}
+	if !strings.HasSuffix(message, ` - - {"id":"xxx","time":"2024-01-01T00:00:00Z","command":"stackit dns zone create","profile":"default","requests":null}`) {
+		t.Fatalf("unexpected message body: %q", message)
+	}
+}
+
+func TestValidateForwardUrl(t *testing.T) {
+	tests := []struct {
+		url     string
+		isValid bool
+	}{
+		{"https://example.com/audit", true},
+		{"http://localhost:8080", true},
+		{"syslog://logs.example.com", true},
+		{"syslog+tcp://logs.example.com:6514", true},
+		{"ftp://example.com", false},
+		{"example.com", false},
+		{"syslog://", false},
+		{"", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			err := ValidateForwardUrl(tt.url)
+			if tt.isValid && err != nil {
+				t.Fatalf("validate URL: %v", err)
+			}
+			if !tt.isValid && err == nil {
+				t.Fatalf("did not fail on invalid URL")
+			}
+		})
+	}
+}
@@ -0,0 +1,327 @@
+package history
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/stackitcloud/stackit-cli/internal/pkg/auth"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/config"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/print"
+
+	"github.com/google/uuid"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+)
+
+const (
+	journalFileName = "history.jsonl"
+
+	redactedValue = "<redacted>"
+
+	// SensitiveArgsAnnotation marks commands whose arguments are not written to the journal, see MarkArgsSensitive
+	SensitiveArgsAnnotation = "history-sensitive-args"
+)
+
+// Parts of the names of flags whose values are not written to the journal
+var sensitiveFlagNameParts = []string{"password", "secret", "token", "private-key", "credential"}
+
+// Record is an entry of the journal, describing a command that changed resources
+type Record struct {
+	Id                string            `json:"id"`
+	Time              time.Time         `json:"time"`
+	Command           string            `json:"command"`
+	Args              []string          `json:"args,omitempty"`
+	Flags             map[string]string `json:"flags,omitempty"`
+	ProjectId         string            `json:"project_id,omitempty"`
+	Profile           string            `json:"profile"`
+	Identity          string            `json:"identity,omitempty"`
+	Hostname          string            `json:"hostname,omitempty"`
+	Requests          []Request         `json:"requests"`
+	ResourceIds       []string          `json:"resource_ids,omitempty"`
+	Snapshots         []Snapshot        `json:"snapshots,omitempty"`
+	UndoneId          string            `json:"undone_id,omitempty"`
+	RestoredSnapshots []int             `json:"restored_snapshots,omitempty"`
+	Error             string            `json:"error,omitempty"`
+}
+
+const (
+	StatusSucceeded = "succeeded"
+	StatusFailed    = "failed"
+)
+
+// Status returns "failed" if the command failed, "succeeded" otherwise
+func (r *Record) Status() string {
+	if r.Error != "" {
+		return StatusFailed
+	}
+	return StatusSucceeded
+}
+
+// Request is a request sent by a command to change resources
+type Request struct {
+	Method     string `json:"method"`
+	URL        string `json:"url"`
+	StatusCode int    `json:"status_code"`
+}
+
+// session holds the requests sent by a command that is running
+type session struct {
+	requests          []Request
+	resourceIds       []string
+	snapshots         []Snapshot
+	undoneId          string
+	restoredSnapshots []int
+}
+
+var (
+	sessionsMutex sync.Mutex
+	// Sessions of the running commands, by the printer of the command
+	sessions = map[*print.Printer]*session{}
+)
+
+// AddRequest records a request that changed resources, sent by the command with the given printer.
+// The IDs of the resources in the response body are recorded as well.
+func AddRequest(p *print.Printer, req Request, responseBody []byte) {
+	sessionsMutex.Lock()
+	defer sessionsMutex.Unlock()
+
+	s := getSession(p)
+	s.requests = append(s.requests, req)
+	for _, id := range resourceIds(responseBody) {
+		if !slices.Contains(s.resourceIds, id) {
+			s.resourceIds = append(s.resourceIds, id)
+		}
+	}
+}
+
+// getSession returns the session of the command with the given printer, creating it if needed.
+// sessionsMutex must be locked by the caller.
+func getSession(p *print.Printer) *session {
+	s, ok := sessions[p]
+	if !ok {
+		s = &session{}
+		sessions[p] = s
+	}
+	return s
+}
+
+// MarkArgsSensitive makes the arguments of the command, and the query parameters of the URLs it sends requests to,
+// be redacted in the journal, e.g. as they may contain credentials
+func MarkArgsSensitive(cmd *cobra.Command) {
+	if cmd.Annotations == nil {
+		cmd.Annotations = map[string]string{}
+	}
+	cmd.Annotations[SensitiveArgsAnnotation] = "true"
+}
+
+// Save appends a record of the executed command to the journal, if it sent requests that changed resources,
+// and forwards it to the endpoint configured in the "history_forward_url" key, if any
+func Save(p *print.Printer, cmd *cobra.Command, cmdErr error) error {
+	sessionsMutex.Lock()
+	s, ok := sessions[p]
+	delete(sessions, p)
+	sessionsMutex.Unlock()
+	// Commands that didn't send any requests, e.g. in dry-run mode, didn't change resources
+	if !ok || len(s.requests) == 0 {
+		return nil
+	}
+
+	record := newRecord(cmd, s)
+	if cmdErr != nil {
+		record.Error = cmdErr.Error()
+	}
+
+	err := appendRecord(journalFilePath(), record)
+	if err != nil {
+		return fmt.Errorf("write history record: %w", err)
+	}
+
+	if forwardUrl := viper.GetString(config.HistoryForwardUrlKey); forwardUrl != "" {
+		err = Forward(forwardUrl, record)
+		if err != nil {
+			return fmt.Errorf("forward history record: %w", err)
+		}
+	}
+	return nil
+}
+
+func newRecord(cmd *cobra.Command, s *session) *Record {
+	record := &Record{
+		Id:                uuid.NewString(),
+		Time:              time.Now().UTC(),
+		Command:           cmd.CommandPath(),
+		Args:              cmd.Flags().Args(),
+		Flags:             sanitizedFlags(cmd.Flags()),
+		ProjectId:         viper.GetString(config.ProjectIdKey),
+		Requests:          s.requests,
+		ResourceIds:       s.resourceIds,
+		Snapshots:         s.snapshots,
+		UndoneId:          s.undoneId,
+		RestoredSnapshots: s.restoredSnapshots,
+	}
+	if cmd.Annotations[SensitiveArgsAnnotation] != "" {
+		redactArgs(record)
+	}
+	if profile, err := config.GetProfile(); err == nil {
+		record.Profile = profile
+	}
+	if identity, err := auth.GetAuthEmail(); err == nil {
+		record.Identity = identity
+	}
+	if hostname, err := os.Hostname(); err == nil {
+		record.Hostname = hostname
+	}
+	return record
+}
+
+// redactArgs redacts the arguments of the record and the query parameters of the URLs of its requests
+func redactArgs(record *Record) {
+	args := make([]string, 0, len(record.Args))
+	for range record.Args {
+		args = append(args, redactedValue)
+	}
+	record.Args = args
+
+	requests := make([]Request, 0, len(record.Requests))
+	for _, req := range record.Requests {
+		if u, err := url.Parse(req.URL); err == nil && u.RawQuery != "" {
+			u.RawQuery = redactedValue
+			req.URL = u.String()
+		}
+		requests = append(requests, req)
+	}
+	record.Requests = requests
+}
+
+// sanitizedFlags returns the flags set in the command, with the values of sensitive flags redacted
+func sanitizedFlags(flagSet *pflag.FlagSet) map[string]string {
+	flagValues := map[string]string{}
+	flagSet.Visit(func(f *pflag.Flag) {
+		value := f.Value.String()
+		for _, part := range sensitiveFlagNameParts {
+			if strings.Contains(f.Name, part) {
+				value = redactedValue
+				break
+			}
+		}
+		flagValues[f.Name] = value
+	})
+	if len(flagValues) == 0 {
+		return nil
+	}
+	return flagValues
+}
+
+// resourceIds returns the IDs in the top-level fields of a JSON response body, or in the objects in them,
+// e.g. "id" and "instanceId" in {"id": "xxx", "instanceId": "yyy"} or "id" in {"zone": {"id": "xxx"}}
+func resourceIds(body []byte) []string {
+	var fields map[string]any
+	if json.Unmarshal(body, &fields) != nil {
+		return nil
+	}
+	ids := idFields(fields)
+	for _, value := range fields {
+		if nestedFields, ok := value.(map[string]any); ok {
+			ids = append(ids, idFields(nestedFields)...)
+		}
+	}
+	slices.Sort(ids)
+	return slices.Compact(ids)
+}
+
+func idFields(fields map[string]any) []string {
+	ids := []string{}
+	for name, value := range fields {
+		id, ok := value.(string)
+		if !ok || id == "" {
+			continue
+		}
+		if name == "id" || strings.HasSuffix(name, "Id") {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+func journalFilePath() string {
+	return filepath.Join(config.GetProfileFolderPath(config.DefaultProfileName), journalFileName)
+}
+
+func appendRecord(path string, record *Record) error {
+	line, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("marshal record: %w", err)
+	}
+	err = os.MkdirAll(filepath.Dir(path), 0o750)
+	if err != nil {
+		return fmt.Errorf("create journal directory: %w", err)
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("open journal: %w", err)
+	}
+	defer func() { _ = f.Close() }()
+	_, err = f.Write(append(line, '\n'))
+	return err
+}
+
+// List returns the records of the journal, the most recent first
+func List() ([]Record, error) {
+	return readRecords(journalFilePath())
+}
+
+// Get returns the record of the journal with the given ID
+func Get(id string) (*Record, error) {
+	records, err := List()
+	if err != nil {
+		return nil, err
+	}
+	for i := range records {
+		if records[i].Id == id {
+			return &records[i], nil
+		}
+	}
+	return nil, fmt.Errorf("history record %q not found", id)
+}
+
+func readRecords(path string) ([]Record, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []Record{}, nil
+		}
+		return nil, fmt.Errorf("read journal: %w", err)
+	}
+
+	records := []Record{}
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	scanner.Buffer(make([]byte, 0, 64*1024), 10*1024*1024)
+	line := 0
+	for scanner.Scan() {
+		line++
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+		var record Record
+		err := json.Unmarshal(scanner.Bytes(), &record)
+		if err != nil {
+			return nil, fmt.Errorf("parse journal line %d: %w", line, err)
+		}
+		records = append(records, record)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read journal: %w", err)
+	}
+	slices.Reverse(records)
+	return records, nil
+}
@@ -0,0 +1,203 @@
+package history
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stackitcloud/stackit-cli/internal/pkg/print"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+func TestSanitizedFlags(t *testing.T) {
+	flagSet := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	flagSet.String("name", "", "")
+	flagSet.String("password", "", "")
+	flagSet.String("service-account-token", "", "")
+	flagSet.String("unset", "default", "")
+	err := flagSet.Parse([]string{"--name", "my-instance", "--password", "xxx", "--service-account-token", "yyy"})
+	if err != nil {
+		t.Fatalf("parse flags: %v", err)
+	}
+
+	expected := map[string]string{
+		"name":                  "my-instance",
+		"password":              redactedValue,
+		"service-account-token": redactedValue,
+	}
+	diff := cmp.Diff(sanitizedFlags(flagSet), expected)
+	if diff != "" {
+		t.Fatalf("Data does not match: %s", diff)
+	}
+}
+
+func TestNewRecordSensitiveArgs(t *testing.T) {
+	tests := []struct {
+		description      string
+		sensitive        bool
+		expectedArgs     []string
+		expectedRequests []Request
+	}{
+		{
+			description:      "base",
+			expectedArgs:     []string{"https://example.com/zones?token=xxx"},
+			expectedRequests: []Request{{Method: "POST", URL: "https://example.com/zones?token=xxx", StatusCode: 202}},
+		},
+		{
+			description:      "sensitive args",
+			sensitive:        true,
+			expectedArgs:     []string{redactedValue},
+			expectedRequests: []Request{{Method: "POST", URL: "https://example.com/zones?" + redactedValue, StatusCode: 202}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			cmd := &cobra.Command{Use: "curl"}
+			if tt.sensitive {
+				MarkArgsSensitive(cmd)
+			}
+			err := cmd.Flags().Parse([]string{"https://example.com/zones?token=xxx"})
+			if err != nil {
+				t.Fatalf("parse flags: %v", err)
+			}
+			s := &session{
+				requests: []Request{{Method: "POST", URL: "https://example.com/zones?token=xxx", StatusCode: 202}},
+			}
+
+			record := newRecord(cmd, s)
+			diff := cmp.Diff(record.Args, tt.expectedArgs)
+			if diff != "" {
+				t.Fatalf("Args do not match: %s", diff)
+			}
+			diff = cmp.Diff(record.Requests, tt.expectedRequests)
+			if diff != "" {
+				t.Fatalf("Requests do not match: %s", diff)
+			}
+		})
+	}
+}
+
+func TestResourceIds(t *testing.T) {
+	tests := []struct {
+		description string
+		body        string
+		expected    []string
+	}{
+		{
+			description: "id",
+			body:        `{"id":"xxx","name":"my-zone"}`,
+			expected:    []string{"xxx"},
+		},
+		{
+			description: "fields ending with Id",
+			body:        `{"instanceId":"yyy","projectId":"xxx","name":"my-instance"}`,
+			expected:    []string{"xxx", "yyy"},
+		},
+		{
+			description: "nested objects",
+			body:        `{"message":"created","zone":{"id":"xxx","projectId":"yyy","records":[{"id":"zzz"}]}}`,
+			expected:    []string{"xxx", "yyy"},
+		},
+		{
+			description: "duplicates and non-string ids are ignored",
+			body:        `{"id":"xxx","instance":{"id":"xxx"},"recordCount":1,"ownerId":5}`,
+			expected:    []string{"xxx"},
+		},
+		{
+			description: "not JSON",
+			body:        "foo",
+			expected:    nil,
+		},
+		{
+			description: "empty",
+			body:        "",
+			expected:    nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			diff := cmp.Diff(resourceIds([]byte(tt.body)), tt.expected)
+			if diff != "" {
+				t.Fatalf("Data does not match: %s", diff)
+			}
+		})
+	}
+}
+
+func TestAddRequest(t *testing.T) {
+	p := print.NewPrinter()
+	defer func() {
+		sessionsMutex.Lock()
+		delete(sessions, p)
+		sessionsMutex.Unlock()
+	}()
+
+	AddRequest(p, Request{Method: "POST", URL: "https://example.com/zones", StatusCode: 202}, []byte(`{"zoneId":"xxx"}`))
+	AddRequest(p, Request{Method: "PATCH", URL: "https://example.com/zones/xxx", StatusCode: 200}, []byte(`{"zoneId":"xxx"}`))
+	AddRequest(print.NewPrinter(), Request{Method: "DELETE", URL: "https://example.com/other"}, nil)
+
+	expected := &session{
+		requests: []Request{
+			{Method: "POST", URL: "https://example.com/zones", StatusCode: 202},
+			{Method: "PATCH", URL: "https://example.com/zones/xxx", StatusCode: 200},
+		},
+		resourceIds: []string{"xxx"},
+	}
+	diff := cmp.Diff(sessions[p], expected, cmp.AllowUnexported(session{}))
+	if diff != "" {
+		t.Fatalf("Data does not match: %s", diff)
+	}
+}
+
+func TestAppendAndReadRecords(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "stackit", journalFileName)
+
+	records, err := readRecords(path)
+	if err != nil {
+		t.Fatalf("read missing journal: %v", err)
+	}
+	if len(records) != 0 {
+		t.Fatalf("expected no records, got %d", len(records))
+	}
+
+	first := &Record{
+		Id:       "1",
+		Time:     time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		Command:  "stackit dns zone create",
+		Flags:    map[string]string{"name": "my-zone"},
+		Profile:  "default",
+		Requests: []Request{{Method: "POST", URL: "https://example.com/zones", StatusCode: 202}},
+	}
+	second := &Record{
+		Id:       "2",
+		Time:     time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
+		Command:  "stackit dns zone delete",
+		Args:     []string{"xxx"},
+		Profile:  "default",
+		Requests: []Request{{Method: "DELETE", URL: "https://example.com/zones/xxx", StatusCode: 404}},
+		Error:    "not found",
+	}
+	for _, record := range []*Record{first, second} {
+		err = appendRecord(path, record)
+		if err != nil {
+			t.Fatalf("append record: %v", err)
+		}
+	}
+
+	records, err = readRecords(path)
+	if err != nil {
+		t.Fatalf("read journal: %v", err)
+	}
+	diff := cmp.Diff(records, []Record{*second, *first})
+	if diff != "" {
+		t.Fatalf("Data does not match: %s", diff)
+	}
+	if records[0].Status() != StatusFailed || records[1].Status() != StatusSucceeded {
+		t.Fatalf("unexpected statuses %q and %q", records[0].Status(), records[1].Status())
+	}
+}
@@ -0,0 +1,131 @@
+package history
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	"github.com/stackitcloud/stackit-cli/internal/pkg/print"
+
+	"github.com/stackitcloud/stackit-sdk-go/services/dns"
+	"github.com/stackitcloud/stackit-sdk-go/services/iaas"
+	"github.com/stackitcloud/stackit-sdk-go/services/loadbalancer"
+)
+
+// Types of the resources that can be restored by "stackit undo"
+const (
+	SecurityGroupRuleResourceType  = "security-group-rule"
+	DNSRecordSetResourceType       = "dns-record-set"
+	LoadBalancerTargetResourceType = "load-balancer-target"
+)
+
+// Operations that can be undone
+const (
+	DeleteOperation = "delete"
+	UpdateOperation = "update"
+)
+
+// Snapshot is the state of a resource before a command deleted or updated it, used to undo the change
+type Snapshot struct {
+	ResourceType string          `json:"resource_type"`
+	Operation    string          `json:"operation"`
+	Data         json.RawMessage `json:"data"`
+}
+
+type SecurityGroupRuleSnapshot struct {
+	ProjectId       string                  `json:"project_id"`
+	SecurityGroupId string                  `json:"security_group_id"`
+	Rule            *iaas.SecurityGroupRule `json:"rule"`
+}
+
+type DNSRecordSetSnapshot struct {
+	ProjectId string         `json:"project_id"`
+	ZoneId    string         `json:"zone_id"`
+	RecordSet *dns.RecordSet `json:"record_set"`
+}
+
+type LoadBalancerTargetSnapshot struct {
+	ProjectId        string              `json:"project_id"`
+	Region           string              `json:"region"`
+	LoadBalancerName string              `json:"load_balancer_name"`
+	TargetPoolName   string              `json:"target_pool_name"`
+	Target           loadbalancer.Target `json:"target"`
+}
+
+// AddSnapshot records the state of a resource before the command with the given printer deletes or updates it.
+// It must be called right before sending the request that changes the resource.
+func AddSnapshot(p *print.Printer, resourceType, operation string, data any) {
+	rawData, err := json.Marshal(data)
+	if err != nil {
+		p.Debug(print.ErrorLevel, "marshal %s snapshot: %v", resourceType, err)
+		return
+	}
+
+	sessionsMutex.Lock()
+	defer sessionsMutex.Unlock()
+	s := getSession(p)
+	s.snapshots = append(s.snapshots, Snapshot{
+		ResourceType: resourceType,
+		Operation:    operation,
+		Data:         rawData,
+	})
+}
+
+// SetUndone records that the command with the given printer undoes the changes of the history record with the given ID
+func SetUndone(p *print.Printer, historyId string) {
+	sessionsMutex.Lock()
+	defer sessionsMutex.Unlock()
+	getSession(p).undoneId = historyId
+}
+
+// AddRestoredSnapshot records that the command with the given printer restored the snapshot with the given index
+// of the history record it undoes (see SetUndone)
+func AddRestoredSnapshot(p *print.Printer, index int) {
+	sessionsMutex.Lock()
+	defer sessionsMutex.Unlock()
+	s := getSession(p)
+	s.restoredSnapshots = append(s.restoredSnapshots, index)
+}
+
+// RestoredSnapshots returns the indices of the snapshots of the given record that were already restored
+// by commands that failed to undo it completely
+func RestoredSnapshots(records []Record, historyId string) []int {
+	restored := []int{}
+	for i := range records {
+		if records[i].UndoneId != historyId {
+			continue
+		}
+		for _, index := range records[i].RestoredSnapshots {
+			if !slices.Contains(restored, index) {
+				restored = append(restored, index)
+			}
+		}
+	}
+	return restored
+}
+
+// FindUndo returns the record of the successful command that undid the given record, if any
+func FindUndo(records []Record, historyId string) (*Record, bool) {
+	for i := range records {
+		if records[i].UndoneId == historyId && records[i].Status() == StatusSucceeded {
+			return &records[i], true
+		}
+	}
+	return nil, false
+}
+
+// LastUndoable returns the most recent record that can be undone, i.e. of a successful command
+// that has snapshots and wasn't undone yet, or only partially. The records must be sorted from the most recent.
+func LastUndoable(records []Record) (*Record, error) {
+	for i := range records {
+		r := &records[i]
+		if len(r.Snapshots) == 0 || r.Status() != StatusSucceeded {
+			continue
+		}
+		if _, ok := FindUndo(records, r.Id); ok {
+			continue
+		}
+		return r, nil
+	}
+	return nil, fmt.Errorf("no command that can be undone found in the history")
+}
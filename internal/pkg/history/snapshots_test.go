@@ -0,0 +1,172 @@
+package history
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stackitcloud/stackit-cli/internal/pkg/print"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func fixtureRecords() []Record {
+	snapshots := []Snapshot{{ResourceType: DNSRecordSetResourceType, Operation: DeleteOperation, Data: json.RawMessage(`{}`)}}
+	// Sorted from the most recent
+	return []Record{
+		{Id: "6"},
+		{Id: "5", Snapshots: snapshots, Error: "not found"},
+		{Id: "4", UndoneId: "3"},
+		{Id: "3", Snapshots: snapshots},
+		{Id: "2", UndoneId: "1", Error: "not found"},
+		{Id: "1", Snapshots: snapshots},
+	}
+}
+
+func TestAddSnapshot(t *testing.T) {
+	p := print.NewPrinter()
+	defer func() {
+		sessionsMutex.Lock()
+		delete(sessions, p)
+		sessionsMutex.Unlock()
+	}()
+
+	AddSnapshot(p, DNSRecordSetResourceType, DeleteOperation, map[string]string{"zone_id": "xxx"})
+	SetUndone(p, "1")
+	AddRestoredSnapshot(p, 0)
+
+	expected := &session{
+		snapshots: []Snapshot{
+			{
+				ResourceType: DNSRecordSetResourceType,
+				Operation:    DeleteOperation,
+				Data:         json.RawMessage(`{"zone_id":"xxx"}`),
+			},
+		},
+		undoneId:          "1",
+		restoredSnapshots: []int{0},
+	}
+	diff := cmp.Diff(sessions[p], expected, cmp.AllowUnexported(session{}))
+	if diff != "" {
+		t.Fatalf("Data does not match: %s", diff)
+	}
+}
+
+func TestFindUndo(t *testing.T) {
+	tests := []struct {
+		description string
+		historyId   string
+		isFound     bool
+		expectedId  string
+	}{
+		{
+			description: "undone",
+			historyId:   "3",
+			isFound:     true,
+			expectedId:  "4",
+		},
+		{
+			description: "undo failed",
+			historyId:   "1",
+			isFound:     false,
+		},
+		{
+			description: "not undone",
+			historyId:   "5",
+			isFound:     false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			undo, ok := FindUndo(fixtureRecords(), tt.historyId)
+			if ok != tt.isFound {
+				t.Fatalf("expected found to be %t, got %t", tt.isFound, ok)
+			}
+			if ok && undo.Id != tt.expectedId {
+				t.Fatalf("expected record %q, got %q", tt.expectedId, undo.Id)
+			}
+		})
+	}
+}
+
+func TestRestoredSnapshots(t *testing.T) {
+	// Sorted from the most recent
+	records := []Record{
+		{Id: "4", UndoneId: "0", RestoredSnapshots: []int{1}, Error: "not found"},
+		{Id: "3", UndoneId: "2", RestoredSnapshots: []int{0}},
+		{Id: "2"},
+		{Id: "1", UndoneId: "0", RestoredSnapshots: []int{2}, Error: "not found"},
+		{Id: "0"},
+	}
+
+	tests := []struct {
+		description string
+		historyId   string
+		expected    []int
+	}{
+		{
+			description: "restored by several undos",
+			historyId:   "0",
+			expected:    []int{1, 2},
+		},
+		{
+			description: "not undone",
+			historyId:   "3",
+			expected:    []int{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			restored := RestoredSnapshots(records, tt.historyId)
+			diff := cmp.Diff(restored, tt.expected)
+			if diff != "" {
+				t.Fatalf("Data does not match: %s", diff)
+			}
+		})
+	}
+}
+
+func TestLastUndoable(t *testing.T) {
+	tests := []struct {
+		description string
+		records     []Record
+		isValid     bool
+		expectedId  string
+	}{
+		{
+			description: "skips failed, undone and commands without snapshots",
+			records:     fixtureRecords(),
+			isValid:     true,
+			expectedId:  "1",
+		},
+		{
+			description: "nothing to undo",
+			records:     fixtureRecords()[:4],
+			isValid:     false,
+		},
+		{
+			description: "empty history",
+			records:     nil,
+			isValid:     false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			record, err := LastUndoable(tt.records)
+			if !tt.isValid {
+				if err == nil {
+					t.Fatalf("did not fail on invalid input")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if record.Id != tt.expectedId {
+				t.Fatalf("expected record %q, got %q", tt.expectedId, record.Id)
+			}
+		})
+	}
+}
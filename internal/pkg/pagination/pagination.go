@@ -0,0 +1,106 @@
+package pagination
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/stackitcloud/stackit-cli/internal/pkg/errors"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/flags"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/print"
+
+	"github.com/spf13/cobra"
+)
+
+const (
+	LimitFlag    = "limit"
+	PageSizeFlag = "page-size"
+	AllFlag      = "all"
+)
+
+// ConfigureFlags adds the --limit, --page-size and --all flags to a list command that fetches the pages of a paginated API,
+// and enables the JSON lines output format for the command
+func ConfigureFlags(cmd *cobra.Command, pageSizeDefault int64) {
+	cmd.Flags().Int64(LimitFlag, 0, "Maximum number of entries to list")
+	cmd.Flags().Int64(PageSizeFlag, pageSizeDefault, "Number of items fetched in each API call. Does not affect the number of items in the command output")
+	cmd.Flags().Bool(AllFlag, false, "If set, lists all entries, ignoring --limit (e.g. if set in an environment variable or flags file)")
+
+	if cmd.Annotations == nil {
+		cmd.Annotations = map[string]string{}
+	}
+	cmd.Annotations[print.JSONLinesAnnotation] = "true"
+}
+
+// ParseFlags returns the values of the --limit and --page-size flags.
+// The limit is nil if not set, or if --all is set.
+func ParseFlags(p *print.Printer, cmd *cobra.Command) (limit *int64, pageSize int64, err error) {
+	limit = flags.FlagToInt64Pointer(p, cmd, LimitFlag)
+	if limit != nil && *limit < 1 {
+		return nil, 0, &errors.FlagValidationError{
+			Flag:    LimitFlag,
+			Details: "must be greater than 0",
+		}
+	}
+	if flags.FlagToBoolValue(p, cmd, AllFlag) {
+		limit = nil
+	}
+
+	pageSize = flags.FlagWithDefaultToInt64Value(p, cmd, PageSizeFlag)
+	if pageSize < 1 {
+		return nil, 0, &errors.FlagValidationError{
+			Flag:    PageSizeFlag,
+			Details: "must be greater than 0",
+		}
+	}
+	return limit, pageSize, nil
+}
+
+// Fetch fetches the pages of a paginated API and passes the items of each page to handlePage, as they arrive.
+// fetchPage gets the index of the page to fetch, starting at 0, and returns its items.
+// Stops at the first page that isn't full, or once the limit (if any) is reached, truncating the last page.
+func Fetch[T any](pageSize int64, limit *int64, fetchPage func(pageIndex int) ([]T, error), handlePage func(items []T) error) error {
+	var count int64
+	for pageIndex := 0; ; pageIndex++ {
+		items, err := fetchPage(pageIndex)
+		if err != nil {
+			return err
+		}
+		if len(items) == 0 {
+			return nil
+		}
+		lastPage := int64(len(items)) < pageSize
+
+		if limit != nil && count+int64(len(items)) >= *limit {
+			items = items[:*limit-count]
+			lastPage = true
+		}
+		count += int64(len(items))
+
+		err = handlePage(items)
+		if err != nil {
+			return err
+		}
+		if lastPage {
+			return nil
+		}
+	}
+}
+
+// AppendTo returns a page handler for Fetch that appends the items of each page to the given slice
+func AppendTo[T any](items *[]T) func(pageItems []T) error {
+	return func(pageItems []T) error {
+		*items = append(*items, pageItems...)
+		return nil
+	}
+}
+
+// OutputJSONLines outputs each item as a JSON object in its own line, allowing to stream the items of a list as they arrive
+func OutputJSONLines[T any](p *print.Printer, items []T) error {
+	for i := range items {
+		line, err := json.Marshal(items[i])
+		if err != nil {
+			return fmt.Errorf("marshal item: %w", err)
+		}
+		p.Outputln(string(line))
+	}
+	return nil
+}
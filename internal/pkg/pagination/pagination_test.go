@@ -0,0 +1,229 @@
+package pagination
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/stackitcloud/stackit-cli/internal/pkg/print"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/utils"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/spf13/cobra"
+)
+
+func TestParseFlags(t *testing.T) {
+	tests := []struct {
+		description      string
+		flagValues       map[string]string
+		isValid          bool
+		expectedLimit    *int64
+		expectedPageSize int64
+	}{
+		{
+			description:      "defaults",
+			flagValues:       map[string]string{},
+			isValid:          true,
+			expectedPageSize: 100,
+		},
+		{
+			description:      "limit and page size",
+			flagValues:       map[string]string{LimitFlag: "10", PageSizeFlag: "20"},
+			isValid:          true,
+			expectedLimit:    utils.Ptr(int64(10)),
+			expectedPageSize: 20,
+		},
+		{
+			description:      "all overrides limit",
+			flagValues:       map[string]string{LimitFlag: "10", AllFlag: "true"},
+			isValid:          true,
+			expectedPageSize: 100,
+		},
+		{
+			description: "limit invalid",
+			flagValues:  map[string]string{LimitFlag: "0"},
+			isValid:     false,
+		},
+		{
+			description: "page size invalid",
+			flagValues:  map[string]string{PageSizeFlag: "0"},
+			isValid:     false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			p := print.NewPrinter()
+			cmd := &cobra.Command{Use: "list"}
+			ConfigureFlags(cmd, 100)
+			for flag, value := range tt.flagValues {
+				err := cmd.Flags().Set(flag, value)
+				if err != nil {
+					t.Fatalf("setting flag --%s=%s: %v", flag, value, err)
+				}
+			}
+
+			limit, pageSize, err := ParseFlags(p, cmd)
+			if !tt.isValid {
+				if err == nil {
+					t.Fatalf("did not fail on invalid input")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("failed on valid input: %v", err)
+			}
+			diff := cmp.Diff(limit, tt.expectedLimit)
+			if diff != "" {
+				t.Fatalf("Limit does not match: %s", diff)
+			}
+			if pageSize != tt.expectedPageSize {
+				t.Fatalf("expected page size %d, got %d", tt.expectedPageSize, pageSize)
+			}
+			if cmd.Annotations[print.JSONLinesAnnotation] == "" {
+				t.Fatalf("command doesn't support the JSON lines output format")
+			}
+		})
+	}
+}
+
+func TestFetch(t *testing.T) {
+	tests := []struct {
+		description       string
+		totalItems        int
+		pageSize          int64
+		limit             *int64
+		fetchFails        bool
+		handleFails       bool
+		isValid           bool
+		expectedNumPages  int
+		expectedPageSizes []int
+	}{
+		{
+			description:       "single page",
+			totalItems:        10,
+			pageSize:          20,
+			isValid:           true,
+			expectedNumPages:  1,
+			expectedPageSizes: []int{10},
+		},
+		{
+			description:       "multiple pages",
+			totalItems:        50,
+			pageSize:          20,
+			isValid:           true,
+			expectedNumPages:  3,
+			expectedPageSizes: []int{20, 20, 10},
+		},
+		{
+			description:       "last page empty",
+			totalItems:        40,
+			pageSize:          20,
+			isValid:           true,
+			expectedNumPages:  3,
+			expectedPageSizes: []int{20, 20},
+		},
+		{
+			description:       "limit truncates last page",
+			totalItems:        50,
+			pageSize:          20,
+			limit:             utils.Ptr(int64(30)),
+			isValid:           true,
+			expectedNumPages:  2,
+			expectedPageSizes: []int{20, 10},
+		},
+		{
+			description:       "limit at end of page",
+			totalItems:        50,
+			pageSize:          20,
+			limit:             utils.Ptr(int64(20)),
+			isValid:           true,
+			expectedNumPages:  1,
+			expectedPageSizes: []int{20},
+		},
+		{
+			description:       "limit greater than total items",
+			totalItems:        30,
+			pageSize:          20,
+			limit:             utils.Ptr(int64(100)),
+			isValid:           true,
+			expectedNumPages:  2,
+			expectedPageSizes: []int{20, 10},
+		},
+		{
+			description: "fetch fails",
+			totalItems:  30,
+			pageSize:    20,
+			fetchFails:  true,
+			isValid:     false,
+		},
+		{
+			description: "handle page fails",
+			totalItems:  30,
+			pageSize:    20,
+			handleFails: true,
+			isValid:     false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			numPages := 0
+			fetchPage := func(pageIndex int) ([]int, error) {
+				numPages++
+				if tt.fetchFails {
+					return nil, fmt.Errorf("fetch failed")
+				}
+				items := []int{}
+				for i := pageIndex * int(tt.pageSize); i < (pageIndex+1)*int(tt.pageSize) && i < tt.totalItems; i++ {
+					items = append(items, i)
+				}
+				return items, nil
+			}
+			pageSizes := []int{}
+			handlePage := func(items []int) error {
+				if tt.handleFails {
+					return fmt.Errorf("handle failed")
+				}
+				pageSizes = append(pageSizes, len(items))
+				return nil
+			}
+
+			err := Fetch(tt.pageSize, tt.limit, fetchPage, handlePage)
+			if !tt.isValid {
+				if err == nil {
+					t.Fatalf("did not fail on invalid input")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("failed on valid input: %v", err)
+			}
+			if numPages != tt.expectedNumPages {
+				t.Fatalf("expected %d pages to be fetched, got %d", tt.expectedNumPages, numPages)
+			}
+			diff := cmp.Diff(pageSizes, tt.expectedPageSizes)
+			if diff != "" {
+				t.Fatalf("Page sizes do not match: %s", diff)
+			}
+		})
+	}
+}
+
+func TestOutputJSONLines(t *testing.T) {
+	p := print.NewPrinter()
+	cmd := &cobra.Command{}
+	buf := &bytes.Buffer{}
+	cmd.SetOut(buf)
+	p.Cmd = cmd
+
+	items := []map[string]string{{"id": "xxx"}, {"id": "yyy"}}
+	err := OutputJSONLines(p, items)
+	if err != nil {
+		t.Fatalf("output JSON lines: %v", err)
+	}
+	expected := "{\"id\":\"xxx\"}\n{\"id\":\"yyy\"}\n"
+	if buf.String() != expected {
+		t.Fatalf("expected output %q, got %q", expected, buf.String())
+	}
+}
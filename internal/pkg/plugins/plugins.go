@@ -0,0 +1,171 @@
+package plugins
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+)
+
+const (
+	// Prefix of the name of the plugin executables, e.g. "stackit-foo" provides the "stackit foo" command
+	ExecutablePrefix = "stackit-"
+
+	// Environment variables passed to the plugins
+	ProfileEnv     = "STACKIT_CLI_PROFILE"
+	ProjectIdEnv   = "STACKIT_PROJECT_ID"
+	RegionEnv      = "STACKIT_REGION"
+	AccessTokenEnv = "STACKIT_ACCESS_TOKEN" //nolint:gosec // name of the environment variable, not a credential
+)
+
+type Plugin struct {
+	Name string `json:"name"`
+	Path string `json:"path"`
+	// Another plugin with the same name comes first in PATH
+	Shadowed bool `json:"shadowed"`
+}
+
+// Context is the state of the CLI passed to a plugin through environment variables
+type Context struct {
+	Profile     string
+	ProjectId   string
+	Region      string
+	AccessToken string
+}
+
+// Find returns the path of the executable of the plugin with the given name
+func Find(name string) (string, bool) {
+	if name == "" || strings.ContainsAny(name, `/\`) {
+		return "", false
+	}
+	path, err := exec.LookPath(ExecutablePrefix + name)
+	if err != nil {
+		return "", false
+	}
+	return path, true
+}
+
+// List returns the plugins found in the directories of PATH, sorted by name.
+// Plugins with the same name as one found earlier in PATH are marked as shadowed.
+func List() []Plugin {
+	plugins := []Plugin{}
+	found := map[string]bool{}
+	seenDirs := map[string]bool{}
+	for _, dir := range filepath.SplitList(os.Getenv("PATH")) {
+		if dir == "" || seenDirs[dir] {
+			continue
+		}
+		seenDirs[dir] = true
+
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			// Missing or unreadable directories in PATH are ignored, as the shell does
+			continue
+		}
+		for _, entry := range entries {
+			name, ok := pluginName(entry.Name())
+			if !ok {
+				continue
+			}
+			path := filepath.Join(dir, entry.Name())
+			if !isExecutable(path) {
+				continue
+			}
+			plugins = append(plugins, Plugin{
+				Name:     name,
+				Path:     path,
+				Shadowed: found[name],
+			})
+			found[name] = true
+		}
+	}
+	sort.SliceStable(plugins, func(i, j int) bool {
+		return plugins[i].Name < plugins[j].Name
+	})
+	return plugins
+}
+
+// Returns the name of the plugin provided by the executable with the given file name
+func pluginName(fileName string) (string, bool) {
+	name, ok := strings.CutPrefix(fileName, ExecutablePrefix)
+	if !ok {
+		return "", false
+	}
+	if runtime.GOOS == "windows" {
+		name = strings.TrimSuffix(name, filepath.Ext(name))
+	}
+	if name == "" {
+		return "", false
+	}
+	return name, true
+}
+
+func isExecutable(path string) bool {
+	info, err := os.Stat(path)
+	if err != nil || info.IsDir() {
+		return false
+	}
+	if runtime.GOOS == "windows" {
+		pathExt := os.Getenv("PATHEXT")
+		if pathExt == "" {
+			pathExt = ".com;.exe;.bat;.cmd"
+		}
+		ext := strings.ToLower(filepath.Ext(path))
+		for _, e := range filepath.SplitList(strings.ToLower(pathExt)) {
+			if ext == e {
+				return true
+			}
+		}
+		return false
+	}
+	return info.Mode().Perm()&0o111 != 0
+}
+
+// Env returns the environment of the plugin process: the environment of the CLI with the context variables set
+func Env(ctx *Context) []string {
+	contextEnv := map[string]string{
+		ProfileEnv:     ctx.Profile,
+		ProjectIdEnv:   ctx.ProjectId,
+		RegionEnv:      ctx.Region,
+		AccessTokenEnv: ctx.AccessToken,
+	}
+
+	env := []string{}
+	for _, v := range os.Environ() {
+		key, _, _ := strings.Cut(v, "=")
+		if _, ok := contextEnv[key]; ok {
+			continue
+		}
+		env = append(env, v)
+	}
+	for _, key := range []string{ProfileEnv, ProjectIdEnv, RegionEnv, AccessTokenEnv} {
+		if contextEnv[key] != "" {
+			env = append(env, fmt.Sprintf("%s=%s", key, contextEnv[key]))
+		}
+	}
+	return env
+}
+
+// Run executes the plugin in the given path with the given arguments and environment, attached to the terminal.
+// It returns the exit code of the plugin.
+func Run(path string, args, env []string) (int, error) {
+	cmd := exec.Command(path, args...) //nolint:gosec // the plugin is chosen by the user
+	cmd.Env = env
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+
+	err := cmd.Run()
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			return exitErr.ExitCode(), nil
+		}
+		return 1, fmt.Errorf("run plugin %q: %w", path, err)
+	}
+	return 0, nil
+}
@@ -0,0 +1,118 @@
+package plugins
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func writeExecutable(t *testing.T, dir, name string, perm os.FileMode) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	err := os.WriteFile(path, []byte("#!/bin/sh\nexit 0\n"), perm) //nolint:gosec // test script must be executable
+	if err != nil {
+		t.Fatalf("Failed to write %q: %v", path, err)
+	}
+	return path
+}
+
+func TestList(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("executables are detected by extension on Windows")
+	}
+
+	dir1 := t.TempDir()
+	dir2 := t.TempDir()
+	fooPath1 := writeExecutable(t, dir1, "stackit-foo", 0o700)
+	fooPath2 := writeExecutable(t, dir2, "stackit-foo", 0o700)
+	barPath := writeExecutable(t, dir2, "stackit-bar", 0o700)
+	writeExecutable(t, dir1, "stackit-not-executable", 0o600)
+	writeExecutable(t, dir1, "other-tool", 0o700)
+	err := os.Mkdir(filepath.Join(dir1, "stackit-dir"), 0o700)
+	if err != nil {
+		t.Fatalf("Failed to create directory: %v", err)
+	}
+
+	t.Setenv("PATH", filepath.Join(dir1, "missing")+string(os.PathListSeparator)+dir1+string(os.PathListSeparator)+dir2)
+
+	expected := []Plugin{
+		{Name: "bar", Path: barPath},
+		{Name: "foo", Path: fooPath1},
+		{Name: "foo", Path: fooPath2, Shadowed: true},
+	}
+	diff := cmp.Diff(List(), expected)
+	if diff != "" {
+		t.Fatalf("Data does not match: %s", diff)
+	}
+
+	path, ok := Find("foo")
+	if !ok || path != fooPath1 {
+		t.Errorf("expected plugin \"foo\" in %q, got %q (found: %t)", fooPath1, path, ok)
+	}
+	for _, name := range []string{"not-executable", "dir", "missing", "", "../foo"} {
+		if _, ok := Find(name); ok {
+			t.Errorf("expected plugin %q not to be found", name)
+		}
+	}
+}
+
+func TestEnv(t *testing.T) {
+	t.Setenv(ProjectIdEnv, "inherited-project-id")
+	t.Setenv(AccessTokenEnv, "inherited-token")
+	t.Setenv("SOME_OTHER_VAR", "value")
+
+	env := Env(&Context{
+		Profile:   "my-profile",
+		ProjectId: "project-id",
+		Region:    "eu01",
+	})
+
+	for _, expected := range []string{
+		"STACKIT_CLI_PROFILE=my-profile",
+		"STACKIT_PROJECT_ID=project-id",
+		"STACKIT_REGION=eu01",
+		"SOME_OTHER_VAR=value",
+	} {
+		if !slices.Contains(env, expected) {
+			t.Errorf("expected %q in environment", expected)
+		}
+	}
+	for _, unexpected := range []string{
+		"STACKIT_PROJECT_ID=inherited-project-id",
+		"STACKIT_ACCESS_TOKEN=inherited-token",
+	} {
+		if slices.Contains(env, unexpected) {
+			t.Errorf("unexpected %q in environment", unexpected)
+		}
+	}
+}
+
+func TestRun(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the fake plugin is a shell script")
+	}
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "stackit-exit")
+	err := os.WriteFile(path, []byte("#!/bin/sh\n[ \"$STACKIT_REGION\" = \"$1\" ] || exit 3\nexit 7\n"), 0o700) //nolint:gosec // test script must be executable
+	if err != nil {
+		t.Fatalf("Failed to write fake plugin: %v", err)
+	}
+
+	exitCode, err := Run(path, []string{"eu01"}, Env(&Context{Region: "eu01"}))
+	if err != nil {
+		t.Fatalf("Failed to run plugin: %v", err)
+	}
+	if exitCode != 7 {
+		t.Errorf("expected exit code 7, got %d", exitCode)
+	}
+
+	_, err = Run(filepath.Join(dir, "missing"), nil, nil)
+	if err == nil {
+		t.Errorf("expected error when running a missing plugin")
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"slices"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/stackitcloud/stackit-sdk-go/core/config"
 )
@@ -133,6 +134,7 @@ func drainBody(b io.ReadCloser) (r1, r2 io.ReadCloser, err error) {
 
 // BuildDebugStrFromHTTPRequest converts an HTTP request to a user-friendly string representation.
 // This function also receives a list of headers to include in the output, if empty, the default headers are used.
+// Sensitive body fields (e.g. passwords) are redacted.
 // The return value is a list of strings that should be printed separately.
 func BuildDebugStrFromHTTPRequest(req *http.Request, includeHeaders []string) ([]string, error) {
 	if req == nil {
@@ -173,6 +175,7 @@ func BuildDebugStrFromHTTPRequest(req *http.Request, includeHeaders []string) ([
 	if len(bodyMap) == 0 {
 		return []string{status, headers}, nil
 	}
+	redactValue(bodyMap)
 	body := fmt.Sprintf("request body: %s", BuildDebugStrFromMap(bodyMap))
 
 	return []string{status, headers, body}, nil
@@ -180,6 +183,7 @@ func BuildDebugStrFromHTTPRequest(req *http.Request, includeHeaders []string) ([
 
 // BuildDebugStrFromHTTPResponse converts an HTTP response to a user-friendly string representation.
 // This function also receives a list of headers to include in the output, if empty, the default headers are used.
+// Sensitive body fields (e.g. passwords) are redacted.
 // The return value is a list of strings that should be printed separately.
 func BuildDebugStrFromHTTPResponse(resp *http.Response, includeHeaders []string) ([]string, error) {
 	if resp == nil {
@@ -222,6 +226,7 @@ func BuildDebugStrFromHTTPResponse(resp *http.Response, includeHeaders []string)
 	if len(bodyMap) == 0 {
 		return []string{status, headers}, nil
 	}
+	redactValue(bodyMap)
 	body := fmt.Sprintf("response body: %s", BuildDebugStrFromMap(bodyMap))
 
 	return []string{status, headers, body}, nil
@@ -244,6 +249,10 @@ type roundTripperWithCapture struct {
 }
 
 func (rt roundTripperWithCapture) RoundTrip(req *http.Request) (*http.Response, error) {
+	if rt.p.IsLogFormatJSON() {
+		return rt.roundTripWithStructuredLogs(req)
+	}
+
 	reqStr, err := BuildDebugStrFromHTTPRequest(req, rt.debugHttpHeaders)
 	if err != nil {
 		rt.p.Debug(ErrorLevel, "printing request to debug logs: %v", err)
@@ -266,6 +275,38 @@ func (rt roundTripperWithCapture) RoundTrip(req *http.Request) (*http.Response,
 	return resp, err
 }
 
+// roundTripWithStructuredLogs executes the request and writes a single structured log record with the request, the response and the latency
+func (rt roundTripperWithCapture) roundTripWithStructuredLogs(req *http.Request) (*http.Response, error) {
+	var err error
+	var reqBody []byte
+	var save io.ReadCloser
+	save, req.Body, err = drainBody(req.Body)
+	if err != nil {
+		rt.p.Debug(ErrorLevel, "drain request body: %v", err)
+	} else {
+		reqBody, _ = io.ReadAll(req.Body)
+		req.Body = save
+	}
+
+	start := time.Now()
+	resp, respErr := rt.transport.RoundTrip(req)
+	latency := time.Since(start)
+
+	var respBody []byte
+	if resp != nil {
+		save, resp.Body, err = drainBody(resp.Body)
+		if err != nil {
+			rt.p.Debug(ErrorLevel, "drain response body: %v", err)
+		} else {
+			respBody, _ = io.ReadAll(resp.Body)
+			resp.Body = save
+		}
+	}
+
+	logHTTPExchange(req, reqBody, resp, respBody, latency, respErr)
+	return resp, respErr
+}
+
 // isEmpty checks if a value is empty (nil, empty string, zero value for other types)
 func isEmpty(value interface{}) bool {
 	if value == nil {
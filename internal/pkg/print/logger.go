@@ -0,0 +1,241 @@
+package print
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/lmittmann/tint"
+	"github.com/mattn/go-colorable"
+)
+
+const (
+	TextLogFormat = "text"
+	JSONLogFormat = "json"
+
+	redactedValue = "REDACTED"
+)
+
+// Headers whose values are never written to the logs
+var sensitiveHTTPHeaders = []string{"Authorization", "Proxy-Authorization", "Cookie", "Set-Cookie", "X-Auth-Token", "X-Api-Key"}
+
+// Substrings of the (case-insensitive) keys of JSON body fields whose values are never written to the logs
+var sensitiveBodyKeys = []string{"password", "secret", "token", "privatekey", "private_key", "apikey", "api_key"}
+
+// Keys of JSON body fields whose values are never written to the logs, e.g. connection strings with credentials
+var sensitiveBodyExactKeys = []string{"uri"}
+
+// Headers identifying a request, added as separate attributes to the structured logs
+var requestIdHTTPHeaders = []string{"X-Request-Id", "X-Trace-Id", "Traceparent"}
+
+func newLogHandler(w io.Writer, logFormat string) slog.Handler {
+	if logFormat == JSONLogFormat {
+		return slog.NewJSONHandler(w, &slog.HandlerOptions{Level: slog.LevelDebug})
+	}
+	noColor := true
+	if f, ok := w.(*os.File); ok {
+		w = colorable.NewColorable(f)
+		noColor = f != os.Stderr && f != os.Stdout
+	}
+	return tint.NewHandler(w, &tint.Options{AddSource: false, Level: slog.LevelDebug, NoColor: noColor})
+}
+
+// ConfigureLogger sets up the default logger used for the debug logs with the given format ("text" or "json").
+// If logFilePath is set, the logs are appended to that file instead of being written to Stderr.
+func (p *Printer) ConfigureLogger(logFormat, logFilePath string) error {
+	if logFormat == "" {
+		logFormat = TextLogFormat
+	}
+	if logFormat != TextLogFormat && logFormat != JSONLogFormat {
+		return fmt.Errorf("unsupported log format %q", logFormat)
+	}
+
+	var w io.Writer = os.Stderr
+	if logFilePath != "" {
+		logFile, err := os.OpenFile(logFilePath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+		if err != nil {
+			return fmt.Errorf("open log file: %w", err)
+		}
+		w = logFile
+	}
+
+	slog.SetDefault(slog.New(newLogHandler(w, logFormat)))
+	p.logFormat = logFormat
+	p.loggerConfigured = true
+	return nil
+}
+
+// IsLoggerConfigured returns True if the default logger was set up with ConfigureLogger, by this printer or its parent.
+func (p *Printer) IsLoggerConfigured() bool {
+	return p.loggerConfigured
+}
+
+// IsLogFormatJSON returns True if the debug logs are written as JSON records, False otherwise.
+func (p *Printer) IsLogFormatJSON() bool {
+	return p.logFormat == JSONLogFormat
+}
+
+// logHTTPExchange writes a structured debug log record for an HTTP request and its response (or error).
+// Sensitive headers and body fields are redacted.
+func logHTTPExchange(req *http.Request, reqBody []byte, resp *http.Response, respBody []byte, latency time.Duration, respErr error) {
+	attrs := []slog.Attr{
+		slog.String("method", req.Method),
+		slog.String("url", unescapeURL(req.URL)),
+		slog.Int64("latency_ms", latency.Milliseconds()),
+	}
+	if resp != nil {
+		attrs = append(attrs, slog.Int("status", resp.StatusCode))
+	}
+	attrs = append(attrs, requestIdAttrs(req, resp)...)
+
+	requestAttrs := []any{slog.Any("headers", redactHTTPHeaders(req.Header))}
+	if body := redactHTTPBody(reqBody); body != nil {
+		requestAttrs = append(requestAttrs, slog.Any("body", body))
+	}
+	attrs = append(attrs, slog.Group("request", requestAttrs...))
+
+	if resp != nil {
+		responseAttrs := []any{slog.Any("headers", redactHTTPHeaders(resp.Header))}
+		if body := redactHTTPBody(respBody); body != nil {
+			responseAttrs = append(responseAttrs, slog.Any("body", body))
+		}
+		attrs = append(attrs, slog.Group("response", responseAttrs...))
+	}
+
+	if respErr != nil {
+		attrs = append(attrs, slog.String("error", respErr.Error()))
+		slog.LogAttrs(context.Background(), slog.LevelError, "http request failed", attrs...)
+		return
+	}
+	slog.LogAttrs(context.Background(), slog.LevelDebug, "http request", attrs...)
+}
+
+// LogHTTPRetry writes a debug log record for an attempt of an HTTP request that failed due to a transient error and is retried.
+// With the JSON log format, the record holds the status (or error) and the request IDs of the attempt, as the request and
+// response capturer only sees the last attempt.
+func (p *Printer) LogHTTPRetry(req *http.Request, resp *http.Response, respErr error, attempt, maxAttempts int, wait time.Duration) {
+	if !p.IsVerbosityDebug() {
+		return
+	}
+	if !p.IsLogFormatJSON() {
+		if respErr != nil {
+			p.Debug(DebugLevel, "%s %s failed (attempt %d/%d): %v, retrying in %s", req.Method, req.URL, attempt, maxAttempts, respErr, wait)
+		} else {
+			p.Debug(DebugLevel, "%s %s failed (attempt %d/%d): %s, retrying in %s", req.Method, req.URL, attempt, maxAttempts, resp.Status, wait)
+		}
+		return
+	}
+
+	attrs := []slog.Attr{
+		slog.String("method", req.Method),
+		slog.String("url", unescapeURL(req.URL)),
+		slog.Int("attempt", attempt),
+		slog.Int("max_attempts", maxAttempts),
+		slog.Int64("retry_in_ms", wait.Milliseconds()),
+	}
+	if resp != nil {
+		attrs = append(attrs, slog.Int("status", resp.StatusCode))
+	}
+	attrs = append(attrs, requestIdAttrs(req, resp)...)
+	if respErr != nil {
+		attrs = append(attrs, slog.String("error", respErr.Error()))
+	}
+	slog.LogAttrs(context.Background(), slog.LevelDebug, "http request retried", attrs...)
+}
+
+// requestIdAttrs returns the headers identifying a request as log attributes, taken from the response if set
+func requestIdAttrs(req *http.Request, resp *http.Response) []slog.Attr {
+	var attrs []slog.Attr
+	for _, header := range requestIdHTTPHeaders {
+		value := ""
+		if resp != nil {
+			value = resp.Header.Get(header)
+		}
+		if value == "" {
+			value = req.Header.Get(header)
+		}
+		if value != "" {
+			attrs = append(attrs, slog.String(strings.ToLower(strings.ReplaceAll(header, "-", "_")), value))
+		}
+	}
+	return attrs
+}
+
+func unescapeURL(u *url.URL) string {
+	unescapedURL, err := url.PathUnescape(u.String())
+	if err != nil {
+		return u.String()
+	}
+	return unescapedURL
+}
+
+// redactHTTPHeaders returns the headers as a map, with the values of the sensitive headers redacted
+func redactHTTPHeaders(headers http.Header) map[string]string {
+	headersMap := make(map[string]string, len(headers))
+	for key, values := range headers {
+		value := strings.Join(values, ", ")
+		for _, sensitiveHeader := range sensitiveHTTPHeaders {
+			if strings.EqualFold(key, sensitiveHeader) {
+				value = redactedValue
+				break
+			}
+		}
+		headersMap[key] = value
+	}
+	return headersMap
+}
+
+// redactHTTPBody returns the JSON body with the values of the sensitive fields redacted.
+// Bodies that are not JSON are replaced by their size, as they may contain sensitive data.
+func redactHTTPBody(body []byte) any {
+	if len(body) == 0 {
+		return nil
+	}
+	var bodyValue any
+	if err := json.Unmarshal(body, &bodyValue); err != nil {
+		return fmt.Sprintf("<%d bytes>", len(body))
+	}
+	return redactValue(bodyValue)
+}
+
+func redactValue(value any) any {
+	switch value := value.(type) {
+	case map[string]any:
+		for key, v := range value {
+			if isSensitiveBodyKey(key) {
+				value[key] = redactedValue
+				continue
+			}
+			value[key] = redactValue(v)
+		}
+		return value
+	case []any:
+		for i, v := range value {
+			value[i] = redactValue(v)
+		}
+		return value
+	default:
+		return value
+	}
+}
+
+func isSensitiveBodyKey(key string) bool {
+	key = strings.ToLower(key)
+	if slices.Contains(sensitiveBodyExactKeys, key) {
+		return true
+	}
+	for _, sensitiveKey := range sensitiveBodyKeys {
+		if strings.Contains(key, sensitiveKey) {
+			return true
+		}
+	}
+	return false
+}
@@ -12,8 +12,6 @@ import (
 	"strings"
 
 	"github.com/fatih/color"
-	"github.com/lmittmann/tint"
-	"github.com/mattn/go-colorable"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 
@@ -32,10 +30,15 @@ const (
 	// Originally defined in "internal/pkg/config/config.go"
 	outputFormatKey = "output-format"
 
-	JSONOutputFormat   = "json"
-	PrettyOutputFormat = "pretty"
-	NoneOutputFormat   = "none"
-	YAMLOutputFormat   = "yaml"
+	JSONOutputFormat      = "json"
+	JSONLinesOutputFormat = "jsonl"
+	PrettyOutputFormat    = "pretty"
+	NoneOutputFormat      = "none"
+	YAMLOutputFormat      = "yaml"
+
+	// Annotation of the commands that support the JSON lines output format.
+	// The other commands use the JSON output format instead.
+	JSONLinesAnnotation = "jsonl-output"
 )
 
 var (
@@ -49,19 +52,29 @@ var (
 type Printer struct {
 	Cmd       *cobra.Command
 	Verbosity Level
+
+	logFormat        string
+	loggerConfigured bool
 }
 
 // Creates a new printer, including setting up the default logger.
 func NewPrinter() *Printer {
-	w := os.Stderr
-	logger := slog.New(
-		tint.NewHandler(colorable.NewColorable(w), &tint.Options{AddSource: false, Level: slog.LevelDebug}),
-	)
+	logger := slog.New(newLogHandler(os.Stderr, TextLogFormat))
 	slog.SetDefault(logger)
 
 	return &Printer{}
 }
 
+// NewChildPrinter creates a printer for a command run by this process, e.g. by the batch command.
+// Unlike NewPrinter, it keeps the default logger, which is shared with this printer.
+func (p *Printer) NewChildPrinter() *Printer {
+	return &Printer{
+		Verbosity:        p.Verbosity,
+		logFormat:        p.logFormat,
+		loggerConfigured: p.loggerConfigured,
+	}
+}
+
 // Print an output using Printf to the defined output (falling back to Stderr if not set).
 // If output format is set to none, it does nothing
 func (p *Printer) Outputf(msg string, args ...any) {
@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"sync"
+	"sync/atomic"
 
 	"github.com/stackitcloud/stackit-cli/internal/pkg/config"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/flags"
@@ -16,6 +18,21 @@ import (
 	"github.com/spf13/viper"
 )
 
+// Project names got from the API by this process, by project ID
+var (
+	projectNamesCache      = map[string]string{}
+	projectNamesCacheMutex sync.Mutex
+)
+
+// Whether project names got from the API are not stored in config, e.g. while commands run concurrently
+var skipStoring atomic.Bool
+
+// SkipStoring sets whether GetProjectName stores the project names it gets from the API in config.
+// The config can't be written by commands running concurrently.
+func SkipStoring(skip bool) {
+	skipStoring.Store(skip)
+}
+
 // Returns the project name associated to the project ID set in config
 //
 // Uses the one stored in config if it's valid, otherwise gets it from the API
@@ -30,19 +47,30 @@ func GetProjectName(ctx context.Context, p *print.Printer, cliVersion string, cm
 		return "", fmt.Errorf("found empty project ID and name")
 	}
 
+	projectNamesCacheMutex.Lock()
+	projectName, ok := projectNamesCache[projectId]
+	projectNamesCacheMutex.Unlock()
+	if ok {
+		return projectName, nil
+	}
+
 	apiClient, err := client.ConfigureClient(p, cliVersion)
 	if err != nil {
 		return "", fmt.Errorf("configure resource manager client: %w", err)
 	}
 
-	projectName, err := utils.GetProjectName(ctx, apiClient, projectId)
+	projectName, err = utils.GetProjectName(ctx, apiClient, projectId)
 	if err != nil {
 		return "", fmt.Errorf("get project name: %w", err)
 	}
 
+	projectNamesCacheMutex.Lock()
+	projectNamesCache[projectId] = projectName
+	projectNamesCacheMutex.Unlock()
+
 	// If project ID is set in config, we store the project name in config
 	// (So next time we can just pull it from there)
-	if !(isProjectIdSetInFlags(p, cmd) || isProjectIdSetInEnvVar()) {
+	if !(isProjectIdSetInFlags(p, cmd) || isProjectIdSetInEnvVar() || skipStoring.Load()) {
 		viper.Set(config.ProjectNameKey, projectName)
 		err = config.Write()
 		if err != nil {
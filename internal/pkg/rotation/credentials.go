@@ -0,0 +1,105 @@
+package rotation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/stackitcloud/stackit-cli/internal/pkg/connection"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/print"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/utils"
+)
+
+// CredentialsClient manages the credentials of an instance of a data service, e.g. Redis.
+// Each service implements it in its utils package.
+type CredentialsClient interface {
+	// GetUsername returns the username of the credentials with the given ID, empty if not set
+	GetUsername(ctx context.Context, credentialsId string) (string, error)
+	// Create creates new credentials and returns their ID and the connection with them.
+	// If the credentials are created but the connection can't be built, their ID is returned with the error.
+	Create(ctx context.Context) (string, *connection.Connection, error)
+	Delete(ctx context.Context, credentialsId string) error
+}
+
+// CredentialsRotation is the rotation of the credentials of an instance
+type CredentialsRotation struct {
+	InstanceId       string
+	InstanceLabel    string
+	CredentialsId    string
+	CredentialsLabel string
+	Options          *Options
+	OutputFormat     string
+	AssumeYes        bool
+}
+
+// GetCredentialsLabel returns the username of the credentials with the given ID or, if not set, their ID.
+// Fails if the credentials don't exist, as they are deleted at the end of the rotation.
+func GetCredentialsLabel(ctx context.Context, client CredentialsClient, credentialsId string) (string, error) {
+	username, err := client.GetUsername(ctx, credentialsId)
+	if err != nil {
+		return "", err
+	}
+	if username == "" {
+		return credentialsId, nil
+	}
+	return username, nil
+}
+
+// RotateCredentials creates new credentials, writes them to the targets and deletes the old credentials once confirmed, then prints the report.
+// The report is also printed if the rotation fails after the new credentials are created.
+// If the new credentials can't be written to any target, they are deleted again, so that no unused credentials are left behind.
+// If they are written to some of the targets only, both the new and the old credentials are kept and the report lists the targets.
+func RotateCredentials(ctx context.Context, p *print.Printer, client CredentialsClient, r *CredentialsRotation) error {
+	newCredentialsId, conn, err := client.Create(ctx)
+	if err != nil {
+		if newCredentialsId == "" {
+			return err
+		}
+		return errors.Join(err, deleteNewCredentials(ctx, p, client, newCredentialsId))
+	}
+	report := &Report{
+		InstanceId:            r.InstanceId,
+		OldCredentialsId:      r.CredentialsId,
+		NewCredentialsId:      newCredentialsId,
+		OldCredentialsDeleted: utils.Ptr(false),
+	}
+
+	report.Targets, err = WriteTargets(ctx, p, r.Options, conn)
+	switch {
+	case err != nil && len(report.Targets) == 0:
+		deleteErr := deleteNewCredentials(ctx, p, client, newCredentialsId)
+		report.NewCredentialsDeleted = utils.Ptr(deleteErr == nil)
+		err = errors.Join(err, deleteErr)
+	case err == nil:
+		err = ConfirmDeletion(p, r.Options, conn, r.CredentialsLabel, r.AssumeYes)
+	}
+	if err != nil {
+		outputErr := OutputReport(p, r.OutputFormat, r.InstanceLabel, report)
+		if outputErr != nil {
+			p.Debug(print.ErrorLevel, "output rotation report: %v", outputErr)
+		}
+		return err
+	}
+
+	err = client.Delete(ctx, r.CredentialsId)
+	if err != nil {
+		outputErr := OutputReport(p, r.OutputFormat, r.InstanceLabel, report)
+		if outputErr != nil {
+			p.Debug(print.ErrorLevel, "output rotation report: %v", outputErr)
+		}
+		return fmt.Errorf("delete old credentials: %w", err)
+	}
+	report.OldCredentialsDeleted = utils.Ptr(true)
+
+	return OutputReport(p, r.OutputFormat, r.InstanceLabel, report)
+}
+
+// deleteNewCredentials deletes new credentials that nothing uses
+func deleteNewCredentials(ctx context.Context, p *print.Printer, client CredentialsClient, credentialsId string) error {
+	err := client.Delete(ctx, credentialsId)
+	if err != nil {
+		return fmt.Errorf("delete the unused new credentials %q, delete them manually: %w", credentialsId, err)
+	}
+	p.Info("Deleted the new credentials %q, as they were not written to any target\n", credentialsId)
+	return nil
+}
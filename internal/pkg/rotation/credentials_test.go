@@ -0,0 +1,219 @@
+package rotation
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/stackitcloud/stackit-cli/internal/pkg/connection"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/utils"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+const (
+	testOldCredentialsId = "old-id"
+	testNewCredentialsId = "new-id"
+)
+
+type credentialsClientMocked struct {
+	getUsernameFails bool
+	username         string
+	createFails      bool
+	// Returns the ID of the created credentials with the error
+	createConnectionFails bool
+	deleteFails           map[string]bool
+	deleted               []string
+}
+
+func (m *credentialsClientMocked) GetUsername(_ context.Context, _ string) (string, error) {
+	if m.getUsernameFails {
+		return "", fmt.Errorf("could not get credentials")
+	}
+	return m.username, nil
+}
+
+func (m *credentialsClientMocked) Create(_ context.Context) (string, *connection.Connection, error) {
+	if m.createFails {
+		return "", nil, fmt.Errorf("could not create credentials")
+	}
+	if m.createConnectionFails {
+		return testNewCredentialsId, nil, fmt.Errorf("no host of the instance returned")
+	}
+	return testNewCredentialsId, fixtureConnection(), nil
+}
+
+func (m *credentialsClientMocked) Delete(_ context.Context, credentialsId string) error {
+	if m.deleteFails[credentialsId] {
+		return fmt.Errorf("could not delete credentials")
+	}
+	m.deleted = append(m.deleted, credentialsId)
+	return nil
+}
+
+func TestGetCredentialsLabel(t *testing.T) {
+	tests := []struct {
+		description string
+		client      *credentialsClientMocked
+		isValid     bool
+		expected    string
+	}{
+		{
+			description: "username",
+			client:      &credentialsClientMocked{username: "user"},
+			isValid:     true,
+			expected:    "user",
+		},
+		{
+			description: "no username",
+			client:      &credentialsClientMocked{},
+			isValid:     true,
+			expected:    testOldCredentialsId,
+		},
+		{
+			description: "get credentials fails",
+			client:      &credentialsClientMocked{getUsernameFails: true},
+			isValid:     false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			label, err := GetCredentialsLabel(context.Background(), tt.client, testOldCredentialsId)
+			if !tt.isValid {
+				if err == nil {
+					t.Fatalf("did not fail on invalid input")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("failed on valid input: %v", err)
+			}
+			if label != tt.expected {
+				t.Fatalf("expected label %q, got %q", tt.expected, label)
+			}
+		})
+	}
+}
+
+func TestRotateCredentials(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("hooks are run with sh")
+	}
+
+	dir := t.TempDir()
+	writableTarget := &fileTarget{path: filepath.Join(dir, "first.env")}
+	failingTarget := &fileTarget{path: filepath.Join(dir, "missing", "second.env")}
+
+	tests := []struct {
+		description     string
+		client          *credentialsClientMocked
+		targets         []Target
+		hook            *string
+		isValid         bool
+		expectedDeleted []string
+		expectedReport  bool
+	}{
+		{
+			description:     "base",
+			client:          &credentialsClientMocked{},
+			targets:         []Target{writableTarget},
+			isValid:         true,
+			expectedReport:  true,
+			expectedDeleted: []string{testOldCredentialsId},
+		},
+		{
+			description:     "create fails",
+			client:          &credentialsClientMocked{createFails: true},
+			targets:         []Target{writableTarget},
+			isValid:         false,
+			expectedDeleted: nil,
+		},
+		{
+			description:     "connection of new credentials fails",
+			client:          &credentialsClientMocked{createConnectionFails: true},
+			targets:         []Target{writableTarget},
+			isValid:         false,
+			expectedDeleted: []string{testNewCredentialsId},
+		},
+		{
+			description:     "no target written",
+			client:          &credentialsClientMocked{},
+			targets:         []Target{failingTarget},
+			isValid:         false,
+			expectedReport:  true,
+			expectedDeleted: []string{testNewCredentialsId},
+		},
+		{
+			description: "no target written and deletion of new credentials fails",
+			client: &credentialsClientMocked{
+				deleteFails: map[string]bool{testNewCredentialsId: true},
+			},
+			targets:         []Target{failingTarget},
+			isValid:         false,
+			expectedReport:  true,
+			expectedDeleted: nil,
+		},
+		{
+			description:     "some targets written",
+			client:          &credentialsClientMocked{},
+			targets:         []Target{writableTarget, failingTarget},
+			isValid:         false,
+			expectedReport:  true,
+			expectedDeleted: nil,
+		},
+		{
+			description:     "hook fails",
+			client:          &credentialsClientMocked{},
+			targets:         []Target{writableTarget},
+			hook:            utils.Ptr("false"),
+			isValid:         false,
+			expectedReport:  true,
+			expectedDeleted: nil,
+		},
+		{
+			description: "deletion of old credentials fails",
+			client: &credentialsClientMocked{
+				deleteFails: map[string]bool{testOldCredentialsId: true},
+			},
+			targets:         []Target{writableTarget},
+			isValid:         false,
+			expectedReport:  true,
+			expectedDeleted: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			err := RotateCredentials(context.Background(), fixturePrinter(buf), tt.client, &CredentialsRotation{
+				InstanceId:       "instance-id",
+				InstanceLabel:    "instance",
+				CredentialsId:    testOldCredentialsId,
+				CredentialsLabel: "user",
+				Options: &Options{
+					Targets: tt.targets,
+					Hook:    tt.hook,
+				},
+				AssumeYes: true,
+			})
+			if !tt.isValid && err == nil {
+				t.Fatalf("did not fail on invalid input")
+			}
+			if tt.isValid && err != nil {
+				t.Fatalf("failed on valid input: %v", err)
+			}
+			diff := cmp.Diff(tt.client.deleted, tt.expectedDeleted)
+			if diff != "" {
+				t.Fatalf("Deleted credentials do not match: %s", diff)
+			}
+			if tt.expectedReport != strings.Contains(buf.String(), "Old credentials "+testOldCredentialsId) {
+				t.Fatalf("expected report: %t, got output %q", tt.expectedReport, buf.String())
+			}
+		})
+	}
+}
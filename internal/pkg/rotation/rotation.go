@@ -0,0 +1,264 @@
+package rotation
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+
+	"github.com/stackitcloud/stackit-cli/internal/pkg/connection"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/errors"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/flags"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/print"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/utils"
+
+	"github.com/goccy/go-yaml"
+	"github.com/spf13/cobra"
+)
+
+const (
+	ToFileFlag             = "to-file"
+	ToSecretsManagerFlag   = "to-secrets-manager" //nolint:gosec // linter false positive
+	SecretsManagerUserFlag = "secrets-manager-user"
+	ToK8sSecretFlag        = "to-k8s-secret" //nolint:gosec // linter false positive
+	KubeconfigFlag         = "kubeconfig"
+	HookFlag               = "hook"
+
+	// Environment variable with the password of the Secrets Manager user, prompted for if not set
+	SecretsManagerPasswordEnv = "STACKIT_SECRETS_MANAGER_PASSWORD" //nolint:gosec // linter false positive
+)
+
+// Options are the values of the flags of the rotation commands
+type Options struct {
+	Targets []Target
+	// Command run once the targets are written, the old credentials are only deleted if it succeeds
+	Hook *string
+}
+
+// ConfigureFlags adds the flags selecting where the new credentials are written to and, if withHook is set, the hook flag
+func ConfigureFlags(cmd *cobra.Command, withHook bool) {
+	cmd.Flags().String(ToFileFlag, "", "Path of a file to write the new credentials to, as environment variables")
+	cmd.Flags().String(ToSecretsManagerFlag, "", "Secrets Manager secret to write the new credentials to, as INSTANCE_ID/PATH. The instance has to be in the configured region, unless the \"secrets_manager_custom_endpoint\" key is set")
+	cmd.Flags().String(SecretsManagerUserFlag, "", fmt.Sprintf("Username of the Secrets Manager user to write the secret with. The password is read from the %s environment variable or prompted for", SecretsManagerPasswordEnv))
+	cmd.Flags().String(ToK8sSecretFlag, "", "Kubernetes Secret to create or update with the new credentials, as [NAMESPACE/]NAME. If the namespace is not specified, the one of the current kubeconfig context is used")
+	cmd.Flags().String(KubeconfigFlag, "", "Path of the kubeconfig of the cluster of the Kubernetes Secret. If not specified, the kubeconfig used by kubectl is used")
+	if withHook {
+		cmd.Flags().String(HookFlag, "", "Shell command run after the new credentials are written, e.g. to restart and check the applications. The old credentials are only deleted if it succeeds. If not specified, you are asked to confirm the deletion")
+	}
+
+	cmd.MarkFlagsRequiredTogether(ToSecretsManagerFlag, SecretsManagerUserFlag)
+}
+
+// ParseFlags returns the values of the rotation flags.
+// If targetRequired is set, at least one target has to be given.
+func ParseFlags(p *print.Printer, cmd *cobra.Command, targetRequired bool) (*Options, error) {
+	options := &Options{
+		Targets: []Target{},
+	}
+	if cmd.Flags().Lookup(HookFlag) != nil {
+		options.Hook = flags.FlagToStringPointer(p, cmd, HookFlag)
+	}
+
+	if path := flags.FlagToStringPointer(p, cmd, ToFileFlag); path != nil {
+		options.Targets = append(options.Targets, &fileTarget{path: *path})
+	}
+
+	if secret := flags.FlagToStringPointer(p, cmd, ToSecretsManagerFlag); secret != nil {
+		instanceId, path, found := strings.Cut(*secret, "/")
+		path = strings.Trim(path, "/")
+		if !found || path == "" {
+			return nil, &errors.FlagValidationError{
+				Flag:    ToSecretsManagerFlag,
+				Details: "must be INSTANCE_ID/PATH",
+			}
+		}
+		err := utils.ValidateUUID(instanceId)
+		if err != nil {
+			return nil, &errors.FlagValidationError{
+				Flag:    ToSecretsManagerFlag,
+				Details: fmt.Sprintf("instance ID: %v", err),
+			}
+		}
+		options.Targets = append(options.Targets, &secretsManagerTarget{
+			instanceId: instanceId,
+			path:       path,
+			username:   flags.FlagToStringValue(p, cmd, SecretsManagerUserFlag),
+			url:        secretsManagerURL(),
+			httpClient: newSecretsManagerHTTPClient(p),
+		})
+	}
+
+	if secret := flags.FlagToStringPointer(p, cmd, ToK8sSecretFlag); secret != nil {
+		namespace, name, found := strings.Cut(*secret, "/")
+		if !found {
+			namespace, name = "", *secret
+		}
+		if name == "" || (found && namespace == "") || strings.Contains(name, "/") {
+			return nil, &errors.FlagValidationError{
+				Flag:    ToK8sSecretFlag,
+				Details: "must be [NAMESPACE/]NAME",
+			}
+		}
+		options.Targets = append(options.Targets, &kubernetesSecretTarget{
+			namespace:  namespace,
+			name:       name,
+			kubeconfig: flags.FlagToStringValue(p, cmd, KubeconfigFlag),
+		})
+	} else if cmd.Flags().Changed(KubeconfigFlag) {
+		return nil, &errors.FlagValidationError{
+			Flag:    KubeconfigFlag,
+			Details: fmt.Sprintf("can only be set with --%s", ToK8sSecretFlag),
+		}
+	}
+
+	if targetRequired && len(options.Targets) == 0 {
+		return nil, &errors.FlagValidationError{
+			Flag:    ToFileFlag,
+			Details: fmt.Sprintf("at least one of --%s, --%s or --%s must be set", ToFileFlag, ToSecretsManagerFlag, ToK8sSecretFlag),
+		}
+	}
+	return options, nil
+}
+
+// WriteTargets writes the connection with the new credentials to all targets.
+// The password of the Secrets Manager user is read before anything is written.
+func WriteTargets(ctx context.Context, p *print.Printer, options *Options, c *connection.Connection) ([]string, error) {
+	for _, target := range options.Targets {
+		if t, ok := target.(*secretsManagerTarget); ok && t.password == "" {
+			password, err := secretsManagerPassword(p)
+			if err != nil {
+				return nil, err
+			}
+			t.password = password
+		}
+	}
+
+	written := []string{}
+	for _, target := range options.Targets {
+		err := target.Write(ctx, c)
+		if err != nil {
+			return written, err
+		}
+		p.Info("Wrote the new credentials to %s\n", target)
+		written = append(written, target.String())
+	}
+	return written, nil
+}
+
+func secretsManagerPassword(p *print.Printer) (string, error) {
+	if password := os.Getenv(SecretsManagerPasswordEnv); password != "" {
+		return password, nil
+	}
+	password, err := p.PromptForPassword("Password of the Secrets Manager user: ")
+	if err != nil {
+		return "", err
+	}
+	if password == "" {
+		return "", fmt.Errorf("no password of the Secrets Manager user given, set it with the %s environment variable", SecretsManagerPasswordEnv)
+	}
+	return password, nil
+}
+
+// ConfirmDeletion runs the hook, with the new credentials in its environment, or asks the user to confirm the deletion of the old credentials.
+// Returns nil if the old credentials can be deleted.
+func ConfirmDeletion(p *print.Printer, options *Options, c *connection.Connection, oldCredentialsLabel string, assumeYes bool) error {
+	if options.Hook != nil {
+		p.Info("Running hook %q\n", *options.Hook)
+		err := hookCommand(*options.Hook, c).Run()
+		if err != nil {
+			return fmt.Errorf("hook %q failed, the old credentials are kept: %w", *options.Hook, err)
+		}
+		return nil
+	}
+	if assumeYes {
+		return nil
+	}
+	prompt := fmt.Sprintf("Update the applications to use the new credentials. Are you sure you want to delete the old credentials %q?", oldCredentialsLabel)
+	return p.PromptForConfirmation(prompt)
+}
+
+// hookCommand returns the command running the hook with the shell, attached to the terminal
+func hookCommand(hook string, c *connection.Connection) *exec.Cmd {
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.Command("cmd", "/C", hook) //nolint:gosec // the hook is chosen by the user
+	} else {
+		cmd = exec.Command("sh", "-c", hook) //nolint:gosec // the hook is chosen by the user
+	}
+	cmd.Env = append(os.Environ(), c.Env(true)...)
+	cmd.Stdin = os.Stdin
+	// The output of the command is kept apart from the report
+	cmd.Stdout = os.Stderr
+	cmd.Stderr = os.Stderr
+	return cmd
+}
+
+// Report is the summary of a rotation
+type Report struct {
+	InstanceId       string `json:"instanceId"`
+	UserId           string `json:"userId,omitempty"`
+	OldCredentialsId string `json:"oldCredentialsId,omitempty"`
+	NewCredentialsId string `json:"newCredentialsId,omitempty"`
+	// Descriptions of the targets the new credentials were written to
+	Targets []string `json:"targets"`
+	// Not set if there are no old credentials to delete
+	OldCredentialsDeleted *bool `json:"oldCredentialsDeleted,omitempty"`
+	// Only set if the new credentials were not written to any target, so they are deleted again
+	NewCredentialsDeleted *bool `json:"newCredentialsDeleted,omitempty"`
+}
+
+// OutputReport prints the report of the rotation of the credentials of the instance
+func OutputReport(p *print.Printer, outputFormat, instanceLabel string, report *Report) error {
+	if report == nil {
+		return fmt.Errorf("no report passed")
+	}
+
+	switch outputFormat {
+	case print.JSONOutputFormat:
+		details, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return fmt.Errorf("marshal rotation report: %w", err)
+		}
+		p.Outputln(string(details))
+
+		return nil
+	case print.YAMLOutputFormat:
+		details, err := yaml.MarshalWithOptions(report, yaml.IndentSequence(true), yaml.UseJSONMarshaler())
+		if err != nil {
+			return fmt.Errorf("marshal rotation report: %w", err)
+		}
+		p.Outputln(string(details))
+
+		return nil
+	default:
+		if report.UserId != "" {
+			p.Outputf("Rotated the password of user %q of instance %q\n", report.UserId, instanceLabel)
+		} else {
+			p.Outputf("Rotated credentials of instance %q\n", instanceLabel)
+		}
+		if report.NewCredentialsId != "" {
+			p.Outputf("New credentials ID: %s\n", report.NewCredentialsId)
+		}
+		for _, target := range report.Targets {
+			p.Outputf("Written to: %s\n", target)
+		}
+		if report.NewCredentialsDeleted != nil {
+			if *report.NewCredentialsDeleted {
+				p.Outputf("New credentials %s: deleted\n", report.NewCredentialsId)
+			} else {
+				p.Outputf("New credentials %s: not deleted\n", report.NewCredentialsId)
+			}
+		}
+		if report.OldCredentialsDeleted != nil {
+			if *report.OldCredentialsDeleted {
+				p.Outputf("Old credentials %s: deleted\n", report.OldCredentialsId)
+			} else {
+				p.Outputf("Old credentials %s: kept\n", report.OldCredentialsId)
+			}
+		}
+		return nil
+	}
+}
@@ -0,0 +1,420 @@
+package rotation
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stackitcloud/stackit-cli/internal/pkg/config"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/connection"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/print"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/utils"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/uuid"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var testSecretsManagerInstanceId = uuid.NewString()
+
+func fixtureConnection() *connection.Connection {
+	return &connection.Connection{
+		Engine:   connection.Redis,
+		Host:     "example.com",
+		Port:     6379,
+		Username: "user",
+		Password: "password",
+	}
+}
+
+func fixturePrinter(buf *bytes.Buffer) *print.Printer {
+	p := print.NewPrinter()
+	p.Cmd = &cobra.Command{}
+	p.Cmd.SetOut(buf)
+	p.Cmd.SetErr(&bytes.Buffer{})
+	return p
+}
+
+func TestParseFlags(t *testing.T) {
+	tests := []struct {
+		description     string
+		flagValues      map[string]string
+		withHook        bool
+		targetRequired  bool
+		isValid         bool
+		expectedOptions *Options
+	}{
+		{
+			description:     "no targets",
+			flagValues:      map[string]string{},
+			isValid:         true,
+			expectedOptions: &Options{Targets: []Target{}},
+		},
+		{
+			description:    "no targets, target required",
+			flagValues:     map[string]string{},
+			targetRequired: true,
+			isValid:        false,
+		},
+		{
+			description: "all targets",
+			flagValues: map[string]string{
+				ToFileFlag:             ".env",
+				ToSecretsManagerFlag:   testSecretsManagerInstanceId + "/apps/redis/",
+				SecretsManagerUserFlag: "my-user",
+				ToK8sSecretFlag:        "my-namespace/redis",
+				KubeconfigFlag:         "kubeconfig.yaml",
+				HookFlag:               "true",
+			},
+			withHook:       true,
+			targetRequired: true,
+			isValid:        true,
+			expectedOptions: &Options{
+				Targets: []Target{
+					&fileTarget{path: ".env"},
+					&secretsManagerTarget{
+						instanceId: testSecretsManagerInstanceId,
+						path:       "apps/redis",
+						username:   "my-user",
+						url:        "https://prod.sm.eu01.stackit.cloud",
+					},
+					&kubernetesSecretTarget{
+						namespace:  "my-namespace",
+						name:       "redis",
+						kubeconfig: "kubeconfig.yaml",
+					},
+				},
+				Hook: utils.Ptr("true"),
+			},
+		},
+		{
+			description: "Kubernetes Secret without namespace",
+			flagValues: map[string]string{
+				ToK8sSecretFlag: "redis",
+			},
+			isValid: true,
+			expectedOptions: &Options{
+				Targets: []Target{
+					&kubernetesSecretTarget{name: "redis"},
+				},
+			},
+		},
+		{
+			description: "Kubernetes Secret with empty namespace",
+			flagValues: map[string]string{
+				ToK8sSecretFlag: "/redis",
+			},
+			isValid: false,
+		},
+		{
+			description: "Kubernetes Secret with too many parts",
+			flagValues: map[string]string{
+				ToK8sSecretFlag: "my-namespace/redis/other",
+			},
+			isValid: false,
+		},
+		{
+			description: "kubeconfig without Kubernetes Secret",
+			flagValues: map[string]string{
+				ToFileFlag:     ".env",
+				KubeconfigFlag: "kubeconfig.yaml",
+			},
+			isValid: false,
+		},
+		{
+			description: "Secrets Manager secret without path",
+			flagValues: map[string]string{
+				ToSecretsManagerFlag:   testSecretsManagerInstanceId,
+				SecretsManagerUserFlag: "my-user",
+			},
+			isValid: false,
+		},
+		{
+			description: "Secrets Manager instance ID invalid",
+			flagValues: map[string]string{
+				ToSecretsManagerFlag:   "invalid-uuid/apps/redis",
+				SecretsManagerUserFlag: "my-user",
+			},
+			isValid: false,
+		},
+		{
+			description: "Secrets Manager user missing",
+			flagValues: map[string]string{
+				ToSecretsManagerFlag: testSecretsManagerInstanceId + "/apps/redis",
+			},
+			isValid: false,
+		},
+		{
+			description: "hook not supported",
+			flagValues: map[string]string{
+				ToFileFlag: ".env",
+				HookFlag:   "true",
+			},
+			isValid: false,
+		},
+	}
+
+	viper.Set(config.RegionKey, "eu01")
+	defer viper.Set(config.RegionKey, nil)
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			p := print.NewPrinter()
+			cmd := &cobra.Command{}
+			ConfigureFlags(cmd, tt.withHook)
+
+			for flag, value := range tt.flagValues {
+				err := cmd.Flags().Set(flag, value)
+				if err != nil {
+					if !tt.isValid {
+						return
+					}
+					t.Fatalf("setting flag --%s=%s: %v", flag, value, err)
+				}
+			}
+
+			err := cmd.ValidateFlagGroups()
+			if err != nil {
+				if !tt.isValid {
+					return
+				}
+				t.Fatalf("error validating flags: %v", err)
+			}
+
+			options, err := ParseFlags(p, cmd, tt.targetRequired)
+			if err != nil {
+				if !tt.isValid {
+					return
+				}
+				t.Fatalf("error parsing flags: %v", err)
+			}
+
+			if !tt.isValid {
+				t.Fatalf("did not fail on invalid input")
+			}
+			diff := cmp.Diff(options, tt.expectedOptions,
+				cmp.AllowUnexported(fileTarget{}, secretsManagerTarget{}, kubernetesSecretTarget{}),
+				cmpopts.IgnoreFields(secretsManagerTarget{}, "httpClient"),
+			)
+			if diff != "" {
+				t.Fatalf("Data does not match: %s", diff)
+			}
+		})
+	}
+}
+
+func TestSecretsManagerURL(t *testing.T) {
+	tests := []struct {
+		description    string
+		region         string
+		customEndpoint string
+		expected       string
+	}{
+		{
+			description: "region",
+			region:      "eu02",
+			expected:    "https://prod.sm.eu02.stackit.cloud",
+		},
+		{
+			description:    "custom endpoint",
+			region:         "eu02",
+			customEndpoint: "https://sm.example.com/",
+			expected:       "https://sm.example.com",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			viper.Set(config.RegionKey, tt.region)
+			viper.Set(config.SecretsManagerCustomEndpointKey, tt.customEndpoint)
+			defer viper.Set(config.RegionKey, nil)
+			defer viper.Set(config.SecretsManagerCustomEndpointKey, nil)
+
+			url := secretsManagerURL()
+			if url != tt.expected {
+				t.Fatalf("expected URL %q, got %q", tt.expected, url)
+			}
+		})
+	}
+}
+
+func TestWriteTargets(t *testing.T) {
+	dir := t.TempDir()
+	options := &Options{
+		Targets: []Target{
+			&fileTarget{path: filepath.Join(dir, "first.env")},
+			&fileTarget{path: filepath.Join(dir, "missing", "second.env")},
+		},
+	}
+
+	written, err := WriteTargets(context.Background(), fixturePrinter(&bytes.Buffer{}), options, fixtureConnection())
+	if err == nil {
+		t.Fatalf("did not fail on target that cannot be written")
+	}
+	diff := cmp.Diff(written, []string{"file " + filepath.Join(dir, "first.env")})
+	if diff != "" {
+		t.Fatalf("Data does not match: %s", diff)
+	}
+}
+
+func TestConfirmDeletion(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("hooks are run with sh")
+	}
+
+	tests := []struct {
+		description string
+		hook        *string
+		assumeYes   bool
+		isValid     bool
+	}{
+		{
+			description: "hook succeeds",
+			hook:        utils.Ptr("true"),
+			isValid:     true,
+		},
+		{
+			description: "hook fails",
+			hook:        utils.Ptr("exit 1"),
+			isValid:     false,
+		},
+		{
+			description: "hook gets new credentials",
+			hook:        utils.Ptr(`test "$REDIS_PASSWORD" = password`),
+			isValid:     true,
+		},
+		{
+			description: "hook fails with assume yes",
+			hook:        utils.Ptr("false"),
+			assumeYes:   true,
+			isValid:     false,
+		},
+		{
+			description: "no hook with assume yes",
+			assumeYes:   true,
+			isValid:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			options := &Options{Hook: tt.hook}
+			err := ConfirmDeletion(fixturePrinter(&bytes.Buffer{}), options, fixtureConnection(), "user", tt.assumeYes)
+			if !tt.isValid && err == nil {
+				t.Fatalf("did not fail on invalid input")
+			}
+			if tt.isValid && err != nil {
+				t.Fatalf("failed on valid input: %v", err)
+			}
+		})
+	}
+}
+
+func TestOutputReport(t *testing.T) {
+	tests := []struct {
+		description  string
+		outputFormat string
+		report       *Report
+		isValid      bool
+		expected     string
+	}{
+		{
+			description: "credentials rotated",
+			report: &Report{
+				InstanceId:            "instance-id",
+				OldCredentialsId:      "old-id",
+				NewCredentialsId:      "new-id",
+				Targets:               []string{"file .env"},
+				OldCredentialsDeleted: utils.Ptr(true),
+			},
+			isValid:  true,
+			expected: "Rotated credentials of instance \"instance\"\nNew credentials ID: new-id\nWritten to: file .env\nOld credentials old-id: deleted\n",
+		},
+		{
+			description: "old credentials kept",
+			report: &Report{
+				InstanceId:            "instance-id",
+				OldCredentialsId:      "old-id",
+				NewCredentialsId:      "new-id",
+				Targets:               []string{},
+				OldCredentialsDeleted: utils.Ptr(false),
+			},
+			isValid:  true,
+			expected: "Rotated credentials of instance \"instance\"\nNew credentials ID: new-id\nOld credentials old-id: kept\n",
+		},
+		{
+			description: "new credentials deleted",
+			report: &Report{
+				InstanceId:            "instance-id",
+				OldCredentialsId:      "old-id",
+				NewCredentialsId:      "new-id",
+				Targets:               []string{},
+				OldCredentialsDeleted: utils.Ptr(false),
+				NewCredentialsDeleted: utils.Ptr(true),
+			},
+			isValid:  true,
+			expected: "Rotated credentials of instance \"instance\"\nNew credentials ID: new-id\nNew credentials new-id: deleted\nOld credentials old-id: kept\n",
+		},
+		{
+			description: "password reset",
+			report: &Report{
+				InstanceId: "instance-id",
+				UserId:     "user-id",
+				Targets:    []string{"Kubernetes Secret redis"},
+			},
+			isValid:  true,
+			expected: "Rotated the password of user \"user-id\" of instance \"instance\"\nWritten to: Kubernetes Secret redis\n",
+		},
+		{
+			description:  "json",
+			outputFormat: print.JSONOutputFormat,
+			report: &Report{
+				InstanceId: "instance-id",
+				UserId:     "user-id",
+				Targets:    []string{"file .env"},
+			},
+			isValid:  true,
+			expected: "{\n  \"instanceId\": \"instance-id\",\n  \"userId\": \"user-id\",\n  \"targets\": [\n    \"file .env\"\n  ]\n}\n",
+		},
+		{
+			description: "no report",
+			isValid:     false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			err := OutputReport(fixturePrinter(buf), tt.outputFormat, "instance", tt.report)
+			if !tt.isValid {
+				if err == nil {
+					t.Fatalf("did not fail on invalid input")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("failed on valid input: %v", err)
+			}
+			diff := cmp.Diff(buf.String(), tt.expected)
+			if diff != "" {
+				t.Fatalf("Data does not match: %s", diff)
+			}
+		})
+	}
+}
+
+func TestSecretsManagerPassword(t *testing.T) {
+	t.Setenv(SecretsManagerPasswordEnv, "secret")
+
+	password, err := secretsManagerPassword(print.NewPrinter())
+	if err != nil {
+		t.Fatalf("failed to read password: %v", err)
+	}
+	if password != "secret" {
+		t.Fatalf("expected password %q, got %q", "secret", password)
+	}
+}
@@ -0,0 +1,250 @@
+package rotation
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/stackitcloud/stackit-cli/internal/pkg/config"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/connection"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/print"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/transport"
+
+	"github.com/spf13/viper"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+const (
+	// Address of the Vault API of Secrets Manager in a region, where the key-value store of each instance is mounted at the instance ID
+	secretsManagerURLFormat = "https://prod.sm.%s.stackit.cloud"
+
+	defaultNamespace = "default"
+)
+
+// Target is a place the new credentials are written to
+type Target interface {
+	// Description of the target, for the report
+	String() string
+	Write(ctx context.Context, c *connection.Connection) error
+}
+
+// fileTarget writes the credentials as an env block to a file only readable by the user
+type fileTarget struct {
+	path string
+}
+
+func (t *fileTarget) String() string {
+	return fmt.Sprintf("file %s", t.path)
+}
+
+func (t *fileTarget) Write(_ context.Context, c *connection.Connection) error {
+	content := strings.Join(c.Env(true), "\n") + "\n"
+	err := os.WriteFile(t.path, []byte(content), 0o600)
+	if err != nil {
+		return fmt.Errorf("write credentials to file %s: %w", t.path, err)
+	}
+	// WriteFile keeps the permissions of existing files
+	err = os.Chmod(t.path, 0o600)
+	if err != nil {
+		return fmt.Errorf("set permissions of file %s: %w", t.path, err)
+	}
+	return nil
+}
+
+// secretsManagerTarget writes the credentials to a secret of a Secrets Manager instance, as a new version
+type secretsManagerTarget struct {
+	instanceId string
+	path       string
+	username   string
+	password   string
+	url        string
+	httpClient *http.Client
+}
+
+func (t *secretsManagerTarget) String() string {
+	return fmt.Sprintf("Secrets Manager secret %s/%s", t.instanceId, t.path)
+}
+
+func (t *secretsManagerTarget) Write(ctx context.Context, c *connection.Connection) error {
+	token, err := t.login(ctx)
+	if err != nil {
+		return fmt.Errorf("log in to Secrets Manager instance %s: %w", t.instanceId, err)
+	}
+
+	body := map[string]any{"data": c.Values()}
+	err = t.do(ctx, fmt.Sprintf("/v1/%s/data/%s", url.PathEscape(t.instanceId), t.path), token, body, nil)
+	if err != nil {
+		return fmt.Errorf("write credentials to Secrets Manager secret %s/%s: %w", t.instanceId, t.path, err)
+	}
+	return nil
+}
+
+// login returns a token of the Secrets Manager user
+func (t *secretsManagerTarget) login(ctx context.Context) (string, error) {
+	var resp struct {
+		Auth *struct {
+			ClientToken string `json:"client_token"`
+		} `json:"auth"`
+	}
+	body := map[string]string{"password": t.password}
+	err := t.do(ctx, fmt.Sprintf("/v1/auth/userpass/login/%s", url.PathEscape(t.username)), "", body, &resp)
+	if err != nil {
+		return "", err
+	}
+	if resp.Auth == nil || resp.Auth.ClientToken == "" {
+		return "", fmt.Errorf("no token returned")
+	}
+	return resp.Auth.ClientToken, nil
+}
+
+func (t *secretsManagerTarget) do(ctx context.Context, path, token string, body, result any) error {
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("marshal request: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, t.url+path, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("build request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if token != "" {
+		req.Header.Set("X-Vault-Token", token)
+	}
+
+	resp, err := t.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close() //nolint:errcheck // the body is only read
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("read response: %w", err)
+	}
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("%s: %s", resp.Status, strings.TrimSpace(string(respBody)))
+	}
+	if result == nil {
+		return nil
+	}
+	err = json.Unmarshal(respBody, result)
+	if err != nil {
+		return fmt.Errorf("parse response: %w", err)
+	}
+	return nil
+}
+
+// secretsManagerURL returns the address of the Vault API of Secrets Manager,
+// set by the "secrets_manager_custom_endpoint" key or otherwise the one of the configured region
+func secretsManagerURL() string {
+	customEndpoint := viper.GetString(config.SecretsManagerCustomEndpointKey)
+	if customEndpoint != "" {
+		return strings.TrimSuffix(customEndpoint, "/")
+	}
+	return fmt.Sprintf(secretsManagerURLFormat, viper.GetString(config.RegionKey))
+}
+
+// newSecretsManagerHTTPClient returns the HTTP client for the Vault API of Secrets Manager,
+// with the same retries, dry-run handling and debug logs as the service clients
+func newSecretsManagerHTTPClient(p *print.Printer) *http.Client {
+	rt := transport.Middleware(p)(http.DefaultTransport)
+	if p.IsVerbosityDebug() {
+		rt = print.RequestResponseCapturer(p, nil)(rt)
+	}
+	return &http.Client{Transport: rt}
+}
+
+// kubernetesSecretTarget creates or updates a Kubernetes Secret with the credentials, in the cluster of the kubeconfig
+type kubernetesSecretTarget struct {
+	namespace  string
+	name       string
+	kubeconfig string
+	// Set when the kubeconfig is loaded
+	client corev1client.SecretsGetter
+}
+
+func (t *kubernetesSecretTarget) String() string {
+	if t.namespace == "" {
+		return fmt.Sprintf("Kubernetes Secret %s", t.name)
+	}
+	return fmt.Sprintf("Kubernetes Secret %s/%s", t.namespace, t.name)
+}
+
+func (t *kubernetesSecretTarget) Write(ctx context.Context, c *connection.Connection) error {
+	if t.client == nil {
+		err := t.loadKubeconfig()
+		if err != nil {
+			return err
+		}
+	}
+
+	secrets := t.client.Secrets(t.namespace)
+	secret, err := secrets.Get(ctx, t.name, metav1.GetOptions{})
+	switch {
+	case apierrors.IsNotFound(err):
+		secret = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      t.name,
+				Namespace: t.namespace,
+			},
+			Type:       corev1.SecretTypeOpaque,
+			StringData: c.Values(),
+		}
+		_, err = secrets.Create(ctx, secret, metav1.CreateOptions{})
+		if err != nil {
+			return fmt.Errorf("create %s: %w", t, err)
+		}
+		return nil
+	case err != nil:
+		return fmt.Errorf("get %s: %w", t, err)
+	}
+
+	// The keys of the old credentials are replaced
+	secret.Data = nil
+	secret.StringData = c.Values()
+	_, err = secrets.Update(ctx, secret, metav1.UpdateOptions{})
+	if err != nil {
+		return fmt.Errorf("update %s: %w", t, err)
+	}
+	return nil
+}
+
+// loadKubeconfig configures the client with the given kubeconfig or, if not set, the one kubectl uses.
+// If no namespace was given, the one of the current context is used.
+func (t *kubernetesSecretTarget) loadKubeconfig() error {
+	loadingRules := clientcmd.NewDefaultClientConfigLoadingRules()
+	if t.kubeconfig != "" {
+		loadingRules.ExplicitPath = t.kubeconfig
+	}
+	clientConfig := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(loadingRules, &clientcmd.ConfigOverrides{})
+
+	restConfig, err := clientConfig.ClientConfig()
+	if err != nil {
+		return fmt.Errorf("load kubeconfig: %w", err)
+	}
+	client, err := corev1client.NewForConfig(restConfig)
+	if err != nil {
+		return fmt.Errorf("configure Kubernetes client: %w", err)
+	}
+	t.client = client
+
+	if t.namespace == "" {
+		namespace, _, err := clientConfig.Namespace()
+		if err != nil || namespace == "" {
+			namespace = defaultNamespace
+		}
+		t.namespace = namespace
+	}
+	return nil
+}
@@ -5,6 +5,7 @@ import (
 	"github.com/stackitcloud/stackit-cli/internal/pkg/config"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/errors"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/print"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/transport"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/utils"
 
 	"github.com/spf13/viper"
@@ -21,6 +22,7 @@ func ConfigureClient(p *print.Printer, cliVersion string) (*authorization.APICli
 	cfgOptions := []sdkConfig.ConfigurationOption{
 		utils.UserAgentConfigOption(cliVersion),
 		authCfgOption,
+		sdkConfig.WithMiddleware(transport.Middleware(p)),
 	}
 
 	customEndpoint := viper.GetString(config.AuthorizationCustomEndpointKey)
@@ -7,6 +7,7 @@ import (
 	"github.com/stackitcloud/stackit-cli/internal/pkg/config"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/errors"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/print"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/transport"
 
 	"github.com/spf13/viper"
 	sdkConfig "github.com/stackitcloud/stackit-sdk-go/core/config"
@@ -22,6 +23,7 @@ func ConfigureClient(p *print.Printer, cliVersion string) (*dns.APIClient, error
 	cfgOptions := []sdkConfig.ConfigurationOption{
 		utils.UserAgentConfigOption(cliVersion),
 		authCfgOption,
+		sdkConfig.WithMiddleware(transport.Middleware(p)),
 	}
 
 	customEndpoint := viper.GetString(config.DNSCustomEndpointKey)
@@ -5,6 +5,7 @@ import (
 	"github.com/stackitcloud/stackit-cli/internal/pkg/config"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/errors"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/print"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/transport"
 
 	"github.com/spf13/viper"
 	sdkConfig "github.com/stackitcloud/stackit-sdk-go/core/config"
@@ -21,7 +22,7 @@ func ConfigureClient(p *print.Printer) (*git.APIClient, error) {
 		p.Debug(print.ErrorLevel, "configure authentication: %v", err)
 		return nil, &errors.AuthError{}
 	}
-	cfgOptions = append(cfgOptions, authCfgOption)
+	cfgOptions = append(cfgOptions, authCfgOption, sdkConfig.WithMiddleware(transport.Middleware(p)))
 
 	customEndpoint := viper.GetString(config.GitCustomEndpointKey)
 
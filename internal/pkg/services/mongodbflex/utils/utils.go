@@ -6,11 +6,15 @@ import (
 	"fmt"
 	"slices"
 	"strings"
+	"time"
 
 	"github.com/stackitcloud/stackit-cli/internal/pkg/errors"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/utils"
 	"golang.org/x/mod/semver"
 
+	"github.com/stackitcloud/stackit-sdk-go/core/wait"
 	"github.com/stackitcloud/stackit-sdk-go/services/mongodbflex"
+	mongodbflexWait "github.com/stackitcloud/stackit-sdk-go/services/mongodbflex/wait"
 )
 
 // The number of replicas is enforced by the API according to the instance type
@@ -152,6 +156,17 @@ func GetInstanceName(ctx context.Context, apiClient MongoDBFlexClient, projectId
 	return *resp.Item.Name, nil
 }
 
+func GetInstanceACL(ctx context.Context, apiClient MongoDBFlexClient, projectId, instanceId, region string) ([]string, error) {
+	resp, err := apiClient.GetInstanceExecute(ctx, projectId, instanceId, region)
+	if err != nil {
+		return nil, fmt.Errorf("get MongoDB Flex instance: %w", err)
+	}
+	if resp.Item == nil || resp.Item.Acl == nil || resp.Item.Acl.Items == nil {
+		return []string{}, nil
+	}
+	return *resp.Item.Acl.Items, nil
+}
+
 func GetUserName(ctx context.Context, apiClient MongoDBFlexClient, projectId, instanceId, userId, region string) (string, error) {
 	resp, err := apiClient.GetUserExecute(ctx, projectId, instanceId, userId, region)
 	if err != nil {
@@ -182,3 +197,55 @@ func GetRestoreStatus(backupId string, restoreJobs *mongodbflex.ListRestoreJobsR
 	}
 	return state
 }
+
+// GetLatestRestoreJob returns the most recent restore job of the instance for the backup, or nil if there is none
+func GetLatestRestoreJob(ctx context.Context, apiClient MongoDBFlexClient, projectId, instanceId, backupId, region string) (*mongodbflex.RestoreInstanceStatus, error) {
+	resp, err := apiClient.ListRestoreJobsExecute(ctx, projectId, instanceId, region)
+	if err != nil {
+		return nil, fmt.Errorf("list MongoDB Flex restore jobs: %w", err)
+	}
+	if resp.Items == nil {
+		return nil, nil
+	}
+
+	var latest *mongodbflex.RestoreInstanceStatus
+	for i := range *resp.Items {
+		restoreJob := &(*resp.Items)[i]
+		if restoreJob.BackupID == nil || *restoreJob.BackupID != backupId {
+			continue
+		}
+		if latest == nil || cmp.Compare(utils.PtrString(restoreJob.Date), utils.PtrString(latest.Date)) > 0 {
+			latest = restoreJob
+		}
+	}
+	return latest, nil
+}
+
+// RestoreJobWaitHandler waits for the restore job of the backup to finish.
+// Jobs listed before the restore was triggered are ignored by passing the ID of the latest of them as previousJobId.
+func RestoreJobWaitHandler(ctx context.Context, apiClient MongoDBFlexClient, projectId, instanceId, backupId, previousJobId, region string) *wait.AsyncActionHandler[mongodbflex.RestoreInstanceStatus] {
+	handler := wait.New(func() (waitFinished bool, response *mongodbflex.RestoreInstanceStatus, err error) {
+		restoreJob, err := GetLatestRestoreJob(ctx, apiClient, projectId, instanceId, backupId, region)
+		if err != nil {
+			return false, nil, err
+		}
+		// The restore job isn't listed right after the restore is triggered
+		if restoreJob == nil || utils.PtrString(restoreJob.Id) == previousJobId {
+			return false, nil, nil
+		}
+
+		switch utils.PtrString(restoreJob.Status) {
+		case mongodbflexWait.RestoreJobFinished:
+			return true, restoreJob, nil
+		case mongodbflexWait.RestoreJobBroken:
+			return true, restoreJob, fmt.Errorf("restore job %s for backup %s is broken", utils.PtrString(restoreJob.Id), backupId)
+		case mongodbflexWait.RestoreJobKilled:
+			return true, restoreJob, fmt.Errorf("restore job %s for backup %s was killed", utils.PtrString(restoreJob.Id), backupId)
+		default:
+			return false, nil, nil
+		}
+	})
+	handler.SetTimeout(45 * time.Minute)
+	handler.SetSleepBeforeWait(5 * time.Second)
+	return handler
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stackitcloud/stackit-cli/internal/pkg/utils"
 
@@ -480,6 +481,67 @@ func TestGetInstanceName(t *testing.T) {
 	}
 }
 
+func TestGetInstanceACL(t *testing.T) {
+	tests := []struct {
+		description      string
+		getInstanceFails bool
+		getInstanceResp  *mongodbflex.GetInstanceResponse
+		isValid          bool
+		expectedOutput   []string
+	}{
+		{
+			description: "base",
+			getInstanceResp: &mongodbflex.GetInstanceResponse{
+				Item: &mongodbflex.Instance{
+					Acl: &mongodbflex.ACL{
+						Items: &[]string{"10.0.0.0/24", "192.0.2.1/32"},
+					},
+				},
+			},
+			isValid:        true,
+			expectedOutput: []string{"10.0.0.0/24", "192.0.2.1/32"},
+		},
+		{
+			description: "no ACL",
+			getInstanceResp: &mongodbflex.GetInstanceResponse{
+				Item: &mongodbflex.Instance{},
+			},
+			isValid:        true,
+			expectedOutput: []string{},
+		},
+		{
+			description:      "get instance fails",
+			getInstanceFails: true,
+			isValid:          false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			client := &mongoDBFlexClientMocked{
+				getInstanceFails: tt.getInstanceFails,
+				getInstanceResp:  tt.getInstanceResp,
+			}
+
+			output, err := GetInstanceACL(context.Background(), client, testProjectId, testInstanceId, testRegion)
+
+			if tt.isValid && err != nil {
+				t.Errorf("failed on valid input")
+			}
+			if !tt.isValid && err == nil {
+				t.Errorf("did not fail on invalid input")
+			}
+			if !tt.isValid {
+				return
+			}
+			diff := cmp.Diff(output, tt.expectedOutput)
+			if diff != "" {
+				t.Errorf("Data does not match: %s", diff)
+			}
+		})
+	}
+}
+
 func TestGetUserName(t *testing.T) {
 	tests := []struct {
 		description    string
@@ -697,3 +759,160 @@ func TestGetInstanceType(t *testing.T) {
 		})
 	}
 }
+
+func TestGetLatestRestoreJob(t *testing.T) {
+	tests := []struct {
+		description          string
+		listRestoreJobsFails bool
+		listRestoreJobsResp  *mongodbflex.ListRestoreJobsResponse
+		isValid              bool
+		expectedOutput       *mongodbflex.RestoreInstanceStatus
+	}{
+		{
+			description: "latest job of the backup",
+			listRestoreJobsResp: &mongodbflex.ListRestoreJobsResponse{
+				Items: &[]mongodbflex.RestoreInstanceStatus{
+					{
+						Id:       utils.Ptr("old"),
+						BackupID: utils.Ptr(testBackupId),
+						Date:     utils.Ptr("2024-05-13T12:01:11Z"),
+					},
+					{
+						Id:       utils.Ptr("new"),
+						BackupID: utils.Ptr(testBackupId),
+						Date:     utils.Ptr("2024-05-14T12:01:11Z"),
+					},
+					{
+						Id:       utils.Ptr("other"),
+						BackupID: utils.Ptr("other-backup"),
+						Date:     utils.Ptr("2024-05-15T12:01:11Z"),
+					},
+				},
+			},
+			isValid: true,
+			expectedOutput: &mongodbflex.RestoreInstanceStatus{
+				Id:       utils.Ptr("new"),
+				BackupID: utils.Ptr(testBackupId),
+				Date:     utils.Ptr("2024-05-14T12:01:11Z"),
+			},
+		},
+		{
+			description: "no job of the backup",
+			listRestoreJobsResp: &mongodbflex.ListRestoreJobsResponse{
+				Items: &[]mongodbflex.RestoreInstanceStatus{
+					{
+						Id:       utils.Ptr("other"),
+						BackupID: utils.Ptr("other-backup"),
+					},
+				},
+			},
+			isValid: true,
+		},
+		{
+			description:         "no jobs",
+			listRestoreJobsResp: &mongodbflex.ListRestoreJobsResponse{},
+			isValid:             true,
+		},
+		{
+			description:          "list restore jobs fails",
+			listRestoreJobsFails: true,
+			isValid:              false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			client := &mongoDBFlexClientMocked{
+				listRestoreJobsFails: tt.listRestoreJobsFails,
+				listRestoreJobsResp:  tt.listRestoreJobsResp,
+			}
+
+			output, err := GetLatestRestoreJob(context.Background(), client, testProjectId, testInstanceId, testBackupId, testRegion)
+
+			if tt.isValid && err != nil {
+				t.Errorf("failed on valid input")
+			}
+			if !tt.isValid && err == nil {
+				t.Errorf("did not fail on invalid input")
+			}
+			if !tt.isValid {
+				return
+			}
+			diff := cmp.Diff(output, tt.expectedOutput)
+			if diff != "" {
+				t.Errorf("Data does not match: %s", diff)
+			}
+		})
+	}
+}
+
+func TestRestoreJobWaitHandler(t *testing.T) {
+	tests := []struct {
+		description   string
+		restoreJob    *mongodbflex.RestoreInstanceStatus
+		previousJobId string
+		isValid       bool
+	}{
+		{
+			description: "finished",
+			restoreJob: &mongodbflex.RestoreInstanceStatus{
+				Id:     utils.Ptr("new"),
+				Status: utils.Ptr("FINISHED"),
+			},
+			previousJobId: "old",
+			isValid:       true,
+		},
+		{
+			description: "broken",
+			restoreJob: &mongodbflex.RestoreInstanceStatus{
+				Id:     utils.Ptr("new"),
+				Status: utils.Ptr("BROKEN"),
+			},
+			isValid: false,
+		},
+		{
+			description: "in progress",
+			restoreJob: &mongodbflex.RestoreInstanceStatus{
+				Id:     utils.Ptr("new"),
+				Status: utils.Ptr("IN_PROGRESS"),
+			},
+			isValid: false,
+		},
+		{
+			description: "only previous job listed",
+			restoreJob: &mongodbflex.RestoreInstanceStatus{
+				Id:     utils.Ptr("old"),
+				Status: utils.Ptr("FINISHED"),
+			},
+			previousJobId: "old",
+			isValid:       false,
+		},
+		{
+			description: "not listed yet",
+			isValid:     false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			resp := &mongodbflex.ListRestoreJobsResponse{Items: &[]mongodbflex.RestoreInstanceStatus{}}
+			if tt.restoreJob != nil {
+				tt.restoreJob.BackupID = utils.Ptr(testBackupId)
+				resp.Items = &[]mongodbflex.RestoreInstanceStatus{*tt.restoreJob}
+			}
+			client := &mongoDBFlexClientMocked{
+				listRestoreJobsResp: resp,
+			}
+
+			handler := RestoreJobWaitHandler(context.Background(), client, testProjectId, testInstanceId, testBackupId, tt.previousJobId, testRegion)
+			_, err := handler.SetSleepBeforeWait(0).SetThrottle(time.Millisecond).SetTimeout(20 * time.Millisecond).WaitWithContext(context.Background())
+
+			if tt.isValid && err != nil {
+				t.Errorf("failed on valid input: %v", err)
+			}
+			if !tt.isValid && err == nil {
+				t.Errorf("did not fail on invalid input")
+			}
+		})
+	}
+}
@@ -0,0 +1,524 @@
+package query
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/stackitcloud/stackit-cli/internal/pkg/print"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/tables"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/transport"
+
+	"github.com/goccy/go-yaml"
+	"github.com/spf13/cobra"
+	"github.com/stackitcloud/stackit-sdk-go/services/observability"
+)
+
+const (
+	UsernameFlag             = "username"
+	TemporaryCredentialsFlag = "temporary-credentials" //nolint:gosec // linter false positive
+
+	// Environment variable from which the password of the credentials given with --username is read
+	PasswordEnv = "STACKIT_OBSERVABILITY_PASSWORD" //nolint:gosec // linter false positive
+
+	// Paths of the Prometheus and Loki HTTP APIs, relative to the metrics and logs URLs of an instance
+	MetricsInstantPath = "/api/v1/query"
+	MetricsRangePath   = "/api/v1/query_range"
+	LogsRangePath      = "/loki/api/v1/query_range"
+
+	// Result types of the Prometheus and Loki HTTP APIs
+	ResultTypeVector  = "vector"
+	ResultTypeMatrix  = "matrix"
+	ResultTypeScalar  = "scalar"
+	ResultTypeString  = "string"
+	ResultTypeStreams = "streams"
+
+	requestTimeout = 2 * time.Minute
+	// Error responses are only read to get their message, anything longer is cut
+	maxErrorBodySize = 4096
+)
+
+// Levels of the sparklines, from the lowest to the highest value
+var sparklineLevels = []rune("▁▂▃▄▅▆▇█")
+
+type CredentialsClient interface {
+	CreateCredentialsExecute(ctx context.Context, instanceId, projectId string) (*observability.CreateCredentialsResponse, error)
+	DeleteCredentialsExecute(ctx context.Context, instanceId, projectId, username string) (*observability.Message, error)
+}
+
+// Credentials are the basic auth credentials of the metrics and logs endpoints of an instance
+type Credentials struct {
+	Username string
+	Password string
+}
+
+// Result is the result of a metrics or logs query. Depending on the result type, either Series, Value or Streams is set.
+type Result struct {
+	ResultType string   `json:"resultType"`
+	Series     []Series `json:"series,omitempty"`
+	Value      *Point   `json:"value,omitempty"`
+	Streams    []Stream `json:"streams,omitempty"`
+}
+
+// Series is a time series, with a single point for instant queries
+type Series struct {
+	Metric map[string]string `json:"metric"`
+	Points []Point           `json:"points"`
+}
+
+// Point is a sample of a time series. The value is kept as returned by the API, which also returns special values such as "NaN".
+type Point struct {
+	Time  time.Time `json:"time"`
+	Value string    `json:"value"`
+}
+
+// Stream is a log stream, with its entries in the order returned by the API
+type Stream struct {
+	Labels  map[string]string `json:"labels"`
+	Entries []Entry           `json:"entries"`
+}
+
+type Entry struct {
+	Time time.Time `json:"time"`
+	Line string    `json:"line"`
+}
+
+// Client queries the Prometheus and Loki HTTP APIs of an instance
+type Client struct {
+	httpClient  *http.Client
+	credentials *Credentials
+}
+
+// apiResponse is the envelope shared by the Prometheus and Loki HTTP APIs
+type apiResponse struct {
+	Status    string          `json:"status"`
+	Data      json.RawMessage `json:"data"`
+	ErrorType string          `json:"errorType"`
+	Error     string          `json:"error"`
+}
+
+type apiData struct {
+	ResultType string          `json:"resultType"`
+	Result     json.RawMessage `json:"result"`
+}
+
+func ConfigureFlags(cmd *cobra.Command) {
+	cmd.Flags().String(UsernameFlag, "", fmt.Sprintf("Username of the credentials to query the instance with, whose password is read from the %s environment variable or prompted for", PasswordEnv))
+	cmd.Flags().Bool(TemporaryCredentialsFlag, false, "Create temporary credentials to query the instance with, which are deleted afterwards")
+
+	cmd.MarkFlagsMutuallyExclusive(UsernameFlag, TemporaryCredentialsFlag)
+	cmd.MarkFlagsOneRequired(UsernameFlag, TemporaryCredentialsFlag)
+}
+
+// GetCredentials returns the credentials to query the instance with.
+// With temporaryCredentials, temporary credentials are created, which are deleted by the returned cleanup function.
+func GetCredentials(ctx context.Context, p *print.Printer, apiClient CredentialsClient, projectId, instanceId, instanceLabel string, username *string, temporaryCredentials, assumeYes bool) (credentials *Credentials, cleanup func(), err error) {
+	if !temporaryCredentials {
+		if username == nil {
+			return nil, nil, fmt.Errorf("either --%s or --%s must be set", UsernameFlag, TemporaryCredentialsFlag)
+		}
+		password, err := password(p, *username)
+		if err != nil {
+			return nil, nil, err
+		}
+		return &Credentials{Username: *username, Password: password}, func() {}, nil
+	}
+
+	if !assumeYes {
+		prompt := fmt.Sprintf("Are you sure you want to create temporary credentials for instance %q to run the query?", instanceLabel)
+		err := p.PromptForConfirmation(prompt)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+	resp, err := apiClient.CreateCredentialsExecute(ctx, instanceId, projectId)
+	if err != nil {
+		return nil, nil, fmt.Errorf("create temporary credentials: %w", err)
+	}
+	if resp == nil || resp.Credentials == nil || resp.Credentials.Username == nil || resp.Credentials.Password == nil {
+		return nil, nil, fmt.Errorf("create temporary credentials: response has no credentials")
+	}
+	credentials = &Credentials{
+		Username: *resp.Credentials.Username,
+		Password: *resp.Credentials.Password,
+	}
+	p.Debug(print.DebugLevel, "created temporary credentials %q", credentials.Username)
+
+	cleanup = func() {
+		_, err := apiClient.DeleteCredentialsExecute(ctx, instanceId, projectId, credentials.Username)
+		if err != nil {
+			p.Warn("delete temporary credentials %q: %v\n", credentials.Username, err)
+			return
+		}
+		p.Debug(print.DebugLevel, "deleted temporary credentials %q", credentials.Username)
+	}
+	return credentials, cleanup, nil
+}
+
+func password(p *print.Printer, username string) (string, error) {
+	if password := os.Getenv(PasswordEnv); password != "" {
+		return password, nil
+	}
+	password, err := p.PromptForPassword(fmt.Sprintf("Password of %q: ", username))
+	if err != nil {
+		return "", err
+	}
+	if password == "" {
+		return "", fmt.Errorf("no password of %q given, set it with the %s environment variable", username, PasswordEnv)
+	}
+	return password, nil
+}
+
+// NewClient returns a client sending its requests through the middleware shared by the service clients of the CLI
+func NewClient(p *print.Printer, credentials *Credentials) *Client {
+	rt := transport.Middleware(p)(http.DefaultTransport)
+	if p.IsVerbosityDebug() {
+		rt = print.RequestResponseCapturer(p, nil)(rt)
+	}
+	return &Client{
+		httpClient: &http.Client{
+			Transport: rt,
+			Timeout:   requestTimeout,
+		},
+		credentials: credentials,
+	}
+}
+
+// Query sends a query to the endpoint, which is the metrics or logs URL of the instance joined with one of the API paths, and parses its result
+func (c *Client) Query(ctx context.Context, endpoint string, params url.Values) (*Result, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, http.NoBody)
+	if err != nil {
+		return nil, fmt.Errorf("build request: %w", err)
+	}
+	req.URL.RawQuery = params.Encode()
+	req.SetBasicAuth(c.credentials.Username, c.credentials.Password)
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("read response: %w", err)
+	}
+	return parseResponse(resp.StatusCode, body)
+}
+
+func parseResponse(statusCode int, body []byte) (*Result, error) {
+	var apiResp apiResponse
+	err := json.Unmarshal(body, &apiResp)
+	if err != nil {
+		if statusCode != http.StatusOK {
+			// Authentication errors, for example, are not returned in the API envelope
+			if len(body) > maxErrorBodySize {
+				body = body[:maxErrorBodySize]
+			}
+			return nil, fmt.Errorf("%d %s: %s", statusCode, http.StatusText(statusCode), strings.TrimSpace(string(body)))
+		}
+		return nil, fmt.Errorf("decode response: %w", err)
+	}
+	if apiResp.Status != "success" {
+		return nil, fmt.Errorf("query failed (%s): %s", apiResp.ErrorType, apiResp.Error)
+	}
+
+	var data apiData
+	err = json.Unmarshal(apiResp.Data, &data)
+	if err != nil {
+		return nil, fmt.Errorf("decode response data: %w", err)
+	}
+	return parseResult(&data)
+}
+
+func parseResult(data *apiData) (*Result, error) {
+	result := &Result{ResultType: data.ResultType}
+	var err error
+	switch data.ResultType {
+	case ResultTypeVector:
+		var vector []struct {
+			Metric map[string]string `json:"metric"`
+			Value  []json.RawMessage `json:"value"`
+		}
+		err = json.Unmarshal(data.Result, &vector)
+		if err != nil {
+			break
+		}
+		result.Series = make([]Series, 0, len(vector))
+		for _, sample := range vector {
+			point, err := parsePoint(sample.Value)
+			if err != nil {
+				return nil, err
+			}
+			result.Series = append(result.Series, Series{Metric: sample.Metric, Points: []Point{*point}})
+		}
+	case ResultTypeMatrix:
+		var matrix []struct {
+			Metric map[string]string   `json:"metric"`
+			Values [][]json.RawMessage `json:"values"`
+		}
+		err = json.Unmarshal(data.Result, &matrix)
+		if err != nil {
+			break
+		}
+		result.Series = make([]Series, 0, len(matrix))
+		for _, samples := range matrix {
+			series := Series{Metric: samples.Metric, Points: make([]Point, 0, len(samples.Values))}
+			for _, value := range samples.Values {
+				point, err := parsePoint(value)
+				if err != nil {
+					return nil, err
+				}
+				series.Points = append(series.Points, *point)
+			}
+			result.Series = append(result.Series, series)
+		}
+	case ResultTypeScalar, ResultTypeString:
+		var value []json.RawMessage
+		err = json.Unmarshal(data.Result, &value)
+		if err != nil {
+			break
+		}
+		result.Value, err = parsePoint(value)
+		if err != nil {
+			return nil, err
+		}
+	case ResultTypeStreams:
+		var streams []struct {
+			Stream map[string]string `json:"stream"`
+			Values [][2]string       `json:"values"`
+		}
+		err = json.Unmarshal(data.Result, &streams)
+		if err != nil {
+			break
+		}
+		result.Streams = make([]Stream, 0, len(streams))
+		for _, s := range streams {
+			stream := Stream{Labels: s.Stream, Entries: make([]Entry, 0, len(s.Values))}
+			for _, value := range s.Values {
+				// Loki returns the timestamps of log entries in nanoseconds
+				ns, err := strconv.ParseInt(value[0], 10, 64)
+				if err != nil {
+					return nil, fmt.Errorf("parse timestamp %q: %w", value[0], err)
+				}
+				stream.Entries = append(stream.Entries, Entry{Time: time.Unix(0, ns).UTC(), Line: value[1]})
+			}
+			result.Streams = append(result.Streams, stream)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported result type %q", data.ResultType)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("decode %s result: %w", data.ResultType, err)
+	}
+	return result, nil
+}
+
+// parsePoint parses a [<unix time in seconds>, "<value>"] pair
+func parsePoint(value []json.RawMessage) (*Point, error) {
+	if len(value) != 2 {
+		return nil, fmt.Errorf("sample has %d elements, expected 2", len(value))
+	}
+	var seconds float64
+	err := json.Unmarshal(value[0], &seconds)
+	if err != nil {
+		return nil, fmt.Errorf("parse sample timestamp: %w", err)
+	}
+	var v string
+	err = json.Unmarshal(value[1], &v)
+	if err != nil {
+		return nil, fmt.Errorf("parse sample value: %w", err)
+	}
+	whole, frac := math.Modf(seconds)
+	return &Point{
+		Time:  time.Unix(int64(whole), int64(math.Round(frac*1e3))*int64(time.Millisecond)).UTC(),
+		Value: v,
+	}, nil
+}
+
+// FormatLabels formats labels in the PromQL selector syntax, with the metric name in front if set
+func FormatLabels(labels map[string]string) string {
+	keys := make([]string, 0, len(labels))
+	for k := range labels {
+		if k != "__name__" {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	pairs := make([]string, 0, len(keys))
+	for _, k := range keys {
+		pairs = append(pairs, fmt.Sprintf("%s=%q", k, labels[k]))
+	}
+	return fmt.Sprintf("%s{%s}", labels["__name__"], strings.Join(pairs, ", "))
+}
+
+// Sparkline renders the values as a line of bars, scaled between the lowest and the highest value.
+// Values that aren't numbers (e.g. "NaN") are rendered as spaces.
+func Sparkline(points []Point) string {
+	values := make([]float64, len(points))
+	low, high := math.Inf(1), math.Inf(-1)
+	for i, point := range points {
+		v, err := strconv.ParseFloat(point.Value, 64)
+		if err != nil || math.IsNaN(v) || math.IsInf(v, 0) {
+			values[i] = math.NaN()
+			continue
+		}
+		values[i] = v
+		low = math.Min(low, v)
+		high = math.Max(high, v)
+	}
+
+	var sb strings.Builder
+	for _, v := range values {
+		switch {
+		case math.IsNaN(v):
+			sb.WriteRune(' ')
+		case high == low:
+			// Flat lines are drawn in the middle
+			sb.WriteRune(sparklineLevels[len(sparklineLevels)/2-1])
+		default:
+			level := int((v - low) / (high - low) * float64(len(sparklineLevels)-1))
+			sb.WriteRune(sparklineLevels[level])
+		}
+	}
+	return sb.String()
+}
+
+// summary returns the lowest, highest and last numeric value of the points
+func summary(points []Point) (low, high, last string) {
+	lowValue, highValue := math.Inf(1), math.Inf(-1)
+	for _, point := range points {
+		v, err := strconv.ParseFloat(point.Value, 64)
+		if err != nil || math.IsNaN(v) {
+			continue
+		}
+		if v < lowValue {
+			lowValue, low = v, point.Value
+		}
+		if v > highValue {
+			highValue, high = v, point.Value
+		}
+	}
+	if len(points) > 0 {
+		last = points[len(points)-1].Value
+	}
+	return low, high, last
+}
+
+// OutputResult prints the result of a metrics or logs query.
+// If sparkline is set, the time series of range queries are rendered as sparklines.
+func OutputResult(p *print.Printer, outputFormat string, sparkline bool, result *Result) error {
+	if result == nil {
+		return fmt.Errorf("result is nil")
+	}
+
+	switch outputFormat {
+	case print.JSONOutputFormat:
+		details, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			return fmt.Errorf("marshal query result: %w", err)
+		}
+		p.Outputln(string(details))
+
+		return nil
+	case print.YAMLOutputFormat:
+		details, err := yaml.MarshalWithOptions(result, yaml.IndentSequence(true))
+		if err != nil {
+			return fmt.Errorf("marshal query result: %w", err)
+		}
+		p.Outputln(string(details))
+
+		return nil
+	}
+
+	switch result.ResultType {
+	case ResultTypeStreams:
+		outputStreams(p, result.Streams)
+		return nil
+	case ResultTypeScalar, ResultTypeString:
+		if result.Value == nil {
+			return fmt.Errorf("%s result has no value", result.ResultType)
+		}
+		table := tables.NewTable()
+		table.SetHeader("VALUE", "TIME")
+		table.AddRow(result.Value.Value, result.Value.Time.Format(time.RFC3339))
+		return table.Display(p)
+	}
+
+	if len(result.Series) == 0 {
+		p.Info("No results found\n")
+		return nil
+	}
+
+	table := tables.NewTable()
+	if result.ResultType == ResultTypeVector {
+		table.SetHeader("SERIES", "VALUE", "TIME")
+		for _, series := range result.Series {
+			value, timestamp := "", ""
+			if len(series.Points) > 0 {
+				value = series.Points[0].Value
+				timestamp = series.Points[0].Time.Format(time.RFC3339)
+			}
+			table.AddRow(FormatLabels(series.Metric), value, timestamp)
+		}
+		return table.Display(p)
+	}
+
+	if sparkline {
+		table.SetHeader("SERIES", "SPARKLINE", "MIN", "MAX", "LAST")
+	} else {
+		table.SetHeader("SERIES", "POINTS", "MIN", "MAX", "LAST")
+	}
+	for _, series := range result.Series {
+		low, high, last := summary(series.Points)
+		if sparkline {
+			table.AddRow(FormatLabels(series.Metric), Sparkline(series.Points), low, high, last)
+		} else {
+			table.AddRow(FormatLabels(series.Metric), len(series.Points), low, high, last)
+		}
+	}
+	return table.Display(p)
+}
+
+// outputStreams prints the log entries of all streams ordered by time, each with the labels of its stream
+func outputStreams(p *print.Printer, streams []Stream) {
+	type line struct {
+		entry  Entry
+		labels string
+	}
+	lines := []line{}
+	for _, stream := range streams {
+		labels := FormatLabels(stream.Labels)
+		for _, entry := range stream.Entries {
+			lines = append(lines, line{entry: entry, labels: labels})
+		}
+	}
+	if len(lines) == 0 {
+		p.Info("No log entries found\n")
+		return
+	}
+	sort.SliceStable(lines, func(i, j int) bool {
+		return lines[i].entry.Time.Before(lines[j].entry.Time)
+	})
+	for _, l := range lines {
+		p.Outputf("%s %s %s\n", l.entry.Time.Format(time.RFC3339Nano), l.labels, strings.TrimRight(l.entry.Line, "\n"))
+	}
+}
+
+// JoinEndpoint joins the metrics or logs URL of an instance with an API path
+func JoinEndpoint(baseURL *string, path string) (string, error) {
+	if baseURL == nil || *baseURL == "" {
+		return "", fmt.Errorf("the instance has no URL for this query")
+	}
+	return strings.TrimSuffix(*baseURL, "/") + path, nil
+}
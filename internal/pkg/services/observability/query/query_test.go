@@ -0,0 +1,521 @@
+package query
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stackitcloud/stackit-cli/internal/pkg/print"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/utils"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/spf13/cobra"
+	"github.com/stackitcloud/stackit-sdk-go/services/observability"
+)
+
+var testTime = time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+type credentialsClientMocked struct {
+	createFails bool
+	createResp  *observability.CreateCredentialsResponse
+	deleteFails bool
+	deleted     []string
+}
+
+func (m *credentialsClientMocked) CreateCredentialsExecute(_ context.Context, _, _ string) (*observability.CreateCredentialsResponse, error) {
+	if m.createFails {
+		return nil, fmt.Errorf("could not create credentials")
+	}
+	return m.createResp, nil
+}
+
+func (m *credentialsClientMocked) DeleteCredentialsExecute(_ context.Context, _, _, username string) (*observability.Message, error) {
+	if m.deleteFails {
+		return nil, fmt.Errorf("could not delete credentials")
+	}
+	m.deleted = append(m.deleted, username)
+	return &observability.Message{}, nil
+}
+
+func TestGetCredentials(t *testing.T) {
+	tests := []struct {
+		description          string
+		username             *string
+		temporaryCredentials bool
+		passwordEnv          string
+		createFails          bool
+		createResp           *observability.CreateCredentialsResponse
+		deleteFails          bool
+		isValid              bool
+		expected             *Credentials
+		expectedDeleted      []string
+	}{
+		{
+			description: "username given",
+			username:    utils.Ptr("my-user"),
+			passwordEnv: "my-password",
+			isValid:     true,
+			expected:    &Credentials{Username: "my-user", Password: "my-password"},
+		},
+		{
+			description: "no credentials given",
+			isValid:     false,
+		},
+		{
+			description:          "temporary credentials",
+			temporaryCredentials: true,
+			createResp: &observability.CreateCredentialsResponse{
+				Credentials: &observability.Credentials{
+					Username: utils.Ptr("temporary-user"),
+					Password: utils.Ptr("temporary-password"),
+				},
+			},
+			isValid:         true,
+			expected:        &Credentials{Username: "temporary-user", Password: "temporary-password"},
+			expectedDeleted: []string{"temporary-user"},
+		},
+		{
+			description:          "temporary credentials not deleted",
+			temporaryCredentials: true,
+			createResp: &observability.CreateCredentialsResponse{
+				Credentials: &observability.Credentials{
+					Username: utils.Ptr("temporary-user"),
+					Password: utils.Ptr("temporary-password"),
+				},
+			},
+			deleteFails: true,
+			isValid:     true,
+			expected:    &Credentials{Username: "temporary-user", Password: "temporary-password"},
+		},
+		{
+			description:          "create fails",
+			temporaryCredentials: true,
+			createFails:          true,
+			isValid:              false,
+		},
+		{
+			description:          "create response without credentials",
+			temporaryCredentials: true,
+			createResp:           &observability.CreateCredentialsResponse{},
+			isValid:              false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			t.Setenv(PasswordEnv, tt.passwordEnv)
+			p := print.NewPrinter()
+			p.Cmd = &cobra.Command{}
+			client := &credentialsClientMocked{
+				createFails: tt.createFails,
+				createResp:  tt.createResp,
+				deleteFails: tt.deleteFails,
+			}
+
+			credentials, cleanup, err := GetCredentials(context.Background(), p, client, "project-id", "instance-id", "my-instance", tt.username, tt.temporaryCredentials, true)
+			if !tt.isValid {
+				if err == nil {
+					t.Fatalf("did not fail on invalid input")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("failed on valid input: %v", err)
+			}
+			cleanup()
+
+			diff := cmp.Diff(credentials, tt.expected)
+			if diff != "" {
+				t.Fatalf("Credentials do not match: %s", diff)
+			}
+			diff = cmp.Diff(client.deleted, tt.expectedDeleted)
+			if diff != "" {
+				t.Fatalf("Deleted credentials do not match: %s", diff)
+			}
+		})
+	}
+}
+
+func TestParseResponse(t *testing.T) {
+	tests := []struct {
+		description string
+		statusCode  int
+		body        string
+		isValid     bool
+		expected    *Result
+	}{
+		{
+			description: "vector",
+			statusCode:  http.StatusOK,
+			body:        `{"status":"success","data":{"resultType":"vector","result":[{"metric":{"__name__":"up","job":"api"},"value":[1704110400,"1"]}]}}`,
+			isValid:     true,
+			expected: &Result{
+				ResultType: ResultTypeVector,
+				Series: []Series{
+					{
+						Metric: map[string]string{"__name__": "up", "job": "api"},
+						Points: []Point{{Time: testTime, Value: "1"}},
+					},
+				},
+			},
+		},
+		{
+			description: "matrix",
+			statusCode:  http.StatusOK,
+			body:        `{"status":"success","data":{"resultType":"matrix","result":[{"metric":{"job":"api"},"values":[[1704110400,"1"],[1704110430.5,"NaN"]]}]}}`,
+			isValid:     true,
+			expected: &Result{
+				ResultType: ResultTypeMatrix,
+				Series: []Series{
+					{
+						Metric: map[string]string{"job": "api"},
+						Points: []Point{
+							{Time: testTime, Value: "1"},
+							{Time: testTime.Add(30500 * time.Millisecond), Value: "NaN"},
+						},
+					},
+				},
+			},
+		},
+		{
+			description: "empty matrix",
+			statusCode:  http.StatusOK,
+			body:        `{"status":"success","data":{"resultType":"matrix","result":[]}}`,
+			isValid:     true,
+			expected: &Result{
+				ResultType: ResultTypeMatrix,
+				Series:     []Series{},
+			},
+		},
+		{
+			description: "scalar",
+			statusCode:  http.StatusOK,
+			body:        `{"status":"success","data":{"resultType":"scalar","result":[1704110400,"42"]}}`,
+			isValid:     true,
+			expected: &Result{
+				ResultType: ResultTypeScalar,
+				Value:      &Point{Time: testTime, Value: "42"},
+			},
+		},
+		{
+			description: "streams",
+			statusCode:  http.StatusOK,
+			body:        `{"status":"success","data":{"resultType":"streams","result":[{"stream":{"app":"api"},"values":[["1704110400000000001","second"],["1704110400000000000","first"]]}]}}`,
+			isValid:     true,
+			expected: &Result{
+				ResultType: ResultTypeStreams,
+				Streams: []Stream{
+					{
+						Labels: map[string]string{"app": "api"},
+						Entries: []Entry{
+							{Time: testTime.Add(time.Nanosecond), Line: "second"},
+							{Time: testTime, Line: "first"},
+						},
+					},
+				},
+			},
+		},
+		{
+			description: "query error",
+			statusCode:  http.StatusBadRequest,
+			body:        `{"status":"error","errorType":"bad_data","error":"parse error"}`,
+			isValid:     false,
+		},
+		{
+			description: "error without envelope",
+			statusCode:  http.StatusUnauthorized,
+			body:        `Unauthorized`,
+			isValid:     false,
+		},
+		{
+			description: "invalid body",
+			statusCode:  http.StatusOK,
+			body:        `not json`,
+			isValid:     false,
+		},
+		{
+			description: "unsupported result type",
+			statusCode:  http.StatusOK,
+			body:        `{"status":"success","data":{"resultType":"unknown","result":[]}}`,
+			isValid:     false,
+		},
+		{
+			description: "invalid sample",
+			statusCode:  http.StatusOK,
+			body:        `{"status":"success","data":{"resultType":"vector","result":[{"metric":{},"value":[1704110400]}]}}`,
+			isValid:     false,
+		},
+		{
+			description: "invalid log timestamp",
+			statusCode:  http.StatusOK,
+			body:        `{"status":"success","data":{"resultType":"streams","result":[{"stream":{},"values":[["yesterday","line"]]}]}}`,
+			isValid:     false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			result, err := parseResponse(tt.statusCode, []byte(tt.body))
+			if !tt.isValid {
+				if err == nil {
+					t.Fatalf("did not fail on invalid input")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("failed on valid input: %v", err)
+			}
+			diff := cmp.Diff(result, tt.expected)
+			if diff != "" {
+				t.Fatalf("Data does not match: %s", diff)
+			}
+		})
+	}
+}
+
+func TestQuery(t *testing.T) {
+	var gotQuery url.Values
+	var gotUsername, gotPassword string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != MetricsInstantPath {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		gotQuery = r.URL.Query()
+		gotUsername, gotPassword, _ = r.BasicAuth()
+		_, _ = w.Write([]byte(`{"status":"success","data":{"resultType":"scalar","result":[1704110400,"1"]}}`))
+	}))
+	defer server.Close()
+
+	p := print.NewPrinter()
+	p.Cmd = &cobra.Command{}
+	client := NewClient(p, &Credentials{Username: "my-user", Password: "my-password"})
+	endpoint, err := JoinEndpoint(utils.Ptr(server.URL+"/"), MetricsInstantPath)
+	if err != nil {
+		t.Fatalf("join endpoint: %v", err)
+	}
+
+	result, err := client.Query(context.Background(), endpoint, url.Values{"query": []string{"1"}})
+	if err != nil {
+		t.Fatalf("query failed: %v", err)
+	}
+	if result.ResultType != ResultTypeScalar {
+		t.Fatalf("expected result type %q, got %q", ResultTypeScalar, result.ResultType)
+	}
+	if gotQuery.Get("query") != "1" {
+		t.Fatalf("expected query %q, got %q", "1", gotQuery.Get("query"))
+	}
+	if gotUsername != "my-user" || gotPassword != "my-password" {
+		t.Fatalf("expected basic auth of %q, got %q", "my-user", gotUsername)
+	}
+}
+
+func TestJoinEndpoint(t *testing.T) {
+	tests := []struct {
+		description string
+		baseURL     *string
+		isValid     bool
+		expected    string
+	}{
+		{
+			description: "base",
+			baseURL:     utils.Ptr("https://example.com/instances/xxx"),
+			isValid:     true,
+			expected:    "https://example.com/instances/xxx/api/v1/query",
+		},
+		{
+			description: "trailing slash",
+			baseURL:     utils.Ptr("https://example.com/instances/xxx/"),
+			isValid:     true,
+			expected:    "https://example.com/instances/xxx/api/v1/query",
+		},
+		{
+			description: "nil",
+			isValid:     false,
+		},
+		{
+			description: "empty",
+			baseURL:     utils.Ptr(""),
+			isValid:     false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			endpoint, err := JoinEndpoint(tt.baseURL, MetricsInstantPath)
+			if !tt.isValid {
+				if err == nil {
+					t.Fatalf("did not fail on invalid input")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("failed on valid input: %v", err)
+			}
+			if endpoint != tt.expected {
+				t.Fatalf("expected %q, got %q", tt.expected, endpoint)
+			}
+		})
+	}
+}
+
+func TestFormatLabels(t *testing.T) {
+	tests := []struct {
+		description string
+		labels      map[string]string
+		expected    string
+	}{
+		{
+			description: "empty",
+			expected:    "{}",
+		},
+		{
+			description: "name only",
+			labels:      map[string]string{"__name__": "up"},
+			expected:    "up{}",
+		},
+		{
+			description: "name and labels",
+			labels:      map[string]string{"__name__": "up", "job": "api", "instance": "host:9090"},
+			expected:    `up{instance="host:9090", job="api"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			got := FormatLabels(tt.labels)
+			if got != tt.expected {
+				t.Fatalf("expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestSparkline(t *testing.T) {
+	tests := []struct {
+		description string
+		values      []string
+		expected    string
+	}{
+		{
+			description: "empty",
+			expected:    "",
+		},
+		{
+			description: "rising",
+			values:      []string{"0", "1", "2", "3", "4", "5", "6", "7"},
+			expected:    "▁▂▃▄▅▆▇█",
+		},
+		{
+			description: "flat",
+			values:      []string{"3", "3", "3"},
+			expected:    "▄▄▄",
+		},
+		{
+			description: "gaps",
+			values:      []string{"0", "NaN", "10", "+Inf"},
+			expected:    "▁ █ ",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			points := make([]Point, len(tt.values))
+			for i, v := range tt.values {
+				points[i] = Point{Time: testTime, Value: v}
+			}
+			got := Sparkline(points)
+			if got != tt.expected {
+				t.Fatalf("expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestOutputResult(t *testing.T) {
+	series := []Series{
+		{
+			Metric: map[string]string{"__name__": "up"},
+			Points: []Point{{Time: testTime, Value: "1"}, {Time: testTime.Add(time.Minute), Value: "0"}},
+		},
+	}
+	tests := []struct {
+		description  string
+		outputFormat string
+		sparkline    bool
+		result       *Result
+		wantErr      bool
+	}{
+		{
+			description: "empty",
+			wantErr:     true,
+		},
+		{
+			description: "empty result",
+			result:      &Result{},
+		},
+		{
+			description: "vector",
+			result:      &Result{ResultType: ResultTypeVector, Series: series},
+		},
+		{
+			description: "matrix",
+			result:      &Result{ResultType: ResultTypeMatrix, Series: series},
+		},
+		{
+			description: "matrix as sparklines",
+			sparkline:   true,
+			result:      &Result{ResultType: ResultTypeMatrix, Series: series},
+		},
+		{
+			description: "series without points",
+			result:      &Result{ResultType: ResultTypeVector, Series: []Series{{}}},
+		},
+		{
+			description: "scalar",
+			result:      &Result{ResultType: ResultTypeScalar, Value: &Point{Time: testTime, Value: "1"}},
+		},
+		{
+			description: "scalar without value",
+			result:      &Result{ResultType: ResultTypeScalar},
+			wantErr:     true,
+		},
+		{
+			description: "streams",
+			result: &Result{
+				ResultType: ResultTypeStreams,
+				Streams: []Stream{
+					{Labels: map[string]string{"app": "api"}, Entries: []Entry{{Time: testTime, Line: "line\n"}}},
+				},
+			},
+		},
+		{
+			description: "empty streams",
+			result:      &Result{ResultType: ResultTypeStreams},
+		},
+		{
+			description:  "json",
+			outputFormat: print.JSONOutputFormat,
+			result:       &Result{ResultType: ResultTypeMatrix, Series: series},
+		},
+		{
+			description:  "yaml",
+			outputFormat: print.YAMLOutputFormat,
+			result:       &Result{ResultType: ResultTypeMatrix, Series: series},
+		},
+	}
+	p := print.NewPrinter()
+	p.Cmd = &cobra.Command{}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			if err := OutputResult(p, tt.outputFormat, tt.sparkline, tt.result); (err != nil) != tt.wantErr {
+				t.Errorf("OutputResult() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -2,18 +2,30 @@ package utils
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"regexp"
 	"strings"
+	"time"
 
 	"github.com/stackitcloud/stackit-cli/internal/pkg/errors"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/utils"
+
+	"github.com/goccy/go-yaml"
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/prometheus/promql/parser"
 	"github.com/stackitcloud/stackit-sdk-go/services/observability"
 )
 
 const (
 	service = "observability"
+
+	// Shortest evaluation interval the API accepts for alert groups
+	minAlertGroupInterval = time.Minute
 )
 
+var alertGroupNameRegex = regexp.MustCompile(`^[a-zA-Z0-9-]+$`)
+
 type ObservabilityClient interface {
 	GetInstanceExecute(ctx context.Context, instanceId, projectId string) (*observability.GetInstanceResponse, error)
 	GetGrafanaConfigsExecute(ctx context.Context, instanceId, projectId string) (*observability.GrafanaConfigs, error)
@@ -36,6 +48,46 @@ var (
 		ScrapeTimeout:  utils.Ptr("2m"),
 		StaticConfigs:  utils.Ptr(defaultStaticConfigs),
 	}
+
+	defaultAlertRules = []observability.UpdateAlertgroupsRequestInnerRulesInner{
+		{
+			Alert: utils.Ptr("InstanceDown"),
+			Expr:  utils.Ptr("up == 0"),
+			For:   utils.Ptr("5m"),
+			Labels: &map[string]interface{}{
+				"severity": "critical",
+			},
+			Annotations: &map[string]interface{}{
+				"summary": "Instance {{ $labels.instance }} is down",
+			},
+		},
+	}
+	DefaultCreateAlertGroupPayload = observability.CreateAlertgroupsPayload{
+		Name:     utils.Ptr("default-name"),
+		Interval: utils.Ptr("5m"),
+		Rules:    utils.Ptr(defaultAlertRules),
+	}
+
+	defaultAlertReceivers = []observability.UpdateAlertConfigsPayloadReceiversInner{
+		{
+			Name: utils.Ptr("default-receiver"),
+			EmailConfigs: &[]observability.CreateAlertConfigReceiverPayloadEmailConfigsInner{
+				{
+					To: utils.Ptr("alerts@example.com"),
+				},
+			},
+		},
+	}
+	DefaultUpdateAlertConfigsPayload = observability.UpdateAlertConfigsPayload{
+		Receivers: utils.Ptr(defaultAlertReceivers),
+		Route: &observability.UpdateAlertConfigsPayloadRoute{
+			Receiver:       utils.Ptr("default-receiver"),
+			GroupBy:        &[]string{"alertname"},
+			GroupWait:      utils.Ptr("30s"),
+			GroupInterval:  utils.Ptr("5m"),
+			RepeatInterval: utils.Ptr("4h"),
+		},
+	}
 )
 
 func ValidatePlanId(planId string, resp *observability.PlansResponse) error {
@@ -248,3 +300,312 @@ func GetPartialUpdateGrafanaConfigsPayload(ctx context.Context, apiClient Observ
 
 	return payload, nil
 }
+
+// ParsePayload decodes a payload given as JSON or YAML into target, which is one of the API payload types
+func ParsePayload(payload string, target any) error {
+	// YAML is a superset of JSON, so both are handled by converting to JSON first
+	jsonPayload, err := yaml.YAMLToJSON([]byte(payload))
+	if err != nil {
+		return fmt.Errorf("parse payload: %w", err)
+	}
+	err = json.Unmarshal(jsonPayload, target)
+	if err != nil {
+		return fmt.Errorf("decode payload: %w", err)
+	}
+	return nil
+}
+
+// ValidatePromQL parses a PromQL expression with the Prometheus parser before it is sent to the API,
+// so that e.g. unknown functions or misplaced operators are reported without a request
+func ValidatePromQL(expr string) error {
+	_, err := parser.ParseExpr(expr)
+	return err
+}
+
+// CheckLogQLSyntaxShallow does a shallow syntax check of a LogQL expression before it is sent to the API.
+// LogQL shares the lexical structure of PromQL, but there is no parser for it without the Loki module:
+// the expression must not be empty, string literals must be terminated, brackets must be balanced
+// and range selectors and subqueries must hold valid durations.
+// It doesn't parse the expression, so e.g. unknown functions or misplaced operators are only reported by the API.
+func CheckLogQLSyntaxShallow(expr string) error {
+	closing := map[rune]rune{'(': ')', '{': '}', '[': ']'}
+	type opener struct {
+		char rune
+		pos  int
+	}
+	var stack []opener
+	empty := true
+
+	runes := []rune(expr)
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		switch r {
+		case ' ', '\t', '\n', '\r':
+			continue
+		case '#':
+			// Comments run until the end of the line
+			for i < len(runes) && runes[i] != '\n' {
+				i++
+			}
+			continue
+		}
+		empty = false
+
+		switch r {
+		case '"', '\'', '`':
+			start := i
+			for i++; i < len(runes) && runes[i] != r; i++ {
+				if runes[i] == '\\' && r != '`' {
+					i++
+				}
+			}
+			if i >= len(runes) {
+				return fmt.Errorf("unterminated string literal at position %d", start+1)
+			}
+		case '(', '{', '[':
+			stack = append(stack, opener{char: r, pos: i})
+		case ')', '}', ']':
+			if len(stack) == 0 || closing[stack[len(stack)-1].char] != r {
+				return fmt.Errorf("unexpected %q at position %d", r, i+1)
+			}
+			open := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			if r == ']' {
+				err := validateRange(string(runes[open.pos+1 : i]))
+				if err != nil {
+					return fmt.Errorf("invalid range at position %d: %w", open.pos+1, err)
+				}
+			}
+		}
+	}
+
+	if empty {
+		return fmt.Errorf("expression is empty")
+	}
+	if len(stack) > 0 {
+		open := stack[len(stack)-1]
+		return fmt.Errorf("unclosed %q at position %d", open.char, open.pos+1)
+	}
+	return nil
+}
+
+// validateRange checks the content of a range selector ("5m") or subquery ("1h:5m") bracket
+func validateRange(rangeStr string) error {
+	rangeDuration, resolution, isSubquery := strings.Cut(rangeStr, ":")
+	rangeDuration = strings.TrimSpace(rangeDuration)
+	if rangeDuration == "" {
+		return fmt.Errorf("missing duration")
+	}
+	if _, err := model.ParseDuration(rangeDuration); err != nil {
+		return err
+	}
+	resolution = strings.TrimSpace(resolution)
+	if isSubquery && resolution != "" {
+		if _, err := model.ParseDuration(resolution); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func ValidateAlertGroupName(name *string) error {
+	if name == nil || *name == "" {
+		return fmt.Errorf("alert group name is required")
+	}
+	if !alertGroupNameRegex.MatchString(*name) {
+		return fmt.Errorf("alert group name %q may only contain letters, digits and hyphens", *name)
+	}
+	return nil
+}
+
+// ValidateAlertGroup checks the interval and rules of an alert group, parsing the PromQL expressions of the rules
+func ValidateAlertGroup(interval *string, rules *[]observability.UpdateAlertgroupsRequestInnerRulesInner) error {
+	if interval != nil {
+		duration, err := model.ParseDuration(*interval)
+		if err != nil {
+			return fmt.Errorf("invalid interval %q: %w", *interval, err)
+		}
+		if time.Duration(duration) < minAlertGroupInterval {
+			return fmt.Errorf("interval %q is shorter than %s", *interval, model.Duration(minAlertGroupInterval))
+		}
+	}
+
+	if rules == nil || len(*rules) == 0 {
+		return fmt.Errorf("at least one rule is required")
+	}
+	for i, rule := range *rules {
+		if rule.Alert == nil || *rule.Alert == "" {
+			return fmt.Errorf("rule #%d: alert name is required", i+1)
+		}
+		if rule.Expr == nil {
+			return fmt.Errorf("rule %q: expression is required", *rule.Alert)
+		}
+		if err := ValidatePromQL(*rule.Expr); err != nil {
+			return fmt.Errorf("rule %q: invalid expression: %w", *rule.Alert, err)
+		}
+		if rule.For != nil {
+			if _, err := model.ParseDuration(*rule.For); err != nil {
+				return fmt.Errorf("rule %q: invalid duration %q: %w", *rule.Alert, *rule.For, err)
+			}
+		}
+	}
+	return nil
+}
+
+// ValidateAlertConfigsPayload checks that the receivers are unique, every route points to an existing receiver
+// and all durations are valid
+func ValidateAlertConfigsPayload(payload *observability.UpdateAlertConfigsPayload) error {
+	if payload == nil {
+		return fmt.Errorf("payload is empty")
+	}
+
+	if payload.Receivers == nil || len(*payload.Receivers) == 0 {
+		return fmt.Errorf("at least one receiver is required")
+	}
+	receivers := map[string]bool{}
+	for i, receiver := range *payload.Receivers {
+		if receiver.Name == nil || *receiver.Name == "" {
+			return fmt.Errorf("receiver #%d: name is required", i+1)
+		}
+		if receivers[*receiver.Name] {
+			return fmt.Errorf("receiver %q is defined more than once", *receiver.Name)
+		}
+		receivers[*receiver.Name] = true
+	}
+
+	if payload.Global != nil && payload.Global.ResolveTimeout != nil {
+		if _, err := model.ParseDuration(*payload.Global.ResolveTimeout); err != nil {
+			return fmt.Errorf("global: invalid resolveTimeout %q: %w", *payload.Global.ResolveTimeout, err)
+		}
+	}
+
+	if payload.Route == nil {
+		return fmt.Errorf("route is required")
+	}
+	if payload.Route.Receiver == nil || *payload.Route.Receiver == "" {
+		return fmt.Errorf("route: receiver is required")
+	}
+	// The nested routes are typed differently on each level, a generic representation allows checking them all the same way
+	routeJSON, err := json.Marshal(payload.Route)
+	if err != nil {
+		return fmt.Errorf("marshal route: %w", err)
+	}
+	var route map[string]interface{}
+	err = json.Unmarshal(routeJSON, &route)
+	if err != nil {
+		return fmt.Errorf("unmarshal route: %w", err)
+	}
+	return validateAlertRoute(route, receivers, "route")
+}
+
+func validateAlertRoute(route map[string]interface{}, receivers map[string]bool, path string) error {
+	if receiver, ok := route["receiver"]; ok {
+		receiverName, isString := receiver.(string)
+		if !isString {
+			return fmt.Errorf("%s: receiver must be a name", path)
+		}
+		if !receivers[receiverName] {
+			return fmt.Errorf("%s: receiver %q is not defined", path, receiverName)
+		}
+	}
+	for _, field := range []string{"groupWait", "groupInterval", "repeatInterval"} {
+		value, ok := route[field]
+		if !ok {
+			continue
+		}
+		durationStr, isString := value.(string)
+		if !isString {
+			return fmt.Errorf("%s: %s must be a duration", path, field)
+		}
+		if _, err := model.ParseDuration(durationStr); err != nil {
+			return fmt.Errorf("%s: invalid %s %q: %w", path, field, durationStr, err)
+		}
+	}
+
+	subRoutes, ok := route["routes"]
+	if !ok {
+		return nil
+	}
+	subRoutesList, isList := subRoutes.([]interface{})
+	if !isList {
+		return fmt.Errorf("%s: routes must be a list", path)
+	}
+	for i, subRoute := range subRoutesList {
+		subRouteMap, isMap := subRoute.(map[string]interface{})
+		if !isMap {
+			return fmt.Errorf("%s.routes[%d]: must be an object", path, i)
+		}
+		if err := validateAlertRoute(subRouteMap, receivers, fmt.Sprintf("%s.routes[%d]", path, i)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func MapToUpdateAlertGroupPayload(resp *observability.AlertGroupResponse) (*observability.UpdateAlertgroupPayload, error) {
+	if resp == nil || resp.Data == nil {
+		return nil, fmt.Errorf("no Observability alert group provided")
+	}
+
+	var rules []observability.UpdateAlertgroupsRequestInnerRulesInner
+	if resp.Data.Rules != nil {
+		for _, rule := range *resp.Data.Rules {
+			if rule.Record != nil {
+				return nil, fmt.Errorf("rule %q is a recording rule, which is not supported in alert group payloads", *rule.Record)
+			}
+			rules = append(rules, observability.UpdateAlertgroupsRequestInnerRulesInner{
+				Alert:       rule.Alert,
+				Annotations: utils.ConvertStringMapToInterfaceMap(rule.Annotations),
+				Expr:        rule.Expr,
+				For:         rule.For,
+				Labels:      utils.ConvertStringMapToInterfaceMap(rule.Labels),
+			})
+		}
+	}
+
+	return &observability.UpdateAlertgroupPayload{
+		Interval: resp.Data.Interval,
+		Rules:    &rules,
+	}, nil
+}
+
+func MapToUpdateAlertConfigsPayload(resp *observability.GetAlertConfigsResponse) (*observability.UpdateAlertConfigsPayload, error) {
+	if resp == nil || resp.Data == nil {
+		return nil, fmt.Errorf("no Observability alert config provided")
+	}
+
+	// The payload types have the same fields as the response, except for the inhibit rules,
+	// which the payload only takes as a single rule
+	alert := *resp.Data
+	inhibitRules := alert.InhibitRules
+	alert.InhibitRules = nil
+
+	alertJSON, err := json.Marshal(alert)
+	if err != nil {
+		return nil, fmt.Errorf("marshal alert config: %w", err)
+	}
+	var payload observability.UpdateAlertConfigsPayload
+	err = json.Unmarshal(alertJSON, &payload)
+	if err != nil {
+		return nil, fmt.Errorf("map alert config: %w", err)
+	}
+
+	if inhibitRules != nil && len(*inhibitRules) > 0 {
+		if len(*inhibitRules) > 1 {
+			return nil, fmt.Errorf("the alert config has %d inhibit rules, but the API payload supports a single one", len(*inhibitRules))
+		}
+		rule := (*inhibitRules)[0]
+		if rule.SourceMatchers != nil || rule.TargetMatchers != nil {
+			return nil, fmt.Errorf("the inhibit rule of the alert config uses matchers, which the API payload doesn't support")
+		}
+		payload.InhibitRules = &observability.UpdateAlertConfigsPayloadInhibitRules{
+			Equal:         rule.Equal,
+			SourceMatch:   utils.ConvertStringMapToInterfaceMap(rule.SourceMatch),
+			SourceMatchRe: utils.ConvertStringMapToInterfaceMap(rule.SourceMatchRe),
+			TargetMatch:   utils.ConvertStringMapToInterfaceMap(rule.TargetMatch),
+			TargetMatchRe: utils.ConvertStringMapToInterfaceMap(rule.TargetMatchRe),
+		}
+	}
+
+	return &payload, nil
+}
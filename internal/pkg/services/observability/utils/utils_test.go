@@ -894,3 +894,777 @@ func TestGetPartialUpdateGrafanaConfigsPayload(t *testing.T) {
 		})
 	}
 }
+
+func TestParsePayload(t *testing.T) {
+	tests := []struct {
+		description     string
+		payload         string
+		isValid         bool
+		expectedPayload *observability.CreateAlertgroupsPayload
+	}{
+		{
+			description: "json",
+			payload:     `{"name": "group", "interval": "5m", "rules": [{"alert": "Down", "expr": "up == 0"}]}`,
+			isValid:     true,
+			expectedPayload: &observability.CreateAlertgroupsPayload{
+				Name:     utils.Ptr("group"),
+				Interval: utils.Ptr("5m"),
+				Rules: &[]observability.UpdateAlertgroupsRequestInnerRulesInner{
+					{
+						Alert: utils.Ptr("Down"),
+						Expr:  utils.Ptr("up == 0"),
+					},
+				},
+			},
+		},
+		{
+			description: "yaml",
+			payload: `name: group
+interval: 5m
+rules:
+  - alert: Down
+    expr: up == 0
+    labels:
+      severity: critical
+`,
+			isValid: true,
+			expectedPayload: &observability.CreateAlertgroupsPayload{
+				Name:     utils.Ptr("group"),
+				Interval: utils.Ptr("5m"),
+				Rules: &[]observability.UpdateAlertgroupsRequestInnerRulesInner{
+					{
+						Alert: utils.Ptr("Down"),
+						Expr:  utils.Ptr("up == 0"),
+						Labels: &map[string]interface{}{
+							"severity": "critical",
+						},
+					},
+				},
+			},
+		},
+		{
+			description: "invalid yaml",
+			payload:     "name: group\n  interval: 5m\n rules: [",
+			isValid:     false,
+		},
+		{
+			description: "wrong type",
+			payload:     `{"name": ["group"]}`,
+			isValid:     false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			payload := &observability.CreateAlertgroupsPayload{}
+			err := ParsePayload(tt.payload, payload)
+			if !tt.isValid {
+				if err == nil {
+					t.Fatalf("did not fail on invalid input")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("failed on valid input: %v", err)
+			}
+			diff := cmp.Diff(payload, tt.expectedPayload)
+			if diff != "" {
+				t.Fatalf("Data does not match: %s", diff)
+			}
+		})
+	}
+}
+
+func TestValidatePromQL(t *testing.T) {
+	tests := []struct {
+		description string
+		expr        string
+		isValid     bool
+	}{
+		{
+			description: "simple",
+			expr:        "up == 0",
+			isValid:     true,
+		},
+		{
+			description: "functions, matchers and range",
+			expr:        `sum by (job) (rate(http_requests_total{code=~"5..", path!="/{id}"}[5m])) > 0.1`,
+			isValid:     true,
+		},
+		{
+			description: "subquery",
+			expr:        "max_over_time(rate(errors_total[1m])[1h:5m]) > 10",
+			isValid:     true,
+		},
+		{
+			description: "empty",
+			expr:        "",
+			isValid:     false,
+		},
+		{
+			description: "unclosed parenthesis",
+			expr:        "sum(rate(errors_total[5m])",
+			isValid:     false,
+		},
+		{
+			description: "unknown function",
+			expr:        "rates(errors_total[5m])",
+			isValid:     false,
+		},
+		{
+			description: "misplaced operator",
+			expr:        "up == == 0",
+			isValid:     false,
+		},
+		{
+			description: "range vector where an instant vector is expected",
+			expr:        "errors_total[5m] > 1",
+			isValid:     false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			err := ValidatePromQL(tt.expr)
+			if tt.isValid && err != nil {
+				t.Errorf("failed on valid input: %v", err)
+			}
+			if !tt.isValid && err == nil {
+				t.Errorf("did not fail on invalid input")
+			}
+		})
+	}
+}
+
+func TestCheckLogQLSyntaxShallow(t *testing.T) {
+	tests := []struct {
+		description string
+		expr        string
+		isValid     bool
+	}{
+		{
+			description: "simple",
+			expr:        "up == 0",
+			isValid:     true,
+		},
+		{
+			description: "line filters",
+			expr:        `{app="my-app"} |= "error" |~ "status=[45].."`,
+			isValid:     true,
+		},
+		{
+			description: "functions, matchers and range",
+			expr:        `sum by (job) (rate(http_requests_total{code=~"5..", path!="/{id}"}[5m])) > 0.1`,
+			isValid:     true,
+		},
+		{
+			description: "subquery",
+			expr:        "max_over_time(rate(errors_total[1m])[1h:5m]) > 10",
+			isValid:     true,
+		},
+		{
+			description: "subquery with default resolution",
+			expr:        "max_over_time(rate(errors_total[1m])[1h:])",
+			isValid:     true,
+		},
+		{
+			description: "escaped quote in string",
+			expr:        `up{job="a\"b"} == 0`,
+			isValid:     true,
+		},
+		{
+			description: "comment",
+			expr:        "up == 0 # unbalanced ( in comment",
+			isValid:     true,
+		},
+		{
+			description: "empty",
+			expr:        "",
+			isValid:     false,
+		},
+		{
+			description: "only whitespace and comments",
+			expr:        "  # nothing\n",
+			isValid:     false,
+		},
+		{
+			description: "unclosed parenthesis",
+			expr:        "sum(rate(errors_total[5m])",
+			isValid:     false,
+		},
+		{
+			description: "unexpected closing bracket",
+			expr:        "up == 0)",
+			isValid:     false,
+		},
+		{
+			description: "mismatched brackets",
+			expr:        "sum(up})",
+			isValid:     false,
+		},
+		{
+			description: "unterminated string",
+			expr:        `up{job="api} == 0`,
+			isValid:     false,
+		},
+		{
+			description: "invalid range duration",
+			expr:        "rate(errors_total[5 minutes])",
+			isValid:     false,
+		},
+		{
+			description: "empty range",
+			expr:        "rate(errors_total[])",
+			isValid:     false,
+		},
+		{
+			description: "invalid subquery resolution",
+			expr:        "max_over_time(up[1h:x])",
+			isValid:     false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			err := CheckLogQLSyntaxShallow(tt.expr)
+			if tt.isValid && err != nil {
+				t.Errorf("failed on valid input: %v", err)
+			}
+			if !tt.isValid && err == nil {
+				t.Errorf("did not fail on invalid input")
+			}
+		})
+	}
+}
+
+func TestValidateAlertGroupName(t *testing.T) {
+	tests := []struct {
+		description string
+		name        *string
+		isValid     bool
+	}{
+		{
+			description: "base",
+			name:        utils.Ptr("my-Group-01"),
+			isValid:     true,
+		},
+		{
+			description: "nil",
+			name:        nil,
+			isValid:     false,
+		},
+		{
+			description: "empty",
+			name:        utils.Ptr(""),
+			isValid:     false,
+		},
+		{
+			description: "invalid characters",
+			name:        utils.Ptr("my_group"),
+			isValid:     false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			err := ValidateAlertGroupName(tt.name)
+			if tt.isValid && err != nil {
+				t.Errorf("failed on valid input: %v", err)
+			}
+			if !tt.isValid && err == nil {
+				t.Errorf("did not fail on invalid input")
+			}
+		})
+	}
+}
+
+func fixtureAlertRules(mods ...func(rule *observability.UpdateAlertgroupsRequestInnerRulesInner)) *[]observability.UpdateAlertgroupsRequestInnerRulesInner {
+	rule := observability.UpdateAlertgroupsRequestInnerRulesInner{
+		Alert: utils.Ptr("HighErrorRate"),
+		Expr:  utils.Ptr("rate(errors_total[5m]) > 1"),
+		For:   utils.Ptr("10m"),
+	}
+	for _, mod := range mods {
+		mod(&rule)
+	}
+	return &[]observability.UpdateAlertgroupsRequestInnerRulesInner{rule}
+}
+
+func TestValidateAlertGroup(t *testing.T) {
+	tests := []struct {
+		description string
+		interval    *string
+		rules       *[]observability.UpdateAlertgroupsRequestInnerRulesInner
+		isValid     bool
+	}{
+		{
+			description: "base",
+			interval:    utils.Ptr("1m"),
+			rules:       fixtureAlertRules(),
+			isValid:     true,
+		},
+		{
+			description: "no interval",
+			rules:       fixtureAlertRules(),
+			isValid:     true,
+		},
+		{
+			description: "default payload",
+			interval:    DefaultCreateAlertGroupPayload.Interval,
+			rules:       DefaultCreateAlertGroupPayload.Rules,
+			isValid:     true,
+		},
+		{
+			description: "interval too short",
+			interval:    utils.Ptr("30s"),
+			rules:       fixtureAlertRules(),
+			isValid:     false,
+		},
+		{
+			description: "interval invalid",
+			interval:    utils.Ptr("often"),
+			rules:       fixtureAlertRules(),
+			isValid:     false,
+		},
+		{
+			description: "no rules",
+			rules:       &[]observability.UpdateAlertgroupsRequestInnerRulesInner{},
+			isValid:     false,
+		},
+		{
+			description: "alert name missing",
+			rules: fixtureAlertRules(func(rule *observability.UpdateAlertgroupsRequestInnerRulesInner) {
+				rule.Alert = nil
+			}),
+			isValid: false,
+		},
+		{
+			description: "expression missing",
+			rules: fixtureAlertRules(func(rule *observability.UpdateAlertgroupsRequestInnerRulesInner) {
+				rule.Expr = nil
+			}),
+			isValid: false,
+		},
+		{
+			description: "expression invalid",
+			rules: fixtureAlertRules(func(rule *observability.UpdateAlertgroupsRequestInnerRulesInner) {
+				rule.Expr = utils.Ptr("rate(errors_total[5m] > 1")
+			}),
+			isValid: false,
+		},
+		{
+			description: "for invalid",
+			rules: fixtureAlertRules(func(rule *observability.UpdateAlertgroupsRequestInnerRulesInner) {
+				rule.For = utils.Ptr("10 minutes")
+			}),
+			isValid: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			err := ValidateAlertGroup(tt.interval, tt.rules)
+			if tt.isValid && err != nil {
+				t.Errorf("failed on valid input: %v", err)
+			}
+			if !tt.isValid && err == nil {
+				t.Errorf("did not fail on invalid input")
+			}
+		})
+	}
+}
+
+func fixtureUpdateAlertConfigsPayload(mods ...func(payload *observability.UpdateAlertConfigsPayload)) *observability.UpdateAlertConfigsPayload {
+	payload := &observability.UpdateAlertConfigsPayload{
+		Global: &observability.UpdateAlertConfigsPayloadGlobal{
+			ResolveTimeout: utils.Ptr("5m"),
+		},
+		Receivers: &[]observability.UpdateAlertConfigsPayloadReceiversInner{
+			{
+				Name: utils.Ptr("team-a"),
+			},
+			{
+				Name: utils.Ptr("team-b"),
+			},
+		},
+		Route: &observability.UpdateAlertConfigsPayloadRoute{
+			Receiver:  utils.Ptr("team-a"),
+			GroupWait: utils.Ptr("30s"),
+			Routes: &[]observability.CreateAlertConfigRoutePayloadRoutesInner{
+				{
+					Receiver:       utils.Ptr("team-b"),
+					RepeatInterval: utils.Ptr("4h"),
+					Routes: &[]map[string]interface{}{
+						{
+							"receiver": "team-a",
+						},
+					},
+				},
+			},
+		},
+	}
+	for _, mod := range mods {
+		mod(payload)
+	}
+	return payload
+}
+
+func TestValidateAlertConfigsPayload(t *testing.T) {
+	tests := []struct {
+		description string
+		payload     *observability.UpdateAlertConfigsPayload
+		isValid     bool
+	}{
+		{
+			description: "base",
+			payload:     fixtureUpdateAlertConfigsPayload(),
+			isValid:     true,
+		},
+		{
+			description: "default payload",
+			payload:     &DefaultUpdateAlertConfigsPayload,
+			isValid:     true,
+		},
+		{
+			description: "nil",
+			payload:     nil,
+			isValid:     false,
+		},
+		{
+			description: "no receivers",
+			payload: fixtureUpdateAlertConfigsPayload(func(payload *observability.UpdateAlertConfigsPayload) {
+				payload.Receivers = nil
+			}),
+			isValid: false,
+		},
+		{
+			description: "duplicate receiver",
+			payload: fixtureUpdateAlertConfigsPayload(func(payload *observability.UpdateAlertConfigsPayload) {
+				(*payload.Receivers)[1].Name = utils.Ptr("team-a")
+			}),
+			isValid: false,
+		},
+		{
+			description: "invalid resolve timeout",
+			payload: fixtureUpdateAlertConfigsPayload(func(payload *observability.UpdateAlertConfigsPayload) {
+				payload.Global.ResolveTimeout = utils.Ptr("soon")
+			}),
+			isValid: false,
+		},
+		{
+			description: "no route",
+			payload: fixtureUpdateAlertConfigsPayload(func(payload *observability.UpdateAlertConfigsPayload) {
+				payload.Route = nil
+			}),
+			isValid: false,
+		},
+		{
+			description: "route receiver missing",
+			payload: fixtureUpdateAlertConfigsPayload(func(payload *observability.UpdateAlertConfigsPayload) {
+				payload.Route.Receiver = nil
+			}),
+			isValid: false,
+		},
+		{
+			description: "route receiver unknown",
+			payload: fixtureUpdateAlertConfigsPayload(func(payload *observability.UpdateAlertConfigsPayload) {
+				payload.Route.Receiver = utils.Ptr("team-c")
+			}),
+			isValid: false,
+		},
+		{
+			description: "route duration invalid",
+			payload: fixtureUpdateAlertConfigsPayload(func(payload *observability.UpdateAlertConfigsPayload) {
+				payload.Route.GroupWait = utils.Ptr("30 seconds")
+			}),
+			isValid: false,
+		},
+		{
+			description: "sub-route receiver unknown",
+			payload: fixtureUpdateAlertConfigsPayload(func(payload *observability.UpdateAlertConfigsPayload) {
+				(*payload.Route.Routes)[0].Receiver = utils.Ptr("team-c")
+			}),
+			isValid: false,
+		},
+		{
+			description: "nested sub-route receiver unknown",
+			payload: fixtureUpdateAlertConfigsPayload(func(payload *observability.UpdateAlertConfigsPayload) {
+				(*(*payload.Route.Routes)[0].Routes)[0]["receiver"] = "team-c"
+			}),
+			isValid: false,
+		},
+		{
+			description: "nested sub-route duration invalid",
+			payload: fixtureUpdateAlertConfigsPayload(func(payload *observability.UpdateAlertConfigsPayload) {
+				(*(*payload.Route.Routes)[0].Routes)[0]["groupInterval"] = "x"
+			}),
+			isValid: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			err := ValidateAlertConfigsPayload(tt.payload)
+			if tt.isValid && err != nil {
+				t.Errorf("failed on valid input: %v", err)
+			}
+			if !tt.isValid && err == nil {
+				t.Errorf("did not fail on invalid input")
+			}
+		})
+	}
+}
+
+func TestMapToUpdateAlertGroupPayload(t *testing.T) {
+	tests := []struct {
+		description     string
+		resp            *observability.AlertGroupResponse
+		isValid         bool
+		expectedPayload *observability.UpdateAlertgroupPayload
+	}{
+		{
+			description: "base",
+			resp: &observability.AlertGroupResponse{
+				Data: &observability.AlertGroup{
+					Name:     utils.Ptr("group"),
+					Interval: utils.Ptr("5m"),
+					Rules: &[]observability.AlertRuleRecord{
+						{
+							Alert:       utils.Ptr("Down"),
+							Expr:        utils.Ptr("up == 0"),
+							For:         utils.Ptr("1m"),
+							Labels:      &map[string]string{"severity": "critical"},
+							Annotations: &map[string]string{"summary": "down"},
+						},
+					},
+				},
+			},
+			isValid: true,
+			expectedPayload: &observability.UpdateAlertgroupPayload{
+				Interval: utils.Ptr("5m"),
+				Rules: &[]observability.UpdateAlertgroupsRequestInnerRulesInner{
+					{
+						Alert:       utils.Ptr("Down"),
+						Expr:        utils.Ptr("up == 0"),
+						For:         utils.Ptr("1m"),
+						Labels:      &map[string]interface{}{"severity": "critical"},
+						Annotations: &map[string]interface{}{"summary": "down"},
+					},
+				},
+			},
+		},
+		{
+			description: "recording rule",
+			resp: &observability.AlertGroupResponse{
+				Data: &observability.AlertGroup{
+					Name: utils.Ptr("group"),
+					Rules: &[]observability.AlertRuleRecord{
+						{
+							Record: utils.Ptr("job:up:sum"),
+							Expr:   utils.Ptr("sum by (job) (up)"),
+						},
+					},
+				},
+			},
+			isValid: false,
+		},
+		{
+			description: "nil response",
+			resp:        nil,
+			isValid:     false,
+		},
+		{
+			description: "nil data",
+			resp:        &observability.AlertGroupResponse{},
+			isValid:     false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			payload, err := MapToUpdateAlertGroupPayload(tt.resp)
+			if !tt.isValid {
+				if err == nil {
+					t.Fatalf("did not fail on invalid input")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("failed on valid input: %v", err)
+			}
+			diff := cmp.Diff(payload, tt.expectedPayload)
+			if diff != "" {
+				t.Fatalf("Data does not match: %s", diff)
+			}
+		})
+	}
+}
+
+func TestMapToUpdateAlertConfigsPayload(t *testing.T) {
+	fixtureAlert := func(mods ...func(alert *observability.Alert)) *observability.Alert {
+		alert := &observability.Alert{
+			Global: &observability.Global{
+				ResolveTimeout: utils.Ptr("5m"),
+				SmtpFrom:       utils.Ptr("alerts@example.com"),
+			},
+			Receivers: &[]observability.Receivers{
+				{
+					Name: utils.Ptr("team-a"),
+					EmailConfigs: &[]observability.EmailConfig{
+						{
+							To: utils.Ptr("team-a@example.com"),
+						},
+					},
+					WebHookConfigs: &[]observability.WebHook{
+						{
+							Url:     utils.Ptr("https://example.com/hook"),
+							MsTeams: utils.Ptr(true),
+						},
+					},
+				},
+			},
+			Route: &observability.Route{
+				Receiver: utils.Ptr("team-a"),
+				GroupBy:  &[]string{"alertname"},
+				Routes: &[]observability.RouteSerializer{
+					{
+						Receiver: utils.Ptr("team-a"),
+						Match:    &map[string]string{"severity": "critical"},
+					},
+				},
+			},
+			InhibitRules: &[]observability.InhibitRules{
+				{
+					Equal:       &[]string{"instance"},
+					SourceMatch: &map[string]string{"severity": "critical"},
+					TargetMatch: &map[string]string{"severity": "warning"},
+				},
+			},
+		}
+		for _, mod := range mods {
+			mod(alert)
+		}
+		return alert
+	}
+
+	tests := []struct {
+		description     string
+		resp            *observability.GetAlertConfigsResponse
+		isValid         bool
+		expectedPayload *observability.UpdateAlertConfigsPayload
+	}{
+		{
+			description: "base",
+			resp: &observability.GetAlertConfigsResponse{
+				Data: fixtureAlert(),
+			},
+			isValid: true,
+			expectedPayload: &observability.UpdateAlertConfigsPayload{
+				Global: &observability.UpdateAlertConfigsPayloadGlobal{
+					ResolveTimeout: utils.Ptr("5m"),
+					SmtpFrom:       utils.Ptr("alerts@example.com"),
+				},
+				Receivers: &[]observability.UpdateAlertConfigsPayloadReceiversInner{
+					{
+						Name: utils.Ptr("team-a"),
+						EmailConfigs: &[]observability.CreateAlertConfigReceiverPayloadEmailConfigsInner{
+							{
+								To: utils.Ptr("team-a@example.com"),
+							},
+						},
+						WebHookConfigs: &[]observability.CreateAlertConfigReceiverPayloadWebHookConfigsInner{
+							{
+								Url:     utils.Ptr("https://example.com/hook"),
+								MsTeams: utils.Ptr(true),
+							},
+						},
+					},
+				},
+				Route: &observability.UpdateAlertConfigsPayloadRoute{
+					Receiver: utils.Ptr("team-a"),
+					GroupBy:  &[]string{"alertname"},
+					Routes: &[]observability.CreateAlertConfigRoutePayloadRoutesInner{
+						{
+							Receiver: utils.Ptr("team-a"),
+							Match:    &map[string]interface{}{"severity": "critical"},
+						},
+					},
+				},
+				InhibitRules: &observability.UpdateAlertConfigsPayloadInhibitRules{
+					Equal:       &[]string{"instance"},
+					SourceMatch: &map[string]interface{}{"severity": "critical"},
+					TargetMatch: &map[string]interface{}{"severity": "warning"},
+				},
+			},
+		},
+		{
+			description: "no inhibit rules",
+			resp: &observability.GetAlertConfigsResponse{
+				Data: fixtureAlert(func(alert *observability.Alert) {
+					alert.InhibitRules = nil
+					alert.Global = nil
+					alert.Receivers = &[]observability.Receivers{{Name: utils.Ptr("team-a")}}
+					alert.Route = &observability.Route{Receiver: utils.Ptr("team-a")}
+				}),
+			},
+			isValid: true,
+			expectedPayload: &observability.UpdateAlertConfigsPayload{
+				Receivers: &[]observability.UpdateAlertConfigsPayloadReceiversInner{
+					{
+						Name: utils.Ptr("team-a"),
+					},
+				},
+				Route: &observability.UpdateAlertConfigsPayloadRoute{
+					Receiver: utils.Ptr("team-a"),
+				},
+			},
+		},
+		{
+			description: "multiple inhibit rules",
+			resp: &observability.GetAlertConfigsResponse{
+				Data: fixtureAlert(func(alert *observability.Alert) {
+					*alert.InhibitRules = append(*alert.InhibitRules, observability.InhibitRules{Equal: &[]string{"job"}})
+				}),
+			},
+			isValid: false,
+		},
+		{
+			description: "inhibit rule with matchers",
+			resp: &observability.GetAlertConfigsResponse{
+				Data: fixtureAlert(func(alert *observability.Alert) {
+					(*alert.InhibitRules)[0].SourceMatchers = &[]string{`severity="critical"`}
+				}),
+			},
+			isValid: false,
+		},
+		{
+			description: "nil response",
+			resp:        nil,
+			isValid:     false,
+		},
+		{
+			description: "nil data",
+			resp:        &observability.GetAlertConfigsResponse{},
+			isValid:     false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			payload, err := MapToUpdateAlertConfigsPayload(tt.resp)
+			if !tt.isValid {
+				if err == nil {
+					t.Fatalf("did not fail on invalid input")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("failed on valid input: %v", err)
+			}
+			diff := cmp.Diff(payload, tt.expectedPayload)
+			if diff != "" {
+				t.Fatalf("Data does not match: %s", diff)
+			}
+		})
+	}
+}
@@ -149,6 +149,17 @@ func GetInstanceName(ctx context.Context, apiClient PostgresFlexClient, projectI
 	return *resp.Item.Name, nil
 }
 
+func GetInstanceACL(ctx context.Context, apiClient PostgresFlexClient, projectId, region, instanceId string) ([]string, error) {
+	resp, err := apiClient.GetInstanceExecute(ctx, projectId, region, instanceId)
+	if err != nil {
+		return nil, fmt.Errorf("get PostgreSQL Flex instance: %w", err)
+	}
+	if resp.Item == nil || resp.Item.Acl == nil || resp.Item.Acl.Items == nil {
+		return []string{}, nil
+	}
+	return *resp.Item.Acl.Items, nil
+}
+
 func GetInstanceStatus(ctx context.Context, apiClient PostgresFlexClient, projectId, region, instanceId string) (string, error) {
 	resp, err := apiClient.GetInstanceExecute(ctx, projectId, region, instanceId)
 	if err != nil {
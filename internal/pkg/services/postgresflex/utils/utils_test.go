@@ -471,6 +471,67 @@ func TestGetInstanceName(t *testing.T) {
 	}
 }
 
+func TestGetInstanceACL(t *testing.T) {
+	tests := []struct {
+		description      string
+		getInstanceFails bool
+		getInstanceResp  *postgresflex.InstanceResponse
+		isValid          bool
+		expectedOutput   []string
+	}{
+		{
+			description: "base",
+			getInstanceResp: &postgresflex.InstanceResponse{
+				Item: &postgresflex.Instance{
+					Acl: &postgresflex.ACL{
+						Items: &[]string{"10.0.0.0/24", "192.0.2.1/32"},
+					},
+				},
+			},
+			isValid:        true,
+			expectedOutput: []string{"10.0.0.0/24", "192.0.2.1/32"},
+		},
+		{
+			description: "no ACL",
+			getInstanceResp: &postgresflex.InstanceResponse{
+				Item: &postgresflex.Instance{},
+			},
+			isValid:        true,
+			expectedOutput: []string{},
+		},
+		{
+			description:      "get instance fails",
+			getInstanceFails: true,
+			isValid:          false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			client := &postgresFlexClientMocked{
+				getInstanceFails: tt.getInstanceFails,
+				getInstanceResp:  tt.getInstanceResp,
+			}
+
+			output, err := GetInstanceACL(context.Background(), client, testProjectId, testRegion, testInstanceId)
+
+			if tt.isValid && err != nil {
+				t.Errorf("failed on valid input")
+			}
+			if !tt.isValid && err == nil {
+				t.Errorf("did not fail on invalid input")
+			}
+			if !tt.isValid {
+				return
+			}
+			diff := cmp.Diff(output, tt.expectedOutput)
+			if diff != "" {
+				t.Errorf("Data does not match: %s", diff)
+			}
+		})
+	}
+}
+
 func TestGetInstanceStatus(t *testing.T) {
 	tests := []struct {
 		description      string
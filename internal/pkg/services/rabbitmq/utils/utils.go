@@ -5,13 +5,18 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/stackitcloud/stackit-cli/internal/pkg/connection"
 	"github.com/stackitcloud/stackit-cli/internal/pkg/errors"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/rotation"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/utils"
 
 	"github.com/stackitcloud/stackit-sdk-go/services/rabbitmq"
 )
 
 const (
 	service = "rabbitmq"
+
+	aclParameterKey = "sgw_acl"
 )
 
 func ValidatePlanId(planId string, offerings *rabbitmq.ListOfferingsResponse) error {
@@ -78,6 +83,21 @@ func GetInstanceName(ctx context.Context, apiClient RabbitMQClient, projectId, i
 	return *resp.Name, nil
 }
 
+func GetInstanceACL(ctx context.Context, apiClient RabbitMQClient, projectId, instanceId string) ([]string, error) {
+	resp, err := apiClient.GetInstanceExecute(ctx, projectId, instanceId)
+	if err != nil {
+		return nil, fmt.Errorf("get RabbitMQ instance: %w", err)
+	}
+	if resp.Parameters == nil {
+		return []string{}, nil
+	}
+	acl, ok := (*resp.Parameters)[aclParameterKey].(string)
+	if !ok || acl == "" {
+		return []string{}, nil
+	}
+	return strings.Split(acl, ","), nil
+}
+
 func GetCredentialsUsername(ctx context.Context, apiClient RabbitMQClient, projectId, instanceId, credentialsId string) (string, error) {
 	resp, err := apiClient.GetCredentialsExecute(ctx, projectId, instanceId, credentialsId)
 	if err != nil {
@@ -85,3 +105,80 @@ func GetCredentialsUsername(ctx context.Context, apiClient RabbitMQClient, proje
 	}
 	return *resp.Raw.Credentials.Username, nil
 }
+
+// GetCredentialsConnection returns the connection to the instance with the given credentials
+func GetCredentialsConnection(resp *rabbitmq.CredentialsResponse) (*connection.Connection, error) {
+	if resp == nil || resp.Raw == nil || resp.Raw.Credentials == nil {
+		return nil, fmt.Errorf("no credentials passed")
+	}
+	credentials := resp.Raw.Credentials
+
+	conn := &connection.Connection{
+		Engine:   connection.RabbitMQ,
+		Host:     utils.PtrString(credentials.Host),
+		Port:     utils.PtrValue(credentials.Port),
+		Username: utils.PtrString(credentials.Username),
+		Password: utils.PtrString(credentials.Password),
+	}
+	// The URI tells whether TLS is used
+	if uri := utils.PtrString(credentials.Uri); uri != "" {
+		parsed, err := connection.FromURI(connection.RabbitMQ, uri)
+		if err != nil {
+			return nil, err
+		}
+		conn.Scheme = parsed.Scheme
+	}
+	if conn.Host == "" {
+		return nil, fmt.Errorf("no host of the instance returned")
+	}
+	return conn, nil
+}
+
+type RabbitMQCredentialsClient interface {
+	GetCredentialsExecute(ctx context.Context, projectId, instanceId, credentialsId string) (*rabbitmq.CredentialsResponse, error)
+	CreateCredentialsExecute(ctx context.Context, projectId, instanceId string) (*rabbitmq.CredentialsResponse, error)
+	DeleteCredentialsExecute(ctx context.Context, projectId, instanceId, credentialsId string) error
+}
+
+// CredentialsClient manages the credentials of a RabbitMQ instance, e.g. to rotate them
+type CredentialsClient struct {
+	ApiClient  RabbitMQCredentialsClient
+	ProjectId  string
+	InstanceId string
+}
+
+var _ rotation.CredentialsClient = &CredentialsClient{}
+
+func (c *CredentialsClient) GetUsername(ctx context.Context, credentialsId string) (string, error) {
+	resp, err := c.ApiClient.GetCredentialsExecute(ctx, c.ProjectId, c.InstanceId, credentialsId)
+	if err != nil {
+		return "", fmt.Errorf("get RabbitMQ credentials: %w", err)
+	}
+	if resp == nil || resp.Raw == nil || resp.Raw.Credentials == nil {
+		return "", nil
+	}
+	return utils.PtrString(resp.Raw.Credentials.Username), nil
+}
+
+func (c *CredentialsClient) Create(ctx context.Context) (string, *connection.Connection, error) {
+	resp, err := c.ApiClient.CreateCredentialsExecute(ctx, c.ProjectId, c.InstanceId)
+	if err != nil {
+		return "", nil, fmt.Errorf("create RabbitMQ credentials: %w", err)
+	}
+	if resp == nil {
+		return "", nil, fmt.Errorf("create RabbitMQ credentials: empty response")
+	}
+	conn, err := GetCredentialsConnection(resp)
+	if err != nil {
+		return utils.PtrString(resp.Id), nil, err
+	}
+	return utils.PtrString(resp.Id), conn, nil
+}
+
+func (c *CredentialsClient) Delete(ctx context.Context, credentialsId string) error {
+	err := c.ApiClient.DeleteCredentialsExecute(ctx, c.ProjectId, c.InstanceId, credentialsId)
+	if err != nil {
+		return fmt.Errorf("delete RabbitMQ credentials: %w", err)
+	}
+	return nil
+}
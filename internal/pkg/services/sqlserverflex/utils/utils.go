@@ -4,14 +4,17 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/stackitcloud/stackit-cli/internal/pkg/errors"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/utils"
 
+	"github.com/stackitcloud/stackit-sdk-go/core/wait"
 	"github.com/stackitcloud/stackit-sdk-go/services/sqlserverflex"
 )
 
 const (
-	ServiceCmd = "beta sqlserverflex"
+	ServiceCmd = "sqlserverflex"
 )
 
 // enforce implementation of interfaces
@@ -23,6 +26,8 @@ type SQLServerFlexClient interface {
 	ListVersionsExecute(ctx context.Context, projectId string, region string) (*sqlserverflex.ListVersionsResponse, error)
 	GetInstanceExecute(ctx context.Context, projectId, instanceId string, region string) (*sqlserverflex.GetInstanceResponse, error)
 	GetUserExecute(ctx context.Context, projectId, instanceId, userId string, region string) (*sqlserverflex.GetUserResponse, error)
+	ListDatabasesExecute(ctx context.Context, projectId string, instanceId string, region string) (*sqlserverflex.ListDatabasesResponse, error)
+	ListRestoreJobsExecute(ctx context.Context, projectId string, instanceId string, region string) (*sqlserverflex.ListRestoreJobsResponse, error)
 }
 
 func ValidateFlavorId(flavorId string, flavors *[]sqlserverflex.InstanceFlavorEntry) error {
@@ -98,6 +103,17 @@ func GetInstanceName(ctx context.Context, apiClient SQLServerFlexClient, project
 	return *resp.Item.Name, nil
 }
 
+func GetInstanceACL(ctx context.Context, apiClient SQLServerFlexClient, projectId, instanceId, region string) ([]string, error) {
+	resp, err := apiClient.GetInstanceExecute(ctx, projectId, instanceId, region)
+	if err != nil {
+		return nil, fmt.Errorf("get SQLServer Flex instance: %w", err)
+	}
+	if resp.Item == nil || resp.Item.Acl == nil || resp.Item.Acl.Items == nil {
+		return []string{}, nil
+	}
+	return *resp.Item.Acl.Items, nil
+}
+
 func GetUserName(ctx context.Context, apiClient SQLServerFlexClient, projectId, instanceId, userId, region string) (string, error) {
 	resp, err := apiClient.GetUserExecute(ctx, projectId, instanceId, userId, region)
 	if err != nil {
@@ -105,3 +121,40 @@ func GetUserName(ctx context.Context, apiClient SQLServerFlexClient, projectId,
 	}
 	return *resp.Item.Username, nil
 }
+
+// RestoreDatabaseWaitHandler waits for the restore of a backup to the database to finish,
+// which is when the database is listed and no restore job for it is running anymore.
+func RestoreDatabaseWaitHandler(ctx context.Context, apiClient SQLServerFlexClient, projectId, instanceId, databaseName, region string) *wait.AsyncActionHandler[sqlserverflex.Database] {
+	handler := wait.New(func() (waitFinished bool, response *sqlserverflex.Database, err error) {
+		restoreJobs, err := apiClient.ListRestoreJobsExecute(ctx, projectId, instanceId, region)
+		if err != nil {
+			return false, nil, fmt.Errorf("list SQLServer Flex restore jobs: %w", err)
+		}
+		if restoreJobs.RunningRestores != nil {
+			for _, restoreJob := range *restoreJobs.RunningRestores {
+				if utils.PtrString(restoreJob.DatabaseName) == databaseName {
+					return false, nil, nil
+				}
+			}
+		}
+
+		databases, err := apiClient.ListDatabasesExecute(ctx, projectId, instanceId, region)
+		if err != nil {
+			return false, nil, fmt.Errorf("list SQLServer Flex databases: %w", err)
+		}
+		if databases.Databases == nil {
+			return false, nil, nil
+		}
+		for i := range *databases.Databases {
+			database := &(*databases.Databases)[i]
+			if utils.PtrString(database.Name) == databaseName {
+				return true, database, nil
+			}
+		}
+		// The database isn't listed right after the restore is triggered
+		return false, nil, nil
+	})
+	handler.SetTimeout(45 * time.Minute)
+	handler.SetSleepBeforeWait(5 * time.Second)
+	return handler
+}
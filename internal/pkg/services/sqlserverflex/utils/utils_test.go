@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stackitcloud/stackit-cli/internal/pkg/utils"
 
@@ -24,6 +25,7 @@ var (
 const (
 	testInstanceName = "instance"
 	testUserName     = "user"
+	testDatabaseName = "database"
 	testRegion       = "eu01"
 )
 
@@ -36,6 +38,8 @@ type sqlServerFlexClientMocked struct {
 	getUserResp          *sqlserverflex.GetUserResponse
 	listRestoreJobsFails bool
 	listRestoreJobsResp  *sqlserverflex.ListRestoreJobsResponse
+	listDatabasesFails   bool
+	listDatabasesResp    *sqlserverflex.ListDatabasesResponse
 }
 
 func (m *sqlServerFlexClientMocked) ListVersionsExecute(_ context.Context, _, _ string) (*sqlserverflex.ListVersionsResponse, error) {
@@ -52,6 +56,13 @@ func (m *sqlServerFlexClientMocked) ListRestoreJobsExecute(_ context.Context, _,
 	return m.listRestoreJobsResp, nil
 }
 
+func (m *sqlServerFlexClientMocked) ListDatabasesExecute(_ context.Context, _, _, _ string) (*sqlserverflex.ListDatabasesResponse, error) {
+	if m.listDatabasesFails {
+		return nil, fmt.Errorf("could not list databases")
+	}
+	return m.listDatabasesResp, nil
+}
+
 func (m *sqlServerFlexClientMocked) GetInstanceExecute(_ context.Context, _, _, _ string) (*sqlserverflex.GetInstanceResponse, error) {
 	if m.getInstanceFails {
 		return nil, fmt.Errorf("could not get instance")
@@ -427,6 +438,67 @@ func TestGetInstanceName(t *testing.T) {
 	}
 }
 
+func TestGetInstanceACL(t *testing.T) {
+	tests := []struct {
+		description      string
+		getInstanceFails bool
+		getInstanceResp  *sqlserverflex.GetInstanceResponse
+		isValid          bool
+		expectedOutput   []string
+	}{
+		{
+			description: "base",
+			getInstanceResp: &sqlserverflex.GetInstanceResponse{
+				Item: &sqlserverflex.Instance{
+					Acl: &sqlserverflex.ACL{
+						Items: &[]string{"10.0.0.0/24", "192.0.2.1/32"},
+					},
+				},
+			},
+			isValid:        true,
+			expectedOutput: []string{"10.0.0.0/24", "192.0.2.1/32"},
+		},
+		{
+			description: "no ACL",
+			getInstanceResp: &sqlserverflex.GetInstanceResponse{
+				Item: &sqlserverflex.Instance{},
+			},
+			isValid:        true,
+			expectedOutput: []string{},
+		},
+		{
+			description:      "get instance fails",
+			getInstanceFails: true,
+			isValid:          false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			client := &sqlServerFlexClientMocked{
+				getInstanceFails: tt.getInstanceFails,
+				getInstanceResp:  tt.getInstanceResp,
+			}
+
+			output, err := GetInstanceACL(context.Background(), client, testProjectId, testInstanceId, testRegion)
+
+			if tt.isValid && err != nil {
+				t.Errorf("failed on valid input")
+			}
+			if !tt.isValid && err == nil {
+				t.Errorf("did not fail on invalid input")
+			}
+			if !tt.isValid {
+				return
+			}
+			diff := cmp.Diff(output, tt.expectedOutput)
+			if diff != "" {
+				t.Errorf("Data does not match: %s", diff)
+			}
+		})
+	}
+}
+
 func TestGetUserName(t *testing.T) {
 	tests := []struct {
 		description    string
@@ -476,3 +548,83 @@ func TestGetUserName(t *testing.T) {
 		})
 	}
 }
+
+func TestRestoreDatabaseWaitHandler(t *testing.T) {
+	tests := []struct {
+		description          string
+		runningRestores      []string
+		databases            []string
+		listRestoreJobsFails bool
+		listDatabasesFails   bool
+		isValid              bool
+	}{
+		{
+			description: "finished",
+			databases:   []string{"other", testDatabaseName},
+			isValid:     true,
+		},
+		{
+			description:     "finished with other restore running",
+			runningRestores: []string{"other"},
+			databases:       []string{testDatabaseName},
+			isValid:         true,
+		},
+		{
+			description:     "restore running",
+			runningRestores: []string{testDatabaseName},
+			databases:       []string{testDatabaseName},
+			isValid:         false,
+		},
+		{
+			description: "database not listed yet",
+			databases:   []string{"other"},
+			isValid:     false,
+		},
+		{
+			description:          "list restore jobs fails",
+			databases:            []string{testDatabaseName},
+			listRestoreJobsFails: true,
+			isValid:              false,
+		},
+		{
+			description:        "list databases fails",
+			listDatabasesFails: true,
+			isValid:            false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			runningRestores := []sqlserverflex.RestoreRunningRestore{}
+			for _, name := range tt.runningRestores {
+				runningRestores = append(runningRestores, sqlserverflex.RestoreRunningRestore{DatabaseName: utils.Ptr(name)})
+			}
+			databases := []sqlserverflex.Database{}
+			for _, name := range tt.databases {
+				databases = append(databases, sqlserverflex.Database{Name: utils.Ptr(name)})
+			}
+			client := &sqlServerFlexClientMocked{
+				listRestoreJobsFails: tt.listRestoreJobsFails,
+				listRestoreJobsResp:  &sqlserverflex.ListRestoreJobsResponse{RunningRestores: &runningRestores},
+				listDatabasesFails:   tt.listDatabasesFails,
+				listDatabasesResp:    &sqlserverflex.ListDatabasesResponse{Databases: &databases},
+			}
+
+			handler := RestoreDatabaseWaitHandler(context.Background(), client, testProjectId, testInstanceId, testDatabaseName, testRegion)
+			database, err := handler.SetSleepBeforeWait(0).SetThrottle(time.Millisecond).SetTimeout(20 * time.Millisecond).WaitWithContext(context.Background())
+
+			if !tt.isValid {
+				if err == nil {
+					t.Errorf("did not fail on invalid input")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("failed on valid input: %v", err)
+			}
+			if utils.PtrString(database.Name) != testDatabaseName {
+				t.Errorf("expected database %q, got %q", testDatabaseName, utils.PtrString(database.Name))
+			}
+		})
+	}
+}
@@ -0,0 +1,114 @@
+package transport
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+
+	"github.com/stackitcloud/stackit-cli/internal/pkg/config"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/print"
+
+	"github.com/goccy/go-yaml"
+	"github.com/spf13/viper"
+)
+
+// ErrDryRun is returned instead of sending a request that changes resources, if the --dry-run flag is set.
+// It stops the command, so only the first of several such requests is previewed, as the later ones may depend on its response.
+var ErrDryRun = errors.New("dry run: the request was not sent")
+
+// Methods of requests that don't change resources, which are sent even in dry-run mode
+var readOnlyMethods = []string{http.MethodGet, http.MethodHead, http.MethodOptions}
+
+// RequestPreview is the request that would be sent by a command in dry-run mode
+type RequestPreview struct {
+	Method string `json:"method"`
+	URL    string `json:"url"`
+	Body   any    `json:"body,omitempty"`
+}
+
+// dryRunRoundTripper prints the first request that changes resources instead of sending it.
+// Read-only requests are sent, as commands may need them e.g. to get the name of a resource.
+type dryRunRoundTripper struct {
+	transport http.RoundTripper
+	p         *print.Printer
+}
+
+func (rt *dryRunRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	if slices.Contains(readOnlyMethods, req.Method) {
+		return rt.transport.RoundTrip(req)
+	}
+
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = io.ReadAll(req.Body)
+		_ = req.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("read request body: %w", err)
+		}
+	}
+
+	preview := &RequestPreview{
+		Method: req.Method,
+		URL:    req.URL.String(),
+	}
+	if len(body) > 0 {
+		var bodyValue any
+		if json.Unmarshal(body, &bodyValue) == nil {
+			preview.Body = bodyValue
+		} else {
+			preview.Body = string(body)
+		}
+	}
+
+	err := outputRequestPreview(rt.p, viper.GetString(config.OutputFormatKey), preview)
+	if err != nil {
+		return nil, err
+	}
+	return nil, ErrDryRun
+}
+
+func outputRequestPreview(p *print.Printer, outputFormat string, preview *RequestPreview) error {
+	switch outputFormat {
+	case print.JSONOutputFormat:
+		details, err := json.MarshalIndent(preview, "", "  ")
+		if err != nil {
+			return fmt.Errorf("marshal request preview: %w", err)
+		}
+		p.Outputln(string(details))
+		return nil
+	case print.JSONLinesOutputFormat:
+		details, err := json.Marshal(preview)
+		if err != nil {
+			return fmt.Errorf("marshal request preview: %w", err)
+		}
+		p.Outputln(string(details))
+		return nil
+	case print.YAMLOutputFormat:
+		details, err := yaml.MarshalWithOptions(preview, yaml.IndentSequence(true), yaml.UseJSONMarshaler())
+		if err != nil {
+			return fmt.Errorf("marshal request preview: %w", err)
+		}
+		p.Outputln(string(details))
+		return nil
+	default:
+		p.Outputf("%s %s\n", preview.Method, preview.URL)
+		if preview.Body == nil {
+			return nil
+		}
+		if body, ok := preview.Body.(string); ok {
+			p.Outputf("\n%s\n", body)
+			return nil
+		}
+		details, err := json.MarshalIndent(preview.Body, "", "  ")
+		if err != nil {
+			return fmt.Errorf("marshal request body: %w", err)
+		}
+		p.Outputf("\n%s\n", bytes.TrimSpace(details))
+		return nil
+	}
+}
@@ -0,0 +1,109 @@
+package transport
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stackitcloud/stackit-cli/internal/pkg/config"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/print"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+func TestDryRunRoundTripper(t *testing.T) {
+	tests := []struct {
+		description    string
+		method         string
+		body           string
+		outputFormat   string
+		expectedOutput string
+		isSent         bool
+	}{
+		{
+			description: "read-only request is sent",
+			method:      http.MethodGet,
+			isSent:      true,
+		},
+		{
+			description:    "json",
+			method:         http.MethodPost,
+			body:           `{"name":"foo"}`,
+			outputFormat:   print.JSONOutputFormat,
+			expectedOutput: "{\n  \"method\": \"POST\",\n  \"url\": \"URL/v1/resources\",\n  \"body\": {\n    \"name\": \"foo\"\n  }\n}\n",
+		},
+		{
+			description:    "yaml",
+			method:         http.MethodPatch,
+			body:           `{"name":"foo"}`,
+			outputFormat:   print.YAMLOutputFormat,
+			expectedOutput: "method: PATCH\nurl: URL/v1/resources\nbody:\n  name: foo\n\n",
+		},
+		{
+			description:    "pretty",
+			method:         http.MethodPut,
+			body:           `{"name":"foo"}`,
+			outputFormat:   print.PrettyOutputFormat,
+			expectedOutput: "PUT URL/v1/resources\n\n{\n  \"name\": \"foo\"\n}\n",
+		},
+		{
+			description:    "pretty without body",
+			method:         http.MethodDelete,
+			outputFormat:   print.PrettyOutputFormat,
+			expectedOutput: "DELETE URL/v1/resources\n",
+		},
+		{
+			description:    "body is not JSON",
+			method:         http.MethodPost,
+			body:           "foo",
+			outputFormat:   print.PrettyOutputFormat,
+			expectedOutput: "POST URL/v1/resources\n\nfoo\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			sent := false
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				sent = true
+				w.WriteHeader(http.StatusOK)
+			}))
+			defer server.Close()
+
+			viper.Set(config.OutputFormatKey, tt.outputFormat)
+			defer viper.Set(config.OutputFormatKey, "")
+
+			output := &bytes.Buffer{}
+			p := print.NewPrinter()
+			p.Cmd = &cobra.Command{}
+			p.Cmd.SetOut(output)
+
+			client := &http.Client{Transport: &dryRunRoundTripper{transport: http.DefaultTransport, p: p}}
+			req, err := http.NewRequest(tt.method, server.URL+"/v1/resources", bytes.NewBufferString(tt.body))
+			if err != nil {
+				t.Fatalf("create request: %v", err)
+			}
+			resp, err := client.Do(req)
+			if tt.isSent {
+				if err != nil {
+					t.Fatalf("do request: %v", err)
+				}
+				_ = resp.Body.Close()
+			} else if !errors.Is(err, ErrDryRun) {
+				t.Fatalf("expected error to be %v, got %v", ErrDryRun, err)
+			}
+
+			if sent != tt.isSent {
+				t.Fatalf("expected request sent to be %t, got %t", tt.isSent, sent)
+			}
+			expectedOutput := strings.ReplaceAll(tt.expectedOutput, "URL", server.URL)
+			if output.String() != expectedOutput {
+				t.Fatalf("expected output to be %q, got %q", expectedOutput, output.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,45 @@
+package transport
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+
+	"github.com/stackitcloud/stackit-cli/internal/pkg/history"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/print"
+)
+
+// historyRecorder records the requests that change resources in the history of the command (see "stackit history")
+type historyRecorder struct {
+	transport http.RoundTripper
+	p         *print.Printer
+}
+
+func (rt *historyRecorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := rt.transport.RoundTrip(req)
+	if slices.Contains(readOnlyMethods, req.Method) {
+		return resp, err
+	}
+
+	record := history.Request{
+		Method: req.Method,
+		URL:    req.URL.String(),
+	}
+	if err != nil {
+		history.AddRequest(rt.p, record, nil)
+		return resp, err
+	}
+	record.StatusCode = resp.StatusCode
+
+	// The body is read to record the IDs of the created resources, and replaced for the SDK to read it again
+	body, readErr := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	history.AddRequest(rt.p, record, body)
+	if readErr != nil {
+		return nil, fmt.Errorf("read response body: %w", readErr)
+	}
+	return resp, nil
+}
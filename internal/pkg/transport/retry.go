@@ -0,0 +1,123 @@
+package transport
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"slices"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/stackitcloud/stackit-cli/internal/pkg/print"
+)
+
+// Wait before the first retry, doubled on each following retry
+var retryBaseWait = 1 * time.Second
+
+// Methods that can be safely retried, as repeating the request has the same effect as sending it once
+var idempotentMethods = []string{http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete}
+
+// Response statuses of transient failures
+var retryableStatuses = []int{http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout}
+
+// retryRoundTripper retries idempotent requests that failed due to transient errors, with exponential backoff.
+// With allMethods, requests of all methods are retried, even if that may create a resource twice.
+type retryRoundTripper struct {
+	transport  http.RoundTripper
+	p          *print.Printer
+	maxRetries int
+	maxWait    time.Duration
+	allMethods bool
+}
+
+func (rt *retryRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	canRetry := rt.maxRetries > 0 && (rt.allMethods || slices.Contains(idempotentMethods, req.Method)) && (req.Body == nil || req.Body == http.NoBody || req.GetBody != nil)
+
+	for attempt := 0; ; attempt++ {
+		if attempt > 0 && req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, fmt.Errorf("get request body to retry: %w", err)
+			}
+			req.Body = body
+		}
+
+		resp, err := rt.transport.RoundTrip(req)
+		if !canRetry || attempt >= rt.maxRetries || !isRetryable(resp, err) {
+			return resp, err
+		}
+
+		wait, ok := rt.waitBeforeRetry(resp, attempt)
+		if !ok {
+			rt.p.Debug(print.DebugLevel, "not retrying %s %s: server asked to wait longer than the maximum wait of %s", req.Method, req.URL, rt.maxWait)
+			return resp, err
+		}
+		rt.p.LogHTTPRetry(req, resp, err, attempt+1, rt.maxRetries+1, wait)
+		if err == nil {
+			// The response is discarded, so its body must be consumed and closed to reuse the connection
+			_, _ = io.Copy(io.Discard, resp.Body)
+			_ = resp.Body.Close()
+		}
+
+		err = sleepWithContext(req.Context(), wait)
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+// isRetryable returns True if the request failed due to a transient error (rate limit, unavailable server or reset connection)
+func isRetryable(resp *http.Response, err error) bool {
+	if err != nil {
+		return errors.Is(err, syscall.ECONNRESET) || errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, io.EOF)
+	}
+	return slices.Contains(retryableStatuses, resp.StatusCode)
+}
+
+// waitBeforeRetry returns how long to wait before the next attempt: the "Retry-After" header of the response, if set,
+// or an exponential backoff with jitter, limited to the maximum wait.
+// Returns false if the server asked to wait longer than the maximum wait.
+func (rt *retryRoundTripper) waitBeforeRetry(resp *http.Response, attempt int) (time.Duration, bool) {
+	if resp != nil {
+		if wait, ok := parseRetryAfter(resp.Header.Get("Retry-After")); ok {
+			return wait, wait <= rt.maxWait
+		}
+	}
+
+	wait := retryBaseWait << attempt
+	// Jitter of up to 25% to avoid retrying many requests at the same time
+	wait += time.Duration(rand.Int64N(int64(wait)/4 + 1)) //nolint:gosec // jitter doesn't need a secure random number
+	if wait > rt.maxWait || wait <= 0 {
+		wait = rt.maxWait
+	}
+	return wait, true
+}
+
+// parseRetryAfter parses the value of the "Retry-After" header, which is either a number of seconds or an HTTP date
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		return max(time.Until(date), 0), true
+	}
+	return 0, false
+}
+
+func sleepWithContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,297 @@
+package transport
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stackitcloud/stackit-cli/internal/pkg/print"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// newFlakyServer returns a server that responds with the given statuses to the first requests, and with 200 OK afterwards.
+// The bodies of the requests are checked to be the expected one.
+func newFlakyServer(t *testing.T, statuses []int, retryAfter, expectedBody string) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+	requests := &atomic.Int32{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			t.Errorf("read request body: %v", err)
+		}
+		if string(body) != expectedBody {
+			t.Errorf("expected request body to be %q, got %q", expectedBody, string(body))
+		}
+
+		i := int(requests.Add(1)) - 1
+		if i < len(statuses) {
+			if retryAfter != "" {
+				w.Header().Set("Retry-After", retryAfter)
+			}
+			w.WriteHeader(statuses[i])
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(server.Close)
+	return server, requests
+}
+
+func TestRetryRoundTripper(t *testing.T) {
+	retryBaseWait = time.Millisecond
+	defer func() { retryBaseWait = 1 * time.Second }()
+
+	tests := []struct {
+		description      string
+		method           string
+		body             string
+		statuses         []int
+		retryAfter       string
+		maxRetries       int
+		maxWait          time.Duration
+		allMethods       bool
+		expectedStatus   int
+		expectedRequests int32
+	}{
+		{
+			description:      "success",
+			method:           http.MethodGet,
+			maxRetries:       3,
+			maxWait:          time.Second,
+			expectedStatus:   http.StatusOK,
+			expectedRequests: 1,
+		},
+		{
+			description:      "transient errors",
+			method:           http.MethodGet,
+			statuses:         []int{http.StatusServiceUnavailable, http.StatusBadGateway},
+			maxRetries:       3,
+			maxWait:          time.Second,
+			expectedStatus:   http.StatusOK,
+			expectedRequests: 3,
+		},
+		{
+			description:      "rate limited with retry after",
+			method:           http.MethodDelete,
+			statuses:         []int{http.StatusTooManyRequests},
+			retryAfter:       "0",
+			maxRetries:       3,
+			maxWait:          time.Second,
+			expectedStatus:   http.StatusOK,
+			expectedRequests: 2,
+		},
+		{
+			description:      "retry after longer than max wait",
+			method:           http.MethodGet,
+			statuses:         []int{http.StatusTooManyRequests},
+			retryAfter:       "120",
+			maxRetries:       3,
+			maxWait:          time.Second,
+			expectedStatus:   http.StatusTooManyRequests,
+			expectedRequests: 1,
+		},
+		{
+			description:      "body is replayed",
+			method:           http.MethodPut,
+			body:             `{"name":"foo"}`,
+			statuses:         []int{http.StatusGatewayTimeout},
+			maxRetries:       3,
+			maxWait:          time.Second,
+			expectedStatus:   http.StatusOK,
+			expectedRequests: 2,
+		},
+		{
+			description:      "non idempotent method is not retried",
+			method:           http.MethodPost,
+			body:             `{"name":"foo"}`,
+			statuses:         []int{http.StatusServiceUnavailable},
+			maxRetries:       3,
+			maxWait:          time.Second,
+			expectedStatus:   http.StatusServiceUnavailable,
+			expectedRequests: 1,
+		},
+		{
+			description:      "non idempotent method is retried with all methods",
+			method:           http.MethodPost,
+			body:             `{"name":"foo"}`,
+			statuses:         []int{http.StatusServiceUnavailable},
+			maxRetries:       3,
+			maxWait:          time.Second,
+			allMethods:       true,
+			expectedStatus:   http.StatusOK,
+			expectedRequests: 2,
+		},
+		{
+			description:      "non transient error is not retried",
+			method:           http.MethodGet,
+			statuses:         []int{http.StatusInternalServerError},
+			maxRetries:       3,
+			maxWait:          time.Second,
+			expectedStatus:   http.StatusInternalServerError,
+			expectedRequests: 1,
+		},
+		{
+			description:      "retries exhausted",
+			method:           http.MethodGet,
+			statuses:         []int{http.StatusServiceUnavailable, http.StatusServiceUnavailable, http.StatusServiceUnavailable},
+			maxRetries:       2,
+			maxWait:          time.Second,
+			expectedStatus:   http.StatusServiceUnavailable,
+			expectedRequests: 3,
+		},
+		{
+			description:      "retries disabled",
+			method:           http.MethodGet,
+			statuses:         []int{http.StatusServiceUnavailable},
+			maxRetries:       0,
+			maxWait:          time.Second,
+			expectedStatus:   http.StatusServiceUnavailable,
+			expectedRequests: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			server, requests := newFlakyServer(t, tt.statuses, tt.retryAfter, tt.body)
+			client := &http.Client{Transport: &retryRoundTripper{
+				transport:  http.DefaultTransport,
+				p:          print.NewPrinter(),
+				maxRetries: tt.maxRetries,
+				maxWait:    tt.maxWait,
+				allMethods: tt.allMethods,
+			}}
+
+			var body io.Reader
+			if tt.body != "" {
+				body = bytes.NewBufferString(tt.body)
+			}
+			req, err := http.NewRequest(tt.method, server.URL, body)
+			if err != nil {
+				t.Fatalf("create request: %v", err)
+			}
+			resp, err := client.Do(req)
+			if err != nil {
+				t.Fatalf("do request: %v", err)
+			}
+			_ = resp.Body.Close()
+
+			if resp.StatusCode != tt.expectedStatus {
+				t.Fatalf("expected status to be %d, got %d", tt.expectedStatus, resp.StatusCode)
+			}
+			if n := requests.Load(); n != tt.expectedRequests {
+				t.Fatalf("expected %d requests, got %d", tt.expectedRequests, n)
+			}
+		})
+	}
+}
+
+func TestRetryRoundTripperLogsAttempts(t *testing.T) {
+	retryBaseWait = time.Millisecond
+	defer func() { retryBaseWait = 1 * time.Second }()
+	defaultLogger := slog.Default()
+	defer slog.SetDefault(defaultLogger)
+
+	p := print.NewPrinter()
+	p.Verbosity = print.DebugLevel
+	logFilePath := filepath.Join(t.TempDir(), "cli.log")
+	err := p.ConfigureLogger(print.JSONLogFormat, logFilePath)
+	if err != nil {
+		t.Fatalf("configure logger: %v", err)
+	}
+
+	server, _ := newFlakyServer(t, []int{http.StatusServiceUnavailable, http.StatusBadGateway}, "", "")
+	client := &http.Client{Transport: &retryRoundTripper{
+		transport:  http.DefaultTransport,
+		p:          p,
+		maxRetries: 3,
+		maxWait:    time.Second,
+	}}
+	resp, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatalf("do request: %v", err)
+	}
+	_ = resp.Body.Close()
+
+	logFile, err := os.Open(logFilePath)
+	if err != nil {
+		t.Fatalf("open log file: %v", err)
+	}
+	defer func() { _ = logFile.Close() }()
+	var statuses []float64
+	scanner := bufio.NewScanner(logFile)
+	for scanner.Scan() {
+		var record map[string]any
+		err = json.Unmarshal(scanner.Bytes(), &record)
+		if err != nil {
+			t.Fatalf("log record is not JSON: %v", err)
+		}
+		if record["msg"] != "http request retried" {
+			continue
+		}
+		if record["attempt"] != float64(len(statuses)+1) || record["max_attempts"] != float64(4) {
+			t.Errorf("unexpected attempt in log record: %v", record)
+		}
+		status, _ := record["status"].(float64)
+		statuses = append(statuses, status)
+	}
+	diff := cmp.Diff(statuses, []float64{http.StatusServiceUnavailable, http.StatusBadGateway})
+	if diff != "" {
+		t.Fatalf("Statuses of the retried attempts do not match: %s", diff)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	tests := []struct {
+		description  string
+		value        string
+		expectedWait time.Duration
+		isValid      bool
+	}{
+		{
+			description:  "seconds",
+			value:        "5",
+			expectedWait: 5 * time.Second,
+			isValid:      true,
+		},
+		{
+			description:  "date in the past",
+			value:        "Wed, 21 Oct 2015 07:28:00 GMT",
+			expectedWait: 0,
+			isValid:      true,
+		},
+		{
+			description: "empty",
+			value:       "",
+		},
+		{
+			description: "invalid",
+			value:       "soon",
+		},
+		{
+			description: "negative",
+			value:       "-1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			wait, ok := parseRetryAfter(tt.value)
+			if ok != tt.isValid {
+				t.Fatalf("expected valid to be %t, got %t", tt.isValid, ok)
+			}
+			if wait != tt.expectedWait {
+				t.Fatalf("expected wait to be %s, got %s", tt.expectedWait, wait)
+			}
+		})
+	}
+}
@@ -0,0 +1,78 @@
+package transport
+
+import (
+	"net/http"
+	"sync"
+
+	"github.com/stackitcloud/stackit-cli/internal/pkg/config"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/flags"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/globalflags"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/print"
+
+	"github.com/spf13/viper"
+	sdkConfig "github.com/stackitcloud/stackit-sdk-go/core/config"
+)
+
+// Headers identifying a request in the STACKIT APIs, in order of preference
+var requestIdHeaders = []string{"X-Request-Id", "X-Trace-Id"}
+
+var (
+	lastFailedRequestIdMutex sync.Mutex
+	lastFailedRequestId      string
+)
+
+// Middleware returns the middleware shared by all the service clients of the CLI.
+// Requests that failed due to transient errors are retried, as configured by the "retries", "retry_max_wait" and "retry_all_methods" keys.
+// Requests that change resources are recorded in the history of the command.
+// If the --dry-run flag is set, requests that change resources are printed instead of being sent.
+func Middleware(p *print.Printer) sdkConfig.Middleware {
+	dryRun := p.Cmd != nil && flags.FlagToBoolValue(p, p.Cmd, globalflags.DryRunFlag)
+	return func(rt http.RoundTripper) http.RoundTripper {
+		rt = &retryRoundTripper{
+			transport:  &requestIdRecorder{transport: rt},
+			p:          p,
+			maxRetries: viper.GetInt(config.RetriesKey),
+			maxWait:    viper.GetDuration(config.RetryMaxWaitKey),
+			allMethods: viper.GetBool(config.RetryAllMethodsKey),
+		}
+		rt = &historyRecorder{transport: rt, p: p}
+		if dryRun {
+			rt = &dryRunRoundTripper{transport: rt, p: p}
+		}
+		return rt
+	}
+}
+
+// LastFailedRequestId returns the ID of the last request that got an error response, if any
+func LastFailedRequestId() string {
+	lastFailedRequestIdMutex.Lock()
+	defer lastFailedRequestIdMutex.Unlock()
+	return lastFailedRequestId
+}
+
+// requestIdRecorder records the ID of requests that get an error response, so that it can be included in the error output
+type requestIdRecorder struct {
+	transport http.RoundTripper
+}
+
+func (rt *requestIdRecorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := rt.transport.RoundTrip(req)
+	if err != nil || resp.StatusCode < http.StatusBadRequest {
+		return resp, err
+	}
+
+	requestId := ""
+	for _, header := range requestIdHeaders {
+		if requestId = resp.Header.Get(header); requestId != "" {
+			break
+		}
+	}
+	if requestId == "" {
+		requestId = req.Header.Get("Traceparent")
+	}
+
+	lastFailedRequestIdMutex.Lock()
+	defer lastFailedRequestIdMutex.Unlock()
+	lastFailedRequestId = requestId
+	return resp, err
+}
@@ -0,0 +1,35 @@
+package transport
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stackitcloud/stackit-cli/internal/pkg/print"
+)
+
+func TestRequestIdRecorder(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Request-Id", r.URL.Path)
+		if r.URL.Path == "/ok" {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: Middleware(print.NewPrinter())(http.DefaultTransport)}
+	for _, path := range []string{"/not-found", "/ok"} {
+		resp, err := client.Get(server.URL + path)
+		if err != nil {
+			t.Fatalf("do request: %v", err)
+		}
+		_ = resp.Body.Close()
+	}
+
+	// Successful requests don't override the ID of the last failed request
+	if requestId := LastFailedRequestId(); requestId != "/not-found" {
+		t.Fatalf("expected last failed request ID to be %q, got %q", "/not-found", requestId)
+	}
+}
@@ -3,6 +3,7 @@ package utils
 import (
 	"testing"
 
+	"github.com/google/go-cmp/cmp"
 	"github.com/stackitcloud/stackit-sdk-go/core/utils"
 )
 
@@ -30,3 +31,60 @@ func TestTruncate(t *testing.T) {
 		})
 	}
 }
+
+func TestSplitArgs(t *testing.T) {
+	tests := []struct {
+		description  string
+		input        string
+		expectedArgs []string
+		isValid      bool
+	}{
+		{
+			description:  "base",
+			input:        "dns zone list",
+			expectedArgs: []string{"dns", "zone", "list"},
+			isValid:      true,
+		},
+		{
+			description:  "extra whitespace",
+			input:        "  dns\tzone   list ",
+			expectedArgs: []string{"dns", "zone", "list"},
+			isValid:      true,
+		},
+		{
+			description:  "empty quoted argument",
+			input:        `dns zone update --description ""`,
+			expectedArgs: []string{"dns", "zone", "update", "--description", ""},
+			isValid:      true,
+		},
+		{
+			description: "unterminated quote",
+			input:       `dns zone update --description "abc`,
+			isValid:     false,
+		},
+		{
+			description: "empty",
+			input:       "   ",
+			isValid:     false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			args, err := SplitArgs(tt.input)
+			if err != nil {
+				if !tt.isValid {
+					return
+				}
+				t.Fatalf("split args: %v", err)
+			}
+			if !tt.isValid {
+				t.Fatalf("did not fail on invalid input")
+			}
+			diff := cmp.Diff(args, tt.expectedArgs)
+			if diff != "" {
+				t.Fatalf("Data does not match: %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,348 @@
+package watch
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/stackitcloud/stackit-cli/internal/pkg/errors"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/globalflags"
+	"github.com/stackitcloud/stackit-cli/internal/pkg/print"
+
+	"github.com/spf13/cobra"
+)
+
+const (
+	WatchFlag = "watch"
+
+	intervalDefault = 5 * time.Second
+	intervalMin     = time.Second
+
+	// Moves the cursor to the top left corner and clears the screen
+	clearScreen = "\033[H\033[2J"
+	// Separator of the columns of the tables in the "tables" package
+	columnSeparator = "│"
+)
+
+// Types of the events emitted in JSON mode
+const (
+	AddedEvent   = "added"
+	ChangedEvent = "changed"
+	RemovedEvent = "removed"
+	ErrorEvent   = "error"
+)
+
+// Event is a change of the output of a watched command, emitted as a JSON line
+type Event struct {
+	Time    time.Time         `json:"time"`
+	Type    string            `json:"type"`
+	Id      string            `json:"id,omitempty"`
+	Item    any               `json:"item,omitempty"`
+	Changes map[string]Change `json:"changes,omitempty"`
+	Error   string            `json:"error,omitempty"`
+}
+
+// Change is the change of a field of an item, given by its path (e.g. "status" or "nics.0.ip")
+type Change struct {
+	Old any `json:"old"`
+	New any `json:"new"`
+}
+
+// Configure adds the --watch flag to a list or describe command.
+// When set, the command is run repeatedly at the given interval until interrupted, redrawing its output in place
+// and highlighting what changed, or, with the JSON output format, outputting only the changes as JSON lines.
+func Configure(p *print.Printer, cmd *cobra.Command) {
+	if cmd.RunE == nil {
+		return
+	}
+	cmd.Flags().Duration(WatchFlag, 0, fmt.Sprintf("If set, re-runs the command at the given interval (%s if no interval is given, e.g. --%s=10s) until interrupted, redrawing the output in place and highlighting changes. With the JSON output format, only the changes are output, as JSON lines", intervalDefault, WatchFlag))
+	cmd.Flags().Lookup(WatchFlag).NoOptDefVal = intervalDefault.String()
+
+	runE := cmd.RunE
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		if !cmd.Flags().Changed(WatchFlag) {
+			return runE(cmd, args)
+		}
+		interval, err := cmd.Flags().GetDuration(WatchFlag)
+		if err != nil {
+			return err
+		}
+		if interval < intervalMin {
+			return &errors.FlagValidationError{
+				Flag:    WatchFlag,
+				Details: fmt.Sprintf("interval must be at least %s", intervalMin),
+			}
+		}
+
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		defer stop()
+
+		outputFormat := globalflags.Parse(p, cmd).OutputFormat
+		w := &watcher{
+			cmd:        cmd,
+			out:        cmd.OutOrStdout(),
+			errOut:     cmd.ErrOrStderr(),
+			interval:   interval,
+			jsonEvents: outputFormat == print.JSONOutputFormat || outputFormat == print.JSONLinesOutputFormat,
+			now:        time.Now,
+		}
+		return w.run(ctx, func() error { return runE(cmd, args) })
+	}
+}
+
+type watcher struct {
+	cmd        *cobra.Command
+	out        io.Writer
+	errOut     io.Writer
+	interval   time.Duration
+	jsonEvents bool
+	now        func() time.Time
+
+	previousLines []string
+	previousItems map[string]any
+}
+
+func (w *watcher) run(ctx context.Context, runCmd func() error) error {
+	defer func() {
+		w.cmd.SetOut(w.out)
+		w.cmd.SetErr(w.errOut)
+	}()
+
+	for i := 0; ; i++ {
+		output, err := w.capture(runCmd)
+		switch {
+		// Errors of the first run (e.g. invalid input) are not transient
+		case err != nil && i == 0:
+			return err
+		case err != nil:
+			w.outputError(err)
+		case w.jsonEvents:
+			err = w.outputEvents(output)
+			if err != nil {
+				return err
+			}
+		default:
+			w.redraw(output)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(w.interval):
+		}
+	}
+}
+
+// capture runs the command, returning its output
+func (w *watcher) capture(runCmd func() error) (string, error) {
+	buf := &bytes.Buffer{}
+	w.cmd.SetOut(buf)
+	// Messages like "no resources found" are part of the redrawn output
+	if !w.jsonEvents {
+		w.cmd.SetErr(buf)
+	}
+	err := runCmd()
+	w.cmd.SetOut(w.out)
+	w.cmd.SetErr(w.errOut)
+	return buf.String(), err
+}
+
+func (w *watcher) outputError(err error) {
+	if w.jsonEvents {
+		w.outputEvent(&Event{Time: w.now(), Type: ErrorEvent, Error: err.Error()})
+		return
+	}
+	fmt.Fprintf(w.errOut, "%s %v\n", print.RedBold("Error:"), err)
+}
+
+// redraw clears the screen and outputs the command output, highlighting the fields that changed since the last run
+func (w *watcher) redraw(output string) {
+	lines := strings.Split(output, "\n")
+	var b strings.Builder
+	b.WriteString(clearScreen)
+	fmt.Fprintf(&b, "Every %s: %s    %s\n", w.interval, w.cmd.CommandPath(), w.now().Format(time.DateTime))
+	b.WriteString(highlightChanges(w.previousLines, lines))
+	fmt.Fprint(w.out, b.String())
+	w.previousLines = lines
+}
+
+// highlightChanges returns the lines with the table cells (or whole lines, if not table rows) that changed highlighted
+func highlightChanges(previousLines, lines []string) string {
+	highlighted := make([]string, 0, len(lines))
+	for i, line := range lines {
+		if previousLines == nil || (i < len(previousLines) && previousLines[i] == line) || strings.TrimSpace(line) == "" {
+			highlighted = append(highlighted, line)
+			continue
+		}
+		if i >= len(previousLines) {
+			highlighted = append(highlighted, print.YellowBold(line))
+			continue
+		}
+
+		cells := strings.Split(line, columnSeparator)
+		previousCells := strings.Split(previousLines[i], columnSeparator)
+		if len(cells) != len(previousCells) {
+			highlighted = append(highlighted, print.YellowBold(line))
+			continue
+		}
+		for j := range cells {
+			if cells[j] != previousCells[j] {
+				cells[j] = print.YellowBold(cells[j])
+			}
+		}
+		highlighted = append(highlighted, strings.Join(cells, columnSeparator))
+	}
+	return strings.Join(highlighted, "\n")
+}
+
+// outputEvents outputs the changes of the items in the command output since the last run, as JSON lines.
+// In the first run, all items are added.
+func (w *watcher) outputEvents(output string) error {
+	items, ids, err := parseItems(output)
+	if err != nil {
+		return fmt.Errorf("parse command output: %w", err)
+	}
+	previousItems := w.previousItems
+	if previousItems == nil {
+		previousItems = map[string]any{}
+	}
+
+	now := w.now()
+	for _, id := range ids {
+		previousItem, ok := previousItems[id]
+		if !ok {
+			w.outputEvent(&Event{Time: now, Type: AddedEvent, Id: id, Item: items[id]})
+			continue
+		}
+		changes := diff(previousItem, items[id])
+		if len(changes) > 0 {
+			w.outputEvent(&Event{Time: now, Type: ChangedEvent, Id: id, Changes: changes})
+		}
+	}
+	removedIds := []string{}
+	for id := range previousItems {
+		if _, ok := items[id]; !ok {
+			removedIds = append(removedIds, id)
+		}
+	}
+	sort.Strings(removedIds)
+	for _, id := range removedIds {
+		w.outputEvent(&Event{Time: now, Type: RemovedEvent, Id: id, Item: previousItems[id]})
+	}
+
+	w.previousItems = items
+	return nil
+}
+
+func (w *watcher) outputEvent(event *Event) {
+	line, err := json.Marshal(event)
+	if err != nil {
+		fmt.Fprintf(w.errOut, "%s marshal watch event: %v\n", print.RedBold("Error:"), err)
+		return
+	}
+	fmt.Fprintln(w.out, string(line))
+}
+
+// parseItems parses the JSON (or JSON lines) output of a command, which is either a list of items or a single item.
+// Returns the items by ID (their "id" or "name" field, or else their position), and the IDs in the order of the output.
+func parseItems(output string) (items map[string]any, ids []string, err error) {
+	var values []any
+	var value any
+	err = json.Unmarshal([]byte(output), &value)
+	if err != nil {
+		// Output of list commands with the JSON lines output format
+		decoder := json.NewDecoder(strings.NewReader(output))
+		for decoder.More() {
+			var line any
+			lineErr := decoder.Decode(&line)
+			if lineErr != nil {
+				return nil, nil, err
+			}
+			values = append(values, line)
+		}
+	} else if list, ok := value.([]any); ok {
+		values = list
+	} else if value != nil {
+		values = []any{value}
+	}
+
+	items = map[string]any{}
+	ids = make([]string, 0, len(values))
+	for i, v := range values {
+		id := itemId(v, i)
+		if _, ok := items[id]; ok {
+			id = strconv.Itoa(i)
+		}
+		items[id] = v
+		ids = append(ids, id)
+	}
+	return items, ids, nil
+}
+
+func itemId(item any, position int) string {
+	if fields, ok := item.(map[string]any); ok {
+		for _, key := range []string{"id", "name"} {
+			if id, ok := fields[key].(string); ok && id != "" {
+				return id
+			}
+		}
+	}
+	return strconv.Itoa(position)
+}
+
+// diff returns the changes of the fields of an item, by path
+func diff(previousItem, item any) map[string]Change {
+	previousFields := map[string]any{}
+	flatten("", previousItem, previousFields)
+	fields := map[string]any{}
+	flatten("", item, fields)
+
+	changes := map[string]Change{}
+	for path, value := range fields {
+		previousValue, ok := previousFields[path]
+		if !ok || !reflect.DeepEqual(previousValue, value) {
+			changes[path] = Change{Old: previousValue, New: value}
+		}
+	}
+	for path, previousValue := range previousFields {
+		if _, ok := fields[path]; !ok {
+			changes[path] = Change{Old: previousValue, New: nil}
+		}
+	}
+	return changes
+}
+
+// flatten adds the leaf values of the JSON value to fields, by their path
+func flatten(path string, value any, fields map[string]any) {
+	join := func(key string) string {
+		if path == "" {
+			return key
+		}
+		return path + "." + key
+	}
+
+	switch v := value.(type) {
+	case map[string]any:
+		for key, field := range v {
+			flatten(join(key), field, fields)
+		}
+	case []any:
+		for i, element := range v {
+			flatten(join(strconv.Itoa(i)), element, fields)
+		}
+	default:
+		if path == "" {
+			path = "."
+		}
+		fields[path] = v
+	}
+}
@@ -0,0 +1,307 @@
+package watch
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stackitcloud/stackit-cli/internal/pkg/print"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/spf13/cobra"
+)
+
+var testTime = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func TestConfigure(t *testing.T) {
+	tests := []struct {
+		description string
+		flagValues  []string
+		isValid     bool
+		expectedRun bool
+	}{
+		{
+			description: "not watching",
+			flagValues:  []string{},
+			isValid:     true,
+			expectedRun: true,
+		},
+		{
+			description: "interval too short",
+			flagValues:  []string{"--watch=100ms"},
+			isValid:     false,
+		},
+		{
+			description: "interval invalid",
+			flagValues:  []string{"--watch=fast"},
+			isValid:     false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			p := print.NewPrinter()
+			ran := false
+			cmd := &cobra.Command{
+				Use: "list",
+				RunE: func(_ *cobra.Command, _ []string) error {
+					ran = true
+					return nil
+				},
+			}
+			p.Cmd = cmd
+			Configure(p, cmd)
+
+			err := cmd.ParseFlags(tt.flagValues)
+			if err == nil {
+				err = cmd.RunE(cmd, nil)
+			}
+			if !tt.isValid {
+				if err == nil {
+					t.Fatalf("did not fail on invalid input")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("failed on valid input: %v", err)
+			}
+			if ran != tt.expectedRun {
+				t.Fatalf("expected command run to be %t, got %t", tt.expectedRun, ran)
+			}
+		})
+	}
+
+	cmd := &cobra.Command{Use: "group"}
+	Configure(print.NewPrinter(), cmd)
+	if cmd.Flags().Lookup(WatchFlag) != nil {
+		t.Fatalf("flag added to command that can't be run")
+	}
+}
+
+// runWatcher runs the watcher on the given outputs of successive command runs, and returns what it output
+func runWatcher(t *testing.T, jsonEvents bool, outputs []string, errs []error) string {
+	t.Helper()
+
+	cmd := &cobra.Command{Use: "list"}
+	out := &bytes.Buffer{}
+	w := &watcher{
+		cmd:        cmd,
+		out:        out,
+		errOut:     out,
+		interval:   time.Millisecond,
+		jsonEvents: jsonEvents,
+		now:        func() time.Time { return testTime },
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	i := 0
+	err := w.run(ctx, func() error {
+		if i == len(outputs)-1 {
+			cancel()
+		}
+		cmd.Print(outputs[i])
+		err := errs[i]
+		i++
+		return err
+	})
+	if err != nil {
+		t.Fatalf("run watcher: %v", err)
+	}
+	return out.String()
+}
+
+func TestRunEvents(t *testing.T) {
+	outputs := []string{
+		`[{"id":"xxx","status":"CREATING"},{"id":"yyy","status":"ACTIVE"}]`,
+		`[{"id":"xxx","status":"CREATING"},{"id":"yyy","status":"ACTIVE"}]`,
+		"",
+		`[{"id":"xxx","status":"ACTIVE"},{"id":"zzz","status":"CREATING"}]`,
+	}
+	errs := []error{nil, nil, fmt.Errorf("request failed"), nil}
+
+	output := runWatcher(t, true, outputs, errs)
+	expected := strings.Join([]string{
+		`{"time":"2024-01-01T00:00:00Z","type":"added","id":"xxx","item":{"id":"xxx","status":"CREATING"}}`,
+		`{"time":"2024-01-01T00:00:00Z","type":"added","id":"yyy","item":{"id":"yyy","status":"ACTIVE"}}`,
+		`{"time":"2024-01-01T00:00:00Z","type":"error","error":"request failed"}`,
+		`{"time":"2024-01-01T00:00:00Z","type":"changed","id":"xxx","changes":{"status":{"old":"CREATING","new":"ACTIVE"}}}`,
+		`{"time":"2024-01-01T00:00:00Z","type":"added","id":"zzz","item":{"id":"zzz","status":"CREATING"}}`,
+		`{"time":"2024-01-01T00:00:00Z","type":"removed","id":"yyy","item":{"id":"yyy","status":"ACTIVE"}}`,
+		"",
+	}, "\n")
+	diff := cmp.Diff(output, expected)
+	if diff != "" {
+		t.Fatalf("Output does not match: %s", diff)
+	}
+}
+
+func TestRunRedraw(t *testing.T) {
+	outputs := []string{"ID │ STATUS\nxxx │ CREATING\n", "ID │ STATUS\nxxx │ ACTIVE\n"}
+	errs := []error{nil, nil}
+
+	output := runWatcher(t, false, outputs, errs)
+	if strings.Count(output, clearScreen) != 2 {
+		t.Fatalf("expected the output to be drawn twice, got %q", output)
+	}
+	if !strings.Contains(output, "Every 1ms: list    2024-01-01 00:00:00") {
+		t.Fatalf("output has no header: %q", output)
+	}
+	if !strings.HasSuffix(output, fmt.Sprintf("ID │ STATUS\nxxx │%s\n", print.YellowBold(" ACTIVE"))) {
+		t.Fatalf("output doesn't end with the last run: %q", output)
+	}
+}
+
+func TestRunFirstRunFails(t *testing.T) {
+	cmd := &cobra.Command{Use: "list"}
+	w := &watcher{cmd: cmd, out: &bytes.Buffer{}, errOut: &bytes.Buffer{}, interval: time.Millisecond, now: time.Now}
+	err := w.run(context.Background(), func() error { return fmt.Errorf("invalid input") })
+	if err == nil {
+		t.Fatalf("did not fail when the first run failed")
+	}
+}
+
+func TestHighlightChanges(t *testing.T) {
+	tests := []struct {
+		description   string
+		previousLines []string
+		lines         []string
+		expected      string
+	}{
+		{
+			description: "first run",
+			lines:       []string{"a │ b", "c │ d"},
+			expected:    "a │ b\nc │ d",
+		},
+		{
+			description:   "changed cell",
+			previousLines: []string{"a │ b", "c │ d"},
+			lines:         []string{"a │ b", "c │ e"},
+			expected:      "a │ b\nc │" + print.YellowBold(" e"),
+		},
+		{
+			description:   "changed line",
+			previousLines: []string{"a: b"},
+			lines:         []string{"a: c"},
+			expected:      print.YellowBold("a: c"),
+		},
+		{
+			description:   "new line",
+			previousLines: []string{"a │ b"},
+			lines:         []string{"a │ b", "c │ d", ""},
+			expected:      "a │ b\n" + print.YellowBold("c │ d") + "\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			highlighted := highlightChanges(tt.previousLines, tt.lines)
+			if highlighted != tt.expected {
+				t.Fatalf("expected %q, got %q", tt.expected, highlighted)
+			}
+		})
+	}
+}
+
+func TestParseItems(t *testing.T) {
+	tests := []struct {
+		description   string
+		output        string
+		isValid       bool
+		expectedItems map[string]any
+		expectedIds   []string
+	}{
+		{
+			description: "list",
+			output:      `[{"id":"xxx"},{"name":"yyy"},{"other":"zzz"},{"id":"xxx"}]`,
+			isValid:     true,
+			expectedItems: map[string]any{
+				"xxx": map[string]any{"id": "xxx"},
+				"yyy": map[string]any{"name": "yyy"},
+				"2":   map[string]any{"other": "zzz"},
+				"3":   map[string]any{"id": "xxx"},
+			},
+			expectedIds: []string{"xxx", "yyy", "2", "3"},
+		},
+		{
+			description:   "single item",
+			output:        `{"id":"xxx","status":"ACTIVE"}`,
+			isValid:       true,
+			expectedItems: map[string]any{"xxx": map[string]any{"id": "xxx", "status": "ACTIVE"}},
+			expectedIds:   []string{"xxx"},
+		},
+		{
+			description: "JSON lines",
+			output:      "{\"id\":\"xxx\"}\n{\"id\":\"yyy\"}\n",
+			isValid:     true,
+			expectedItems: map[string]any{
+				"xxx": map[string]any{"id": "xxx"},
+				"yyy": map[string]any{"id": "yyy"},
+			},
+			expectedIds: []string{"xxx", "yyy"},
+		},
+		{
+			description:   "empty",
+			output:        "",
+			isValid:       true,
+			expectedItems: map[string]any{},
+			expectedIds:   []string{},
+		},
+		{
+			description: "invalid",
+			output:      "No zones found",
+			isValid:     false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			items, ids, err := parseItems(tt.output)
+			if !tt.isValid {
+				if err == nil {
+					t.Fatalf("did not fail on invalid input")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("failed on valid input: %v", err)
+			}
+			diff := cmp.Diff(items, tt.expectedItems)
+			if diff != "" {
+				t.Fatalf("Items do not match: %s", diff)
+			}
+			diff = cmp.Diff(ids, tt.expectedIds)
+			if diff != "" {
+				t.Fatalf("IDs do not match: %s", diff)
+			}
+		})
+	}
+}
+
+func TestDiff(t *testing.T) {
+	previousItem := map[string]any{
+		"id":     "xxx",
+		"status": "CREATING",
+		"nics":   []any{map[string]any{"ip": "10.0.0.1"}},
+		"labels": map[string]any{"env": "dev"},
+	}
+	item := map[string]any{
+		"id":     "xxx",
+		"status": "ACTIVE",
+		"nics":   []any{map[string]any{"ip": "10.0.0.1"}, map[string]any{"ip": "10.0.0.2"}},
+		"labels": map[string]any{},
+	}
+
+	expected := map[string]Change{
+		"status":     {Old: "CREATING", New: "ACTIVE"},
+		"nics.1.ip":  {Old: nil, New: "10.0.0.2"},
+		"labels.env": {Old: "dev", New: nil},
+	}
+	diff := cmp.Diff(diff(previousItem, item), expected)
+	if diff != "" {
+		t.Fatalf("Changes do not match: %s", diff)
+	}
+}